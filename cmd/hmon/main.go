@@ -2,14 +2,27 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"maps"
 	"net/http"
-	"net/url"
 	"os"
-
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/alerting"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/apiclient"
 	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
 	"github.com/kaldun-tech/hedera-network-monitor/pkg/hedera"
 	"github.com/spf13/cobra"
@@ -18,9 +31,35 @@ import (
 var (
 	// Global flags
 	apiURL     string
+	apiToken   string
 	loglevel   string
 	network    string
 	configFile string
+
+	// alerts list flags
+	alertsList alertListOptions
+
+	// alerts add flags
+	alertsFromFile string
+	alertFlags     alertRuleFlags
+
+	// account info flags
+	accountInfoJSON bool
+
+	// account transactions flags
+	hbarDecimals      int
+	transactionsSince time.Duration
+	transactionsLimit int
+
+	// metrics watch flags
+	watchName string
+
+	// alerts replay flags
+	replayFile     string
+	replayWebhooks []string
+	replayRemove   bool
+	replayCAFile   string
+	replayInsecure bool
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -35,9 +74,13 @@ network status, and manage alert rules.
 Usage:
   hmon account balance <account-id>
   hmon account transactions <account-id>
+  hmon account info <account-id> [--json]
   hmon network status
-  hmon alerts list
-  hmon alerts add <rule>`,
+  hmon metrics watch [--name <metric>]
+  hmon alerts list [--severity <level>] [--metric <name>] [--json]
+  hmon alerts add <rule>
+  hmon alerts add --from-file <rules.json>
+  hmon alerts replay --file <deadletter.jsonl>`,
 	Version: "0.1.0",
 }
 
@@ -58,7 +101,7 @@ var accountBalanceCmd = &cobra.Command{
 		accountID := args[0]
 		fmt.Printf("Querying balance for account: %s\n", accountID)
 		operatorID, operatorKey := getCredentials()
-		client, err := hedera.NewClient(getNetworkName(), operatorID, operatorKey)
+		client, err := hedera.NewClient(getNetworkName(), "", operatorID, operatorKey)
 		if err != nil {
 			return err
 		}
@@ -81,20 +124,66 @@ var accountTransactionsCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		accountID := args[0]
+		if transactionsLimit < 1 {
+			return fmt.Errorf("--limit must be positive, got %d", transactionsLimit)
+		}
+		if transactionsSince < 0 {
+			return fmt.Errorf("--since must not be negative, got %s", transactionsSince)
+		}
 		fmt.Printf("Querying transactions for account: %s\n", accountID)
 		operatorID, operatorKey := getCredentials()
-		client, err := hedera.NewClient(getNetworkName(), operatorID, operatorKey)
+		client, err := hedera.NewClient(getNetworkName(), "", operatorID, operatorKey)
 		if err != nil {
 			return err
 		}
 
-		transactions, err := client.GetAccountRecords(accountID, 10)
+		transactions, err := client.GetAccountRecords(accountID, transactionsLimit)
 		if err != nil {
 			return err
 		}
+		transactions = filterTransactionsSince(transactions, transactionsSince, time.Now())
 
 		fmt.Printf("\nRecent transactions for account %s:\n", accountID)
-		fmt.Println(formatTransactions(transactions))
+		fmt.Println(formatTransactions(transactions, hbarDecimals))
+		return nil
+	},
+}
+
+// accountInfoCmd represents the account info command
+var accountInfoCmd = &cobra.Command{
+	Use:   "info <account-id>",
+	Short: "Get account details",
+	Long:  "Retrieve balance, key, auto-renew period, expiry and memo for a given account ID",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		accountID := args[0]
+		operatorID, operatorKey := getCredentials()
+		client, err := hedera.NewClient(getNetworkName(), "", operatorID, operatorKey)
+		if err != nil {
+			return err
+		}
+
+		info, err := client.GetAccountInfo(accountID)
+		if err != nil {
+			return err
+		}
+
+		expiry, err := client.GetAccountExpiry(accountID)
+		if err != nil {
+			return err
+		}
+
+		details := newAccountDetails(info, expiry)
+		if accountInfoJSON {
+			data, err := json.MarshalIndent(details, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal account info: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Print(formatAccountDetails(details))
 		return nil
 	},
 }
@@ -115,12 +204,13 @@ var networkStatusCmd = &cobra.Command{
 		fmt.Println("Querying network status from monitoring service...")
 
 		// Query metrics from the monitoring service
-		nodeMetrics, err := queryMetricsByName("network_nodes_available")
+		client := newAPIClient()
+		nodeMetrics, err := client.QueryMetrics("network_nodes_available", 10)
 		if err != nil {
 			return fmt.Errorf("failed to query network metrics: %w", err)
 		}
 
-		consensusMetrics, err := queryMetricsByName("network_consensus_active")
+		consensusMetrics, err := client.QueryMetrics("network_consensus_active", 10)
 		if err != nil {
 			return fmt.Errorf("failed to query consensus metrics: %w", err)
 		}
@@ -135,9 +225,8 @@ var networkStatusCmd = &cobra.Command{
 		}
 
 		if len(consensusMetrics) > 0 {
-			isActive := consensusMetrics[0].Value == 1.0
 			status := "DOWN"
-			if isActive {
+			if hedera.IsConsensusUp(consensusMetrics[0].Value) {
 				status = "UP"
 			}
 			fmt.Printf("  Consensus Status: %s\n", status)
@@ -149,6 +238,93 @@ var networkStatusCmd = &cobra.Command{
 	},
 }
 
+// metricsCmd represents the metrics command group
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Inspect collected metrics",
+	Long:  "Inspect metrics collected by the monitoring service",
+}
+
+// metricsWatchCmd represents the metrics watch command
+var metricsWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Print metrics as they are collected",
+	Long: `Connect to the monitoring service's metrics stream and print each metric
+as it arrives, like tail -f. Dropped connections are retried with backoff
+until interrupted with Ctrl-C.
+
+Examples:
+  hmon metrics watch
+  hmon metrics watch --name account_balance`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return handleMetricsWatch(ctx, newAPIClient(), watchName, cmd.OutOrStdout(), cmd.ErrOrStderr(), defaultWatchBackoff)
+	},
+}
+
+// watchBackoff is the delay between reconnection attempts of metrics watch:
+// Initial after the first failure, doubling up to Max while attempts keep failing
+type watchBackoff struct {
+	Initial time.Duration
+	Max     time.Duration
+}
+
+// defaultWatchBackoff retries after 1s, backing off to at most 30s
+var defaultWatchBackoff = watchBackoff{Initial: time.Second, Max: 30 * time.Second}
+
+// handleMetricsWatch prints streamed metrics named name (empty = all) to out until
+// ctx is cancelled, reconnecting with backoff and reporting disconnects to errOut
+// A connection that delivered metrics resets the backoff. A rejected handshake
+// (404, 401, 403) is returned at once since reconnecting can't fix it
+func handleMetricsWatch(ctx context.Context, client *apiclient.Client, name string, out, errOut io.Writer, backoff watchBackoff) error {
+	delay := backoff.Initial
+	for {
+		received := false
+		err := client.StreamMetrics(ctx, name, func(metric apiclient.Metric) {
+			received = true
+			fmt.Fprintln(out, formatMetricLine(metric))
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+		if errors.Is(err, apiclient.ErrStreamRejected) {
+			return err
+		}
+		if received {
+			delay = backoff.Initial
+		}
+
+		fmt.Fprintf(errOut, "%v; reconnecting in %s\n", err, delay)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, backoff.Max)
+	}
+}
+
+// formatMetricLine formats a metric for metrics watch, e.g.
+// "2024-01-02T15:04:05Z account_balance{account_id=0.0.5000} 1000000000"
+func formatMetricLine(metric apiclient.Metric) string {
+	var b strings.Builder
+	b.WriteString(time.Unix(metric.Timestamp, 0).UTC().Format(time.RFC3339))
+	b.WriteByte(' ')
+	b.WriteString(metric.Name)
+	if len(metric.Labels) > 0 {
+		labels := make([]string, 0, len(metric.Labels))
+		for _, key := range slices.Sorted(maps.Keys(metric.Labels)) {
+			labels = append(labels, key+"="+metric.Labels[key])
+		}
+		b.WriteString("{" + strings.Join(labels, ",") + "}")
+	}
+	b.WriteByte(' ')
+	b.WriteString(formatThreshold(metric.Value))
+	return b.String()
+}
+
 // alertsCmd represents the alerts command group
 var alertsCmd = &cobra.Command{
 	Use:   "alerts",
@@ -160,12 +336,26 @@ var alertsCmd = &cobra.Command{
 var alertsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List alert rules",
-	Long:  "Display all configured alert rules",
+	Long: `Display all configured alert rules.
+
+Filter by --severity and --metric (applied after fetching), and use --json to
+print the apiclient.AlertList as JSON for scripts.
+
+Examples:
+  hmon alerts list --severity critical
+  hmon alerts list --metric account_balance --json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return handleAlertsList()
+		return handleAlertsList(alertsList)
 	},
 }
 
+// alertListOptions holds the alerts list flags
+type alertListOptions struct {
+	json     bool
+	severity string // Only rules with this severity (empty = any)
+	metric   string // Only rules on this metric (empty = any)
+}
+
 // alertsAddCmd represents the alerts add command
 var alertsAddCmd = &cobra.Command{
 	Use:   "add <rule-json>",
@@ -175,18 +365,169 @@ Rule must be provided as JSON with required fields:
   - name: Rule name
   - metric_name: Metric to monitor (e.g., "account_balance")
   - condition: Comparison operator (>, <, >=, <=, ==, !=)
-  - threshold: Numeric threshold value
+  - threshold: Numeric threshold value (decimals and scientific notation like 1e9 are accepted)
   - severity: Alert severity (info, warning, critical)
 
 Optional fields:
   - description: Rule description
   - cooldown_seconds: Cooldown between alerts (default: 300)
+  - for_seconds: Condition must hold this long before firing (default: 0)
+  - warmup_seconds: Don't fire until the rule has existed this long (default: 0)
+  - min_change: For "changed", the smallest difference that fires (default: 0, any change)
+  - min_change_percent: For "changed", the smallest percent move from the previous value that fires (default: 0, any change)
+  - escalate_after: Bump severity after this many fires without recovery (default: 0, never)
+  - escalate_window_seconds: Only count fires within this window (default: 0, no window)
+  - denominator_metric: Alert on metric_name / denominator_metric instead (e.g. failed / total transactions)
+  - tags: Groups for filtering and bulk deletion (e.g. ["balances"])
+  - annotations: Key/value context sent with webhooks (e.g. {"runbook_url":"https://..."})
+  - webhooks: Send this rule's alerts only to these URLs instead of the global webhooks
+
+Rules can also be read from a file with --from-file, containing either a
+single rule object or an array of rules. Use "-" to read from stdin.
+
+For quick adds, build the rule from flags instead of JSON by passing --metric.
+Condition defaults to ">" and severity to "warning"; the name defaults to
+"<metric> <condition> <threshold>".
+
+Examples:
+  hmon alerts add '{"name":"Low Balance","metric_name":"account_balance","condition":"<","threshold":1000000000,"severity":"warning"}'
+  hmon alerts add --metric account_balance --condition "<" --threshold 1000000000
+  hmon alerts add --from-file rules.json
+  cat rules.json | hmon alerts add --from-file -`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if alertsFromFile != "" || alertFlags.metric != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if alertsFromFile != "" {
+			return handleAlertAddFromFile(alertsFromFile, cmd.InOrStdin())
+		}
+		if alertFlags.metric != "" {
+			if !cmd.Flags().Changed("threshold") && !types.Condition(alertFlags.condition).IsState() {
+				return fmt.Errorf("--threshold is required for condition %q", alertFlags.condition)
+			}
+			return handleAlertAddRequest(alertFlags.request())
+		}
+		return handleAlertAdd(args[0])
+	},
+}
+
+// alertRuleFlags holds the alerts add flags used to build a rule without JSON
+type alertRuleFlags struct {
+	name        string
+	description string
+	metric      string
+	denominator string
+	condition   string
+	threshold   float64
+	severity    string
+	cooldown    int
+	forSeconds  int
+	warmup      int
+	minChange   float64
+	minPercent  float64
+	tags        []string
+	annotations map[string]string
+	webhooks    []string
+}
+
+// request builds a apiclient.CreateAlertRequest from the flags, naming the rule after its condition if unnamed
+func (f alertRuleFlags) request() apiclient.CreateAlertRequest {
+	name := f.name
+	if name == "" {
+		metric := f.metric
+		if f.denominator != "" {
+			metric += "/" + f.denominator
+		}
+		name = fmt.Sprintf("%s %s %s", metric, f.condition, formatThreshold(f.threshold))
+	}
+	return apiclient.CreateAlertRequest{
+		Name:            name,
+		Description:     f.description,
+		MetricName:      f.metric,
+		Condition:       f.condition,
+		Threshold:       f.threshold,
+		Severity:        f.severity,
+		CooldownSeconds: f.cooldown,
+		ForSeconds:      f.forSeconds,
+		WarmupSeconds:   f.warmup,
+		MinChange:       f.minChange,
+		Tags:            f.tags,
+		Annotations:     f.annotations,
+		Webhooks:        f.webhooks,
+
+		DenominatorMetric: f.denominator,
+		MinChangePercent:  f.minPercent,
+	}
+}
+
+// alertsAddDefaultsCmd represents the alerts add-defaults command
+var alertsAddDefaultsCmd = &cobra.Command{
+	Use:   "add-defaults",
+	Short: "Add a starter set of alert rules",
+	Long: `Create a curated set of starter alert rules through the API:
+
+  - Low Operator Balance: operator_balance below 1 HBAR (critical)
+  - Consensus Down: network_consensus_active below 1 for 2 minutes (critical)
+  - Node Count Dropped: network_nodes_available decreased (warning)
+
+Rules whose name already exists are skipped, so this is safe to run again.
+The rules are tagged "defaults"; remove them all with
+DELETE /api/v1/alerts?tag=defaults. If the monitor uses collectors.namespace,
+add the rules from JSON with prefixed metric names instead.
+
+Examples:
+  hmon alerts add-defaults
+  hmon alerts add-defaults --api-url http://monitor:8080`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleAlertsAddDefaults(cmd.OutOrStdout())
+	},
+}
 
-Example:
-  hmon alerts add '{"name":"Low Balance","metric_name":"account_balance","condition":"<","threshold":1000000000,"severity":"warning"}'`,
+// alertsDeleteCmd represents the alerts delete command
+var alertsDeleteCmd = &cobra.Command{
+	Use:   "delete <rule-id>",
+	Short: "Delete an alert rule",
+	Long: `Delete an alert rule by its ID, as shown by "hmon alerts list".
+
+Examples:
+  hmon alerts delete 4b0f5c1e-2d7a-4a8e-9c3b-1f6d2e8a7b90`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return handleAlertAdd(args[0])
+		return handleAlertDelete(args[0], cmd.OutOrStdout())
+	},
+}
+
+// alertsReplayCmd represents the alerts replay command
+var alertsReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Resend failed webhook payloads",
+	Long: `Resend webhook payloads stored one JSON object per line (JSONL), such as
+alerts that failed delivery. Each payload is POSTed to every webhook, and the
+result is reported per line.
+
+Webhooks default to alerting.webhooks in the config file; use --webhook to
+override. With --remove, successfully replayed lines are removed from the file
+so only failures remain.
+
+Examples:
+  hmon alerts replay --file deadletter.jsonl
+  hmon alerts replay --file deadletter.jsonl --webhook https://hooks.example.com/alerts --remove
+  hmon alerts replay --file deadletter.jsonl --ca-file internal-ca.pem`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		webhooks := replayWebhooks
+		if len(webhooks) == 0 {
+			webhooks = getWebhooks()
+		}
+		webhookConfig, err := replayWebhookConfig(replayCAFile, replayInsecure)
+		if err != nil {
+			return err
+		}
+		return handleAlertsReplay(replayFile, webhooks, replayRemove, webhookConfig, cmd.OutOrStdout())
 	},
 }
 
@@ -219,49 +560,99 @@ func getCredentials() (operatorID, operatorKey string) {
 	return operatorID, operatorKey
 }
 
-// MetricResponse defines types for API queries
-type MetricResponse struct {
-	Name      string            `json:"name"`
-	Timestamp int64             `json:"timestamp"`
-	Value     float64           `json:"value"`
-	Labels    map[string]string `json:"labels"`
+// getWebhooks loads the configured webhook URLs from the config file
+func getWebhooks() []string {
+	if configFile == "" {
+		return nil
+	}
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		log.Printf("Failed to load config from %s: %v", configFile, err)
+		return nil
+	}
+	return cfg.Alerting.Webhooks
+}
+
+// newAPIClient returns a client for the monitoring service API from the global flags
+func newAPIClient() *apiclient.Client {
+	return apiclient.New(apiURL, apiToken)
 }
 
-type MetricsAPIResponse struct {
-	Metrics []MetricResponse `json:"metrics"`
-	Count   int              `json:"count"`
-	Error   string           `json:"error,omitempty"`
+// AccountDetails is the snapshot printed by `hmon account info`
+type AccountDetails struct {
+	AccountID              string  `json:"account_id"`
+	BalanceTinybar         int64   `json:"balance_tinybar"`
+	BalanceHbar            float64 `json:"balance_hbar"`
+	Key                    string  `json:"key"`
+	AutoRenewPeriodSeconds int64   `json:"auto_renew_period_seconds"`
+	Expiry                 int64   `json:"expiry"` // Unix seconds
+	Memo                   string  `json:"memo"`
+	Deleted                bool    `json:"deleted"`
 }
 
-// queryMetricsByName queries the monitoring service API for metrics by name
-func queryMetricsByName(metricName string) ([]MetricResponse, error) {
-	params := url.Values{}
-	params.Add("name", metricName)
-	params.Add("limit", "10")
+// newAccountDetails extracts the fields shown by `hmon account info`
+func newAccountDetails(info *hiero.AccountInfo, expiry int64) AccountDetails {
+	key := ""
+	if info.Key != nil {
+		key = info.Key.String()
+	}
 
-	fullURL := fmt.Sprintf("%s/api/v1/metrics?%s", apiURL, params.Encode())
+	tinybar := info.Balance.AsTinybar()
+	return AccountDetails{
+		AccountID:              info.AccountID.String(),
+		BalanceTinybar:         tinybar,
+		BalanceHbar:            hedera.TinybarToHbar(tinybar),
+		Key:                    key,
+		AutoRenewPeriodSeconds: int64(info.AutoRenewPeriod / time.Second),
+		Expiry:                 expiry,
+		Memo:                   info.AccountMemo,
+		Deleted:                info.IsDeleted,
+	}
+}
 
-	resp, err := http.Get(fullURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query API: %w", err)
+// formatAccountDetails formats account details for display
+func formatAccountDetails(details AccountDetails) string {
+	key := details.Key
+	if key == "" {
+		key = "(none)"
+	}
+	memo := details.Memo
+	if memo == "" {
+		memo = "(none)"
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	output := fmt.Sprintf("\nAccount %s:\n", details.AccountID)
+	output += fmt.Sprintf("  Balance:          %s ℏ (%d tinybar)\n", hedera.FormatHbar(details.BalanceTinybar), details.BalanceTinybar)
+	output += fmt.Sprintf("  Key:              %s\n", key)
+	output += fmt.Sprintf("  Auto-Renew:       %s\n", time.Duration(details.AutoRenewPeriodSeconds)*time.Second)
+	output += fmt.Sprintf("  Expiry:           %s\n", time.Unix(details.Expiry, 0).UTC().Format(time.RFC3339))
+	output += fmt.Sprintf("  Memo:             %s\n", memo)
+	if details.Deleted {
+		output += "  Deleted:          true\n"
 	}
+	return output
+}
 
-	var apiResp MetricsAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+// filterTransactionsSince keeps records whose consensus timestamp is within since of now
+// A zero duration keeps every record
+func filterTransactionsSince(transactions []hedera.Record, since time.Duration, now time.Time) []hedera.Record {
+	if since == 0 {
+		return transactions
 	}
 
-	return apiResp.Metrics, nil
+	cutoff := now.Add(-since).Unix()
+	filtered := make([]hedera.Record, 0, len(transactions))
+	for _, tx := range transactions {
+		if cutoff <= tx.Timestamp {
+			filtered = append(filtered, tx)
+		}
+	}
+	return filtered
 }
 
 // formatTransactions formats a slice of transaction records for display
-func formatTransactions(transactions []hedera.Record) string {
+// Amounts are shown in HBAR rounded to the given number of decimal places
+func formatTransactions(transactions []hedera.Record, decimals int) string {
 	if len(transactions) == 0 {
 		return "No transactions found"
 	}
@@ -276,67 +667,53 @@ func formatTransactions(transactions []hedera.Record) string {
 
 	// Rows
 	for _, tx := range transactions {
-		// Convert tinybar to HBAR
-		hbarAmount := float64(tx.AmountTinyBar) / float64(hedera.TinybarPerHbar)
-
-		output += fmt.Sprintf("%-37s %-20s %14.2f %-10s\n",
+		output += fmt.Sprintf("%-37s %-20s %14s %-10s\n",
 			tx.TransactionID,
 			tx.Type.String(),
-			hbarAmount,
+			hedera.FormatHbarDecimals(tx.AmountTinyBar, decimals),
 			tx.Status)
 	}
 
 	return output
 }
 
-// AlertRuleResponse represents an alert rule from the API
-type AlertRuleResponse struct {
-	ID              string  `json:"id"`
-	Name            string  `json:"name"`
-	Description     string  `json:"description"`
-	MetricName      string  `json:"metric_name"`
-	Condition       string  `json:"condition"`
-	Threshold       float64 `json:"threshold"`
-	Severity        string  `json:"severity"`
-	Enabled         bool    `json:"enabled"`
-	CooldownSeconds int     `json:"cooldown_seconds"`
-}
-
-// AlertListResponse wraps alert rules
-type AlertListResponse struct {
-	Alerts []AlertRuleResponse `json:"alerts"`
-	Count  int                 `json:"count"`
-}
-
-// CreateAlertRequest is the request payload for creating an alert
-type CreateAlertRequest struct {
-	Name            string  `json:"name"`
-	Description     string  `json:"description"`
-	MetricName      string  `json:"metric_name"`
-	Condition       string  `json:"condition"`
-	Threshold       float64 `json:"threshold"`
-	Severity        string  `json:"severity"`
-	CooldownSeconds int     `json:"cooldown_seconds"`
+// filterAlertRules returns the rules matching severity and metric; empty filters match any rule
+func filterAlertRules(rules []apiclient.AlertRule, severity, metric string) []apiclient.AlertRule {
+	filtered := make([]apiclient.AlertRule, 0, len(rules))
+	for _, rule := range rules {
+		if severity != "" && rule.Severity != severity {
+			continue
+		}
+		if metric != "" && rule.MetricName != metric {
+			continue
+		}
+		filtered = append(filtered, rule)
+	}
+	return filtered
 }
 
-// handleAlertsList fetches and displays all alert rules
-func handleAlertsList() error {
-	fullURL := fmt.Sprintf("%s/api/v1/alerts", apiURL)
+// handleAlertsList fetches alert rules, filters them and displays them as text or JSON
+func handleAlertsList(opts alertListOptions) error {
+	if opts.severity != "" && !types.Severity(opts.severity).Valid() {
+		return fmt.Errorf("invalid --severity %q: must be one of %s",
+			opts.severity, types.SeverityNames())
+	}
 
-	resp, err := http.Get(fullURL)
+	response, err := newAPIClient().ListAlerts()
 	if err != nil {
-		return fmt.Errorf("failed to query API: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
-	}
+	response.Alerts = filterAlertRules(response.Alerts, opts.severity, opts.metric)
+	response.Count = len(response.Alerts)
 
-	var response AlertListResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	if opts.json {
+		data, err := json.MarshalIndent(response, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal alert rules: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
 	}
 
 	// Display results
@@ -354,64 +731,386 @@ func handleAlertsList() error {
 		if rule.Description != "" {
 			fmt.Printf("    Description:     %s\n", rule.Description)
 		}
-		fmt.Printf("    Metric:          %s\n", rule.MetricName)
-		fmt.Printf("    Condition:       %s %.0f\n", rule.Condition, rule.Threshold)
+		if rule.DenominatorMetric != "" {
+			fmt.Printf("    Metric:          %s / %s\n", rule.MetricName, rule.DenominatorMetric)
+		} else {
+			fmt.Printf("    Metric:          %s\n", rule.MetricName)
+		}
+		fmt.Printf("    Condition:       %s %s\n", rule.Condition, formatThreshold(rule.Threshold))
 		fmt.Printf("    Severity:        %s\n", rule.Severity)
 		fmt.Printf("    Enabled:         %v\n", rule.Enabled)
 		if rule.CooldownSeconds > 0 {
 			fmt.Printf("    Cooldown:        %d seconds\n", rule.CooldownSeconds)
 		}
+		if rule.ForSeconds > 0 {
+			fmt.Printf("    For:             %d seconds\n", rule.ForSeconds)
+		}
+		if rule.WarmupSeconds > 0 {
+			fmt.Printf("    Warmup:          %d seconds\n", rule.WarmupSeconds)
+		}
+		if rule.MinChange > 0 {
+			fmt.Printf("    Min Change:      %s\n", formatThreshold(rule.MinChange))
+		}
+		if rule.MinChangePercent > 0 {
+			fmt.Printf("    Min Change Pct:  %s%%\n", formatThreshold(rule.MinChangePercent))
+		}
+		if rule.EscalateAfter > 0 {
+			fmt.Printf("    Escalate After:  %d fires\n", rule.EscalateAfter)
+		}
+		if len(rule.Tags) > 0 {
+			fmt.Printf("    Tags:            %s\n", strings.Join(rule.Tags, ", "))
+		}
+		if len(rule.Webhooks) > 0 {
+			fmt.Printf("    Webhooks:        %s\n", strings.Join(rule.Webhooks, ", "))
+		}
+		for _, key := range slices.Sorted(maps.Keys(rule.Annotations)) {
+			fmt.Printf("    %-17s%s\n", key+":", rule.Annotations[key])
+		}
+		fmt.Printf("    Evaluations:     %d\n", rule.Evaluations)
+		if rule.LastEvaluated > 0 {
+			fmt.Printf("    Last Evaluated:  %s\n", time.Unix(rule.LastEvaluated, 0).UTC().Format(time.RFC3339))
+		}
+		if rule.LastFired > 0 {
+			fmt.Printf("    Last Fired:      %s\n", time.Unix(rule.LastFired, 0).UTC().Format(time.RFC3339))
+		}
 	}
 
 	return nil
 }
 
-// handleAlertAdd creates a new alert rule
+// formatThreshold formats a rule threshold or min change for display: in full
+// without exponents, keeping any fractional digits (e.g. 1e9 = "1000000000", 0.25 = "0.25")
+func formatThreshold(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// validateAlertRequest checks a rule client-side so obvious mistakes fail before reaching the API
+func validateAlertRequest(request apiclient.CreateAlertRequest) error {
+	if request.Name == "" {
+		return fmt.Errorf("field \"name\" is required")
+	}
+	if request.MetricName == "" {
+		return fmt.Errorf("field \"metric_name\" is required")
+	}
+	if !types.Condition(request.Condition).Valid() {
+		return fmt.Errorf("field \"condition\" has invalid value %q: must be one of %s",
+			request.Condition, types.ConditionNames())
+	}
+	if !types.Severity(request.Severity).Valid() {
+		return fmt.Errorf("field \"severity\" has invalid value %q: must be one of %s",
+			request.Severity, types.SeverityNames())
+	}
+	if request.CooldownSeconds < 0 {
+		return fmt.Errorf("field \"cooldown_seconds\" cannot be negative: %d", request.CooldownSeconds)
+	}
+	if request.ForSeconds < 0 {
+		return fmt.Errorf("field \"for_seconds\" cannot be negative: %d", request.ForSeconds)
+	}
+	if request.WarmupSeconds < 0 {
+		return fmt.Errorf("field \"warmup_seconds\" cannot be negative: %d", request.WarmupSeconds)
+	}
+	if request.MinChange < 0 {
+		return fmt.Errorf("field \"min_change\" cannot be negative: %g", request.MinChange)
+	}
+	if request.MinChange != 0 && types.Condition(request.Condition) != types.ConditionChanged {
+		return fmt.Errorf("field \"min_change\" only applies to condition \"changed\", not %q", request.Condition)
+	}
+	if request.MinChangePercent < 0 {
+		return fmt.Errorf("field \"min_change_percent\" cannot be negative: %g", request.MinChangePercent)
+	}
+	if request.MinChangePercent != 0 && types.Condition(request.Condition) != types.ConditionChanged {
+		return fmt.Errorf("field \"min_change_percent\" only applies to condition \"changed\", not %q", request.Condition)
+	}
+	if request.DenominatorMetric != "" && request.DenominatorMetric == request.MetricName {
+		return fmt.Errorf("field \"denominator_metric\" must differ from \"metric_name\": %s", request.DenominatorMetric)
+	}
+	for i, webhookURL := range request.Webhooks {
+		if err := config.ValidateWebhookURL(webhookURL); err != nil {
+			return fmt.Errorf("field \"webhooks\" has an invalid URL at index %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// handleAlertAdd creates a new alert rule from JSON
 func handleAlertAdd(ruleJSON string) error {
-	// Parse JSON into CreateAlertRequest
-	var request CreateAlertRequest
-	if err := json.Unmarshal([]byte(ruleJSON), &request); err != nil {
+	// Parse JSON into apiclient.CreateAlertRequest, rejecting unknown fields so typos
+	// aren't silently dropped before the rule reaches the API
+	var request apiclient.CreateAlertRequest
+	dec := json.NewDecoder(strings.NewReader(ruleJSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&request); err != nil {
 		return fmt.Errorf("failed to parse rule JSON: %w (expected JSON format)", err)
 	}
 
-	// Make POST request to API
-	fullURL := fmt.Sprintf("%s/api/v1/alerts", apiURL)
+	return handleAlertAddRequest(request)
+}
+
+// handleAlertAddRequest validates a rule and creates it through the API
+func handleAlertAddRequest(request apiclient.CreateAlertRequest) error {
+	if err := validateAlertRequest(request); err != nil {
+		return fmt.Errorf("invalid rule: %w", err)
+	}
 
-	body, err := json.Marshal(request)
+	response, err := newAPIClient().CreateAlert(request)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
+		return err
 	}
 
-	resp, err := http.Post(fullURL, "application/json", bytes.NewBuffer(body))
+	fmt.Println("\nAlert rule created successfully!")
+	fmt.Printf("ID:        %s\n", response.ID)
+	fmt.Printf("Name:      %s\n", response.Name)
+	fmt.Printf("Metric:    %s\n", response.MetricName)
+	fmt.Printf("Condition: %s %s\n", response.Condition, formatThreshold(response.Threshold))
+	fmt.Printf("Severity:  %s\n", response.Severity)
+
+	return nil
+}
+
+// handleAlertDelete deletes the alert rule with the given ID through the API
+func handleAlertDelete(id string, out io.Writer) error {
+	if err := newAPIClient().DeleteAlert(id); err != nil {
+		var statusErr *apiclient.StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("alert rule %q not found", id)
+		}
+		return err
+	}
+	fmt.Fprintf(out, "Deleted alert rule %s\n", id)
+	return nil
+}
+
+// handleAlertAddFromFile reads one rule or an array of rules from a file
+// (or stdin when path is "-") and creates each via handleAlertAdd
+// Every rule is attempted; failures are reported together at the end
+func handleAlertAddFromFile(path string, stdin io.Reader) error {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to create alert: %w", err)
+		return fmt.Errorf("failed to read rules from %s: %w", path, err)
 	}
-	defer resp.Body.Close()
 
-	respBody, _ := io.ReadAll(resp.Body)
+	rules, err := splitRuleJSON(data)
+	if err != nil {
+		return err
+	}
 
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(respBody))
+	var errs []error
+	for i, rule := range rules {
+		if err := handleAlertAdd(string(rule)); err != nil {
+			errs = append(errs, fmt.Errorf("rule %d: %w", i+1, err))
+		}
 	}
 
-	var response AlertRuleResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to add %d of %d rules: %w", len(errs), len(rules), errors.Join(errs...))
 	}
+	return nil
+}
 
-	fmt.Println("\nAlert rule created successfully!")
-	fmt.Printf("ID:        %s\n", response.ID)
-	fmt.Printf("Name:      %s\n", response.Name)
-	fmt.Printf("Metric:    %s\n", response.MetricName)
-	fmt.Printf("Condition: %s %.0f\n", response.Condition, response.Threshold)
-	fmt.Printf("Severity:  %s\n", response.Severity)
+// defaultAlertRules are the starter rules created by "alerts add-defaults", covering
+// the failures every deployment should hear about. They share the "defaults" tag
+// so they can be listed or removed together
+var defaultAlertRules = []apiclient.CreateAlertRequest{
+	{
+		Name:        "Low Operator Balance",
+		Description: "The operator account pays for every query; monitoring stops when it runs out of HBAR",
+		MetricName:  "operator_balance",
+		Condition:   "<",
+		Threshold:   float64(hedera.TinybarPerHbar), // 1 HBAR
+		Severity:    "critical",
+		Tags:        []string{"defaults"},
+	},
+	{
+		Name:        "Consensus Down",
+		Description: "The network's address book could not be queried",
+		MetricName:  "network_consensus_active",
+		Condition:   "<",
+		Threshold:   hedera.ConsensusUp,
+		Severity:    "critical",
+		ForSeconds:  120,
+		Tags:        []string{"defaults"},
+	},
+	{
+		Name:        "Node Count Dropped",
+		Description: "Fewer network nodes are available than in the previous cycle",
+		MetricName:  "network_nodes_available",
+		Condition:   "decreased",
+		Severity:    "warning",
+		Tags:        []string{"defaults"},
+	},
+}
 
+// handleAlertsAddDefaults creates each of defaultAlertRules unless a rule with the
+// same name already exists, so running it again never duplicates rules
+func handleAlertsAddDefaults(out io.Writer) error {
+	existing, err := newAPIClient().ListAlerts()
+	if err != nil {
+		return err
+	}
+	names := make(map[string]bool, len(existing.Alerts))
+	for _, rule := range existing.Alerts {
+		names[rule.Name] = true
+	}
+
+	var errs []error
+	added := 0
+	for _, rule := range defaultAlertRules {
+		if names[rule.Name] {
+			fmt.Fprintf(out, "Skipped %q: a rule with this name already exists\n", rule.Name)
+			continue
+		}
+		if err := handleAlertAddRequest(rule); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", rule.Name, err))
+			continue
+		}
+		added++
+	}
+
+	fmt.Fprintf(out, "\nAdded %d of %d default rules\n", added, len(defaultAlertRules))
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to add %d default rules: %w", len(errs), errors.Join(errs...))
+	}
+	return nil
+}
+
+// splitRuleJSON splits file contents into individual rule JSON documents
+// Accepts a single JSON object or an array of objects
+func splitRuleJSON(data []byte) ([]json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("no rules found: input is empty")
+	}
+
+	if trimmed[0] != '[' {
+		return []json.RawMessage{trimmed}, nil
+	}
+
+	var rules []json.RawMessage
+	if err := json.Unmarshal(trimmed, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse rules array: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("no rules found: array is empty")
+	}
+	return rules, nil
+}
+
+// handleAlertsReplay re-POSTs each JSONL webhook payload in path to every webhook,
+// writing a result line per entry to out. When remove is set the file is rewritten
+// to keep only the entries that failed. Returns an error if any entry failed
+func handleAlertsReplay(path string, webhooks []string, remove bool, webhookConfig alerting.WebhookConfig, out io.Writer) error {
+	if path == "" {
+		return fmt.Errorf("--file is required")
+	}
+	if len(webhooks) == 0 {
+		return fmt.Errorf("no webhooks configured: set alerting.webhooks in the config file or pass --webhook")
+	}
+	for _, webhookURL := range webhooks {
+		if err := config.ValidateWebhookURL(webhookURL); err != nil {
+			return err
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read payloads from %s: %w", path, err)
+	}
+
+	var remaining []string
+	total, failed := 0, 0
+	for i, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		total++
+
+		if err := replayPayload(line, webhooks, webhookConfig); err != nil {
+			failed++
+			remaining = append(remaining, line)
+			fmt.Fprintf(out, "line %d: failed: %v\n", i+1, err)
+			continue
+		}
+		fmt.Fprintf(out, "line %d: replayed to %d webhook(s)\n", i+1, len(webhooks))
+	}
+
+	fmt.Fprintf(out, "Replayed %d of %d payloads\n", total-failed, total)
+
+	if remove {
+		if err := rewriteLines(path, remaining); err != nil {
+			return err
+		}
+	}
+
+	if 0 < failed {
+		return fmt.Errorf("failed to replay %d of %d payloads", failed, total)
+	}
+	return nil
+}
+
+// replayWebhookConfig builds the webhook config for replays, trusting caFile's CAs
+// in addition to the system roots or skipping verification when insecure is set
+func replayWebhookConfig(caFile string, insecure bool) (alerting.WebhookConfig, error) {
+	webhookConfig := alerting.DefaultWebhookConfig()
+	if caFile == "" && !insecure {
+		return webhookConfig, nil
+	}
+	if caFile != "" {
+		pool, err := alerting.LoadWebhookCA(caFile)
+		if err != nil {
+			return webhookConfig, err
+		}
+		webhookConfig.RootCAs = pool
+	}
+	webhookConfig.InsecureSkipVerify = insecure
+	webhookConfig.Client = alerting.NewWebhookClient(webhookConfig)
+	return webhookConfig, nil
+}
+
+// replayPayload decodes one stored payload and sends it to every webhook
+func replayPayload(line string, webhooks []string, webhookConfig alerting.WebhookConfig) error {
+	var payload alerting.WebhookPayload
+	dec := json.NewDecoder(strings.NewReader(line))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&payload); err != nil {
+		return fmt.Errorf("invalid payload: %w", err)
+	}
+
+	var errs []error
+	for _, webhookURL := range webhooks {
+		if err := alerting.SendWebhookRequest(webhookURL, payload, webhookConfig); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", webhookURL, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// rewriteLines atomically replaces path with the given lines
+func rewriteLines(path string, lines []string) error {
+	content := ""
+	if len(lines) > 0 {
+		content = strings.Join(lines, "\n") + "\n"
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
 	return nil
 }
 
 func init() {
 	// Add persistent flags
 	rootCmd.PersistentFlags().StringVar(&apiURL, "api-url", "http://localhost:8080", "API server URL")
+	rootCmd.PersistentFlags().StringVar(&apiToken, "api-token", os.Getenv("HMON_API_TOKEN"), "Bearer token for the API server, defaults to HMON_API_TOKEN env var")
 	rootCmd.PersistentFlags().StringVar(&loglevel, "loglevel", "info", "Log level (debug, info, warn, error)")
 	rootCmd.PersistentFlags().StringVar(&configFile, "config", "config/config.yaml", "Path to config file (for loading operator credentials)")
 	rootCmd.PersistentFlags().StringVar(&network, "network", "", "Hedera network name (mainnet/testnet), defaults to config or NETWORK_NAME env var or testnet")
@@ -419,18 +1118,67 @@ func init() {
 	// Add command groups
 	rootCmd.AddCommand(accountCmd)
 	rootCmd.AddCommand(networkCmd)
+	rootCmd.AddCommand(metricsCmd)
 	rootCmd.AddCommand(alertsCmd)
 
 	// Add account subcommands
 	accountCmd.AddCommand(accountBalanceCmd)
 	accountCmd.AddCommand(accountTransactionsCmd)
+	accountCmd.AddCommand(accountInfoCmd)
 
 	// Add network subcommands
 	networkCmd.AddCommand(networkStatusCmd)
 
+	// Add metrics subcommands
+	metricsCmd.AddCommand(metricsWatchCmd)
+	metricsWatchCmd.Flags().StringVar(&watchName, "name", "", "Only print metrics with this name (empty = all)")
+
 	// Add alerts subcommands
 	alertsCmd.AddCommand(alertsListCmd)
 	alertsCmd.AddCommand(alertsAddCmd)
+	alertsCmd.AddCommand(alertsAddDefaultsCmd)
+	alertsCmd.AddCommand(alertsDeleteCmd)
+	alertsCmd.AddCommand(alertsReplayCmd)
+
+	// Add alerts list flags
+	alertsListCmd.Flags().BoolVar(&alertsList.json, "json", false, "Output the rules as JSON")
+	alertsListCmd.Flags().StringVar(&alertsList.severity, "severity", "", "Only list rules with this severity (info, warning, critical)")
+	alertsListCmd.Flags().StringVar(&alertsList.metric, "metric", "", "Only list rules on this metric")
+
+	// Add alerts add flags
+	alertsAddCmd.Flags().StringVar(&alertsFromFile, "from-file", "", "Read rule JSON (object or array) from file, or - for stdin")
+	alertsAddCmd.Flags().StringVar(&alertFlags.metric, "metric", "", "Metric to monitor; builds the rule from flags instead of JSON")
+	alertsAddCmd.Flags().StringVar(&alertFlags.denominator, "denominator", "", "Alert on --metric divided by this metric (ratio rule)")
+	alertsAddCmd.Flags().StringVar(&alertFlags.condition, "condition", ">", "Condition operator (>, <, >=, <=, ==, !=, changed, increased, decreased)")
+	alertsAddCmd.Flags().Float64Var(&alertFlags.threshold, "threshold", 0, "Threshold value (required unless the condition is changed/increased/decreased)")
+	alertsAddCmd.Flags().StringVar(&alertFlags.severity, "severity", "warning", "Alert severity (info, warning, critical)")
+	alertsAddCmd.Flags().StringVar(&alertFlags.name, "name", "", "Rule name (defaults to \"<metric> <condition> <threshold>\")")
+	alertsAddCmd.Flags().StringVar(&alertFlags.description, "description", "", "Rule description")
+	alertsAddCmd.Flags().IntVar(&alertFlags.cooldown, "cooldown", 0, "Cooldown between alerts in seconds (0 = server default)")
+	alertsAddCmd.Flags().IntVar(&alertFlags.forSeconds, "for", 0, "Seconds the condition must hold before firing")
+	alertsAddCmd.Flags().IntVar(&alertFlags.warmup, "warmup", 0, "Seconds after the rule is added before it can fire")
+	alertsAddCmd.Flags().Float64Var(&alertFlags.minChange, "min-change", 0, "Smallest difference that fires a \"changed\" rule (0 = any change)")
+	alertsAddCmd.Flags().Float64Var(&alertFlags.minPercent, "min-change-percent", 0, "Smallest percent move from the previous value that fires a \"changed\" rule (0 = any change)")
+	alertsAddCmd.Flags().StringSliceVar(&alertFlags.tags, "tag", nil, "Tag for grouping the rule (repeatable)")
+	alertsAddCmd.Flags().StringToStringVar(&alertFlags.annotations, "annotation", nil, "Annotation sent with webhooks as key=value (repeatable)")
+	alertsAddCmd.Flags().StringSliceVar(&alertFlags.webhooks, "webhook", nil, "Send this rule's alerts only to this webhook URL instead of the global ones (repeatable)")
+	alertsAddCmd.MarkFlagsMutuallyExclusive("from-file", "metric")
+
+	// Add alerts replay flags
+	alertsReplayCmd.Flags().StringVar(&replayFile, "file", "", "JSONL file of webhook payloads to resend")
+	alertsReplayCmd.Flags().StringSliceVar(&replayWebhooks, "webhook", nil, "Webhook URL to send to (repeatable; defaults to alerting.webhooks from config)")
+	alertsReplayCmd.Flags().BoolVar(&replayRemove, "remove", false, "Remove successfully replayed payloads from the file")
+	alertsReplayCmd.Flags().StringVar(&replayCAFile, "ca-file", "", "PEM CA file to trust for webhooks with internal certificates")
+	alertsReplayCmd.Flags().BoolVar(&replayInsecure, "insecure-skip-verify", false, "Skip webhook TLS certificate verification (testing only)")
+	_ = alertsReplayCmd.MarkFlagRequired("file")
+
+	// Add account transactions flags
+	accountTransactionsCmd.Flags().IntVar(&hbarDecimals, "hbar-decimals", 2, "Decimal places shown for HBAR amounts (0-8)")
+	accountTransactionsCmd.Flags().DurationVar(&transactionsSince, "since", 0, "Only show transactions within this duration, e.g. 24h (0 = all)")
+	accountTransactionsCmd.Flags().IntVar(&transactionsLimit, "limit", 10, "Maximum number of records to query")
+
+	// Add account info flags
+	accountInfoCmd.Flags().BoolVar(&accountInfoJSON, "json", false, "Print account info as JSON")
 }
 
 func main() {
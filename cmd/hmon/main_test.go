@@ -1,14 +1,28 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+	"golang.org/x/net/websocket"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/alerting"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/apiclient"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/hedera"
 )
 
 // ============================================================================
@@ -19,7 +33,7 @@ import (
 func TestAlertListCommand_NoAlerts(t *testing.T) {
 	// Mock API returning empty alerts
 	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
-		response := AlertListResponse{Alerts: []AlertRuleResponse{}, Count: 0}
+		response := apiclient.AlertList{Alerts: []apiclient.AlertRule{}, Count: 0}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		_ = json.NewEncoder(w).Encode(response)
@@ -31,7 +45,7 @@ func TestAlertListCommand_NoAlerts(t *testing.T) {
 
 	// Verify output contains "No alert rules configured"
 	output := captureCommandOutput(t, func() error {
-		return handleAlertsList()
+		return handleAlertsList(alertListOptions{})
 	})
 
 	if !strings.Contains(output, "No alert rules configured") {
@@ -39,17 +53,64 @@ func TestAlertListCommand_NoAlerts(t *testing.T) {
 	}
 }
 
+// TestAlertListCommand_ThresholdFormatting tests large and fractional thresholds are listed in full
+func TestAlertListCommand_ThresholdFormatting(t *testing.T) {
+	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		response := apiclient.AlertList{Alerts: []apiclient.AlertRule{
+			{ID: "big", Name: "Big", Condition: ">", Threshold: 1e9},
+			{ID: "small", Name: "Small", Condition: "<", Threshold: 0.25},
+		}, Count: 2}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	})
+	defer server.Close()
+
+	setGlobalFlags(server.URL, "info")
+	output := captureCommandOutput(t, func() error {
+		return handleAlertsList(alertListOptions{})
+	})
+
+	for _, want := range []string{"Condition:       > 1000000000\n", "Condition:       < 0.25\n"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output, got: %s", want, output)
+		}
+	}
+}
+
+// TestAlertListCommand_SendsAPIToken tests the --api-token value is sent as a bearer token
+func TestAlertListCommand_SendsAPIToken(t *testing.T) {
+	var gotAuth string
+	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(apiclient.AlertList{Alerts: []apiclient.AlertRule{}})
+	})
+	defer server.Close()
+
+	setGlobalFlags(server.URL, "info")
+	apiToken = "secret"
+	defer func() { apiToken = "" }()
+
+	captureCommandOutput(t, func() error {
+		return handleAlertsList(alertListOptions{})
+	})
+
+	if gotAuth != "Bearer secret" {
+		t.Errorf("Expected Authorization 'Bearer secret', got: %q", gotAuth)
+	}
+}
+
 // TestAlertListCommand_WithAlerts tests alerts list displays rules correctly
 func TestAlertListCommand_WithAlerts(t *testing.T) {
 	// Mock API with 3 rules
-	rules := []AlertRuleResponse{
+	rules := []apiclient.AlertRule{
 		{ID: "1", Name: "Rule 1", MetricName: "metric1", Condition: ">", Threshold: 100, Severity: "warning"},
 		{ID: "2", Name: "Rule 2", MetricName: "metric2", Condition: "<", Threshold: 50, Severity: "critical"},
 		{ID: "3", Name: "Rule 3", MetricName: "metric3", Condition: "==", Threshold: 10, Severity: "info"},
 	}
 
 	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
-		response := AlertListResponse{Alerts: rules, Count: len(rules)}
+		response := apiclient.AlertList{Alerts: rules, Count: len(rules)}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		_ = json.NewEncoder(w).Encode(response)
@@ -58,7 +119,7 @@ func TestAlertListCommand_WithAlerts(t *testing.T) {
 
 	setGlobalFlags(server.URL, "info")
 	output := captureCommandOutput(t, func() error {
-		return handleAlertsList()
+		return handleAlertsList(alertListOptions{})
 	})
 
 	// Verify it contains the length of 3
@@ -80,6 +141,90 @@ func TestAlertListCommand_WithAlerts(t *testing.T) {
 	}
 }
 
+// newAlertListServer returns a mock API serving the given rules from GET /api/v1/alerts
+func newAlertListServer(t *testing.T, rules []apiclient.AlertRule) *httptest.Server {
+	return createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(apiclient.AlertList{Alerts: rules, Count: len(rules)})
+	})
+}
+
+// TestAlertListCommand_JSON tests --json prints a decodable apiclient.AlertList
+func TestAlertListCommand_JSON(t *testing.T) {
+	rules := []apiclient.AlertRule{
+		{ID: "1", Name: "Rule 1", MetricName: "account_balance", Condition: "<", Threshold: 100, Severity: "warning"},
+		{ID: "2", Name: "Rule 2", MetricName: "network_nodes_available", Condition: "<", Threshold: 10, Severity: "critical"},
+	}
+	server := newAlertListServer(t, rules)
+	defer server.Close()
+
+	setGlobalFlags(server.URL, "info")
+	output := captureCommandOutput(t, func() error {
+		return handleAlertsList(alertListOptions{json: true})
+	})
+
+	var response apiclient.AlertList
+	if err := json.Unmarshal([]byte(output), &response); err != nil {
+		t.Fatalf("Expected JSON output, got error %v for: %s", err, output)
+	}
+	if response.Count != 2 || len(response.Alerts) != 2 {
+		t.Errorf("Expected 2 rules, got count %d with %d alerts", response.Count, len(response.Alerts))
+	}
+	if response.Alerts[1].Severity != "critical" {
+		t.Errorf("Expected second rule to be critical, got %s", response.Alerts[1].Severity)
+	}
+}
+
+// TestAlertListCommand_Filters tests --severity and --metric filter the fetched rules
+func TestAlertListCommand_Filters(t *testing.T) {
+	rules := []apiclient.AlertRule{
+		{ID: "1", Name: "Low Balance", MetricName: "account_balance", Condition: "<", Threshold: 100, Severity: "warning"},
+		{ID: "2", Name: "Nodes Down", MetricName: "network_nodes_available", Condition: "<", Threshold: 10, Severity: "critical"},
+		{ID: "3", Name: "Empty Balance", MetricName: "account_balance", Condition: "<", Threshold: 1, Severity: "critical"},
+	}
+	server := newAlertListServer(t, rules)
+	defer server.Close()
+	setGlobalFlags(server.URL, "info")
+
+	tests := []struct {
+		opts     alertListOptions
+		expected []string
+	}{
+		{alertListOptions{json: true, severity: "critical"}, []string{"2", "3"}},
+		{alertListOptions{json: true, metric: "account_balance"}, []string{"1", "3"}},
+		{alertListOptions{json: true, severity: "critical", metric: "account_balance"}, []string{"3"}},
+		{alertListOptions{json: true, severity: "info"}, []string{}},
+	}
+	for _, tt := range tests {
+		output := captureCommandOutput(t, func() error {
+			return handleAlertsList(tt.opts)
+		})
+		var response apiclient.AlertList
+		if err := json.Unmarshal([]byte(output), &response); err != nil {
+			t.Fatalf("Expected JSON output, got error %v for: %s", err, output)
+		}
+		ids := make([]string, 0, len(response.Alerts))
+		for _, rule := range response.Alerts {
+			ids = append(ids, rule.ID)
+		}
+		if !reflect.DeepEqual(ids, tt.expected) || response.Count != len(tt.expected) {
+			t.Errorf("Expected rules %v for %+v, got %v (count %d)", tt.expected, tt.opts, ids, response.Count)
+		}
+	}
+
+	// Text output reports the filtered count
+	output := captureCommandOutput(t, func() error {
+		return handleAlertsList(alertListOptions{severity: "critical"})
+	})
+	if !strings.Contains(output, "Configured Alert Rules (2)") || strings.Contains(output, "Low Balance") {
+		t.Errorf("Expected only the 2 critical rules, got: %s", output)
+	}
+
+	if err := handleAlertsList(alertListOptions{severity: "urgent"}); err == nil {
+		t.Error("Expected error for an invalid --severity")
+	}
+}
+
 // TestAlertListCommand_APIError tests handling of API errors
 func TestAlertListCommand_APIError(t *testing.T) {
 	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
@@ -89,7 +234,7 @@ func TestAlertListCommand_APIError(t *testing.T) {
 	defer server.Close()
 
 	setGlobalFlags(server.URL, "info")
-	err := handleAlertsList()
+	err := handleAlertsList(alertListOptions{})
 	if err == nil {
 		t.Errorf("Expected error on 500 response, got nil")
 	}
@@ -108,7 +253,7 @@ func TestAlertListCommand_InvalidJSON(t *testing.T) {
 	defer server.Close()
 
 	setGlobalFlags(server.URL, "info")
-	err := handleAlertsList()
+	err := handleAlertsList(alertListOptions{})
 	if err == nil {
 		t.Errorf("Expected error on invalid JSON, got nil")
 	} else if !strings.Contains(err.Error(), "decode") && !strings.Contains(err.Error(), "unmarshal") {
@@ -119,7 +264,7 @@ func TestAlertListCommand_InvalidJSON(t *testing.T) {
 // TestAlertListCommand_ConnectionRefused tests handling when API is unavailable
 func TestAlertListCommand_ConnectionRefused(t *testing.T) {
 	setGlobalFlags("http://localhost:1", "info")
-	err := handleAlertsList()
+	err := handleAlertsList(alertListOptions{})
 	if err == nil {
 		t.Errorf("Expected error on connection refused, got nil")
 	}
@@ -132,7 +277,7 @@ func TestAlertListCommand_ConnectionRefused(t *testing.T) {
 // TestAlertAddCommand_ValidRule tests adding a valid alert rule
 func TestAlertAddCommand_ValidRule(t *testing.T) {
 	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
-		rule := AlertRuleResponse{
+		rule := apiclient.AlertRule{
 			ID:         "rule1",
 			Name:       "Test Rule",
 			MetricName: "account_balance",
@@ -164,6 +309,28 @@ func TestAlertAddCommand_InvalidJSON(t *testing.T) {
 	}
 }
 
+// TestAlertAddCommand_UnknownField tests that unknown rule fields are rejected before reaching the API
+func TestAlertAddCommand_UnknownField(t *testing.T) {
+	called := false
+	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusCreated)
+	})
+	defer server.Close()
+
+	setGlobalFlags(server.URL, "info")
+	err := handleAlertAdd(`{"name":"test","metric_name":"test","condition":">","threshold":100,"severity":"warning","cooldown":60}`)
+	if err == nil {
+		t.Fatal("Expected error for unknown field, got nil")
+	}
+	if !strings.Contains(err.Error(), "cooldown") {
+		t.Errorf("Expected error naming the unknown field, got: %v", err)
+	}
+	if called {
+		t.Error("Expected API not to be called for an invalid rule")
+	}
+}
+
 // TestAlertAddCommand_MissingRequiredField tests validation of required fields
 func TestAlertAddCommand_MissingRequiredField(t *testing.T) {
 	missingFieldTests := []struct {
@@ -254,7 +421,7 @@ func TestAlertAddCommand_APIError(t *testing.T) {
 // TestAlertAddCommand_WithOptionalFields tests adding rule with optional fields
 func TestAlertAddCommand_WithOptionalFields(t *testing.T) {
 	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
-		rule := AlertRuleResponse{
+		rule := apiclient.AlertRule{
 			ID:              "rule1",
 			Name:            "Test",
 			Description:     "Test Description",
@@ -278,25 +445,273 @@ func TestAlertAddCommand_WithOptionalFields(t *testing.T) {
 	}
 }
 
+// runAlertsAddWithFlags executes "alerts add" with args, resetting the add flags afterwards
+func runAlertsAddWithFlags(t *testing.T, args ...string) error {
+	t.Helper()
+	t.Cleanup(func() {
+		alertFlags = alertRuleFlags{condition: ">", severity: "warning"}
+		for _, name := range []string{"metric", "condition", "threshold", "severity", "name", "description", "cooldown", "for", "tag", "annotation", "webhook", "denominator"} {
+			alertsAddCmd.Flags().Lookup(name).Changed = false
+		}
+		rootCmd.SetArgs(nil)
+	})
+
+	rootCmd.SetArgs(append([]string{"alerts", "add", "--api-url", apiURL}, args...))
+	_, err := captureCommandResult(t, rootCmd.Execute)
+	return err
+}
+
+// TestAlertAddCommand_Flags tests that flags build the rule sent to the API
+func TestAlertAddCommand_Flags(t *testing.T) {
+	var received apiclient.CreateAlertRequest
+	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(apiclient.AlertRule{ID: "rule", Name: received.Name})
+	})
+	defer server.Close()
+
+	setGlobalFlags(server.URL, "info")
+	err := runAlertsAddWithFlags(t, "--metric", "account_balance", "--condition", "<",
+		"--threshold", "1000000000", "--tag", "balances", "--for", "60")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := apiclient.CreateAlertRequest{
+		Name:       "account_balance < 1000000000",
+		MetricName: "account_balance",
+		Condition:  "<",
+		Threshold:  1000000000,
+		Severity:   "warning",
+		ForSeconds: 60,
+		Tags:       []string{"balances"},
+	}
+	if !reflect.DeepEqual(received, want) {
+		t.Errorf("Expected request %+v, got %+v", want, received)
+	}
+}
+
+// TestAlertAddCommand_FlagsScientificThreshold tests --threshold accepts scientific notation
+// and the default name shows the threshold in full
+func TestAlertAddCommand_FlagsScientificThreshold(t *testing.T) {
+	var received apiclient.CreateAlertRequest
+	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(apiclient.AlertRule{ID: "rule"})
+	})
+	defer server.Close()
+
+	setGlobalFlags(server.URL, "info")
+	if err := runAlertsAddWithFlags(t, "--metric", "account_balance", "--condition", "<", "--threshold", "1e9"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if received.Threshold != 1e9 {
+		t.Errorf("Expected threshold 1e9, got %v", received.Threshold)
+	}
+	if received.Name != "account_balance < 1000000000" {
+		t.Errorf("Expected default name without an exponent, got %q", received.Name)
+	}
+}
+
+// TestAlertAddCommand_FlagsRatio tests --denominator builds a ratio rule named after both metrics
+func TestAlertAddCommand_FlagsRatio(t *testing.T) {
+	var received apiclient.CreateAlertRequest
+	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(apiclient.AlertRule{ID: "rule"})
+	})
+	defer server.Close()
+
+	setGlobalFlags(server.URL, "info")
+	err := runAlertsAddWithFlags(t, "--metric", "failed_transactions", "--denominator", "total_transactions",
+		"--threshold", "0.1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if received.DenominatorMetric != "total_transactions" {
+		t.Errorf("Expected denominator total_transactions, got %q", received.DenominatorMetric)
+	}
+	if received.Name != "failed_transactions/total_transactions > 0.1" {
+		t.Errorf("Expected default name to show the ratio, got %q", received.Name)
+	}
+}
+
+// TestAlertAddCommand_FractionalThreshold tests fractional thresholds are displayed, not truncated
+func TestAlertAddCommand_FractionalThreshold(t *testing.T) {
+	var received apiclient.CreateAlertRequest
+	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(apiclient.AlertRule{ID: "rule", Condition: received.Condition, Threshold: received.Threshold})
+	})
+	defer server.Close()
+
+	setGlobalFlags(server.URL, "info")
+	output := captureCommandOutput(t, func() error {
+		return handleAlertAdd(`{"name":"Cheap HBAR","metric_name":"hbar_price_usd","condition":"<","threshold":0.05,"severity":"info"}`)
+	})
+
+	if received.Threshold != 0.05 {
+		t.Errorf("Expected threshold 0.05, got %v", received.Threshold)
+	}
+	if !strings.Contains(output, "Condition: < 0.05\n") {
+		t.Errorf("Expected fractional threshold in output, got: %s", output)
+	}
+}
+
+// TestAlertAddCommand_FlagsValidation tests invalid flag rules are rejected before reaching the API
+func TestAlertAddCommand_FlagsValidation(t *testing.T) {
+	called := false
+	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusCreated)
+	})
+	defer server.Close()
+
+	setGlobalFlags(server.URL, "info")
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"missing_threshold", []string{"--metric", "account_balance", "--condition", "<"}, "--threshold"},
+		{"bad_severity", []string{"--metric", "account_balance", "--threshold", "1", "--severity", "urgent"}, "severity"},
+		{"bad_condition", []string{"--metric", "account_balance", "--threshold", "1", "--condition", "=>"}, "condition"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runAlertsAddWithFlags(t, tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error mentioning %q, got: %v", tt.want, err)
+			}
+		})
+	}
+
+	if called {
+		t.Error("Expected API not to be called for invalid flag rules")
+	}
+}
+
+// TestAlertAddCommand_FlagsStateCondition tests state conditions don't need a threshold
+func TestAlertAddCommand_FlagsStateCondition(t *testing.T) {
+	var received apiclient.CreateAlertRequest
+	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(apiclient.AlertRule{ID: "rule"})
+	})
+	defer server.Close()
+
+	setGlobalFlags(server.URL, "info")
+	err := runAlertsAddWithFlags(t, "--metric", "account_balance", "--condition", "decreased",
+		"--name", "Balance Dropped", "--severity", "info")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if received.Name != "Balance Dropped" || received.Condition != "decreased" || received.Severity != "info" {
+		t.Errorf("Unexpected request: %+v", received)
+	}
+}
+
+// TestAlertAddCommand_FromFile tests adding every rule from a JSON array file
+func TestAlertAddCommand_FromFile(t *testing.T) {
+	var received []apiclient.CreateAlertRequest
+	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var req apiclient.CreateAlertRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, req)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(apiclient.AlertRule{ID: "rule", Name: req.Name})
+	})
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "rules.json")
+	rules := `[
+  {"name":"Low Balance","metric_name":"account_balance","condition":"<","threshold":1000000000,"severity":"warning"},
+  {"name":"Nodes Down","metric_name":"network_nodes_available","condition":"<","threshold":10,"severity":"critical"}
+]`
+	if err := os.WriteFile(path, []byte(rules), 0o600); err != nil {
+		t.Fatalf("Failed to write rules file: %v", err)
+	}
+
+	setGlobalFlags(server.URL, "info")
+	if err := handleAlertAddFromFile(path, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("Expected 2 rules submitted, got %d", len(received))
+	}
+	if received[0].Name != "Low Balance" || received[1].Name != "Nodes Down" {
+		t.Errorf("Expected rules submitted in file order, got %q and %q", received[0].Name, received[1].Name)
+	}
+}
+
+// TestAlertAddCommand_FromStdin tests reading a single rule object from stdin
+func TestAlertAddCommand_FromStdin(t *testing.T) {
+	calls := 0
+	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(apiclient.AlertRule{ID: "rule1", Name: "Test Rule"})
+	})
+	defer server.Close()
+
+	setGlobalFlags(server.URL, "info")
+	if err := handleAlertAddFromFile("-", strings.NewReader(createValidRuleJSON())); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 rule submitted, got %d", calls)
+	}
+}
+
+// TestAlertAddCommand_FromFile_Missing tests error for a missing rules file
+func TestAlertAddCommand_FromFile_Missing(t *testing.T) {
+	setGlobalFlags("http://localhost:8080", "info")
+	err := handleAlertAddFromFile(filepath.Join(t.TempDir(), "missing.json"), nil)
+	if err == nil {
+		t.Error("Expected error for missing file, got nil")
+	}
+}
+
 // ============================================================================
 // INTEGRATION TESTS FOR ALERTS COMMANDS
 // ============================================================================
 
 // TestAlertsIntegration_ListThenAdd tests full workflow: list then add rule
 func TestAlertsIntegration_ListThenAdd(t *testing.T) {
-	var rules []AlertRuleResponse
+	var rules []apiclient.AlertRule
 
 	// Create stateful mock API
 	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodGet {
 			// List endpoint
-			response := AlertListResponse{Alerts: rules, Count: len(rules)}
+			response := apiclient.AlertList{Alerts: rules, Count: len(rules)}
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusOK)
 			_ = json.NewEncoder(w).Encode(response)
 		} else if r.Method == http.MethodPost {
 			// Add endpoint
-			var newRule AlertRuleResponse
+			var newRule apiclient.AlertRule
 			_ = json.NewDecoder(r.Body).Decode(&newRule)
 			newRule.ID = "rule1"
 			rules = append(rules, newRule)
@@ -310,7 +725,7 @@ func TestAlertsIntegration_ListThenAdd(t *testing.T) {
 	setGlobalFlags(server.URL, "info")
 
 	// List empty rules
-	err := handleAlertsList()
+	err := handleAlertsList(alertListOptions{})
 	if err != nil {
 		t.Errorf("First list failed: %v", err)
 	}
@@ -322,7 +737,7 @@ func TestAlertsIntegration_ListThenAdd(t *testing.T) {
 	}
 
 	// List again - should have the rule
-	err = handleAlertsList()
+	err = handleAlertsList(alertListOptions{})
 	if err != nil {
 		t.Errorf("Second list failed: %v", err)
 	}
@@ -330,14 +745,14 @@ func TestAlertsIntegration_ListThenAdd(t *testing.T) {
 
 // TestAlertsIntegration_MultipleRules tests managing multiple alert rules
 func TestAlertsIntegration_MultipleRules(t *testing.T) {
-	rules := []AlertRuleResponse{
+	rules := []apiclient.AlertRule{
 		{ID: "1", Name: "Rule 1", MetricName: "metric1", Condition: ">", Threshold: 100, Severity: "warning"},
 		{ID: "2", Name: "Rule 2", MetricName: "metric2", Condition: "<", Threshold: 50, Severity: "critical"},
 		{ID: "3", Name: "Rule 3", MetricName: "metric3", Condition: "==", Threshold: 10, Severity: "info"},
 	}
 
 	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
-		response := AlertListResponse{Alerts: rules, Count: len(rules)}
+		response := apiclient.AlertList{Alerts: rules, Count: len(rules)}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		_ = json.NewEncoder(w).Encode(response)
@@ -345,12 +760,211 @@ func TestAlertsIntegration_MultipleRules(t *testing.T) {
 	defer server.Close()
 
 	setGlobalFlags(server.URL, "info")
-	err := handleAlertsList()
+	err := handleAlertsList(alertListOptions{})
 	if err != nil {
 		t.Errorf("Expected success, got error: %v", err)
 	}
 }
 
+// TestAlertsDeleteCommand tests deleting a rule by ID and reporting a missing one
+func TestAlertsDeleteCommand(t *testing.T) {
+	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("Expected DELETE, got %s", r.Method)
+		}
+		if r.URL.Query().Get("id") != "rule1" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"alert rule not found"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer server.Close()
+
+	setGlobalFlags(server.URL, "info")
+
+	var out bytes.Buffer
+	if err := handleAlertDelete("rule1", &out); err != nil {
+		t.Fatalf("Expected delete to succeed, got: %v", err)
+	}
+	if !strings.Contains(out.String(), "Deleted alert rule rule1") {
+		t.Errorf("Expected confirmation, got: %s", out.String())
+	}
+
+	err := handleAlertDelete("missing", &out)
+	if err == nil || !strings.Contains(err.Error(), `"missing" not found`) {
+		t.Errorf("Expected a not found error, got: %v", err)
+	}
+}
+
+// TestAlertsAddDefaults_Idempotent tests running add-defaults twice creates each rule once
+func TestAlertsAddDefaults_Idempotent(t *testing.T) {
+	rules := []apiclient.AlertRule{
+		{ID: "existing", Name: "Consensus Down", MetricName: "network_consensus_active", Condition: "<", Threshold: 1, Severity: "critical"},
+	}
+
+	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(apiclient.AlertList{Alerts: rules, Count: len(rules)})
+		case http.MethodPost:
+			var newRule apiclient.AlertRule
+			_ = json.NewDecoder(r.Body).Decode(&newRule)
+			newRule.ID = fmt.Sprintf("rule%d", len(rules))
+			rules = append(rules, newRule)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(newRule)
+		}
+	})
+	defer server.Close()
+
+	setGlobalFlags(server.URL, "info")
+
+	var out bytes.Buffer
+	if err := handleAlertsAddDefaults(&out); err != nil {
+		t.Fatalf("Expected first run to succeed, got: %v", err)
+	}
+	if !strings.Contains(out.String(), `Skipped "Consensus Down"`) {
+		t.Errorf("Expected the existing rule to be skipped, got:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "Added 2 of 3 default rules") {
+		t.Errorf("Expected 2 rules added on the first run, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := handleAlertsAddDefaults(&out); err != nil {
+		t.Fatalf("Expected second run to succeed, got: %v", err)
+	}
+	if !strings.Contains(out.String(), "Added 0 of 3 default rules") {
+		t.Errorf("Expected nothing added on the second run, got:\n%s", out.String())
+	}
+
+	if len(rules) != len(defaultAlertRules) {
+		t.Fatalf("Expected %d rules after two runs, got %d", len(defaultAlertRules), len(rules))
+	}
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		if seen[rule.Name] {
+			t.Errorf("Expected rule %q to be created once", rule.Name)
+		}
+		seen[rule.Name] = true
+	}
+}
+
+// writeReplayFile writes JSONL webhook payloads to a temp file
+func writeReplayFile(t *testing.T, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "deadletter.jsonl")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write replay file: %v", err)
+	}
+	return path
+}
+
+// newReplayTestConfig returns a webhook config that fails fast
+func newReplayTestConfig() alerting.WebhookConfig {
+	return alerting.WebhookConfig{
+		Timeout:        time.Second,
+		MaxRetries:     0,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	}
+}
+
+// TestAlertsReplayCommand_Success tests replaying every payload in a JSONL file
+func TestAlertsReplayCommand_Success(t *testing.T) {
+	var received []alerting.WebhookPayload
+	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var payload alerting.WebhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, payload)
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	path := writeReplayFile(t,
+		`{"rule_id":"low_balance","rule_name":"Low Balance","severity":"warning","message":"","value":5,"timestamp":1700000000,"metric_id":"account_balance[0.0.5000]"}`,
+		``,
+		`{"rule_id":"nodes_down","rule_name":"Nodes Down","severity":"critical","message":"","value":3,"timestamp":1700000060,"metric_id":"network_nodes_available"}`,
+	)
+
+	var out strings.Builder
+	if err := handleAlertsReplay(path, []string{server.URL}, true, newReplayTestConfig(), &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("Expected 2 payloads replayed, got %d", len(received))
+	}
+	if received[0].RuleID != "low_balance" || received[1].RuleID != "nodes_down" {
+		t.Errorf("Expected payloads replayed in file order, got %q and %q", received[0].RuleID, received[1].RuleID)
+	}
+	if !strings.Contains(out.String(), "line 1: replayed") || !strings.Contains(out.String(), "line 3: replayed") {
+		t.Errorf("Expected per-line results, got:\n%s", out.String())
+	}
+
+	data, _ := os.ReadFile(path)
+	if len(data) != 0 {
+		t.Errorf("Expected --remove to empty the file, got: %q", string(data))
+	}
+}
+
+// TestAlertsReplayCommand_PartialFailure tests that failed entries are reported and kept
+func TestAlertsReplayCommand_PartialFailure(t *testing.T) {
+	server := createMockAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var payload alerting.WebhookPayload
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		if payload.RuleID == "rejected" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	ok := `{"rule_id":"accepted","severity":"info","value":1,"timestamp":1700000000}`
+	rejected := `{"rule_id":"rejected","severity":"info","value":1,"timestamp":1700000000}`
+	invalid := `{"rule_id":"typo","sevrity":"info"}`
+	path := writeReplayFile(t, ok, rejected, invalid)
+
+	var out strings.Builder
+	err := handleAlertsReplay(path, []string{server.URL}, true, newReplayTestConfig(), &out)
+	if err == nil {
+		t.Fatal("Expected error when some payloads fail")
+	}
+	if !strings.Contains(err.Error(), "2 of 3") {
+		t.Errorf("Expected failure count in error, got: %v", err)
+	}
+	if !strings.Contains(out.String(), "line 2: failed") || !strings.Contains(out.String(), "line 3: failed: invalid payload") {
+		t.Errorf("Expected per-line failures, got:\n%s", out.String())
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != rejected+"\n"+invalid+"\n" {
+		t.Errorf("Expected only failed lines to remain, got: %q", string(data))
+	}
+}
+
+// TestAlertsReplayCommand_NoWebhooks tests that a replay without webhooks is rejected
+func TestAlertsReplayCommand_NoWebhooks(t *testing.T) {
+	path := writeReplayFile(t, `{"rule_id":"r"}`)
+	if err := handleAlertsReplay(path, nil, false, newReplayTestConfig(), io.Discard); err == nil {
+		t.Error("Expected error when no webhooks are configured")
+	}
+}
+
+// TestAlertsReplayCommand_InvalidWebhook tests that a malformed --webhook URL is rejected
+func TestAlertsReplayCommand_InvalidWebhook(t *testing.T) {
+	path := writeReplayFile(t, `{"rule_id":"r"}`)
+	if err := handleAlertsReplay(path, []string{"hooks.example.com/alerts"}, false, newReplayTestConfig(), io.Discard); err == nil {
+		t.Error("Expected error for a webhook URL without a scheme")
+	}
+}
+
 // ============================================================================
 // UNIT TESTS FOR ACCOUNT COMMANDS
 // ============================================================================
@@ -405,6 +1019,239 @@ func TestAccountTransactionsCommand_InvalidAccount(t *testing.T) {
 	t.Skip("Implement account transactions invalid account test")
 }
 
+// TestAccountTransactionsCommand_FormatDecimals tests HBAR amounts honour the decimals setting
+func TestAccountTransactionsCommand_FormatDecimals(t *testing.T) {
+	transactions := []hedera.Record{
+		{TransactionID: "0.0.5000@1700000000.000000000", Type: hedera.TransactionTypeCryptoTransfer, AmountTinyBar: -123_456_789, Status: "SUCCESS"},
+	}
+
+	if output := formatTransactions(transactions, 2); !strings.Contains(output, "-1.23") {
+		t.Errorf("Expected amount rounded to 2 decimals, got:\n%s", output)
+	}
+	if output := formatTransactions(transactions, 8); !strings.Contains(output, "-1.23456789") {
+		t.Errorf("Expected amount with 8 decimals, got:\n%s", output)
+	}
+	if output := formatTransactions(nil, 2); output != "No transactions found" {
+		t.Errorf("Expected empty message, got: %s", output)
+	}
+}
+
+// TestAccountTransactionsCommand_FilterSince tests records are filtered to the --since window
+func TestAccountTransactionsCommand_FilterSince(t *testing.T) {
+	now := time.Unix(1700086400, 0)
+	transactions := []hedera.Record{
+		{TransactionID: "old", Timestamp: now.Add(-48 * time.Hour).Unix()},
+		{TransactionID: "edge", Timestamp: now.Add(-24 * time.Hour).Unix()},
+		{TransactionID: "recent", Timestamp: now.Add(-time.Hour).Unix()},
+	}
+
+	tests := []struct {
+		since time.Duration
+		want  []string
+	}{
+		{0, []string{"old", "edge", "recent"}},
+		{24 * time.Hour, []string{"edge", "recent"}},
+		{2 * time.Hour, []string{"recent"}},
+		{time.Minute, []string{}},
+	}
+
+	for _, tt := range tests {
+		filtered := filterTransactionsSince(transactions, tt.since, now)
+		if len(filtered) != len(tt.want) {
+			t.Errorf("Expected %d records for --since %s, got %d", len(tt.want), tt.since, len(filtered))
+			continue
+		}
+		for i, tx := range filtered {
+			if tx.TransactionID != tt.want[i] {
+				t.Errorf("Expected record %s at %d for --since %s, got %s", tt.want[i], i, tt.since, tx.TransactionID)
+			}
+		}
+	}
+}
+
+// TestAccountInfoCommand_Format tests formatting details from a mocked AccountInfo
+func TestAccountInfoCommand_Format(t *testing.T) {
+	key, err := hiero.PrivateKeyGenerateEd25519()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	expiry := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	info := &hiero.AccountInfo{
+		AccountID:       hiero.AccountID{Shard: 0, Realm: 0, Account: 5000},
+		Key:             key.PublicKey(),
+		Balance:         hiero.HbarFromTinybar(1_250_000_000),
+		AutoRenewPeriod: 7776000 * time.Second,
+		AccountMemo:     "treasury",
+	}
+
+	details := newAccountDetails(info, expiry.Unix())
+	if details.BalanceTinybar != 1_250_000_000 || details.BalanceHbar != 12.5 {
+		t.Errorf("Expected balance 12.5 HBAR (1250000000 tinybar), got %f (%d)", details.BalanceHbar, details.BalanceTinybar)
+	}
+	if details.AutoRenewPeriodSeconds != 7776000 {
+		t.Errorf("Expected auto-renew 7776000 seconds, got %d", details.AutoRenewPeriodSeconds)
+	}
+
+	output := formatAccountDetails(details)
+	for _, want := range []string{
+		"Account 0.0.5000",
+		"12.5 ℏ (1250000000 tinybar)",
+		key.PublicKey().String(),
+		"2160h0m0s",
+		"2026-01-02T03:04:05Z",
+		"treasury",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Deleted") {
+		t.Errorf("Expected no deleted line for a live account, got:\n%s", output)
+	}
+}
+
+// TestAccountInfoCommand_FormatEmptyFields tests placeholders for missing key and memo
+func TestAccountInfoCommand_FormatEmptyFields(t *testing.T) {
+	info := &hiero.AccountInfo{AccountID: hiero.AccountID{Account: 5001}, IsDeleted: true}
+
+	output := formatAccountDetails(newAccountDetails(info, 0))
+	if strings.Count(output, "(none)") != 2 {
+		t.Errorf("Expected placeholders for key and memo, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Deleted:          true") {
+		t.Errorf("Expected deleted line, got:\n%s", output)
+	}
+}
+
+// TestAccountInfoCommand_JSON tests the --json field names
+func TestAccountInfoCommand_JSON(t *testing.T) {
+	info := &hiero.AccountInfo{
+		AccountID:   hiero.AccountID{Account: 5000},
+		Balance:     hiero.HbarFromTinybar(100),
+		AccountMemo: "memo",
+	}
+
+	data, err := json.Marshal(newAccountDetails(info, 1700000000))
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	for _, field := range []string{"account_id", "balance_tinybar", "balance_hbar", "key", "auto_renew_period_seconds", "expiry", "memo"} {
+		if _, ok := decoded[field]; !ok {
+			t.Errorf("Expected JSON field %q, got %s", field, string(data))
+		}
+	}
+	if decoded["expiry"] != float64(1700000000) {
+		t.Errorf("Expected expiry 1700000000, got %v", decoded["expiry"])
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes and reads
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// TestMetricsWatch_RejectedHandshake tests that watch gives up on a server that refuses the stream
+func TestMetricsWatch_RejectedHandshake(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- handleMetricsWatch(context.Background(), apiclient.New(server.URL, ""), "", &syncBuffer{}, &syncBuffer{}, watchBackoff{Initial: 10 * time.Millisecond, Max: 20 * time.Millisecond})
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, apiclient.ErrStreamRejected) {
+			t.Errorf("Expected ErrStreamRejected, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected watch to stop instead of reconnecting")
+	}
+}
+
+// TestMetricsWatch_PrintsAndReconnects tests that watch prints streamed metrics and reconnects after a drop
+func TestMetricsWatch_PrintsAndReconnects(t *testing.T) {
+	var mu sync.Mutex
+	connections := 0
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		mu.Lock()
+		connections++
+		n := connections
+		mu.Unlock()
+
+		metric := apiclient.Metric{
+			Name:      "account_balance",
+			Timestamp: 1700000000 + int64(n),
+			Value:     float64(n),
+			Labels:    map[string]string{"account_id": "0.0.5000"},
+		}
+		if err := websocket.JSON.Send(ws, metric); err != nil {
+			return
+		}
+		if n > 1 {
+			// Hold the second connection open until the client goes away
+			var discard string
+			_ = websocket.Message.Receive(ws, &discard)
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	out := &syncBuffer{}
+	errOut := &syncBuffer{}
+	done := make(chan error, 1)
+	go func() {
+		done <- handleMetricsWatch(ctx, apiclient.New(server.URL, ""), "", out, errOut, watchBackoff{Initial: 10 * time.Millisecond, Max: 20 * time.Millisecond})
+	}()
+
+	first := "2023-11-14T22:13:21Z account_balance{account_id=0.0.5000} 1"
+	second := "2023-11-14T22:13:22Z account_balance{account_id=0.0.5000} 2"
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(out.String(), second) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected both metrics to be printed, got %q", out.String())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !strings.Contains(out.String(), first) {
+		t.Errorf("Expected output to contain %q, got %q", first, out.String())
+	}
+	if !strings.Contains(errOut.String(), "reconnecting") {
+		t.Errorf("Expected reconnect notice on stderr, got %q", errOut.String())
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected nil error after cancel, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected watch to return after cancel")
+	}
+}
+
 // ============================================================================
 // HELPER FUNCTIONS FOR TESTING
 // ============================================================================
@@ -416,6 +1263,14 @@ func createMockAPIServer(t *testing.T, handler http.HandlerFunc) *httptest.Serve
 
 // captureCommandOutput captures stdout from running a cobra command
 func captureCommandOutput(t *testing.T, cmdFunc func() error) string {
+	output, _ := captureCommandResult(t, cmdFunc)
+	return output
+}
+
+// captureCommandResult captures stdout from running a cobra command along with the
+// command's error, which is handed back over a channel so the caller doesn't race
+// with the goroutine running the command
+func captureCommandResult(t *testing.T, cmdFunc func() error) (string, error) {
 	oldStdout := os.Stdout
 	r, w, err := os.Pipe()
 	if err != nil {
@@ -424,8 +1279,9 @@ func captureCommandOutput(t *testing.T, cmdFunc func() error) string {
 
 	os.Stdout = w
 
+	cmdErr := make(chan error, 1)
 	go func() {
-		_ = cmdFunc()
+		cmdErr <- cmdFunc()
 		w.Close()
 	}()
 
@@ -436,7 +1292,7 @@ func captureCommandOutput(t *testing.T, cmdFunc func() error) string {
 		t.Fatalf("Failed to read output: %v", err)
 	}
 
-	return string(output)
+	return string(output), <-cmdErr
 }
 
 // setGlobalFlags sets CLI global flags for testing
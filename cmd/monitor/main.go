@@ -2,13 +2,18 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/kaldun-tech/hedera-network-monitor/internal/alerting"
 	"github.com/kaldun-tech/hedera-network-monitor/internal/api"
 	"github.com/kaldun-tech/hedera-network-monitor/internal/collector"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/export"
 	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
 	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
 	"github.com/kaldun-tech/hedera-network-monitor/pkg/hedera"
@@ -16,15 +21,211 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+const defaultConfigFile = "config/config.yaml"
+
+// options holds the command-line options for the monitor service
+type options struct {
+	configFile string
+	once       bool          // Run every collector a single time, then exit
+	serveFor   time.Duration // In one-shot mode, keep the API up this long after collecting
+}
+
+// parseFlags parses command-line arguments into options
+func parseFlags(args []string) (options, error) {
+	var opts options
+	fs := flag.NewFlagSet("monitor", flag.ContinueOnError)
+	fs.StringVar(&opts.configFile, "config", defaultConfigFile, "Path to config file")
+	fs.BoolVar(&opts.once, "once", false, "Run each collector once, then exit")
+	fs.DurationVar(&opts.serveFor, "serve-for", 0, "With -once, serve the API this long before exiting (e.g. 30s)")
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if opts.serveFor < 0 {
+		return opts, fmt.Errorf("-serve-for must not be negative, got %s", opts.serveFor)
+	}
+	if opts.serveFor > 0 && !opts.once {
+		return opts, errors.New("-serve-for requires -once")
+	}
+	return opts, nil
+}
+
 func main() {
-	// Load configuration
-	cfg, err := config.Load("config/config.yaml")
+	opts, err := parseFlags(os.Args[1:])
 	if err != nil {
-		// Use default logger before config is loaded
-		logger.Error("Failed to load configuration", "error", err)
+		os.Exit(2)
+	}
+
+	// Setup context with cancellation
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Setup signal handling for graceful shutdown
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	// Wait for shutdown signal in a separate goroutine
+	go func() {
+		sig := <-sigChan
+		logger.Info("Received signal, initiating graceful shutdown", "signal", sig)
+		cancel()
+	}()
+
+	if err := run(ctx, opts); err != nil {
+		logger.Error("Service error", "error", err)
 		os.Exit(1)
 	}
 
+	logger.Info("Service shut down successfully")
+}
+
+// shutdownSummary totals what the service did while it ran, logged once on shutdown
+type shutdownSummary struct {
+	Uptime           time.Duration
+	MetricsCollected int64
+	AlertsFired      int64
+	WebhookFailures  int64
+}
+
+// buildShutdownSummary reads the counters from the collectors and alert manager
+func buildShutdownSummary(uptime time.Duration, collectors []collector.Collector, alertManager *alerting.Manager) shutdownSummary {
+	summary := shutdownSummary{
+		Uptime:          uptime,
+		AlertsFired:     alertManager.AlertsFired(),
+		WebhookFailures: alertManager.WebhookFailures(),
+	}
+	for _, c := range collectors {
+		if counter, ok := c.(interface{ MetricsCollected() int64 }); ok {
+			summary.MetricsCollected += counter.MetricsCollected()
+		}
+	}
+	return summary
+}
+
+// logArgs returns the summary as structured log key-value pairs
+func (s shutdownSummary) logArgs() []any {
+	return []any{
+		"uptime", s.Uptime.Round(time.Second).String(),
+		"metrics_collected", s.MetricsCollected,
+		"alerts_fired", s.AlertsFired,
+		"webhook_failures", s.WebhookFailures,
+	}
+}
+
+// newClientFunc creates a Hedera client for a monitored network; injectable for tests
+type newClientFunc func(network config.NetworkConfig) (hedera.Client, error)
+
+// newHederaClient connects to a network as its operator, reading records from its
+// mirror node when one is configured
+func newHederaClient(network config.NetworkConfig) (hedera.Client, error) {
+	client, err := hedera.NewClient(network.Name, network.Fallback, network.OperatorID, network.OperatorKey)
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := client.(interface{ SetMirrorNode(string) }); ok {
+		m.SetMirrorNode(network.MirrorNodeURL)
+	}
+	return client, nil
+}
+
+// buildCollectors creates the account, network and operator collectors for each
+// monitored network. When several networks are configured via networks, every
+// collector labels its metrics with network=<name> so the sets stay distinguishable
+func buildCollectors(cfg *config.Config, newClient newClientFunc) ([]collector.Collector, error) {
+	var collectors []collector.Collector
+	portfolioNetwork := cfg.PortfolioNetwork()
+	for _, monitored := range cfg.MonitoredNetworks() {
+		hederaClient, err := newClient(monitored.Network)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Hedera client for %s: %w", monitored.Network.Name, err)
+		}
+		if a, ok := hederaClient.(interface{ SetAddressBookTTL(time.Duration) }); ok {
+			a.SetAddressBookTTL(time.Duration(cfg.Collectors.AddressBookTTLSeconds) * time.Second)
+		}
+
+		accountCollector := collector.NewAccountCollector(hederaClient, monitored.Accounts)
+		accountCollector.SetIncludeZeroTypes(cfg.Collectors.IncludeZeroTransactionTypes)
+		accountCollector.SetTransactionTypes(cfg.Collectors.TransactionTypes)
+		accountCollector.SetRecordsLimit(cfg.Collectors.RecordsLimit)
+		if monitored.Network.Name == portfolioNetwork {
+			accountCollector.SetPortfolioAccounts(cfg.Collectors.PortfolioAccounts)
+		}
+		networkCollectors := []collector.Collector{
+			accountCollector,
+			collector.NewNetworkCollector(hederaClient),
+		}
+
+		// Always watch the operator account, since every query is paid from it
+		if op, ok := hederaClient.(interface{ OperatorAccountID() string }); ok {
+			networkCollectors = append(networkCollectors, collector.NewOperatorCollector(hederaClient,
+				op.OperatorAccountID(), cfg.Collectors.OperatorBalanceFloor))
+		}
+
+		mirrorURL := monitored.Network.MirrorNodeURL
+		if mirrorURL == "" && cfg.Collectors.HbarPrice {
+			mirrorURL = hedera.DefaultMirrorNodeURL(monitored.Network.Name)
+		}
+		if mirrorURL != "" {
+			mirror := hedera.NewMirrorClient(mirrorURL)
+
+			// Probe the mirror node so outages that blank out mirror-backed metrics are visible
+			networkCollectors = append(networkCollectors, collector.NewMirrorHealthCollector(mirror))
+
+			// Price balances in USD from the network's exchange rate, read from the free mirror node
+			if cfg.Collectors.HbarPrice {
+				networkCollectors = append(networkCollectors, collector.NewPriceCollector(mirror))
+			}
+		}
+
+		if len(cfg.Networks) > 0 {
+			for _, c := range networkCollectors {
+				if n, ok := c.(interface{ SetNetwork(string) }); ok {
+					n.SetNetwork(monitored.Network.Name)
+				}
+			}
+		}
+		collectors = append(collectors, networkCollectors...)
+	}
+	return collectors, nil
+}
+
+// runOnce runs every collector a single time, then keeps the API serving the results
+// for serveFor. Collection failures are logged rather than returned, as in the
+// collection loop, so a cron run still exits cleanly with partial metrics
+func runOnce(ctx context.Context, collectors []collector.Collector, store storage.Storage,
+	alertMgr collector.AlertManager, serveFor time.Duration) error {
+	if err := collector.RunOnce(ctx, collectors, store, alertMgr); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		logger.Warn("One-shot collection completed with failures", "summary", err)
+	}
+
+	logger.Info("One-shot collection complete", "serve_for", serveFor)
+	if serveFor <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(serveFor)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run loads configuration, starts all service components, and blocks until
+// the context is cancelled or a component fails
+func run(ctx context.Context, opts options) error {
+	startedAt := time.Now()
+
+	// Load configuration
+	cfg, err := config.Load(opts.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration from %s: %w", opts.configFile, err)
+	}
+
 	// Initialize logger based on configuration
 	logLevel := logger.ParseLevel(cfg.Logging.Level)
 	if cfg.Logging.Format == "json" {
@@ -33,36 +234,115 @@ func main() {
 		logger.Init(logLevel, os.Stdout)
 	}
 
+	networkNames := make([]string, 0, len(cfg.MonitoredNetworks()))
+	for _, monitored := range cfg.MonitoredNetworks() {
+		networkNames = append(networkNames, monitored.Network.Name)
+	}
 	logger.Info("Starting Hedera Network Monitor",
-		"network", cfg.Network.Name,
+		"networks", networkNames,
 		"log_level", cfg.Logging.Level,
-		"log_format", cfg.Logging.Format)
-
-	// Setup context with cancellation
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	// Setup signal handling for graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+		"log_format", cfg.Logging.Format,
+		"config_file", opts.configFile)
 
 	// Initialize components
-	hederaClient, err := hedera.NewClient(cfg.Network.Name, cfg.Network.OperatorID, cfg.Network.OperatorKey)
-	if err != nil {
-		logger.Error("Failed to create Hedera client", "error", err)
-		os.Exit(1)
-	}
 	store := storage.NewMemoryStorage()
+	if cfg.Storage.SnapshotPath != "" {
+		// A bad snapshot shouldn't keep the monitor down; start empty instead
+		if err := storage.LoadSnapshotFile(store, cfg.Storage.SnapshotPath); err != nil {
+			logger.Warn("Failed to restore metrics snapshot, starting empty", "error", err)
+		}
+	}
+	// Global labels go on everything the monitor produces: collected metrics, webhook
+	// delivery metrics and API request metrics
+	var labeledStore storage.Storage = store
+	if len(cfg.GlobalLabels) > 0 {
+		labeledStore = storage.NewLabelingStorage(store, cfg.GlobalLabels)
+	}
+	// Collectors store through the sampler, the global labels and the series limit, so
+	// configured high-frequency metrics keep 1 of every N samples; alerts still see every
+	// sample as collected. The limit sits directly on the store so it counts series by the
+	// label sets actually stored
+	var collectorStore storage.Storage = store
+	if cfg.Storage.MaxSeriesPerMetric > 0 {
+		collectorStore = storage.NewCardinalityLimitStorage(collectorStore, cfg.Storage.MaxSeriesPerMetric)
+	}
+	if len(cfg.GlobalLabels) > 0 {
+		collectorStore = storage.NewLabelingStorage(collectorStore, cfg.GlobalLabels)
+	}
+	if len(cfg.Storage.SampleEveryByName) > 0 {
+		collectorStore = storage.NewSamplingStorage(collectorStore, cfg.Storage.SampleEveryByName)
+	}
 	alertManager := alerting.NewManager(cfg.Alerting)
+	alertManager.SetDeliveryMetricsStore(labeledStore)
+	if cfg.Alerting.WebhookCAFile != "" || cfg.Alerting.WebhookInsecureSkipVerify {
+		if err := alertManager.SetWebhookTLS(cfg.Alerting.WebhookCAFile, cfg.Alerting.WebhookInsecureSkipVerify); err != nil {
+			return fmt.Errorf("failed to configure webhook TLS: %w", err)
+		}
+	}
 
-	// Initialize collectors
-	collectors := []collector.Collector{
-		collector.NewAccountCollector(hederaClient, cfg.Accounts),
-		collector.NewNetworkCollector(hederaClient),
+	// Initialize collectors for every monitored network
+	collectors, err := buildCollectors(cfg, newHederaClient)
+	if err != nil {
+		return err
+	}
+
+	// Apply scheduling jitter so collectors don't query the network in lockstep,
+	// bound each query so a stuck one can't stall a whole cycle, namespace
+	// metric names so several monitors can share a push target, and round values
+	// before they are stored and checked against alert rules
+	jitter := time.Duration(cfg.Collectors.JitterSeconds) * time.Second
+	queryTimeout := time.Duration(cfg.Collectors.QueryTimeoutSeconds) * time.Second
+	roundDecimals := -1
+	if cfg.Storage.RoundDecimals != nil {
+		roundDecimals = *cfg.Storage.RoundDecimals
+	}
+	for _, c := range collectors {
+		if j, ok := c.(interface{ SetJitter(time.Duration) }); ok {
+			j.SetJitter(jitter)
+		}
+		if q, ok := c.(interface{ SetQueryTimeout(time.Duration) }); ok {
+			q.SetQueryTimeout(queryTimeout)
+		}
+		if n, ok := c.(interface{ SetNamespace(string) }); ok {
+			n.SetNamespace(cfg.Collectors.Namespace)
+		}
+		if r, ok := c.(interface{ SetRounding(int, map[string]int) }); ok {
+			r.SetRounding(roundDecimals, cfg.Storage.RoundDecimalsByName)
+		}
 	}
 
-	// Initialize API server
-	server := api.NewServer(cfg.API.Port, store, alertManager)
+	// Initialize API server and register collectors for on-demand runs
+	// Without alerting, the API serves metrics only and the alert endpoints report 503
+	var alertAPI api.AlertingManager = alertManager
+	if !cfg.Alerting.Enabled {
+		alertAPI = api.NoopAlertManager{}
+	}
+	server := api.NewServer(cfg.API.Port, store, alertAPI)
+	server.SetRequestMetricsStore(labeledStore)
+	if cfg.API.TLSCert != "" && cfg.API.TLSKey != "" {
+		if err := server.SetTLS(cfg.API.TLSCert, cfg.API.TLSKey); err != nil {
+			return fmt.Errorf("failed to configure API TLS: %w", err)
+		}
+	}
+	server.SetAuthTokens(cfg.API.AuthToken, cfg.API.ScrapeToken)
+	server.SetMaxBodyBytes(cfg.API.MaxBodyBytes)
+	server.SetCORS(cfg.API.CORS)
+	server.SetStrictParams(cfg.API.StrictParams)
+	server.SetReloader(func() (api.ReloadResponse, error) {
+		return reloadConfig(opts.configFile, alertManager)
+	})
+	if !opts.once {
+		// One-shot collectors run no loop to pick up on-demand triggers
+		for _, c := range collectors {
+			if t, ok := c.(api.CollectTrigger); ok {
+				server.AddCollector(t)
+			}
+		}
+	}
+
+	// In one-shot mode every component is stopped once collection (and serve-for) is done
+	ctx, stopService := context.WithCancel(ctx)
+	defer stopService()
 
 	// Run service in goroutine group with error handling
 	eg, egCtx := errgroup.WithContext(ctx)
@@ -73,13 +353,22 @@ func main() {
 		return server.Start(egCtx)
 	})
 
-	// Start collectors
-	for _, c := range collectors {
+	// Start collectors: a single pass in one-shot mode, otherwise one loop each
+	loops := collectors
+	if opts.once {
+		loops = nil
+		eg.Go(func() error {
+			defer stopService()
+			return runOnce(egCtx, collectors, collectorStore, alertManager, opts.serveFor)
+		})
+	}
+	for _, c := range loops {
 		// Capture collector in local variable to avoid closure issue
 		coll := c
 		eg.Go(func() error {
 			logger.Info("Starting collector", "name", coll.Name())
-			return coll.Collect(egCtx, store, alertManager)
+			// Partial failures are logged per cycle; Collect only returns once stopped
+			return coll.Collect(egCtx, collectorStore, alertManager)
 		})
 	}
 
@@ -89,18 +378,114 @@ func main() {
 		return alertManager.Run(egCtx)
 	})
 
-	// Wait for shutdown signal in a separate goroutine
-	go func() {
-		sig := <-sigChan
-		logger.Info("Received signal, initiating graceful shutdown", "signal", sig)
-		cancel()
-	}()
+	// SIGHUP reloads the same settings as POST /api/v1/reload
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	eg.Go(func() error {
+		return reloadOnSignal(egCtx, hup, opts.configFile, alertManager)
+	})
+
+	// Start retention job if configured
+	retention := storage.RetentionPolicy{
+		Default: time.Duration(cfg.Storage.RetentionSeconds) * time.Second,
+		ByName:  make(map[string]time.Duration, len(cfg.Storage.RetentionByName)),
+	}
+	for name, seconds := range cfg.Storage.RetentionByName {
+		retention.ByName[name] = time.Duration(seconds) * time.Second
+	}
+	if retention.Enabled() {
+		eg.Go(func() error {
+			return storage.RunRetention(egCtx, store, retention, storage.DefaultRetentionInterval)
+		})
+	}
+
+	// Start remote-write exporter if configured
+	if cfg.Export.RemoteWriteURL != "" {
+		exporter := export.NewRemoteWriteExporter(cfg.Export.RemoteWriteURL,
+			time.Duration(cfg.Export.IntervalSeconds)*time.Second, store)
+		exporter.SetAccountLabel(cfg.Export.AccountLabel)
+		eg.Go(func() error {
+			return exporter.Run(egCtx)
+		})
+	}
 
 	// Wait for all services to complete or error
-	if err := eg.Wait(); err != nil {
-		logger.Error("Service error", "error", err)
-		os.Exit(1)
+	err = eg.Wait()
+	if opts.once && errors.Is(err, context.Canceled) {
+		// The other components report the one-shot stop as a cancellation
+		err = nil
 	}
 
-	logger.Info("Service shut down successfully")
+	// Collectors have stopped, so the snapshot captures everything stored
+	if cfg.Storage.SnapshotPath != "" {
+		if snapErr := storage.SaveSnapshotFile(store, cfg.Storage.SnapshotPath); snapErr != nil {
+			logger.Error("Failed to save metrics snapshot", "error", snapErr)
+		}
+	}
+
+	// Close last so buffered writes are flushed, and report a failure as a shutdown error
+	err = errors.Join(err, closeStorage(store))
+
+	summary := buildShutdownSummary(time.Since(startedAt), collectors, alertManager)
+	logger.Info("Shutdown summary", summary.logArgs()...)
+	return err
+}
+
+// reloadConfig re-reads configFile and applies the settings that can change at runtime:
+// alert rules, alert webhooks and the log level. Everything else needs a restart
+// An invalid file is rejected without applying anything
+func reloadConfig(configFile string, alertManager *alerting.Manager) (api.ReloadResponse, error) {
+	// config.Load falls back to defaults for a missing file, which would drop every rule
+	if _, err := os.Stat(configFile); err != nil {
+		return api.ReloadResponse{}, fmt.Errorf("failed to reload configuration: %w", err)
+	}
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		return api.ReloadResponse{}, fmt.Errorf("failed to reload configuration from %s: %w", configFile, err)
+	}
+
+	result := alertManager.Reload(cfg.Alerting)
+	logger.SetLevel(logger.ParseLevel(cfg.Logging.Level))
+	return api.ReloadResponse{
+		RulesAdded:      result.Added,
+		RulesRemoved:    result.Removed,
+		RulesUpdated:    result.Updated,
+		WebhooksChanged: result.WebhooksChanged,
+		LogLevel:        cfg.Logging.Level,
+	}, nil
+}
+
+// reloadOnSignal runs reloadConfig for every signal received on sigs until ctx is done
+// A failed reload is logged and leaves the running configuration in place
+func reloadOnSignal(ctx context.Context, sigs <-chan os.Signal, configFile string, alertManager *alerting.Manager) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case sig := <-sigs:
+			logger.Info("Received signal, reloading configuration", "signal", sig, "config_file", configFile)
+			response, err := reloadConfig(configFile, alertManager)
+			if err != nil {
+				logger.Error("Configuration reload failed", "error", err)
+				continue
+			}
+			logger.Info("Configuration reloaded",
+				"rules_added", response.RulesAdded,
+				"rules_removed", response.RulesRemoved,
+				"rules_updated", response.RulesUpdated,
+				"webhooks_changed", response.WebhooksChanged,
+				"log_level", response.LogLevel)
+		}
+	}
+}
+
+// closeStorage closes store, logging and returning any error since a failed
+// flush means stored metrics may have been lost
+func closeStorage(store storage.Storage) error {
+	if err := store.Close(); err != nil {
+		logger.Error("Failed to close storage, metrics may have been lost", "error", err)
+		return fmt.Errorf("failed to close storage: %w", err)
+	}
+	return nil
 }
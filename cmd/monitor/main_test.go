@@ -0,0 +1,450 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/alerting"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/collector"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/hedera"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+)
+
+// TestParseFlags_Default tests that the config flag defaults to the standard path
+func TestParseFlags_Default(t *testing.T) {
+	opts, err := parseFlags([]string{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if opts.configFile != defaultConfigFile {
+		t.Errorf("expected config file %s, got %s", defaultConfigFile, opts.configFile)
+	}
+}
+
+// TestParseFlags_Config tests that a custom config path is parsed
+func TestParseFlags_Config(t *testing.T) {
+	opts, err := parseFlags([]string{"-config", "/tmp/other.yaml"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if opts.configFile != "/tmp/other.yaml" {
+		t.Errorf("expected config file /tmp/other.yaml, got %s", opts.configFile)
+	}
+}
+
+// TestParseFlags_Once tests the one-shot flags and that serve-for requires -once
+func TestParseFlags_Once(t *testing.T) {
+	opts, err := parseFlags([]string{"-once", "-serve-for", "30s"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !opts.once || opts.serveFor != 30*time.Second {
+		t.Errorf("expected once with serve-for 30s, got %+v", opts)
+	}
+
+	if _, err := parseFlags([]string{"-serve-for", "30s"}); err == nil {
+		t.Error("expected error for -serve-for without -once")
+	}
+	if _, err := parseFlags([]string{"-once", "-serve-for", "-1s"}); err == nil {
+		t.Error("expected error for a negative -serve-for")
+	}
+}
+
+// TestRun_UsesConfigFlag tests that run loads the config file given by the flag
+func TestRun_UsesConfigFlag(t *testing.T) {
+	// An invalid config proves the file was read: a missing file would fall back to defaults
+	configPath := filepath.Join(t.TempDir(), "custom.yaml")
+	content := "network:\n  name: invalid\n"
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	opts, err := parseFlags([]string{"-config", configPath})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	err = run(context.Background(), opts)
+	if err == nil {
+		t.Fatal("expected error loading invalid config")
+	}
+	if !strings.Contains(err.Error(), configPath) {
+		t.Errorf("expected error to reference %s, got: %v", configPath, err)
+	}
+	if !strings.Contains(err.Error(), "invalid network name") {
+		t.Errorf("expected network validation error, got: %v", err)
+	}
+}
+
+// reloadTestConfig is a valid config with one alert rule on ruleMetric
+func reloadTestConfig(ruleID, ruleMetric, level string) string {
+	return `network:
+  name: testnet
+accounts:
+  - id: "0.0.5000"
+    label: "Main"
+logging:
+  level: ` + level + `
+alerting:
+  enabled: true
+  webhooks:
+    - "https://hooks.example.com/alerts"
+  rules:
+    - id: "` + ruleID + `"
+      name: "Rule ` + ruleID + `"
+      metric_name: "` + ruleMetric + `"
+      condition: "<"
+      threshold: 100
+      severity: "warning"
+`
+}
+
+// TestReloadConfig_UpdatesRules tests that editing the config file and reloading
+// replaces the loaded rules and applies the new log level
+func TestReloadConfig_UpdatesRules(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(reloadTestConfig("low_balance", "account_balance", "info")), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	alertManager := alerting.NewManager(cfg.Alerting)
+
+	if err := os.WriteFile(configPath, []byte(reloadTestConfig("consensus_down", "network_consensus_active", "debug")), 0600); err != nil {
+		t.Fatalf("failed to rewrite config: %v", err)
+	}
+	defer logger.SetLevel(logger.LevelInfo)
+
+	response, err := reloadConfig(configPath, alertManager)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !reflect.DeepEqual(response.RulesAdded, []string{"consensus_down"}) {
+		t.Errorf("expected consensus_down added, got %v", response.RulesAdded)
+	}
+	if !reflect.DeepEqual(response.RulesRemoved, []string{"low_balance"}) {
+		t.Errorf("expected low_balance removed, got %v", response.RulesRemoved)
+	}
+	if response.LogLevel != "debug" {
+		t.Errorf("expected log level debug, got %s", response.LogLevel)
+	}
+
+	rules := alertManager.GetRules()
+	if len(rules) != 1 || rules[0].ID != "consensus_down" || rules[0].MetricName != "network_consensus_active" {
+		t.Errorf("expected only the consensus_down rule after reload, got %+v", rules)
+	}
+}
+
+// TestReloadConfig_MissingFile tests that a missing config file is an error, not a reset to defaults
+func TestReloadConfig_MissingFile(t *testing.T) {
+	alertManager := alerting.NewManager(config.AlertingConfig{
+		QueueBufferSize: 10,
+		Rules:           []config.AlertRule{{ID: "low_balance", Name: "Low", MetricName: "account_balance", Condition: "<", Severity: "warning"}},
+	})
+
+	if _, err := reloadConfig(filepath.Join(t.TempDir(), "missing.yaml"), alertManager); err == nil {
+		t.Fatal("expected error for a missing config file")
+	}
+	if rules := alertManager.GetRules(); len(rules) != 1 {
+		t.Errorf("expected the rules to be kept, got %d", len(rules))
+	}
+}
+
+// TestReloadOnSignal tests that a signal reloads the config file like the API does
+func TestReloadOnSignal(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(reloadTestConfig("low_balance", "account_balance", "info")), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	alertManager := alerting.NewManager(cfg.Alerting)
+	if err := os.WriteFile(configPath, []byte(reloadTestConfig("consensus_down", "network_consensus_active", "info")), 0600); err != nil {
+		t.Fatalf("failed to rewrite config: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sigs := make(chan os.Signal)
+	done := make(chan error, 1)
+	go func() { done <- reloadOnSignal(ctx, sigs, configPath, alertManager) }()
+
+	// The unbuffered send returns once the signal is taken; the second waits for the first reload
+	sigs <- syscall.SIGHUP
+	sigs <- syscall.SIGHUP
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	rules := alertManager.GetRules()
+	if len(rules) != 1 || rules[0].ID != "consensus_down" {
+		t.Errorf("expected the consensus_down rule after SIGHUP, got %+v", rules)
+	}
+}
+
+// countingCollector is a collector that reports a fixed number of collected metrics
+type countingCollector struct {
+	collected int64
+}
+
+func (c *countingCollector) Name() string {
+	return "CountingCollector"
+}
+
+func (c *countingCollector) Collect(ctx context.Context, store storage.Storage, alertMgr collector.AlertManager) error {
+	return nil
+}
+
+func (c *countingCollector) MetricsCollected() int64 {
+	return c.collected
+}
+
+// storingCollector is a one-shot collector that stores a single metric per cycle
+type storingCollector struct {
+	countingCollector
+}
+
+func (c *storingCollector) CollectOnce(ctx context.Context, store storage.Storage, alertMgr collector.AlertManager) error {
+	return store.StoreMetric(types.Metric{Name: "one_shot", Timestamp: time.Now().Unix(), Value: 1})
+}
+
+// TestRunOnce_StoresAndReturns tests one-shot mode collects, serves for the given time and returns
+func TestRunOnce_StoresAndReturns(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	collectors := []collector.Collector{&storingCollector{}}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- runOnce(context.Background(), collectors, store, nil, 10*time.Millisecond)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected runOnce to return after serve-for elapsed")
+	}
+
+	if metrics, _ := store.GetMetrics("one_shot", 0); len(metrics) != 1 {
+		t.Errorf("expected 1 stored metric, got %d", len(metrics))
+	}
+}
+
+// TestRunOnce_CancelledWhileServing tests a shutdown signal ends the serve-for wait early
+func TestRunOnce_CancelledWhileServing(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- runOnce(ctx, []collector.Collector{&storingCollector{}}, storage.NewMemoryStorage(), nil, time.Hour)
+	}()
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected runOnce to stop when cancelled")
+	}
+}
+
+// errFlushFailed is the forced flush failure of flakyStorage
+var errFlushFailed = errors.New("disk full")
+
+// flakyStorage is a buffering backend whose flush on Close fails
+type flakyStorage struct {
+	*storage.MemoryStorage
+	flushErr error
+	closed   bool
+}
+
+func (s *flakyStorage) Close() error {
+	s.closed = true
+	if s.flushErr != nil {
+		return s.flushErr
+	}
+	return s.MemoryStorage.Close()
+}
+
+// TestCloseStorage_SurfacesFlushError tests a failed flush on Close is returned, not swallowed
+func TestCloseStorage_SurfacesFlushError(t *testing.T) {
+	store := &flakyStorage{MemoryStorage: storage.NewMemoryStorage(), flushErr: errFlushFailed}
+
+	err := closeStorage(store)
+	if !store.closed {
+		t.Error("expected Close to be called")
+	}
+	if !errors.Is(err, errFlushFailed) {
+		t.Errorf("expected the flush error to be returned, got: %v", err)
+	}
+
+	store.flushErr = nil
+	if err := closeStorage(store); err != nil {
+		t.Errorf("expected no error after a clean flush, got: %v", err)
+	}
+}
+
+// TestBuildShutdownSummary tests that collector counts are summed with the alert manager's counters
+func TestBuildShutdownSummary(t *testing.T) {
+	collectors := []collector.Collector{
+		&countingCollector{collected: 12},
+		&countingCollector{collected: 30},
+	}
+	alertManager := alerting.NewManager(config.AlertingConfig{QueueBufferSize: 1})
+
+	summary := buildShutdownSummary(90*time.Minute, collectors, alertManager)
+
+	expected := shutdownSummary{Uptime: 90 * time.Minute, MetricsCollected: 42}
+	if summary != expected {
+		t.Errorf("expected summary %+v, got %+v", expected, summary)
+	}
+}
+
+// TestShutdownSummary_LogArgs tests the summary's structured log fields for known counts
+func TestShutdownSummary_LogArgs(t *testing.T) {
+	summary := shutdownSummary{
+		Uptime:           2*time.Hour + 3*time.Minute + 4*time.Second + 600*time.Millisecond,
+		MetricsCollected: 1500,
+		AlertsFired:      7,
+		WebhookFailures:  2,
+	}
+
+	expected := []any{
+		"uptime", "2h3m5s",
+		"metrics_collected", int64(1500),
+		"alerts_fired", int64(7),
+		"webhook_failures", int64(2),
+	}
+	if args := summary.logArgs(); !reflect.DeepEqual(args, expected) {
+		t.Errorf("expected log args %v, got %v", expected, args)
+	}
+}
+
+// TestBuildCollectors_MultipleNetworks tests that each network gets its own collector set
+// labelled with the network name
+func TestBuildCollectors_MultipleNetworks(t *testing.T) {
+	cfg := &config.Config{
+		Networks: []config.MonitoredNetwork{
+			{Network: config.NetworkConfig{Name: "testnet"}, Accounts: []collector.AccountConfig{{ID: "0.0.5000"}}},
+			{Network: config.NetworkConfig{Name: "mainnet"}, Accounts: []collector.AccountConfig{{ID: "0.0.6000"}}},
+		},
+	}
+	var clientsFor []string
+	newClient := func(network config.NetworkConfig) (hedera.Client, error) {
+		clientsFor = append(clientsFor, network.Name)
+		return nil, nil
+	}
+
+	collectors, err := buildCollectors(cfg, newClient)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !reflect.DeepEqual(clientsFor, []string{"testnet", "mainnet"}) {
+		t.Errorf("expected a client per network, got %v", clientsFor)
+	}
+
+	// Each network gets an account and a network collector
+	counts := make(map[string]int)
+	for _, c := range collectors {
+		n, ok := c.(interface{ Network() string })
+		if !ok {
+			t.Fatalf("expected %s to support network labels", c.Name())
+		}
+		counts[n.Network()+"/"+c.Name()]++
+	}
+	expected := map[string]int{
+		"testnet/AccountCollector": 1,
+		"testnet/NetworkCollector": 1,
+		"mainnet/AccountCollector": 1,
+		"mainnet/NetworkCollector": 1,
+	}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("expected collectors %v, got %v", expected, counts)
+	}
+}
+
+// TestBuildCollectors_SingleNetwork tests that the top-level network isn't labelled,
+// so existing series keep their labels
+func TestBuildCollectors_SingleNetwork(t *testing.T) {
+	cfg := &config.Config{
+		Network:  config.NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{{ID: "0.0.5000"}},
+	}
+	collectors, err := buildCollectors(cfg, func(config.NetworkConfig) (hedera.Client, error) { return nil, nil })
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(collectors) != 2 {
+		t.Fatalf("expected 2 collectors, got %d", len(collectors))
+	}
+	for _, c := range collectors {
+		if n := c.(interface{ Network() string }).Network(); n != "" {
+			t.Errorf("expected no network label on %s, got %q", c.Name(), n)
+		}
+	}
+}
+
+// noisyPriceSource returns its prices in turn, repeating the last one
+type noisyPriceSource struct {
+	prices []float64
+	calls  int
+}
+
+func (s *noisyPriceSource) GetHbarPriceUSD() (float64, error) {
+	price := s.prices[min(s.calls, len(s.prices)-1)]
+	s.calls++
+	return price, nil
+}
+
+// TestRounding_ChangedRuleIgnoresNoise tests that a "changed" rule doesn't fire when
+// collected values differ only below the configured precision
+func TestRounding_ChangedRuleIgnoresNoise(t *testing.T) {
+	// Computed at run time; the constant expression 0.1 + 0.2 is exactly 0.3
+	tenth, fifth := 0.1, 0.2
+	noisy := tenth + fifth
+
+	fires := func(round bool) int64 {
+		manager := alerting.NewManager(config.AlertingConfig{
+			Enabled:         true,
+			QueueBufferSize: 10,
+			Rules: []config.AlertRule{
+				{ID: "price_changed", Name: "Price Changed", MetricName: "hbar_price_usd", Condition: "changed", Severity: "info"},
+			},
+		})
+		pc := collector.NewPriceCollector(&noisyPriceSource{prices: []float64{noisy, 0.3}})
+		if round {
+			pc.SetRounding(6, nil)
+		}
+		store := storage.NewMemoryStorage()
+		for range 2 {
+			if err := collector.RunOnce(context.Background(), []collector.Collector{pc}, store, manager); err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+		}
+		return manager.AlertsFired()
+	}
+
+	if got := fires(false); got != 1 {
+		t.Fatalf("expected float noise to fire the rule without rounding, got %d alerts", got)
+	}
+	if got := fires(true); got != 0 {
+		t.Errorf("expected no alert for sub-precision noise with rounding, got %d", got)
+	}
+}
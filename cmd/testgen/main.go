@@ -2,7 +2,9 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
+	"math/rand/v2"
 	"time"
 
 	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
@@ -24,10 +26,18 @@ func main() {
 	count := flag.Int("count", defaultTransactionCount, "Number of transactions to send")
 	intervalSeconds := flag.Int("interval", defaultIntervalSeconds, "Seconds between transactions")
 	amountTinybar := flag.Int64("amount", defaultAmountTinybar, "Amount in tinybar to transfer")
+	amountMax := flag.Int64("amount-max", 0, "Randomize each amount between -amount and this many tinybar (0 = fixed amount)")
+	ramp := flag.Int64("ramp", 0, "Tinybar added to the amount for each successive transaction")
 	network := flag.String("network", "", "Override network from config (mainnet/testnet)")
+	dryRun := flag.Bool("dry-run", false, "Log the transactions that would be sent without sending them")
 
 	flag.Parse()
 
+	plan := amountPlan{base: *amountTinybar, max: *amountMax, ramp: *ramp}
+	if err := plan.validate(); err != nil {
+		log.Fatalf("Invalid amount flags: %v", err)
+	}
+
 	// Load configuration
 	cfg, err := config.Load(*configFile)
 	if err != nil {
@@ -54,10 +64,14 @@ func main() {
 
 	setOperator(client, cfg)
 	fromAccount, toAccount := determineAccounts(cfg, fromAccountID, toAccountID)
-	logConfiguration(networkName, fromAccount, toAccount, *count, *intervalSeconds, *amountTinybar)
-	sendTransactions(client, fromAccount, toAccount, *count, *intervalSeconds, *amountTinybar)
+	logConfiguration(networkName, fromAccount, toAccount, *count, *intervalSeconds, plan, *dryRun)
+	sendTransactions(&sdkExecutor{client: client}, fromAccount, toAccount, *count, *intervalSeconds, plan, *dryRun)
 
 	log.Println()
+	if *dryRun {
+		log.Printf("Dry run complete: %d transactions logged, none sent", *count)
+		return
+	}
 	log.Printf("✅ Completed %d transactions", *count)
 	log.Println("Metrics should now be updating. Check ./hmon account balance <account-id> to verify")
 }
@@ -110,36 +124,115 @@ func determineAccounts(cfg *config.Config, fromFlag, toFlag *string) (hiero.Acco
 
 // logConfiguration prints the transaction generator configuration
 func logConfiguration(networkName string, fromAccount, toAccount hiero.AccountID, count, intervalSeconds int,
-	amountTinybar int64) {
+	plan amountPlan, dryRun bool) {
 	log.Printf("Starting transaction generator")
 	log.Printf("Network: %s", networkName)
 	log.Printf("From: %s", fromAccount)
 	log.Printf("To: %s", toAccount)
 	log.Printf("Count: %d transactions", count)
 	log.Printf("Interval: %d seconds", intervalSeconds)
-	log.Printf("Amount: %d tinybar (%.2f HBAR)", amountTinybar,
-		float64(amountTinybar)/float64(hedera.TinybarPerHbar))
+	log.Printf("Amount: %s", plan)
+	if dryRun {
+		log.Printf("Mode: dry run (no transactions will be sent)")
+	}
 	log.Println()
 }
 
+// amountPlan chooses the amount of each transfer, so balances change by varying
+// amounts and exercise the changed/increased/decreased alert conditions
+type amountPlan struct {
+	base int64 // Amount of the first transfer, and the lower bound when randomized
+	max  int64 // Upper bound of the first transfer when randomized; 0 sends base
+	ramp int64 // Added to the amount (and range) for each successive transfer
+}
+
+// validate checks the plan only produces positive amounts
+func (p amountPlan) validate() error {
+	if p.base <= 0 {
+		return fmt.Errorf("amount must be positive, got %d", p.base)
+	}
+	if p.max != 0 && p.max < p.base {
+		return fmt.Errorf("amount-max (%d) must be at least amount (%d)", p.max, p.base)
+	}
+	if p.ramp < 0 {
+		return fmt.Errorf("ramp must not be negative, got %d", p.ramp)
+	}
+	return nil
+}
+
+// amountFor returns the amount in tinybar of the i-th transfer (1-based)
+// randN returns a random number in [0, n), e.g. rand.Int64N
+func (p amountPlan) amountFor(i int, randN func(n int64) int64) int64 {
+	amount := p.base + p.ramp*int64(i-1)
+	if p.base < p.max {
+		amount += randN(p.max - p.base + 1)
+	}
+	return amount
+}
+
+// String describes the plan for the configuration log
+func (p amountPlan) String() string {
+	description := fmt.Sprintf("%d tinybar (%s HBAR)", p.base, hedera.FormatHbar(p.base))
+	if p.base < p.max {
+		description = fmt.Sprintf("random %d-%d tinybar (%s-%s HBAR)",
+			p.base, p.max, hedera.FormatHbar(p.base), hedera.FormatHbar(p.max))
+	}
+	if 0 < p.ramp {
+		description += fmt.Sprintf(", increasing by %d tinybar each transaction", p.ramp)
+	}
+	return description
+}
+
+// transactionExecutor submits transfers and fetches their receipts
+type transactionExecutor interface {
+	Execute(txn *hiero.TransferTransaction) (hiero.TransactionResponse, error)
+	GetReceipt(response hiero.TransactionResponse) (hiero.TransactionReceipt, error)
+}
+
+// sdkExecutor executes transactions against the network through a Hedera client
+type sdkExecutor struct {
+	client *hiero.Client
+}
+
+// Execute implements transactionExecutor
+func (e *sdkExecutor) Execute(txn *hiero.TransferTransaction) (hiero.TransactionResponse, error) {
+	return txn.Execute(e.client)
+}
+
+// GetReceipt implements transactionExecutor
+func (e *sdkExecutor) GetReceipt(response hiero.TransactionResponse) (hiero.TransactionReceipt, error) {
+	return response.GetReceipt(e.client)
+}
+
 // sendTransactions sends HBAR transfers from one account to another
-func sendTransactions(client *hiero.Client, fromAccount, toAccount hiero.AccountID, count, intervalSeconds int,
-	amountTinybar int64) {
+// With dryRun set each transfer is only logged: nothing is executed and no time is spent waiting
+func sendTransactions(executor transactionExecutor, fromAccount, toAccount hiero.AccountID, count, intervalSeconds int,
+	plan amountPlan, dryRun bool) {
 	for i := 1; i <= count; i++ {
-		log.Printf("[%d/%d] Sending transaction...", i, count)
+		amountTinybar := plan.amountFor(i, rand.Int64N)
+		if dryRun {
+			log.Printf("[%d/%d] Dry run: would send %d tinybar (%s HBAR) from %s to %s",
+				i, count, amountTinybar, hedera.FormatHbar(amountTinybar), fromAccount, toAccount)
+			if i < count {
+				log.Printf("  Would wait %d seconds before next transaction", intervalSeconds)
+			}
+			continue
+		}
+
+		log.Printf("[%d/%d] Sending %d tinybar (%s HBAR)...", i, count, amountTinybar, hedera.FormatHbar(amountTinybar))
 
 		txn := hiero.NewTransferTransaction().
 			AddHbarTransfer(fromAccount, hiero.HbarFromTinybar(-amountTinybar)).
 			AddHbarTransfer(toAccount, hiero.HbarFromTinybar(amountTinybar))
 
-		txResponse, err := txn.Execute(client)
+		txResponse, err := executor.Execute(txn)
 		if err != nil {
 			log.Printf("  ❌ Failed to execute transaction: %v", err)
 			continue
 		}
 
 		transactionID := txResponse.TransactionID
-		receipt, err := txResponse.GetReceipt(client)
+		receipt, err := executor.GetReceipt(txResponse)
 		if err != nil {
 			log.Printf("  ❌ Failed to get receipt: %v", err)
 		} else {
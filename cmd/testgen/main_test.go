@@ -0,0 +1,124 @@
+package main
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+)
+
+// fakeExecutor counts transfers and receipt queries instead of sending them
+type fakeExecutor struct {
+	executes int
+	receipts int
+}
+
+func (f *fakeExecutor) Execute(txn *hiero.TransferTransaction) (hiero.TransactionResponse, error) {
+	f.executes++
+	return hiero.TransactionResponse{}, nil
+}
+
+func (f *fakeExecutor) GetReceipt(response hiero.TransactionResponse) (hiero.TransactionReceipt, error) {
+	f.receipts++
+	return hiero.TransactionReceipt{Status: hiero.StatusSuccess}, nil
+}
+
+// TestSendTransactions_DryRun tests that a dry run never executes or queries receipts
+func TestSendTransactions_DryRun(t *testing.T) {
+	executor := &fakeExecutor{}
+	from := hiero.AccountID{Account: 2}
+	to := hiero.AccountID{Account: 5000}
+
+	sendTransactions(executor, from, to, 3, 60, amountPlan{base: 1000000}, true)
+
+	if executor.executes != 0 || executor.receipts != 0 {
+		t.Errorf("Expected no executes or receipts in a dry run, got %d executes and %d receipts",
+			executor.executes, executor.receipts)
+	}
+}
+
+// TestSendTransactions_Sends tests that each transfer is executed and its receipt fetched
+func TestSendTransactions_Sends(t *testing.T) {
+	executor := &fakeExecutor{}
+	from := hiero.AccountID{Account: 2}
+	to := hiero.AccountID{Account: 5000}
+
+	sendTransactions(executor, from, to, 3, 0, amountPlan{base: 1000000}, false)
+
+	if executor.executes != 3 || executor.receipts != 3 {
+		t.Errorf("Expected 3 executes and 3 receipts, got %d executes and %d receipts",
+			executor.executes, executor.receipts)
+	}
+}
+
+// TestAmountPlan_Fixed tests the default plan sends the same amount every time
+func TestAmountPlan_Fixed(t *testing.T) {
+	plan := amountPlan{base: 1000000}
+	for i := 1; i <= 5; i++ {
+		if got := plan.amountFor(i, rand.Int64N); got != 1000000 {
+			t.Errorf("Expected transfer %d to send 1000000, got %d", i, got)
+		}
+	}
+}
+
+// TestAmountPlan_RangeBounds tests random amounts stay within [base, max] and reach both ends
+func TestAmountPlan_RangeBounds(t *testing.T) {
+	plan := amountPlan{base: 100, max: 105}
+
+	if got := plan.amountFor(1, func(n int64) int64 { return 0 }); got != 100 {
+		t.Errorf("Expected the lowest draw to send 100, got %d", got)
+	}
+	if got := plan.amountFor(1, func(n int64) int64 { return n - 1 }); got != 105 {
+		t.Errorf("Expected the highest draw to send 105, got %d", got)
+	}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	for i := 0; i < 1000; i++ {
+		if got := plan.amountFor(1, rng.Int64N); got < 100 || 105 < got {
+			t.Fatalf("Expected amount within [100, 105], got %d", got)
+		}
+	}
+}
+
+// TestAmountPlan_Ramp tests the amount, and any random range, grows by ramp each transfer
+func TestAmountPlan_Ramp(t *testing.T) {
+	plan := amountPlan{base: 1000, ramp: 500}
+	for i, expected := range []int64{1000, 1500, 2000, 2500} {
+		if got := plan.amountFor(i+1, rand.Int64N); got != expected {
+			t.Errorf("Expected transfer %d to send %d, got %d", i+1, expected, got)
+		}
+	}
+
+	ranged := amountPlan{base: 1000, max: 1100, ramp: 500}
+	lowest := func(n int64) int64 { return 0 }
+	highest := func(n int64) int64 { return n - 1 }
+	if got := ranged.amountFor(3, lowest); got != 2000 {
+		t.Errorf("Expected the third transfer's range to start at 2000, got %d", got)
+	}
+	if got := ranged.amountFor(3, highest); got != 2100 {
+		t.Errorf("Expected the third transfer's range to end at 2100, got %d", got)
+	}
+}
+
+// TestAmountPlan_Validate tests plans that could send non-positive or inverted amounts are rejected
+func TestAmountPlan_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		plan    amountPlan
+		wantErr bool
+	}{
+		{"fixed", amountPlan{base: 1000}, false},
+		{"range and ramp", amountPlan{base: 1000, max: 2000, ramp: 10}, false},
+		{"zero amount", amountPlan{base: 0}, true},
+		{"max below amount", amountPlan{base: 1000, max: 999}, true},
+		{"negative ramp", amountPlan{base: 1000, ramp: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.plan.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package alerting
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+)
+
+// digestEnabled reports whether alerts are batched into digests instead of sent one by one
+func (m *Manager) digestEnabled() bool {
+	return 0 < m.digestInterval
+}
+
+// bufferDigest adds alert to the pending digest of each webhook
+// A digest that reaches digestMaxAlerts is sent immediately instead of waiting for the timer
+func (m *Manager) bufferDigest(ctx context.Context, webhooks []string, alert AlertEvent) {
+	var full []string
+	m.digestMutex.Lock()
+	for _, webhook := range webhooks {
+		m.digestBuffers[webhook] = append(m.digestBuffers[webhook], alert)
+		if 0 < m.digestMaxAlerts && m.digestMaxAlerts <= len(m.digestBuffers[webhook]) {
+			full = append(full, webhook)
+		}
+	}
+	m.digestMutex.Unlock()
+
+	for _, webhook := range full {
+		m.flushDigest(ctx, webhook)
+	}
+}
+
+// flushDigests sends every pending digest
+func (m *Manager) flushDigests(ctx context.Context) {
+	m.digestMutex.Lock()
+	webhooks := slices.Sorted(maps.Keys(m.digestBuffers))
+	m.digestMutex.Unlock()
+
+	for _, webhook := range webhooks {
+		m.flushDigest(ctx, webhook)
+	}
+}
+
+// flushDigest sends the alerts buffered for webhookURL as one payload
+// Like dispatch, it waits for a free delivery slot and delivers in the background
+func (m *Manager) flushDigest(ctx context.Context, webhookURL string) {
+	m.digestMutex.Lock()
+	alerts := m.digestBuffers[webhookURL]
+	delete(m.digestBuffers, webhookURL)
+	m.digestMutex.Unlock()
+
+	if len(alerts) == 0 {
+		return
+	}
+	if !m.acquireWebhookSlot(ctx) {
+		m.webhookFailures.Add(1)
+		logger.Error("Alert digest abandoned waiting for a free slot",
+			"component", "AlertManager",
+			"webhook_url", webhookURL,
+			"alerts", len(alerts),
+			"error", ctx.Err())
+		return
+	}
+	m.inflight.Add(1)
+	go func() {
+		defer m.inflight.Done()
+		defer m.releaseWebhookSlot()
+		m.sendDigest(ctx, webhookURL, alerts)
+	}()
+}
+
+// sendDigest delivers alerts to webhookURL as one digest, counting and logging failures
+func (m *Manager) sendDigest(ctx context.Context, webhookURL string, alerts []AlertEvent) {
+	start := time.Now()
+	err := m.deliverDigest(ctx, webhookURL, alerts)
+	m.recordDelivery(webhookURL, err, time.Since(start))
+	if err != nil {
+		m.webhookFailures.Add(1)
+		logger.Error("Failed to send alert digest",
+			"component", "AlertManager",
+			"webhook_url", webhookURL,
+			"alerts", len(alerts),
+			"error", err)
+	}
+}
+
+// deliverDigest builds a payload for each alert and POSTs them to webhookURL as a JSON array
+func (m *Manager) deliverDigest(ctx context.Context, webhookURL string, alerts []AlertEvent) error {
+	version, err := WebhookSchemaVersionFor(webhookURL)
+	if err != nil {
+		return err
+	}
+
+	payloads := make([]WebhookPayload, len(alerts))
+	for i, alert := range alerts {
+		payloads[i] = newWebhookPayload(version, alert)
+	}
+	return SendWebhookDigestCtx(ctx, webhookURL, payloads, m.webhookConfig)
+}
@@ -0,0 +1,146 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
+)
+
+// newDigestServer returns a webhook receiver that forwards each decoded digest to the returned channel
+func newDigestServer(t *testing.T) (*httptest.Server, chan []WebhookPayload) {
+	digests := make(chan []WebhookPayload, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payloads []WebhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payloads); err != nil {
+			t.Errorf("Failed to decode digest: %v", err)
+		}
+		digests <- payloads
+		w.WriteHeader(http.StatusOK)
+	}))
+	return server, digests
+}
+
+// newDigestManager returns a manager in digest mode sending to webhookURL
+func newDigestManager(webhookURL string, intervalSeconds, maxAlerts int) *Manager {
+	return NewManager(config.AlertingConfig{
+		Enabled:              true,
+		Webhooks:             []string{webhookURL},
+		QueueBufferSize:      10,
+		CooldownSeconds:      300,
+		ShutdownGraceSeconds: 5,
+		Digest: config.DigestConfig{
+			Enabled:         true,
+			IntervalSeconds: intervalSeconds,
+			MaxAlerts:       maxAlerts,
+		},
+	})
+}
+
+// digestAlert returns a distinct alert for digest tests
+func digestAlert(i int) AlertEvent {
+	return AlertEvent{
+		RuleID:   fmt.Sprintf("rule_%d", i),
+		RuleName: "Digest Test",
+		Severity: "warning",
+		Value:    float64(i),
+	}
+}
+
+// TestDigest_FlushesAtMaxAlerts tests that a digest is sent as soon as it holds max_alerts alerts
+func TestDigest_FlushesAtMaxAlerts(t *testing.T) {
+	server, digests := newDigestServer(t)
+	defer server.Close()
+
+	manager := newDigestManager(server.URL, 3600, 3)
+	ctx := context.Background()
+
+	manager.dispatch(ctx, digestAlert(0))
+	manager.dispatch(ctx, digestAlert(1))
+	select {
+	case payloads := <-digests:
+		t.Fatalf("Expected no digest before max_alerts, got %d alerts", len(payloads))
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	manager.dispatch(ctx, digestAlert(2))
+	select {
+	case payloads := <-digests:
+		if len(payloads) != 3 {
+			t.Fatalf("Expected 3 alerts in the digest, got %d", len(payloads))
+		}
+		for i, payload := range payloads {
+			if payload.RuleID != fmt.Sprintf("rule_%d", i) {
+				t.Errorf("Expected alert %d to be rule_%d, got %s", i, i, payload.RuleID)
+			}
+			if payload.SchemaVersion != WebhookSchemaVersion {
+				t.Errorf("Expected schema version %d, got %d", WebhookSchemaVersion, payload.SchemaVersion)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected a digest once max_alerts was reached")
+	}
+}
+
+// TestDigest_FlushesOnTimer tests that buffered alerts are sent together when the interval elapses
+func TestDigest_FlushesOnTimer(t *testing.T) {
+	server, digests := newDigestServer(t)
+	defer server.Close()
+
+	manager := newDigestManager(server.URL, 3600, 0)
+	manager.digestInterval = 20 * time.Millisecond
+
+	manager.alertQueue <- digestAlert(0)
+	manager.alertQueue <- digestAlert(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = manager.Run(ctx)
+	}()
+
+	select {
+	case payloads := <-digests:
+		if len(payloads) != 2 {
+			t.Errorf("Expected 2 alerts in the digest, got %d", len(payloads))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected a digest when the interval elapsed")
+	}
+}
+
+// TestDigest_FlushesOnShutdown tests that pending digests are sent during the shutdown drain
+func TestDigest_FlushesOnShutdown(t *testing.T) {
+	server, digests := newDigestServer(t)
+	defer server.Close()
+
+	manager := newDigestManager(server.URL, 3600, 0)
+	for i := 0; i < 3; i++ {
+		manager.alertQueue <- digestAlert(i)
+	}
+
+	// Cancel before Run starts so every alert is drained at shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := manager.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	select {
+	case payloads := <-digests:
+		if len(payloads) != 3 {
+			t.Errorf("Expected 3 alerts in the digest, got %d", len(payloads))
+		}
+	default:
+		t.Fatal("Expected the pending digest to be sent before Run returned")
+	}
+	if len(digests) != 0 {
+		t.Errorf("Expected a single digest, got %d more", len(digests))
+	}
+}
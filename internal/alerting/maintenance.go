@@ -0,0 +1,91 @@
+package alerting
+
+import (
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+)
+
+// MaintenanceWindow is a period during which alerts are evaluated but not sent
+// The zero value means no window is scheduled
+type MaintenanceWindow struct {
+	Start time.Time // When suppression begins
+	End   time.Time // When suppression ends; zero keeps it open until cleared
+}
+
+// IsZero reports whether no window is scheduled
+func (w MaintenanceWindow) IsZero() bool {
+	return w.Start.IsZero()
+}
+
+// Active reports whether alerts are suppressed at now
+func (w MaintenanceWindow) Active(now time.Time) bool {
+	if w.IsZero() || now.Before(w.Start) {
+		return false
+	}
+	return w.End.IsZero() || now.Before(w.End)
+}
+
+// expired reports whether a timed window has closed at now
+func (w MaintenanceWindow) expired(now time.Time) bool {
+	return !w.End.IsZero() && !now.Before(w.End)
+}
+
+// maintenanceFromConfig builds the startup window from config
+// An enabled window without a start opens at now
+func maintenanceFromConfig(cfg config.MaintenanceConfig, now time.Time) MaintenanceWindow {
+	if !cfg.Enabled {
+		return MaintenanceWindow{}
+	}
+	// Bounds are checked by config validation; a bad value leaves the window open-ended
+	start, end, _ := cfg.Window()
+	if start.IsZero() {
+		start = now
+	}
+	return MaintenanceWindow{Start: start, End: end}
+}
+
+// SetMaintenance schedules a maintenance window, replacing any existing one
+// While it is active, alerts still fire and are recorded in History, but no
+// webhooks are called. A zero window ends maintenance immediately
+func (m *Manager) SetMaintenance(window MaintenanceWindow) {
+	m.maintenanceMutex.Lock()
+	m.maintenance = window
+	m.maintenanceMutex.Unlock()
+
+	if window.IsZero() {
+		logger.Info("Maintenance window cleared", "component", "AlertManager")
+		return
+	}
+	end := "until cleared"
+	if !window.End.IsZero() {
+		end = window.End.Format(time.RFC3339)
+	}
+	logger.Info("Maintenance window scheduled",
+		"component", "AlertManager",
+		"start", window.Start.Format(time.RFC3339),
+		"end", end)
+}
+
+// Maintenance returns the scheduled maintenance window, or the zero window if
+// none is scheduled. Timed windows are dropped once their end has passed
+func (m *Manager) Maintenance() MaintenanceWindow {
+	now := m.now()
+
+	m.maintenanceMutex.Lock()
+	defer m.maintenanceMutex.Unlock()
+
+	if m.maintenance.expired(now) {
+		logger.Info("Maintenance window ended",
+			"component", "AlertManager",
+			"end", m.maintenance.End.Format(time.RFC3339))
+		m.maintenance = MaintenanceWindow{}
+	}
+	return m.maintenance
+}
+
+// inMaintenance reports whether alert delivery is currently suppressed
+func (m *Manager) inMaintenance() bool {
+	return m.Maintenance().Active(m.now())
+}
@@ -0,0 +1,154 @@
+package alerting
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
+)
+
+// newMaintenanceTestManager returns a manager with one low-balance rule and no cooldown
+// whose webhook deliveries are counted by received
+func newMaintenanceTestManager(t *testing.T, received *atomic.Int32) *Manager {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return NewManager(config.AlertingConfig{
+		Enabled:         true,
+		Webhooks:        []string{server.URL},
+		QueueBufferSize: 10,
+		CooldownSeconds: 1,
+		Rules: []config.AlertRule{{
+			ID:              "low_balance",
+			MetricName:      "account_balance",
+			Condition:       "<",
+			Threshold:       100,
+			Severity:        "warning",
+			CooldownSeconds: 1,
+		}},
+	})
+}
+
+// fireAndDrain evaluates a firing metric and dispatches the queued alert
+func fireAndDrain(t *testing.T, manager *Manager) {
+	t.Helper()
+	manager.alertMutex.Lock()
+	clear(manager.lastAlerts) // Skip the cooldown between fires
+	manager.alertMutex.Unlock()
+
+	if err := manager.CheckMetric(types.Metric{Name: "account_balance", Value: 50}); err != nil {
+		t.Fatalf("CheckMetric failed: %v", err)
+	}
+	if err := manager.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain failed: %v", err)
+	}
+}
+
+// TestMaintenance_SuppressesThenResumes tests that alerts are recorded but not sent
+// during a timed window, and are sent again once it expires
+func TestMaintenance_SuppressesThenResumes(t *testing.T) {
+	var received atomic.Int32
+	manager := newMaintenanceTestManager(t, &received)
+
+	clock := time.Unix(1700000000, 0)
+	manager.now = func() time.Time { return clock }
+	manager.SetMaintenance(MaintenanceWindow{Start: clock, End: clock.Add(time.Hour)})
+
+	fireAndDrain(t, manager)
+
+	if got := received.Load(); got != 0 {
+		t.Errorf("Expected no webhook deliveries during maintenance, got %d", got)
+	}
+	if manager.AlertsSuppressed() != 1 {
+		t.Errorf("Expected 1 suppressed alert, got %d", manager.AlertsSuppressed())
+	}
+	history := manager.History()
+	if len(history) != 1 || !history[0].Suppressed || history[0].RuleID != "low_balance" {
+		t.Fatalf("Expected one suppressed low_balance alert in history, got %+v", history)
+	}
+
+	// The window expires on its own
+	clock = clock.Add(time.Hour)
+	fireAndDrain(t, manager)
+
+	if got := received.Load(); got != 1 {
+		t.Errorf("Expected 1 webhook delivery after maintenance, got %d", got)
+	}
+	if !manager.Maintenance().IsZero() {
+		t.Errorf("Expected the expired window to be cleared, got %+v", manager.Maintenance())
+	}
+	history = manager.History()
+	if len(history) != 2 || history[1].Suppressed {
+		t.Errorf("Expected the second alert to be recorded as sent, got %+v", history)
+	}
+}
+
+// TestMaintenance_ClearEndsOpenWindow tests an open-ended window lasts until cleared
+func TestMaintenance_ClearEndsOpenWindow(t *testing.T) {
+	var received atomic.Int32
+	manager := newMaintenanceTestManager(t, &received)
+
+	manager.SetMaintenance(MaintenanceWindow{Start: time.Now()})
+	fireAndDrain(t, manager)
+	if got := received.Load(); got != 0 {
+		t.Errorf("Expected no webhook deliveries during maintenance, got %d", got)
+	}
+
+	manager.SetMaintenance(MaintenanceWindow{})
+	fireAndDrain(t, manager)
+	if got := received.Load(); got != 1 {
+		t.Errorf("Expected 1 webhook delivery after clearing maintenance, got %d", got)
+	}
+}
+
+// TestMaintenance_FutureWindow tests a window doesn't suppress alerts before it starts
+func TestMaintenance_FutureWindow(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	window := MaintenanceWindow{Start: now.Add(time.Minute), End: now.Add(time.Hour)}
+
+	if window.Active(now) {
+		t.Error("Expected window to be inactive before its start")
+	}
+	if !window.Active(now.Add(time.Minute)) {
+		t.Error("Expected window to be active at its start")
+	}
+	if window.Active(now.Add(time.Hour)) {
+		t.Error("Expected window to be inactive at its end")
+	}
+	if (MaintenanceWindow{}).Active(now) {
+		t.Error("Expected the zero window to never be active")
+	}
+}
+
+// TestMaintenance_FromConfig tests the startup window built from config
+func TestMaintenance_FromConfig(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	if window := maintenanceFromConfig(config.MaintenanceConfig{End: "2030-01-01T00:00:00Z"}, now); !window.IsZero() {
+		t.Errorf("Expected no window when disabled, got %+v", window)
+	}
+
+	window := maintenanceFromConfig(config.MaintenanceConfig{Enabled: true}, now)
+	if !window.Start.Equal(now) || !window.End.IsZero() {
+		t.Errorf("Expected an open window starting now, got %+v", window)
+	}
+
+	window = maintenanceFromConfig(config.MaintenanceConfig{
+		Enabled: true,
+		Start:   "2030-01-01T00:00:00Z",
+		End:     "2030-01-01T02:00:00Z",
+	}, now)
+	expectedStart := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !window.Start.Equal(expectedStart) || !window.End.Equal(expectedStart.Add(2*time.Hour)) {
+		t.Errorf("Expected the configured bounds, got %+v", window)
+	}
+}
@@ -3,77 +3,269 @@ package alerting
 import (
 	"context"
 	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"text/template"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
 	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
 	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
 	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
 )
 
+// alertHistoryLimit is how many dispatched alerts History keeps
+const alertHistoryLimit = 1000
+
 // MetricState tracks the state of a metric for alert evaluation
 type MetricState struct {
 	Value       float64
 	Initialized bool
 }
 
+// fireCount tracks consecutive fires of a rule on one series for escalation
+type fireCount struct {
+	count int
+	first time.Time // When the first counted fire happened
+}
+
 // Manager handles alert rules and sending notifications
 type Manager struct {
 	rules           []AlertRule
-	webhooks        []string // Webhook URLs for notifications
+	configRules     map[string]bool // IDs of rules loaded from config, which Reload may update or remove; guarded by ruleMutex
+	webhooks        []string        // Webhook URLs for notifications; guarded by ruleMutex
 	alertQueue      chan AlertEvent
 	ruleMutex       sync.RWMutex
-	lastAlerts      map[string]time.Time   // Track when we last alerted on each rule to avoid spam
-	lastMetrics     map[string]MetricState // Maps rule ID to previously observed metric state
+	lastAlerts      map[string]time.Time    // Track when we last alerted on each rule to avoid spam
+	lastMetrics     map[string]MetricState  // Maps rule ID to previously observed metric state
+	ratioValues     map[string]types.Metric // Latest sample of each series (storage.SeriesKey) used by ratio rules; guarded by metricMutex
 	metricMutex     sync.Mutex
 	alertMutex      sync.Mutex
 	webhookConfig   WebhookConfig
 	defaultCooldown int
+	pendingSince    map[string]time.Time // Maps rule+series to when its condition first became true
+	pendingMutex    sync.Mutex
+	now             func() time.Time // Clock used for sustained conditions (injectable for tests)
+	shutdownGrace   time.Duration    // How long Run keeps draining queued alerts after cancellation
+	inflight        sync.WaitGroup   // Webhook deliveries that haven't finished yet
+	webhookSlots    chan struct{}    // Semaphore bounding concurrent webhook deliveries; nil = unbounded
+
+	fireCounts map[string]fireCount // Maps rule+series to fires since the condition last cleared
+	fireMutex  sync.Mutex
+
+	templates map[string]*template.Template // Compiled MessageTemplates keyed by rule ID; guarded by ruleMutex
+	ruleAdded map[string]time.Time          // When each rule was loaded or added, for warmup; guarded by ruleMutex
+
+	alertsFired     atomic.Int64 // Alerts queued for delivery since start
+	webhookFailures atomic.Int64 // Webhook deliveries that failed after all retries
+
+	alertsSuppressed atomic.Int64 // Alerts not sent because a maintenance window was active
+
+	deliveryStore  storage.Storage  // Receives webhook delivery metrics; nil disables them
+	deliveryCounts map[string]int64 // Maps target+outcome to deliveries so far
+	deliveryMutex  sync.Mutex
+
+	maintenance      MaintenanceWindow // Zero when no maintenance is scheduled
+	maintenanceMutex sync.Mutex
+
+	history      []AlertEvent // Most recent dispatched alerts, oldest first
+	historyMutex sync.Mutex
+
+	ruleStats  map[string]RuleStats // Evaluation counts and times by rule ID
+	statsMutex sync.Mutex
+
+	digestInterval  time.Duration           // How often batched alerts are sent; 0 = one webhook call per alert
+	digestMaxAlerts int                     // Send a webhook's digest early once this many alerts are buffered (0 = no limit)
+	digestBuffers   map[string][]AlertEvent // Alerts waiting for the next digest, keyed by webhook URL
+	digestMutex     sync.Mutex
+}
+
+// ruleFromConfig converts a config file rule to an enabled alerting rule
+func ruleFromConfig(cfgRule config.AlertRule) AlertRule {
+	return AlertRule{
+		ID:              cfgRule.ID,
+		Name:            cfgRule.Name,
+		MetricName:      cfgRule.MetricName,
+		Condition:       cfgRule.Condition,
+		Threshold:       cfgRule.Threshold,
+		Severity:        cfgRule.Severity,
+		Enabled:         true, // Rules are enabled by default
+		CooldownSeconds: cfgRule.CooldownSeconds,
+		ForSeconds:      cfgRule.ForSeconds,
+		WarmupSeconds:   cfgRule.WarmupSeconds,
+
+		EscalateAfter:         cfgRule.EscalateAfter,
+		EscalateWindowSeconds: cfgRule.EscalateWindowSeconds,
+		MinChange:             cfgRule.MinChange,
+
+		MinChangePercent: cfgRule.MinChangePercent,
+
+		DenominatorMetric: cfgRule.DenominatorMetric,
+
+		Tags:        cfgRule.Tags,
+		Annotations: cfgRule.Annotations,
+		Webhooks:    cfgRule.Webhooks,
+
+		Description:     cfgRule.Description,
+		MessageTemplate: cfgRule.MessageTemplate,
+	}
 }
 
 // NewManager creates a new alert manager
 func NewManager(config config.AlertingConfig) *Manager {
 	// Convert config rules to alerting rules
 	rules := make([]AlertRule, len(config.Rules))
+	configRules := make(map[string]bool, len(config.Rules))
 	for i, cfgRule := range config.Rules {
-		rules[i] = AlertRule{
-			// Use ID from config if available, will be set below if empty
-			ID:              cfgRule.ID,
-			Name:            cfgRule.Name,
-			MetricName:      cfgRule.MetricName,
-			Condition:       cfgRule.Condition,
-			Threshold:       cfgRule.Threshold,
-			Severity:        cfgRule.Severity,
-			Enabled:         true, // Rules are enabled by default
-			CooldownSeconds: cfgRule.CooldownSeconds,
-		}
 		// Generate ID if not provided in config
-		if rules[i].ID == "" {
-			rules[i].ID = uuid.New().String()
+		if cfgRule.ID == "" {
+			cfgRule.ID = uuid.New().String()
 		}
+		rules[i] = ruleFromConfig(cfgRule)
+		configRules[cfgRule.ID] = true
+	}
+
+	now := time.Now
+	templates := make(map[string]*template.Template)
+	ruleAdded := make(map[string]time.Time)
+	for _, rule := range rules {
+		cacheMessageTemplate(templates, rule)
+		ruleAdded[rule.ID] = now()
+	}
+
+	// Build one pooled client so webhook sends reuse connections
+	webhookConfig := DefaultWebhookConfig()
+	if config.WebhookMaxIdleConns > 0 {
+		webhookConfig.MaxIdleConnsPerHost = config.WebhookMaxIdleConns
+	}
+	if config.WebhookIdleConnTimeoutSeconds > 0 {
+		webhookConfig.IdleConnTimeout = time.Duration(config.WebhookIdleConnTimeoutSeconds) * time.Second
+	}
+	webhookConfig.MaxElapsed = time.Duration(config.WebhookMaxElapsedSeconds) * time.Second
+	webhookConfig.Client = NewWebhookClient(webhookConfig)
+
+	var webhookSlots chan struct{}
+	if config.WebhookMaxConcurrency > 0 {
+		webhookSlots = make(chan struct{}, config.WebhookMaxConcurrency)
+	}
+
+	var digestInterval time.Duration
+	if config.Digest.Enabled {
+		digestInterval = time.Duration(config.Digest.IntervalSeconds) * time.Second
 	}
 
 	return &Manager{
 		rules:           rules,
+		configRules:     configRules,
+		templates:       templates,
+		ruleAdded:       ruleAdded,
 		webhooks:        config.Webhooks,
 		alertQueue:      make(chan AlertEvent, config.QueueBufferSize),
 		lastAlerts:      make(map[string]time.Time),
 		lastMetrics:     make(map[string]MetricState),
-		webhookConfig:   DefaultWebhookConfig(),
+		ratioValues:     make(map[string]types.Metric),
+		webhookConfig:   webhookConfig,
+		webhookSlots:    webhookSlots,
 		defaultCooldown: config.CooldownSeconds,
+		pendingSince:    make(map[string]time.Time),
+		now:             now,
+		shutdownGrace:   time.Duration(config.ShutdownGraceSeconds) * time.Second,
+		fireCounts:      make(map[string]fireCount),
+		deliveryCounts:  make(map[string]int64),
+		maintenance:     maintenanceFromConfig(config.Maintenance, now()),
+
+		digestInterval:  digestInterval,
+		digestMaxAlerts: config.Digest.MaxAlerts,
+		digestBuffers:   make(map[string][]AlertEvent),
+
+		ruleStats: make(map[string]RuleStats),
 	}
 }
 
+// SetDeliveryMetricsStore records webhook_delivery_total and webhook_delivery_duration_ms
+// into store after every webhook delivery, so delivery shows up in the metrics API
+// Must be called before Run
+func (m *Manager) SetDeliveryMetricsStore(store storage.Storage) {
+	m.deliveryStore = store
+}
+
+// SetWebhookTLS rebuilds the webhook client to trust the CAs in caFile (in addition to
+// the system roots) or, with insecureSkipVerify, to skip certificate verification
+// Must be called before Run; an empty caFile keeps the system roots
+func (m *Manager) SetWebhookTLS(caFile string, insecureSkipVerify bool) error {
+	if caFile != "" {
+		pool, err := LoadWebhookCA(caFile)
+		if err != nil {
+			return err
+		}
+		m.webhookConfig.RootCAs = pool
+	}
+	m.webhookConfig.InsecureSkipVerify = insecureSkipVerify
+	m.webhookConfig.Client = NewWebhookClient(m.webhookConfig)
+
+	if insecureSkipVerify {
+		logger.Warn("Webhook TLS certificate verification is disabled",
+			"component", "AlertManager")
+	}
+	return nil
+}
+
 // AddRule adds a new alert rule
+// Its MessageTemplate is compiled once here; a malformed template is logged and the
+// rule's alerts use Description instead
 func (m *Manager) AddRule(rule AlertRule) error {
 	m.ruleMutex.Lock()
 	defer m.ruleMutex.Unlock()
 
 	m.rules = append(m.rules, rule)
+	cacheMessageTemplate(m.templates, rule)
+	m.ruleAdded[rule.ID] = m.now()
 	return nil
 }
 
+// cacheMessageTemplate compiles a rule's MessageTemplate into templates
+// Rules without a valid template are left out so their alerts use Description
+func cacheMessageTemplate(templates map[string]*template.Template, rule AlertRule) {
+	delete(templates, rule.ID)
+	tmpl, err := parseMessageTemplate(rule)
+	if err != nil {
+		logger.Warn("Ignoring message template, alerts will use the description",
+			"component", "AlertManager",
+			"rule_id", rule.ID,
+			"error", err)
+		return
+	}
+	if tmpl != nil {
+		templates[rule.ID] = tmpl
+	}
+}
+
+// alertMessage renders the rule's cached message template for an alert,
+// falling back to the rule's Description when there is none or rendering fails
+func (m *Manager) alertMessage(rule AlertRule, metric types.Metric, alert AlertEvent) string {
+	m.ruleMutex.RLock()
+	tmpl := m.templates[rule.ID]
+	m.ruleMutex.RUnlock()
+
+	if tmpl == nil {
+		return rule.Description
+	}
+	message, err := renderMessage(tmpl, rule, metric, alert)
+	if err != nil {
+		logger.Warn("Failed to render message template, using the description",
+			"component", "AlertManager",
+			"rule_id", rule.ID,
+			"error", err)
+		return rule.Description
+	}
+	return message
+}
+
 // RemoveRule removes an alert rule by ID
 func (m *Manager) RemoveRule(ruleID string) error {
 	m.ruleMutex.Lock()
@@ -82,6 +274,11 @@ func (m *Manager) RemoveRule(ruleID string) error {
 	for i, rule := range m.rules {
 		if rule.ID == ruleID {
 			m.rules = append(m.rules[:i], m.rules[i+1:]...)
+			delete(m.templates, ruleID)
+			delete(m.ruleAdded, ruleID)
+			delete(m.configRules, ruleID)
+			m.forgetStats(ruleID)
+			m.forgetRuleState(ruleID)
 			return nil
 		}
 	}
@@ -102,18 +299,144 @@ func (m *Manager) GetRules() []AlertRule {
 
 // formatMetricId Create a metric ID by concatenating the labels
 func formatMetricId(alert *AlertEvent, metric types.Metric) {
+	alert.MetricID = metricSeriesID(metric)
+}
+
+// metricSeriesID identifies the series a metric belongs to
+func metricSeriesID(metric types.Metric) string {
 	// Simple approach: name + account_id (if present)
+	// Output: "account_balance[0.0.5000]"
 	accountID := metric.Labels["account_id"]
 	if accountID != "" {
-		alert.MetricID = fmt.Sprintf("%s[%s]", metric.Name, accountID)
-	} else {
-		alert.MetricID = metric.Name
+		return fmt.Sprintf("%s[%s]", metric.Name, accountID)
 	}
-	// Output: "account_balance[0.0.5000]"
+	return metric.Name
+}
+
+// sustained reports whether a rule's condition has held continuously for the
+// rule's ForSeconds on the metric's series. A false condition resets the timer.
+func (m *Manager) sustained(rule AlertRule, metric types.Metric, conditionMet bool) bool {
+	key := rule.ID + "|" + metricSeriesID(metric)
+
+	m.pendingMutex.Lock()
+	defer m.pendingMutex.Unlock()
+
+	if !conditionMet {
+		delete(m.pendingSince, key)
+		return false
+	}
+
+	now := m.now()
+	since, pending := m.pendingSince[key]
+	if !pending {
+		m.pendingSince[key] = now
+		since = now
+	}
+
+	held := now.Sub(since)
+	if held < time.Duration(rule.ForSeconds)*time.Second {
+		logger.Debug("Condition pending (for duration not yet elapsed)",
+			"component", "AlertManager",
+			"rule_id", rule.ID,
+			"held", held.String(),
+			"for_seconds", rule.ForSeconds)
+		return false
+	}
+	return true
+}
+
+// recordFire counts a fire of the rule on the metric's series and reports whether
+// the rule has now fired often enough to escalate. Fires older than the rule's
+// escalation window restart the count.
+func (m *Manager) recordFire(rule AlertRule, metric types.Metric) bool {
+	key := rule.ID + "|" + metricSeriesID(metric)
+
+	m.fireMutex.Lock()
+	defer m.fireMutex.Unlock()
+
+	now := m.now()
+	fc, exists := m.fireCounts[key]
+	window := time.Duration(rule.EscalateWindowSeconds) * time.Second
+	if !exists || (0 < window && window < now.Sub(fc.first)) {
+		fc = fireCount{first: now}
+	}
+	fc.count++
+	m.fireCounts[key] = fc
+
+	return rule.EscalateAfter <= fc.count
+}
+
+// unrecordFire reverses recordFire for an alert that could not be queued
+func (m *Manager) unrecordFire(rule AlertRule, metric types.Metric) {
+	key := rule.ID + "|" + metricSeriesID(metric)
+
+	m.fireMutex.Lock()
+	defer m.fireMutex.Unlock()
+
+	fc, exists := m.fireCounts[key]
+	if !exists {
+		return
+	}
+	fc.count--
+	if fc.count <= 0 {
+		delete(m.fireCounts, key)
+		return
+	}
+	m.fireCounts[key] = fc
+}
+
+// resetFires clears the escalation count once a rule's condition recovers
+func (m *Manager) resetFires(rule AlertRule, metric types.Metric) {
+	m.fireMutex.Lock()
+	defer m.fireMutex.Unlock()
+
+	delete(m.fireCounts, rule.ID+"|"+metricSeriesID(metric))
+}
+
+// forgetRuleState drops the rule's previous value, pending conditions and escalation
+// counts, so its next evaluation starts fresh
+func (m *Manager) forgetRuleState(ruleID string) {
+	prefix := ruleID + "|"
+
+	m.metricMutex.Lock()
+	delete(m.lastMetrics, ruleID)
+	m.metricMutex.Unlock()
+
+	m.pendingMutex.Lock()
+	maps.DeleteFunc(m.pendingSince, func(key string, _ time.Time) bool { return strings.HasPrefix(key, prefix) })
+	m.pendingMutex.Unlock()
+
+	m.fireMutex.Lock()
+	maps.DeleteFunc(m.fireCounts, func(key string, _ fireCount) bool { return strings.HasPrefix(key, prefix) })
+	m.fireMutex.Unlock()
+}
+
+// warmingUp reports whether the rule was added less than its WarmupSeconds ago
+// A rule added mid-incident would otherwise fire at once on data that was already
+// breaching; during warmup its state is still tracked so it has a baseline
+func (m *Manager) warmingUp(rule AlertRule) bool {
+	m.ruleMutex.RLock()
+	added, ok := m.ruleAdded[rule.ID]
+	m.ruleMutex.RUnlock()
+	if !ok {
+		return false
+	}
+
+	age := m.now().Sub(added)
+	if age < time.Duration(rule.WarmupSeconds)*time.Second {
+		logger.Debug("Skipping alert (rule warming up)",
+			"component", "AlertManager",
+			"rule_id", rule.ID,
+			"warmup_remaining", (time.Duration(rule.WarmupSeconds)*time.Second - age).String())
+		return true
+	}
+	return false
 }
 
 // queueAlert creates and queues the alert
-func (m *Manager) queueAlert(rule AlertRule, metric types.Metric) {
+// Escalated alerts are sent one severity level above the rule's severity
+// Returns false if the queue is full and the alert was dropped
+func (m *Manager) queueAlert(rule AlertRule, metric types.Metric, escalated bool) bool {
 	// Create and queue the alert
 	alert := AlertEvent{
 		RuleID:    rule.ID,
@@ -122,21 +445,63 @@ func (m *Manager) queueAlert(rule AlertRule, metric types.Metric) {
 		Message:   rule.Description,
 		Timestamp: time.Now().Unix(),
 		Value:     metric.Value,
+		Escalated: escalated,
+
+		Annotations: rule.Annotations,
+		Webhooks:    rule.Webhooks,
+	}
+	if escalated {
+		alert.Severity = rule.Severity.Escalate()
 	}
 	formatMetricId(&alert, metric)
+	alert.Message = m.alertMessage(rule, metric, alert)
 
 	select {
 	case m.alertQueue <- alert:
-		m.alertMutex.Lock()
-		m.lastAlerts[rule.ID] = time.Now()
-		m.alertMutex.Unlock()
+		m.alertsFired.Add(1)
+		return true
 	default:
 		logger.Warn("Alert queue full, dropping alert",
 			"component", "AlertManager",
 			"rule_id", rule.ID)
+		return false
 	}
 }
 
+// reserveAlert atomically checks the cooldown for a rule and, if it has elapsed,
+// records now as the last alert time so concurrent callers can't both pass the gate
+// Returns the previous alert time (zero if none) for rollback, and whether the
+// caller may queue an alert
+func (m *Manager) reserveAlert(ruleID string, cooldown time.Duration) (time.Time, bool) {
+	m.alertMutex.Lock()
+	defer m.alertMutex.Unlock()
+
+	lastAlert, exists := m.lastAlerts[ruleID]
+	if exists && time.Since(lastAlert) < cooldown {
+		logger.Debug("Skipping alert (cooldown period)",
+			"component", "AlertManager",
+			"rule_id", ruleID,
+			"cooldown_remaining", (cooldown - time.Since(lastAlert)).String())
+		return lastAlert, false
+	}
+
+	m.lastAlerts[ruleID] = time.Now()
+	return lastAlert, true
+}
+
+// releaseAlert restores the previous alert time after a reservation whose alert
+// could not be queued, so the dropped alert doesn't start a cooldown
+func (m *Manager) releaseAlert(ruleID string, previous time.Time) {
+	m.alertMutex.Lock()
+	defer m.alertMutex.Unlock()
+
+	if previous.IsZero() {
+		delete(m.lastAlerts, ruleID)
+		return
+	}
+	m.lastAlerts[ruleID] = previous
+}
+
 // CheckMetric evaluates a metric against all active rules
 // If a rule condition is met, an alert is queued for sending
 func (m *Manager) CheckMetric(metric types.Metric) error {
@@ -149,99 +514,292 @@ func (m *Manager) CheckMetric(metric types.Metric) error {
 			continue
 		}
 
+		// Ratio rules evaluate numerator / denominator whenever either side arrives
+		if rule.IsRatio() {
+			if ratio, ok := m.ratioMetric(rule, metric); ok {
+				m.evaluateRule(rule, ratio)
+			}
+			continue
+		}
+
 		// Skip rules that don't apply to this metric
 		if rule.MetricName != metric.Name {
 			continue
 		}
 
-		logger.Debug("Evaluating metric against rule",
-			"component", "AlertManager",
-			"rule_id", rule.ID,
-			"metric_name", metric.Name,
-			"metric_value", metric.Value)
+		m.evaluateRule(rule, metric)
+	}
 
-		// Extract and compare to actual metric value
-		m.metricMutex.Lock()
-		state := m.lastMetrics[rule.ID]
-		m.metricMutex.Unlock()
+	return nil
+}
 
-		shouldAlert := rule.EvaluateCondition(metric.Value, state.Value, state.Initialized)
+// evaluateRule checks one metric against one rule, queues an alert if it fires,
+// and records the value as the rule's previous state
+func (m *Manager) evaluateRule(rule AlertRule, metric types.Metric) {
+	logger.Debug("Evaluating metric against rule",
+		"component", "AlertManager",
+		"rule_id", rule.ID,
+		"metric_name", metric.Name,
+		"metric_value", metric.Value)
+	m.recordEvaluation(rule.ID, m.now())
+
+	// Extract and compare to actual metric value
+	m.metricMutex.Lock()
+	state := m.lastMetrics[rule.ID]
+	m.metricMutex.Unlock()
+
+	shouldAlert := rule.EvaluateCondition(metric.Value, state.Value, state.Initialized)
+	if 0 < rule.ForSeconds {
+		shouldAlert = m.sustained(rule, metric, shouldAlert)
+	}
+	if shouldAlert && 0 < rule.WarmupSeconds && m.warmingUp(rule) {
+		shouldAlert = false
+	}
 
-		if shouldAlert {
-			// Check if we recently alerted on this rule to avoid spam
-			m.alertMutex.Lock()
-			lastAlert, exists := m.lastAlerts[rule.ID]
-			m.alertMutex.Unlock()
+	if shouldAlert {
+		cooldownSeconds := rule.CooldownSeconds
+		if cooldownSeconds == 0 {
+			cooldownSeconds = m.defaultCooldown
+		}
+		cooldown := time.Duration(cooldownSeconds) * time.Second
 
-			cooldownSeconds := rule.CooldownSeconds
-			if cooldownSeconds == 0 {
-				cooldownSeconds = m.defaultCooldown
-			}
-			cooldown := time.Duration(cooldownSeconds) * time.Second
-			if exists && time.Since(lastAlert) < cooldown {
-				logger.Debug("Skipping alert (cooldown period)",
-					"component", "AlertManager",
-					"rule_id", rule.ID,
-					"cooldown_remaining", (cooldown - time.Since(lastAlert)).String())
-				continue
-			}
+		// Check and claim the cooldown slot in one step to avoid duplicate alerts
+		previous, ok := m.reserveAlert(rule.ID, cooldown)
+		if !ok {
+			return
+		}
 
-			m.queueAlert(rule, metric)
+		escalated := false
+		if 0 < rule.EscalateAfter {
+			escalated = m.recordFire(rule, metric)
 		}
 
-		// Update metric state
-		m.metricMutex.Lock()
-		m.lastMetrics[rule.ID] = MetricState{
-			Value:       metric.Value,
-			Initialized: true,
+		if m.queueAlert(rule, metric, escalated) {
+			m.recordFired(rule.ID, m.now())
+		} else {
+			m.releaseAlert(rule.ID, previous)
+			if 0 < rule.EscalateAfter {
+				m.unrecordFire(rule, metric)
+			}
 		}
-		m.metricMutex.Unlock()
+	} else if 0 < rule.EscalateAfter {
+		// The condition cleared, so the next fire starts a fresh escalation count
+		m.resetFires(rule, metric)
 	}
 
-	return nil
+	// Update metric state
+	m.metricMutex.Lock()
+	m.lastMetrics[rule.ID] = MetricState{
+		Value:       metric.Value,
+		Initialized: true,
+	}
+	m.metricMutex.Unlock()
 }
 
 // Run starts the alert manager's main loop
 // It processes queued alerts and sends notifications via webhooks
+// When the context is cancelled, queued alerts are drained for up to the
+// configured shutdown grace period before returning
+// Webhook deliveries outlive ctx so the drain can finish them, and are cancelled when Run returns
+// In digest mode buffered alerts are sent every digest interval and during the drain
 func (m *Manager) Run(ctx context.Context) error {
 	logger.Info("Starting alert processor", "component", "AlertManager")
 
+	deliveryCtx, cancelDeliveries := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelDeliveries()
+
+	var digestTick <-chan time.Time
+	if m.digestEnabled() {
+		ticker := time.NewTicker(m.digestInterval)
+		defer ticker.Stop()
+		digestTick = ticker.C
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			logger.Info("Stopping alert processor", "component", "AlertManager")
+			if 0 < m.shutdownGrace {
+				drainCtx, cancel := context.WithTimeout(context.Background(), m.shutdownGrace)
+				_ = m.Drain(drainCtx)
+				cancel()
+			}
 			return ctx.Err()
 		case alert := <-m.alertQueue:
-			logger.Info("Alert triggered",
+			m.dispatch(deliveryCtx, alert)
+		case <-digestTick:
+			m.flushDigests(deliveryCtx)
+		}
+	}
+}
+
+// Drain dispatches every alert still in the queue, sends any pending digests,
+// and waits for in-flight webhook deliveries to finish
+// Returns the context error if the deadline passes first; undelivered alerts are dropped
+func (m *Manager) Drain(ctx context.Context) error {
+	drained := 0
+	for empty := false; !empty; {
+		select {
+		case alert := <-m.alertQueue:
+			m.dispatch(ctx, alert)
+			drained++
+		default:
+			empty = true
+		}
+	}
+	m.flushDigests(ctx)
+
+	done := make(chan struct{})
+	go func() {
+		m.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		logger.Info("Drained alert queue",
+			"component", "AlertManager",
+			"alerts", drained)
+		return nil
+	case <-ctx.Done():
+		logger.Warn("Alert drain deadline exceeded, undelivered alerts dropped",
+			"component", "AlertManager",
+			"alerts", drained,
+			"error", ctx.Err())
+		return ctx.Err()
+	}
+}
+
+// dispatch logs an alert and sends it to every webhook (the rule's own, if set) in parallel
+// During a maintenance window the alert is only recorded, flagged as suppressed
+// When the concurrent delivery limit is reached, dispatch waits for a free slot
+// before starting each delivery, so a burst of alerts queues up instead of
+// spawning a request per alert and webhook
+// In digest mode the alert is buffered per webhook and sent with the next digest
+// Deliveries, including their retries, are abandoned once ctx is cancelled
+func (m *Manager) dispatch(ctx context.Context, alert AlertEvent) {
+	if m.inMaintenance() {
+		alert.Suppressed = true
+		m.recordHistory(alert)
+		m.alertsSuppressed.Add(1)
+		logger.Info("Alert suppressed (maintenance window)",
+			"component", "AlertManager",
+			"rule_name", alert.RuleName,
+			"severity", alert.Severity.String(),
+			"metric_id", alert.MetricID)
+		return
+	}
+	m.recordHistory(alert)
+
+	logger.Info("Alert triggered",
+		"component", "AlertManager",
+		"rule_name", alert.RuleName,
+		"severity", alert.Severity.String(),
+		"escalated", alert.Escalated,
+		"value", alert.Value,
+		"metric_id", alert.MetricID)
+
+	// A rule with its own webhooks notifies only those
+	m.ruleMutex.RLock()
+	webhooks := m.webhooks
+	m.ruleMutex.RUnlock()
+	if len(alert.Webhooks) > 0 {
+		webhooks = alert.Webhooks
+	}
+
+	if m.digestEnabled() {
+		m.bufferDigest(ctx, webhooks, alert)
+		return
+	}
+
+	// Send to webhooks in parallel using goroutines
+	for _, webhook := range webhooks {
+		if !m.acquireWebhookSlot(ctx) {
+			m.webhookFailures.Add(1)
+			logger.Error("Webhook delivery abandoned waiting for a free slot",
 				"component", "AlertManager",
-				"rule_name", alert.RuleName,
-				"severity", alert.Severity,
-				"value", alert.Value,
-				"metric_id", alert.MetricID)
-
-			// Send to webhooks in parallel using goroutines
-			for _, webhook := range m.webhooks {
-				go m.sendWebhook(webhook, alert)
-			}
+				"webhook_url", webhook,
+				"rule_id", alert.RuleID,
+				"error", ctx.Err())
+			continue
 		}
+		m.inflight.Add(1)
+		go func(webhookURL string) {
+			defer m.inflight.Done()
+			defer m.releaseWebhookSlot()
+			m.sendWebhook(ctx, webhookURL, alert)
+		}(webhook)
 	}
 }
 
-// sendWebhook sends an alert to a webhook URL
-// Uses HTTP POST with retry logic and exponential backoff
-func (m *Manager) sendWebhook(webhookURL string, alert AlertEvent) {
-	payload := WebhookPayload{
-		RuleID:    alert.RuleID,
-		RuleName:  alert.RuleName,
-		Severity:  alert.Severity,
-		Message:   alert.Message,
-		Value:     alert.Value,
-		Timestamp: alert.Timestamp,
-		MetricID:  alert.MetricID,
-	}
-
-	err := SendWebhookRequest(webhookURL, payload, m.webhookConfig)
+// acquireWebhookSlot blocks until a webhook delivery may start
+// Returns false if ctx is cancelled first
+func (m *Manager) acquireWebhookSlot(ctx context.Context) bool {
+	if m.webhookSlots == nil {
+		return true
+	}
+	select {
+	case m.webhookSlots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// releaseWebhookSlot frees the slot taken by acquireWebhookSlot
+func (m *Manager) releaseWebhookSlot() {
+	if m.webhookSlots != nil {
+		<-m.webhookSlots
+	}
+}
+
+// AlertsFired returns how many alerts have been queued for delivery since the manager started
+func (m *Manager) AlertsFired() int64 {
+	return m.alertsFired.Load()
+}
+
+// AlertsSuppressed returns how many alerts were not sent because of a maintenance window
+func (m *Manager) AlertsSuppressed() int64 {
+	return m.alertsSuppressed.Load()
+}
+
+// QueueDepth returns how many alerts are waiting in the queue for dispatch
+func (m *Manager) QueueDepth() int {
+	return len(m.alertQueue)
+}
+
+// History returns the most recently dispatched alerts, oldest first, including
+// those suppressed by a maintenance window
+func (m *Manager) History() []AlertEvent {
+	m.historyMutex.Lock()
+	defer m.historyMutex.Unlock()
+	return slices.Clone(m.history)
+}
+
+// recordHistory appends alert to the history, dropping the oldest entry when full
+func (m *Manager) recordHistory(alert AlertEvent) {
+	m.historyMutex.Lock()
+	defer m.historyMutex.Unlock()
+
+	if len(m.history) == alertHistoryLimit {
+		m.history = slices.Delete(m.history, 0, 1)
+	}
+	m.history = append(m.history, alert)
+}
+
+// WebhookFailures returns how many webhook deliveries have failed since the manager started
+func (m *Manager) WebhookFailures() int64 {
+	return m.webhookFailures.Load()
+}
+
+// sendWebhook sends an alert to a webhook URL, counting and logging failures
+// Delivery metrics are recorded only after the send finishes, so they never delay it
+func (m *Manager) sendWebhook(ctx context.Context, webhookURL string, alert AlertEvent) {
+	start := time.Now()
+	err := m.deliverWebhook(ctx, webhookURL, alert)
+	m.recordDelivery(webhookURL, err, time.Since(start))
 	if err != nil {
+		m.webhookFailures.Add(1)
 		logger.Error("Failed to send webhook",
 			"component", "AlertManager",
 			"webhook_url", webhookURL,
@@ -249,3 +807,74 @@ func (m *Manager) sendWebhook(webhookURL string, alert AlertEvent) {
 			"error", err)
 	}
 }
+
+// deliverWebhook builds the payload for alert and POSTs it to webhookURL
+// Uses HTTP POST with retry logic and exponential backoff
+func (m *Manager) deliverWebhook(ctx context.Context, webhookURL string, alert AlertEvent) error {
+	version, err := WebhookSchemaVersionFor(webhookURL)
+	if err != nil {
+		return err
+	}
+
+	return SendWebhookRequestCtx(ctx, webhookURL, newWebhookPayload(version, alert), m.webhookConfig)
+}
+
+// newWebhookPayload converts alert to the webhook payload for schema version
+func newWebhookPayload(version int, alert AlertEvent) WebhookPayload {
+	return WebhookPayload{
+		SchemaVersion: version,
+		RuleID:        alert.RuleID,
+		RuleName:      alert.RuleName,
+		Severity:      alert.Severity.String(),
+		Message:       alert.Message,
+		Value:         alert.Value,
+		Timestamp:     alert.Timestamp,
+		MetricID:      alert.MetricID,
+		Escalated:     alert.Escalated,
+		Annotations:   alert.Annotations,
+	}
+}
+
+// webhookTarget returns the host of webhookURL for metric labels
+// Paths and queries are dropped because webhook URLs often embed secret tokens
+func webhookTarget(webhookURL string) string {
+	u, err := url.Parse(webhookURL)
+	if err != nil || u.Host == "" {
+		return "invalid"
+	}
+	return u.Host
+}
+
+// recordDelivery stores the delivery count and duration for webhookURL's target
+// labelled outcome=success or outcome=failure
+func (m *Manager) recordDelivery(webhookURL string, err error, elapsed time.Duration) {
+	if m.deliveryStore == nil {
+		return
+	}
+
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+	}
+	target := webhookTarget(webhookURL)
+
+	m.deliveryMutex.Lock()
+	m.deliveryCounts[target+"|"+outcome]++
+	count := m.deliveryCounts[target+"|"+outcome]
+	m.deliveryMutex.Unlock()
+
+	now := time.Now().Unix()
+	for _, metric := range []types.Metric{
+		{Name: "webhook_delivery_total", Value: float64(count)},
+		{Name: "webhook_delivery_duration_ms", Value: float64(elapsed.Milliseconds())},
+	} {
+		metric.Timestamp = now
+		metric.Labels = map[string]string{"target": target, "outcome": outcome}
+		if err := m.deliveryStore.StoreMetric(metric); err != nil {
+			logger.Warn("Failed to store webhook delivery metric",
+				"component", "AlertManager",
+				"metric", metric.Name,
+				"error", err)
+		}
+	}
+}
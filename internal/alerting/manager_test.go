@@ -1,10 +1,20 @@
 package alerting
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
 	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
 	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
 )
@@ -726,6 +736,97 @@ func TestCheckMetricCooldown(t *testing.T) {
 	}
 }
 
+// TestCheckMetricConcurrentDeduplication tests that concurrent checks of the same
+// metric can't both pass the cooldown gate and queue duplicate alerts
+func TestCheckMetricConcurrentDeduplication(t *testing.T) {
+	cfg := config.AlertingConfig{
+		Enabled:         true,
+		Webhooks:        []string{},
+		QueueBufferSize: 100,
+		CooldownSeconds: 300,
+	}
+	manager := NewManager(cfg)
+
+	rule := AlertRule{
+		ID:         "dedup_rule",
+		Name:       "Dedup Test",
+		MetricName: "test_metric",
+		Condition:  ">",
+		Threshold:  50.0,
+		Enabled:    true,
+		Severity:   "warning",
+	}
+	if err := manager.AddRule(rule); err != nil {
+		t.Fatalf("AddRule failed: %v", err)
+	}
+
+	metric := types.Metric{
+		Name:      "test_metric",
+		Value:     100.0,
+		Timestamp: time.Now().Unix(),
+		Labels:    map[string]string{},
+	}
+
+	const goroutines = 50
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if err := manager.CheckMetric(metric); err != nil {
+				t.Errorf("CheckMetric failed: %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if queued := len(manager.alertQueue); queued != 1 {
+		t.Errorf("Expected exactly 1 alert queued, got %d", queued)
+	}
+}
+
+// TestCheckMetricQueueFullReleasesCooldown tests that a dropped alert doesn't start a cooldown
+func TestCheckMetricQueueFullReleasesCooldown(t *testing.T) {
+	cfg := config.AlertingConfig{
+		Enabled:         true,
+		Webhooks:        []string{},
+		QueueBufferSize: 1,
+		CooldownSeconds: 300,
+	}
+	manager := NewManager(cfg)
+
+	// Fill the queue so the next alert is dropped
+	manager.alertQueue <- AlertEvent{RuleID: "filler"}
+
+	rule := AlertRule{
+		ID:         "full_rule",
+		Name:       "Queue Full Test",
+		MetricName: "test_metric",
+		Condition:  ">",
+		Threshold:  50.0,
+		Enabled:    true,
+		Severity:   "warning",
+	}
+	if err := manager.AddRule(rule); err != nil {
+		t.Fatalf("AddRule failed: %v", err)
+	}
+
+	metric := types.Metric{Name: "test_metric", Value: 100.0, Labels: map[string]string{}}
+	if err := manager.CheckMetric(metric); err != nil {
+		t.Fatalf("CheckMetric failed: %v", err)
+	}
+
+	manager.alertMutex.Lock()
+	_, exists := manager.lastAlerts["full_rule"]
+	manager.alertMutex.Unlock()
+	if exists {
+		t.Error("Expected no cooldown to be recorded for a dropped alert")
+	}
+}
+
 // TestAlertEventCreation verifies AlertEvent structure
 func TestAlertEventCreation(t *testing.T) {
 	event := AlertEvent{
@@ -990,3 +1091,605 @@ func TestCheckMetricStateDecreased(t *testing.T) {
 		t.Log("Second alert was correctly not queued")
 	}
 }
+
+// newForTestManager creates a manager with a controllable clock and a rule
+// requiring the condition to hold for 60 seconds
+func newForTestManager(t *testing.T) (*Manager, *time.Time) {
+	t.Helper()
+	cfg := config.AlertingConfig{
+		Enabled:         true,
+		Webhooks:        []string{},
+		QueueBufferSize: 100,
+		CooldownSeconds: 300,
+	}
+	manager := NewManager(cfg)
+
+	clock := time.Unix(1700000000, 0)
+	manager.now = func() time.Time { return clock }
+
+	rule := AlertRule{
+		ID:         "sustained_rule",
+		Name:       "Sustained Low Balance",
+		MetricName: "account_balance",
+		Condition:  "<",
+		Threshold:  100.0,
+		Enabled:    true,
+		Severity:   "warning",
+		ForSeconds: 60,
+	}
+	if err := manager.AddRule(rule); err != nil {
+		t.Fatalf("AddRule failed: %v", err)
+	}
+	return manager, &clock
+}
+
+// checkAt advances the fake clock to offset seconds and checks a metric value
+func checkAt(t *testing.T, manager *Manager, clock *time.Time, offset int64, value float64) {
+	t.Helper()
+	*clock = time.Unix(1700000000+offset, 0)
+	metric := types.Metric{
+		Name:   "account_balance",
+		Value:  value,
+		Labels: map[string]string{"account_id": "0.0.5000"},
+	}
+	if err := manager.CheckMetric(metric); err != nil {
+		t.Fatalf("CheckMetric failed: %v", err)
+	}
+}
+
+// TestCheckMetricForDuration_Brief tests that a briefly true condition doesn't fire
+func TestCheckMetricForDuration_Brief(t *testing.T) {
+	manager, clock := newForTestManager(t)
+
+	checkAt(t, manager, clock, 0, 50)
+	checkAt(t, manager, clock, 30, 50)
+	checkAt(t, manager, clock, 45, 500)
+
+	if queued := len(manager.alertQueue); queued != 0 {
+		t.Errorf("Expected no alerts for brief condition, got %d", queued)
+	}
+}
+
+// TestCheckMetricForDuration_Sustained tests that a sustained condition fires once
+func TestCheckMetricForDuration_Sustained(t *testing.T) {
+	manager, clock := newForTestManager(t)
+
+	checkAt(t, manager, clock, 0, 50)
+	checkAt(t, manager, clock, 30, 50)
+	if queued := len(manager.alertQueue); queued != 0 {
+		t.Fatalf("Expected no alert before for duration elapsed, got %d", queued)
+	}
+
+	checkAt(t, manager, clock, 60, 50)
+	checkAt(t, manager, clock, 90, 50)
+
+	if queued := len(manager.alertQueue); queued != 1 {
+		t.Errorf("Expected exactly 1 alert for sustained condition, got %d", queued)
+	}
+}
+
+// TestCheckMetricForDuration_Flapping tests that a flapping condition never fires
+func TestCheckMetricForDuration_Flapping(t *testing.T) {
+	manager, clock := newForTestManager(t)
+
+	// Alternate true/false every 30 seconds: never true for 60 seconds straight
+	for i := int64(0); i < 10; i++ {
+		value := 50.0
+		if i%2 == 1 {
+			value = 500.0
+		}
+		checkAt(t, manager, clock, i*30, value)
+	}
+
+	if queued := len(manager.alertQueue); queued != 0 {
+		t.Errorf("Expected no alerts for flapping condition, got %d", queued)
+	}
+}
+
+// newEscalationTestManager creates a manager with a controllable clock, no cooldown,
+// and a warning rule that escalates after 3 fires within 10 minutes
+func newEscalationTestManager(t *testing.T) (*Manager, *time.Time) {
+	t.Helper()
+	manager := NewManager(config.AlertingConfig{
+		Enabled:         true,
+		Webhooks:        []string{},
+		QueueBufferSize: 100,
+	})
+
+	clock := time.Unix(1700000000, 0)
+	manager.now = func() time.Time { return clock }
+
+	rule := AlertRule{
+		ID:                    "escalating_rule",
+		Name:                  "Repeated Low Balance",
+		MetricName:            "account_balance",
+		Condition:             "<",
+		Threshold:             100.0,
+		Enabled:               true,
+		Severity:              "warning",
+		EscalateAfter:         3,
+		EscalateWindowSeconds: 600,
+	}
+	if err := manager.AddRule(rule); err != nil {
+		t.Fatalf("AddRule failed: %v", err)
+	}
+	return manager, &clock
+}
+
+// drainQueued removes and returns every alert currently queued
+func drainQueued(manager *Manager) []AlertEvent {
+	var alerts []AlertEvent
+	for {
+		select {
+		case alert := <-manager.alertQueue:
+			alerts = append(alerts, alert)
+		default:
+			return alerts
+		}
+	}
+}
+
+// TestCheckMetricWarmup tests that a newly added rule doesn't fire on breaching
+// data during its warmup, but does once warmup has passed
+func TestCheckMetricWarmup(t *testing.T) {
+	manager := NewManager(config.AlertingConfig{
+		Enabled:         true,
+		QueueBufferSize: 100,
+		CooldownSeconds: 300,
+	})
+	clock := time.Unix(1700000000, 0)
+	manager.now = func() time.Time { return clock }
+
+	rule := AlertRule{
+		ID:            "warmup_rule",
+		Name:          "Low Balance",
+		MetricName:    "account_balance",
+		Condition:     "<",
+		Threshold:     100.0,
+		Enabled:       true,
+		Severity:      "warning",
+		WarmupSeconds: 120,
+	}
+	if err := manager.AddRule(rule); err != nil {
+		t.Fatalf("AddRule failed: %v", err)
+	}
+
+	checkAt(t, manager, &clock, 0, 50)
+	checkAt(t, manager, &clock, 119, 40)
+	if queued := len(manager.alertQueue); queued != 0 {
+		t.Fatalf("Expected no alerts during warmup, got %d", queued)
+	}
+
+	checkAt(t, manager, &clock, 120, 40)
+	if queued := len(manager.alertQueue); queued != 1 {
+		t.Errorf("Expected 1 alert after warmup, got %d", queued)
+	}
+}
+
+// TestCheckMetricWarmup_StateBaseline tests a state rule uses values seen during
+// warmup as its baseline, so the first check after warmup can fire
+func TestCheckMetricWarmup_StateBaseline(t *testing.T) {
+	manager := NewManager(config.AlertingConfig{
+		Enabled:         true,
+		QueueBufferSize: 100,
+		CooldownSeconds: 300,
+	})
+	clock := time.Unix(1700000000, 0)
+	manager.now = func() time.Time { return clock }
+
+	rule := AlertRule{
+		ID:            "warmup_changed",
+		Name:          "Balance Moved",
+		MetricName:    "account_balance",
+		Condition:     "changed",
+		Enabled:       true,
+		Severity:      "info",
+		WarmupSeconds: 60,
+	}
+	if err := manager.AddRule(rule); err != nil {
+		t.Fatalf("AddRule failed: %v", err)
+	}
+
+	checkAt(t, manager, &clock, 0, 500)
+	checkAt(t, manager, &clock, 30, 400)
+	checkAt(t, manager, &clock, 60, 300)
+
+	if queued := len(manager.alertQueue); queued != 1 {
+		t.Errorf("Expected 1 alert for the change after warmup, got %d", queued)
+	}
+}
+
+// TestCheckMetricEscalation_ReachesCount tests that the Nth fire is escalated to critical
+func TestCheckMetricEscalation_ReachesCount(t *testing.T) {
+	manager, clock := newEscalationTestManager(t)
+
+	for i := int64(0); i < 4; i++ {
+		checkAt(t, manager, clock, i*60, 50)
+	}
+
+	alerts := drainQueued(manager)
+	if len(alerts) != 4 {
+		t.Fatalf("Expected 4 alerts, got %d", len(alerts))
+	}
+	for i, alert := range alerts[:2] {
+		if alert.Escalated || alert.Severity != "warning" {
+			t.Errorf("Expected alert %d to be an unescalated warning, got severity %s escalated %v",
+				i+1, alert.Severity, alert.Escalated)
+		}
+	}
+	for i, alert := range alerts[2:] {
+		if !alert.Escalated || alert.Severity != "critical" {
+			t.Errorf("Expected alert %d to be escalated to critical, got severity %s escalated %v",
+				i+3, alert.Severity, alert.Escalated)
+		}
+	}
+}
+
+// TestCheckMetricEscalation_ResetOnRecovery tests that recovery restarts the fire count
+func TestCheckMetricEscalation_ResetOnRecovery(t *testing.T) {
+	manager, clock := newEscalationTestManager(t)
+
+	checkAt(t, manager, clock, 0, 50)
+	checkAt(t, manager, clock, 60, 50)
+	checkAt(t, manager, clock, 120, 500) // Recovered
+	checkAt(t, manager, clock, 180, 50)
+	checkAt(t, manager, clock, 240, 50)
+
+	for i, alert := range drainQueued(manager) {
+		if alert.Escalated {
+			t.Errorf("Expected alert %d not to be escalated after recovery reset the count", i+1)
+		}
+	}
+
+	checkAt(t, manager, clock, 300, 50)
+	alerts := drainQueued(manager)
+	if len(alerts) != 1 || !alerts[0].Escalated {
+		t.Errorf("Expected third fire since recovery to escalate, got %+v", alerts)
+	}
+}
+
+// TestCheckMetricEscalation_WindowExpires tests that fires outside the window don't count
+func TestCheckMetricEscalation_WindowExpires(t *testing.T) {
+	manager, clock := newEscalationTestManager(t)
+
+	checkAt(t, manager, clock, 0, 50)
+	checkAt(t, manager, clock, 300, 50)
+	checkAt(t, manager, clock, 900, 50) // First fire is now outside the 600s window
+
+	for i, alert := range drainQueued(manager) {
+		if alert.Escalated {
+			t.Errorf("Expected alert %d not to be escalated once the window expired", i+1)
+		}
+	}
+}
+
+// TestRunDrainsQueueOnShutdown tests that alerts queued at cancellation are still
+// dispatched within the shutdown grace period
+func TestRunDrainsQueueOnShutdown(t *testing.T) {
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Slow enough that deliveries are still in flight when Run is cancelled
+		time.Sleep(50 * time.Millisecond)
+		received.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := config.AlertingConfig{
+		Enabled:              true,
+		Webhooks:             []string{server.URL},
+		QueueBufferSize:      10,
+		CooldownSeconds:      300,
+		ShutdownGraceSeconds: 5,
+	}
+	manager := NewManager(cfg)
+
+	for i := 0; i < 3; i++ {
+		manager.alertQueue <- AlertEvent{
+			RuleID:   fmt.Sprintf("rule_%d", i),
+			RuleName: "Drain Test",
+			Severity: "warning",
+		}
+	}
+
+	// Cancel before Run starts so every alert is still queued at shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- manager.Run(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected Run to return within the grace period")
+	}
+
+	if got := received.Load(); got != 3 {
+		t.Errorf("Expected 3 alerts delivered before Run returned, got %d", got)
+	}
+	if len(manager.alertQueue) != 0 {
+		t.Errorf("Expected empty queue after drain, got %d", len(manager.alertQueue))
+	}
+}
+
+// TestDrainDeadlineExceeded tests that Drain gives up when deliveries outlast the deadline
+func TestDrainDeadlineExceeded(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	manager := NewManager(config.AlertingConfig{
+		Enabled:         true,
+		Webhooks:        []string{server.URL},
+		QueueBufferSize: 10,
+		CooldownSeconds: 300,
+	})
+	manager.alertQueue <- AlertEvent{RuleID: "slow_rule", RuleName: "Slow"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := manager.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+// TestAnnotationsDeliveredInPayload tests that rule annotations reach the webhook payload
+func TestAnnotationsDeliveredInPayload(t *testing.T) {
+	payloads := make(chan WebhookPayload, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload WebhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+		payloads <- payload
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	annotations := map[string]string{
+		"runbook_url": "https://wiki.example.com/runbooks/low-balance",
+		"team":        "treasury",
+	}
+	manager := NewManager(config.AlertingConfig{
+		Enabled:         true,
+		Webhooks:        []string{server.URL},
+		QueueBufferSize: 10,
+		CooldownSeconds: 300,
+		Rules: []config.AlertRule{{
+			ID:          "low_balance",
+			Name:        "Low Balance",
+			MetricName:  "account_balance",
+			Condition:   "<",
+			Threshold:   100,
+			Severity:    "warning",
+			Annotations: annotations,
+		}},
+	})
+
+	if err := manager.CheckMetric(types.Metric{Name: "account_balance", Value: 50}); err != nil {
+		t.Fatalf("CheckMetric failed: %v", err)
+	}
+	if err := manager.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain failed: %v", err)
+	}
+
+	select {
+	case payload := <-payloads:
+		if !reflect.DeepEqual(payload.Annotations, annotations) {
+			t.Errorf("Expected annotations %v, got %v", annotations, payload.Annotations)
+		}
+	default:
+		t.Fatal("Expected a webhook delivery")
+	}
+}
+
+// TestRuleWebhooksOverrideGlobal tests a rule with its own webhooks reaches only those,
+// while a rule without them uses the global webhooks
+func TestRuleWebhooksOverrideGlobal(t *testing.T) {
+	// newRecorder returns a webhook server that records the rule IDs it receives
+	newRecorder := func() (*httptest.Server, func() []string) {
+		var mu sync.Mutex
+		var received []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload WebhookPayload
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			mu.Lock()
+			received = append(received, payload.RuleID)
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		}))
+		return server, func() []string {
+			mu.Lock()
+			defer mu.Unlock()
+			return slices.Sorted(slices.Values(received))
+		}
+	}
+	globalServer, globalReceived := newRecorder()
+	defer globalServer.Close()
+	ruleServer, ruleReceived := newRecorder()
+	defer ruleServer.Close()
+
+	manager := NewManager(config.AlertingConfig{
+		Enabled:         true,
+		Webhooks:        []string{globalServer.URL},
+		QueueBufferSize: 10,
+		CooldownSeconds: 300,
+		Rules: []config.AlertRule{
+			{ID: "global_rule", MetricName: "account_balance", Condition: "<", Threshold: 100, Severity: "warning"},
+			{ID: "own_rule", MetricName: "network_nodes_available", Condition: "<", Threshold: 10, Severity: "critical",
+				Webhooks: []string{ruleServer.URL}},
+		},
+	})
+
+	if err := manager.CheckMetric(types.Metric{Name: "account_balance", Value: 50}); err != nil {
+		t.Fatalf("CheckMetric failed: %v", err)
+	}
+	if err := manager.CheckMetric(types.Metric{Name: "network_nodes_available", Value: 3}); err != nil {
+		t.Fatalf("CheckMetric failed: %v", err)
+	}
+	if err := manager.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain failed: %v", err)
+	}
+
+	if got := globalReceived(); !reflect.DeepEqual(got, []string{"global_rule"}) {
+		t.Errorf("Expected global webhook to receive only global_rule, got %v", got)
+	}
+	if got := ruleReceived(); !reflect.DeepEqual(got, []string{"own_rule"}) {
+		t.Errorf("Expected rule webhook to receive only own_rule, got %v", got)
+	}
+}
+
+// TestManagerCounters tests that fired alerts and failed webhook deliveries are counted
+func TestManagerCounters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	manager := NewManager(config.AlertingConfig{
+		Enabled:         true,
+		Webhooks:        []string{server.URL},
+		QueueBufferSize: 10,
+		CooldownSeconds: 300,
+		Rules: []config.AlertRule{{
+			ID:         "low_balance",
+			MetricName: "account_balance",
+			Condition:  "<",
+			Threshold:  100,
+			Severity:   "warning",
+		}},
+	})
+	manager.webhookConfig.MaxRetries = 0
+
+	if manager.AlertsFired() != 0 || manager.WebhookFailures() != 0 {
+		t.Fatalf("Expected zero counters, got %d fired and %d failures", manager.AlertsFired(), manager.WebhookFailures())
+	}
+
+	if err := manager.CheckMetric(types.Metric{Name: "account_balance", Value: 50}); err != nil {
+		t.Fatalf("CheckMetric failed: %v", err)
+	}
+	// Still in cooldown, so this doesn't fire again
+	if err := manager.CheckMetric(types.Metric{Name: "account_balance", Value: 40}); err != nil {
+		t.Fatalf("CheckMetric failed: %v", err)
+	}
+	if err := manager.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain failed: %v", err)
+	}
+
+	if manager.AlertsFired() != 1 {
+		t.Errorf("Expected 1 alert fired, got %d", manager.AlertsFired())
+	}
+	if manager.WebhookFailures() != 1 {
+		t.Errorf("Expected 1 webhook failure, got %d", manager.WebhookFailures())
+	}
+}
+
+// TestSendWebhookRecordsDeliveryMetrics tests successful and failed sends are recorded by target and outcome
+func TestSendWebhookRecordsDeliveryMetrics(t *testing.T) {
+	okServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer okServer.Close()
+	failServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failServer.Close()
+
+	store := storage.NewMemoryStorage()
+	manager := NewManager(config.AlertingConfig{Enabled: true, QueueBufferSize: 10})
+	manager.webhookConfig.MaxRetries = 0
+	manager.SetDeliveryMetricsStore(store)
+
+	alert := AlertEvent{RuleID: "rule_1", RuleName: "Delivery Test", Severity: "warning"}
+	manager.sendWebhook(context.Background(), okServer.URL, alert)
+	manager.sendWebhook(context.Background(), okServer.URL+"/secret-token", alert)
+	manager.sendWebhook(context.Background(), failServer.URL, alert)
+
+	okTarget := webhookTarget(okServer.URL)
+	failTarget := webhookTarget(failServer.URL)
+	if okTarget != okServer.Listener.Addr().String() {
+		t.Fatalf("Expected target to be the webhook host, got %s", okTarget)
+	}
+
+	totals, _ := store.GetMetrics("webhook_delivery_total", 0)
+	latest := make(map[string]float64)
+	for _, m := range storage.LatestPerSeries(totals) {
+		latest[m.Labels["target"]+"|"+m.Labels["outcome"]] = m.Value
+	}
+	expected := map[string]float64{
+		okTarget + "|success":   2,
+		failTarget + "|failure": 1,
+	}
+	if !reflect.DeepEqual(latest, expected) {
+		t.Errorf("Expected delivery totals %v, got %v", expected, latest)
+	}
+
+	durations, _ := store.GetMetrics("webhook_delivery_duration_ms", 0)
+	if len(durations) != 3 {
+		t.Fatalf("Expected 3 duration metrics, got %d", len(durations))
+	}
+	for _, m := range durations {
+		if m.Labels["outcome"] != "success" && m.Labels["outcome"] != "failure" {
+			t.Errorf("Expected outcome label, got %v", m.Labels)
+		}
+	}
+}
+
+// TestDispatchRespectsWebhookConcurrency tests that a burst of alerts never has more
+// webhook deliveries in flight than the configured cap, and every webhook gets every alert
+func TestDispatchRespectsWebhookConcurrency(t *testing.T) {
+	const limit = 2
+	var inFlight, peak, received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		for {
+			previous := peak.Load()
+			if current <= previous || peak.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		inFlight.Add(-1)
+		received.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	webhooks := []string{server.URL + "/a", server.URL + "/b", server.URL + "/c"}
+	manager := NewManager(config.AlertingConfig{
+		Enabled:               true,
+		Webhooks:              webhooks,
+		QueueBufferSize:       10,
+		CooldownSeconds:       300,
+		WebhookMaxConcurrency: limit,
+	})
+
+	const alerts = 5
+	for i := 0; i < alerts; i++ {
+		manager.alertQueue <- AlertEvent{RuleID: fmt.Sprintf("burst_%d", i), RuleName: "Burst"}
+	}
+	if err := manager.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain failed: %v", err)
+	}
+
+	if got := peak.Load(); got > limit {
+		t.Errorf("Expected at most %d concurrent deliveries, got %d", limit, got)
+	}
+	if got := received.Load(); got != alerts*int32(len(webhooks)) {
+		t.Errorf("Expected %d deliveries, got %d", alerts*len(webhooks), got)
+	}
+	if manager.WebhookFailures() != 0 {
+		t.Errorf("Expected no failed deliveries, got %d", manager.WebhookFailures())
+	}
+}
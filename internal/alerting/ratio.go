@@ -0,0 +1,64 @@
+package alerting
+
+import (
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+)
+
+// IsRatio reports whether the rule compares MetricName / DenominatorMetric
+// against its threshold instead of MetricName alone
+func (r *AlertRule) IsRatio() bool {
+	return r.DenominatorMetric != ""
+}
+
+// RatioPairWindowSeconds is how far apart the numerator and denominator samples of a
+// ratio rule may be and still be divided, so both sides come from the same collection
+// cycle rather than a fresh value being paired with a stale one
+const RatioPairWindowSeconds = 5
+
+// ratioMetric records metric as the latest sample of its series if it is either
+// side of the ratio rule, then divides the latest numerator by the latest
+// denominator. Sides are paired on their full label set, so per-account and
+// per-network metrics are only divided by the same account's and network's denominator
+// Returns false if metric isn't part of the rule, the other side hasn't been seen
+// within RatioPairWindowSeconds of it, or the denominator is zero
+func (m *Manager) ratioMetric(rule AlertRule, metric types.Metric) (types.Metric, bool) {
+	if metric.Name != rule.MetricName && metric.Name != rule.DenominatorMetric {
+		return types.Metric{}, false
+	}
+
+	numerator := types.Metric{Name: rule.MetricName, Labels: metric.Labels}
+	denominator := types.Metric{Name: rule.DenominatorMetric, Labels: metric.Labels}
+
+	m.metricMutex.Lock()
+	m.ratioValues[storage.SeriesKey(metric)] = metric
+	numeratorSample, hasNumerator := m.ratioValues[storage.SeriesKey(numerator)]
+	denominatorSample, hasDenominator := m.ratioValues[storage.SeriesKey(denominator)]
+	m.metricMutex.Unlock()
+
+	if !hasNumerator || !hasDenominator {
+		return types.Metric{}, false
+	}
+	if skew := numeratorSample.Timestamp - denominatorSample.Timestamp; skew < -RatioPairWindowSeconds || RatioPairWindowSeconds < skew {
+		logger.Debug("Skipping ratio rule (sides are from different cycles)",
+			"component", "AlertManager",
+			"rule_id", rule.ID,
+			"numerator_timestamp", numeratorSample.Timestamp,
+			"denominator_timestamp", denominatorSample.Timestamp)
+		return types.Metric{}, false
+	}
+	if denominatorSample.Value == 0 {
+		logger.Debug("Skipping ratio rule (denominator is zero)",
+			"component", "AlertManager",
+			"rule_id", rule.ID,
+			"denominator_metric", rule.DenominatorMetric)
+		return types.Metric{}, false
+	}
+
+	// The ratio is reported as the numerator series so alert IDs and templates
+	// name the metric being alerted on
+	numerator.Value = numeratorSample.Value / denominatorSample.Value
+	numerator.Timestamp = metric.Timestamp
+	return numerator, true
+}
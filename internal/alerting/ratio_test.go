@@ -0,0 +1,181 @@
+package alerting
+
+import (
+	"testing"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
+)
+
+// newRatioTestManager returns a manager with one rule firing when failed
+// transactions exceed 10% of the total
+func newRatioTestManager(t *testing.T) *Manager {
+	t.Helper()
+	return NewManager(config.AlertingConfig{
+		Enabled:         true,
+		QueueBufferSize: 10,
+		CooldownSeconds: 300,
+		Rules: []config.AlertRule{{
+			ID:                "failure_ratio",
+			Name:              "Failure Ratio",
+			MetricName:        "failed_transactions",
+			DenominatorMetric: "total_transactions",
+			Condition:         ">",
+			Threshold:         0.1,
+			Severity:          "warning",
+		}},
+	})
+}
+
+// checkMetrics runs CheckMetric on each metric in order
+func checkMetrics(t *testing.T, manager *Manager, metrics ...types.Metric) {
+	t.Helper()
+	for _, metric := range metrics {
+		if err := manager.CheckMetric(metric); err != nil {
+			t.Fatalf("CheckMetric failed: %v", err)
+		}
+	}
+}
+
+// TestRatioRule_AboveThreshold tests a ratio over the threshold fires with the ratio as its value
+func TestRatioRule_AboveThreshold(t *testing.T) {
+	manager := newRatioTestManager(t)
+
+	checkMetrics(t, manager,
+		types.Metric{Name: "total_transactions", Value: 200},
+		types.Metric{Name: "failed_transactions", Value: 30},
+	)
+
+	if queued := len(manager.alertQueue); queued != 1 {
+		t.Fatalf("Expected 1 alert for a 15%% failure ratio, got %d", queued)
+	}
+	alert := <-manager.alertQueue
+	if alert.Value != 0.15 {
+		t.Errorf("Expected alert value 0.15, got %v", alert.Value)
+	}
+	if alert.MetricID != "failed_transactions" {
+		t.Errorf("Expected alert for the numerator series, got %q", alert.MetricID)
+	}
+}
+
+// TestRatioRule_BelowThreshold tests a ratio under the threshold doesn't fire
+func TestRatioRule_BelowThreshold(t *testing.T) {
+	manager := newRatioTestManager(t)
+
+	checkMetrics(t, manager,
+		types.Metric{Name: "failed_transactions", Value: 5},
+		types.Metric{Name: "total_transactions", Value: 200},
+	)
+
+	if queued := len(manager.alertQueue); queued != 0 {
+		t.Errorf("Expected no alerts for a 2.5%% failure ratio, got %d", queued)
+	}
+}
+
+// TestRatioRule_WaitsForBothSides tests nothing is evaluated until both metrics have been seen
+func TestRatioRule_WaitsForBothSides(t *testing.T) {
+	manager := newRatioTestManager(t)
+
+	checkMetrics(t, manager, types.Metric{Name: "failed_transactions", Value: 50})
+	if queued := len(manager.alertQueue); queued != 0 {
+		t.Fatalf("Expected no alerts without a denominator, got %d", queued)
+	}
+
+	// The denominator arriving later uses the latest numerator
+	checkMetrics(t, manager, types.Metric{Name: "total_transactions", Value: 100})
+	if queued := len(manager.alertQueue); queued != 1 {
+		t.Errorf("Expected 1 alert once the denominator arrives, got %d", queued)
+	}
+}
+
+// TestRatioRule_ZeroDenominator tests a zero denominator skips evaluation instead of dividing
+func TestRatioRule_ZeroDenominator(t *testing.T) {
+	manager := newRatioTestManager(t)
+
+	checkMetrics(t, manager,
+		types.Metric{Name: "total_transactions", Value: 0},
+		types.Metric{Name: "failed_transactions", Value: 3},
+	)
+
+	if queued := len(manager.alertQueue); queued != 0 {
+		t.Errorf("Expected no alerts with a zero denominator, got %d", queued)
+	}
+	manager.metricMutex.Lock()
+	_, evaluated := manager.lastMetrics["failure_ratio"]
+	manager.metricMutex.Unlock()
+	if evaluated {
+		t.Error("Expected the rule to not record state for an undefined ratio")
+	}
+}
+
+// TestRatioRule_PairsSeries tests per-account metrics are divided by the same account's denominator
+func TestRatioRule_PairsSeries(t *testing.T) {
+	manager := newRatioTestManager(t)
+	account := func(id string) map[string]string { return map[string]string{"account_id": id} }
+
+	checkMetrics(t, manager,
+		types.Metric{Name: "total_transactions", Value: 1000, Labels: account("0.0.1")},
+		types.Metric{Name: "total_transactions", Value: 10, Labels: account("0.0.2")},
+		types.Metric{Name: "failed_transactions", Value: 50, Labels: account("0.0.1")},
+	)
+	if queued := len(manager.alertQueue); queued != 0 {
+		t.Fatalf("Expected no alerts for 0.0.1 at 5%%, got %d", queued)
+	}
+
+	checkMetrics(t, manager, types.Metric{Name: "failed_transactions", Value: 5, Labels: account("0.0.2")})
+	if queued := len(manager.alertQueue); queued != 1 {
+		t.Fatalf("Expected 1 alert for 0.0.2 at 50%%, got %d", queued)
+	}
+	if alert := <-manager.alertQueue; alert.MetricID != "failed_transactions[0.0.2]" {
+		t.Errorf("Expected alert for failed_transactions[0.0.2], got %q", alert.MetricID)
+	}
+}
+
+// TestRatioRule_SkipsStalePairs tests a fresh sample isn't divided by one from an earlier cycle
+func TestRatioRule_SkipsStalePairs(t *testing.T) {
+	manager := newRatioTestManager(t)
+
+	checkMetrics(t, manager,
+		types.Metric{Name: "total_transactions", Timestamp: 1000, Value: 100},
+		types.Metric{Name: "failed_transactions", Timestamp: 1000, Value: 1},
+	)
+	if queued := len(manager.alertQueue); queued != 0 {
+		t.Fatalf("Expected no alerts at 1%%, got %d", queued)
+	}
+
+	// Next cycle: the numerator arrives first and would read 50% against the old total
+	checkMetrics(t, manager, types.Metric{Name: "failed_transactions", Timestamp: 1060, Value: 50})
+	if queued := len(manager.alertQueue); queued != 0 {
+		t.Fatalf("Expected no alerts pairing with the previous cycle's denominator, got %d", queued)
+	}
+
+	checkMetrics(t, manager, types.Metric{Name: "total_transactions", Timestamp: 1061, Value: 1000})
+	if queued := len(manager.alertQueue); queued != 0 {
+		t.Errorf("Expected no alerts at 5%% once the denominator caught up, got %d", queued)
+	}
+}
+
+// TestRatioRule_PairsNetworks tests one network's numerator isn't divided by another's denominator
+func TestRatioRule_PairsNetworks(t *testing.T) {
+	manager := newRatioTestManager(t)
+	network := func(name string) map[string]string {
+		return map[string]string{"account_id": "0.0.1", "network": name}
+	}
+
+	checkMetrics(t, manager,
+		types.Metric{Name: "total_transactions", Timestamp: 1000, Value: 10, Labels: network("testnet")},
+		types.Metric{Name: "total_transactions", Timestamp: 1000, Value: 1000, Labels: network("mainnet")},
+		types.Metric{Name: "failed_transactions", Timestamp: 1000, Value: 5, Labels: network("mainnet")},
+	)
+	if queued := len(manager.alertQueue); queued != 0 {
+		t.Fatalf("Expected no alerts for mainnet at 0.5%%, got %d", queued)
+	}
+
+	checkMetrics(t, manager, types.Metric{Name: "failed_transactions", Timestamp: 1000, Value: 5, Labels: network("testnet")})
+	if queued := len(manager.alertQueue); queued != 1 {
+		t.Fatalf("Expected 1 alert for testnet at 50%%, got %d", queued)
+	}
+	if alert := <-manager.alertQueue; alert.Value != 0.5 {
+		t.Errorf("Expected the testnet ratio 0.5, got %v", alert.Value)
+	}
+}
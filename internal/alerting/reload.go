@@ -0,0 +1,96 @@
+package alerting
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
+)
+
+// ReloadResult lists the rule IDs a Reload added, removed or updated, and
+// whether the global webhooks changed
+type ReloadResult struct {
+	Added           []string
+	Removed         []string
+	Updated         []string
+	WebhooksChanged bool
+}
+
+// Reload applies a re-read alerting config at runtime: rules loaded from config
+// are added, updated or removed to match cfg.Rules, and the global webhooks are
+// replaced. Rules added through the API are left alone. A config rule without an
+// ID keeps the ID of the loaded config rule with the same name, so it isn't
+// reported as removed and added again
+// Other alerting settings (queue size, cooldown default, digest) need a restart
+func (m *Manager) Reload(cfg config.AlertingConfig) ReloadResult {
+	m.ruleMutex.Lock()
+	defer m.ruleMutex.Unlock()
+
+	idsByName := make(map[string]string, len(m.configRules))
+	for _, rule := range m.rules {
+		if m.configRules[rule.ID] {
+			idsByName[rule.Name] = rule.ID
+		}
+	}
+
+	var result ReloadResult
+	loaded := make(map[string]bool, len(cfg.Rules))
+	for _, cfgRule := range cfg.Rules {
+		if cfgRule.ID == "" {
+			cfgRule.ID = idsByName[cfgRule.Name]
+		}
+		if cfgRule.ID == "" {
+			cfgRule.ID = uuid.New().String()
+		}
+		loaded[cfgRule.ID] = true
+
+		rule := ruleFromConfig(cfgRule)
+		i := slices.IndexFunc(m.rules, func(r AlertRule) bool { return r.ID == rule.ID })
+		switch {
+		case i < 0:
+			m.rules = append(m.rules, rule)
+			m.ruleAdded[rule.ID] = m.now()
+			result.Added = append(result.Added, rule.ID)
+		case !reflect.DeepEqual(m.rules[i].ConfigRule(), cfgRule):
+			rule.Enabled = m.rules[i].Enabled
+			// State built on another metric or condition would be compared against the wrong values
+			if watchChanged(m.rules[i], rule) {
+				m.forgetRuleState(rule.ID)
+			}
+			m.rules[i] = rule
+			result.Updated = append(result.Updated, rule.ID)
+		default:
+			continue
+		}
+		cacheMessageTemplate(m.templates, rule)
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(m.configRules)) {
+		if loaded[id] {
+			continue
+		}
+		m.rules = slices.DeleteFunc(m.rules, func(r AlertRule) bool { return r.ID == id })
+		delete(m.templates, id)
+		delete(m.ruleAdded, id)
+		m.forgetStats(id)
+		m.forgetRuleState(id)
+		result.Removed = append(result.Removed, id)
+	}
+	m.configRules = loaded
+
+	if !slices.Equal(m.webhooks, cfg.Webhooks) {
+		m.webhooks = slices.Clone(cfg.Webhooks)
+		result.WebhooksChanged = true
+	}
+	return result
+}
+
+// watchChanged reports whether updated evaluates different values than old: another
+// metric, denominator or condition
+func watchChanged(old, updated AlertRule) bool {
+	return old.MetricName != updated.MetricName ||
+		old.DenominatorMetric != updated.DenominatorMetric ||
+		old.Condition != updated.Condition
+}
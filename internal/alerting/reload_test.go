@@ -0,0 +1,121 @@
+package alerting
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
+)
+
+// reloadConfig returns an alerting config with the given rules and webhooks
+func reloadConfig(webhooks []string, rules ...config.AlertRule) config.AlertingConfig {
+	return config.AlertingConfig{
+		Enabled:         true,
+		Webhooks:        webhooks,
+		Rules:           rules,
+		QueueBufferSize: 10,
+		CooldownSeconds: 300,
+	}
+}
+
+// TestReload_DiffsConfigRules tests that Reload adds, updates and removes config rules
+// and reports each change
+func TestReload_DiffsConfigRules(t *testing.T) {
+	low := config.AlertRule{ID: "low", Name: "Low", MetricName: "account_balance", Condition: "<", Threshold: 100, Severity: "warning"}
+	high := config.AlertRule{ID: "high", Name: "High", MetricName: "account_balance", Condition: ">", Threshold: 900, Severity: "info"}
+	manager := NewManager(reloadConfig([]string{"https://hooks.example.com/a"}, low, high))
+
+	low.Threshold = 50
+	added := config.AlertRule{ID: "down", Name: "Down", MetricName: "network_consensus_active", Condition: "<", Threshold: 1, Severity: "critical"}
+	result := manager.Reload(reloadConfig([]string{"https://hooks.example.com/b"}, low, added))
+
+	if !slices.Equal(result.Added, []string{"down"}) {
+		t.Errorf("Expected added [down], got %v", result.Added)
+	}
+	if !slices.Equal(result.Updated, []string{"low"}) {
+		t.Errorf("Expected updated [low], got %v", result.Updated)
+	}
+	if !slices.Equal(result.Removed, []string{"high"}) {
+		t.Errorf("Expected removed [high], got %v", result.Removed)
+	}
+	if !result.WebhooksChanged {
+		t.Error("Expected webhooks to be reported as changed")
+	}
+
+	rules := manager.GetRules()
+	if len(rules) != 2 {
+		t.Fatalf("Expected 2 rules after reload, got %d", len(rules))
+	}
+	for _, rule := range rules {
+		if rule.ID == "low" && rule.Threshold != 50 {
+			t.Errorf("Expected updated threshold 50, got %v", rule.Threshold)
+		}
+	}
+}
+
+// TestReload_Unchanged tests that reloading the same config reports no changes,
+// including for rules whose IDs were generated at load
+func TestReload_Unchanged(t *testing.T) {
+	cfg := reloadConfig([]string{"https://hooks.example.com/a"},
+		config.AlertRule{Name: "Unnamed ID", MetricName: "account_balance", Condition: "changed", Severity: "info", Tags: []string{"balances"}})
+	manager := NewManager(cfg)
+	before := manager.GetRules()
+
+	result := manager.Reload(cfg)
+
+	if len(result.Added)+len(result.Removed)+len(result.Updated) != 0 || result.WebhooksChanged {
+		t.Errorf("Expected no changes, got %+v", result)
+	}
+	if after := manager.GetRules(); after[0].ID != before[0].ID {
+		t.Errorf("Expected generated ID %s to be kept, got %s", before[0].ID, after[0].ID)
+	}
+}
+
+// TestReload_KeepsAPIRules tests that rules added through the API survive a reload
+func TestReload_KeepsAPIRules(t *testing.T) {
+	manager := NewManager(reloadConfig(nil,
+		config.AlertRule{ID: "low", Name: "Low", MetricName: "account_balance", Condition: "<", Threshold: 100, Severity: "warning"}))
+	if err := manager.AddRule(AlertRule{ID: "api_rule", Name: "From API", MetricName: "account_balance", Condition: ">", Threshold: 1, Enabled: true}); err != nil {
+		t.Fatalf("AddRule failed: %v", err)
+	}
+
+	result := manager.Reload(reloadConfig(nil))
+
+	if !slices.Equal(result.Removed, []string{"low"}) {
+		t.Errorf("Expected removed [low], got %v", result.Removed)
+	}
+	rules := manager.GetRules()
+	if len(rules) != 1 || rules[0].ID != "api_rule" {
+		t.Errorf("Expected only the API rule to remain, got %+v", rules)
+	}
+}
+
+// TestReload_ResetsStateWhenWatchChanges tests that a rule moved to another metric doesn't
+// compare its first value against the old metric's, while other updates keep the state
+func TestReload_ResetsStateWhenWatchChanges(t *testing.T) {
+	moved := config.AlertRule{ID: "moved", Name: "Moved", MetricName: "account_balance", Condition: "changed", Severity: "info"}
+	kept := config.AlertRule{ID: "kept", Name: "Kept", MetricName: "account_balance", Condition: "changed", Severity: "info"}
+	manager := NewManager(reloadConfig(nil, moved, kept))
+	if err := manager.CheckMetric(types.Metric{Name: "account_balance", Value: 100}); err != nil {
+		t.Fatalf("CheckMetric failed: %v", err)
+	}
+
+	moved.MetricName = "account_total_volume"
+	kept.Description = "Balance moved"
+	manager.Reload(reloadConfig(nil, moved, kept))
+
+	if err := manager.CheckMetric(types.Metric{Name: "account_total_volume", Value: 500}); err != nil {
+		t.Fatalf("CheckMetric failed: %v", err)
+	}
+	if queued := len(manager.alertQueue); queued != 0 {
+		t.Errorf("Expected no alert on the moved rule's first value, got %d", queued)
+	}
+
+	manager.metricMutex.Lock()
+	state, ok := manager.lastMetrics["kept"]
+	manager.metricMutex.Unlock()
+	if !ok || state.Value != 100 {
+		t.Errorf("Expected the kept rule's previous value 100 to survive the reload, got %+v", state)
+	}
+}
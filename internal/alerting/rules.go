@@ -1,28 +1,107 @@
 package alerting
 
+import (
+	"math"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
+)
+
 // AlertRule defines a condition that triggers an alert
 type AlertRule struct {
 	ID              string
 	Name            string
 	Description     string
-	MetricName      string // The metric this rule applies to
-	Condition       string // Condition language defined in EvaluateCondition
+	MetricName      string          // The metric this rule applies to
+	Condition       types.Condition // Evaluated by EvaluateCondition
 	Threshold       float64
 	Enabled         bool
-	Severity        string // "info", "warning", "critical"
-	CooldownSeconds int    // Cooldown period between alerts in seconds (default: 300)
+	Severity        types.Severity
+	CooldownSeconds int     // Cooldown period between alerts in seconds (default: 300)
+	ForSeconds      int     // Condition must hold continuously this long before firing (0 = fire immediately)
+	MinChange       float64 // "changed" only fires when the value moves by at least this much (0 = any change)
+	WarmupSeconds   int     // The rule doesn't fire until it has existed this long, so state has a baseline (0 = fire immediately)
+
+	// Escalation: once the rule fires EscalateAfter times on a series without the
+	// condition clearing, alerts are sent one severity level higher (0 = never escalate)
+	EscalateAfter         int
+	EscalateWindowSeconds int // Fires older than this don't count toward escalation (0 = no window)
+
+	// MinChangePercent makes "changed" ignore moves smaller than this percent of the
+	// previous value, on top of MinChange (0 = any change)
+	MinChangePercent float64
+
+	// DenominatorMetric makes this a ratio rule: the condition and threshold apply
+	// to MetricName / DenominatorMetric, using the latest value of each once both are
+	// from the same cycle (see IsRatio and RatioPairWindowSeconds)
+	DenominatorMetric string
+
+	Tags []string // Groups the rule belongs to, e.g. "balances" or "network"
+
+	Annotations map[string]string // Context passed to webhooks as-is, e.g. runbook_url, team, dashboard
+
+	Webhooks []string // Sent only to these URLs instead of the manager's webhooks (empty = the manager's)
+
+	// MessageTemplate is a text/template rendered with MessageData for the alert message
+	// Empty, malformed, or failing templates fall back to Description
+	MessageTemplate string
 }
 
 // AlertEvent represents a triggered alert
 type AlertEvent struct {
 	RuleID          string
 	RuleName        string
-	Severity        string
+	Severity        types.Severity
 	Message         string
 	Timestamp       int64
 	MetricID        string // Reference to the metric that triggered this
 	Value           float64
 	CooldownSeconds int
+	Escalated       bool              // Severity was bumped because the rule kept firing without recovery
+	Annotations     map[string]string // Copied from the rule
+	Webhooks        []string          // Copied from the rule; empty sends to the manager's webhooks
+	Suppressed      bool              // Not sent because a maintenance window was active
+}
+
+// ConfigRule converts the rule to its config file form, the inverse of
+// ruleFromConfig. Enabled has no config equivalent and is dropped
+func (r *AlertRule) ConfigRule() config.AlertRule {
+	return config.AlertRule{
+		ID:              r.ID,
+		Name:            r.Name,
+		MetricName:      r.MetricName,
+		Condition:       r.Condition,
+		Threshold:       r.Threshold,
+		Severity:        r.Severity,
+		CooldownSeconds: r.CooldownSeconds,
+		ForSeconds:      r.ForSeconds,
+		WarmupSeconds:   r.WarmupSeconds,
+		MinChange:       r.MinChange,
+
+		EscalateAfter:         r.EscalateAfter,
+		EscalateWindowSeconds: r.EscalateWindowSeconds,
+
+		MinChangePercent: r.MinChangePercent,
+
+		DenominatorMetric: r.DenominatorMetric,
+
+		Tags:        r.Tags,
+		Annotations: r.Annotations,
+		Webhooks:    r.Webhooks,
+
+		Description:     r.Description,
+		MessageTemplate: r.MessageTemplate,
+	}
+}
+
+// HasTag reports whether the rule belongs to the given group
+func (r *AlertRule) HasTag(tag string) bool {
+	for _, t := range r.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
 }
 
 // EvaluateCondition checks if a metric value satisfies the rule condition
@@ -30,28 +109,43 @@ type AlertEvent struct {
 // must be true or the condition will return false (first metric doesn't trigger)
 func (r *AlertRule) EvaluateCondition(metricValue float64, previousValue float64, hasPreviousValue bool) bool {
 	switch r.Condition {
-	case ">":
+	case types.ConditionGreater:
 		return metricValue > r.Threshold
-	case "<":
+	case types.ConditionLess:
 		return metricValue < r.Threshold
-	case ">=":
+	case types.ConditionGreaterEqual:
 		return metricValue >= r.Threshold
-	case "<=":
+	case types.ConditionLessEqual:
 		return metricValue <= r.Threshold
-	case "==":
+	case types.ConditionEqual:
 		return metricValue == r.Threshold
-	case "!=":
+	case types.ConditionNotEqual:
 		return metricValue != r.Threshold
-	case "changed":
-		// Don't trigger on first metric (no previous value to compare)
-		return hasPreviousValue && metricValue != previousValue
-	case "increased":
+	case types.ConditionChanged:
+		// Don't trigger on first metric (no previous value to compare), or on
+		// changes smaller than MinChange or MinChangePercent such as dust transfers
+		return hasPreviousValue && metricValue != previousValue &&
+			math.Abs(metricValue-previousValue) >= r.MinChange &&
+			math.Abs(percentChange(previousValue, metricValue)) >= r.MinChangePercent
+	case types.ConditionIncreased:
 		// Don't trigger on first metric
 		return hasPreviousValue && previousValue < metricValue
-	case "decreased":
+	case types.ConditionDecreased:
 		// Don't trigger on first metric
 		return hasPreviousValue && metricValue < previousValue
 	default:
 		return false
 	}
 }
+
+// percentChange returns the change from previous to current as a percentage of previous
+// A change away from zero is infinitely large, so it passes any percent tolerance
+func percentChange(previous, current float64) float64 {
+	if previous == 0 {
+		if current == 0 {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return (current - previous) / math.Abs(previous) * 100
+}
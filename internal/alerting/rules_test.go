@@ -173,6 +173,64 @@ func TestEvaluateCondition_Changed(t *testing.T) {
 	}
 }
 
+// TestEvaluateCondition_ChangedMinChange tests that changed ignores differences below MinChange
+func TestEvaluateCondition_ChangedMinChange(t *testing.T) {
+	rule := &AlertRule{
+		ID:        "changed_min_rule",
+		Name:      "Changed Min Test",
+		Condition: "changed",
+		MinChange: 100.0,
+	}
+
+	// Dust change (1000 -> 1001) is below the minimum
+	if rule.EvaluateCondition(1001.0, 1000.0, true) {
+		t.Error("expected changed condition to be false for a change below min_change")
+	}
+
+	// Change of exactly the minimum fires, in either direction
+	if !rule.EvaluateCondition(1100.0, 1000.0, true) {
+		t.Error("expected changed condition to be true for a change equal to min_change")
+	}
+	if !rule.EvaluateCondition(500.0, 1000.0, true) {
+		t.Error("expected changed condition to be true for a decrease above min_change")
+	}
+
+	// First metric still doesn't trigger, however far from zero
+	if rule.EvaluateCondition(5000.0, 0.0, false) {
+		t.Error("expected changed condition to be false for first metric (no previous value)")
+	}
+}
+
+// TestEvaluateCondition_ChangedMinChangePercent tests that changed ignores moves below MinChangePercent
+func TestEvaluateCondition_ChangedMinChangePercent(t *testing.T) {
+	rule := &AlertRule{
+		ID:               "changed_pct_rule",
+		Name:             "Changed Percent Test",
+		Condition:        "changed",
+		MinChangePercent: 1.0,
+	}
+
+	// Oscillating by 0.5% either way stays within the tolerance
+	for _, value := range []float64{1005.0, 995.0} {
+		if rule.EvaluateCondition(value, 1000.0, true) {
+			t.Errorf("expected changed condition to be false for 1000 -> %v (below 1%%)", value)
+		}
+	}
+
+	// Moves of 1% or more fire, in either direction
+	if !rule.EvaluateCondition(1010.0, 1000.0, true) {
+		t.Error("expected changed condition to be true for a 1% increase")
+	}
+	if !rule.EvaluateCondition(900.0, 1000.0, true) {
+		t.Error("expected changed condition to be true for a 10% decrease")
+	}
+
+	// Any move away from zero exceeds the tolerance
+	if !rule.EvaluateCondition(1.0, 0.0, true) {
+		t.Error("expected changed condition to be true for a change from zero")
+	}
+}
+
 // TestEvaluateCondition_Increased tests the increased condition (value > previous)
 func TestEvaluateCondition_Increased(t *testing.T) {
 	rule := &AlertRule{
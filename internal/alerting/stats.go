@@ -0,0 +1,49 @@
+package alerting
+
+import (
+	"maps"
+	"time"
+)
+
+// RuleStats records how often a rule has been evaluated and when it last fired, to tell
+// a rule whose metric never arrives from one whose condition is never true
+type RuleStats struct {
+	Evaluations   int64     // Metrics checked against the rule since it was loaded or added
+	LastEvaluated time.Time // Zero if never evaluated
+	LastFired     time.Time // When an alert was last queued; zero if never
+}
+
+// RuleStats returns evaluation stats keyed by rule ID; rules never evaluated are absent
+func (m *Manager) RuleStats() map[string]RuleStats {
+	m.statsMutex.Lock()
+	defer m.statsMutex.Unlock()
+	return maps.Clone(m.ruleStats)
+}
+
+// recordEvaluation counts one evaluation of the rule
+func (m *Manager) recordEvaluation(ruleID string, at time.Time) {
+	m.statsMutex.Lock()
+	defer m.statsMutex.Unlock()
+
+	stats := m.ruleStats[ruleID]
+	stats.Evaluations++
+	stats.LastEvaluated = at
+	m.ruleStats[ruleID] = stats
+}
+
+// recordFired notes that the rule queued an alert
+func (m *Manager) recordFired(ruleID string, at time.Time) {
+	m.statsMutex.Lock()
+	defer m.statsMutex.Unlock()
+
+	stats := m.ruleStats[ruleID]
+	stats.LastFired = at
+	m.ruleStats[ruleID] = stats
+}
+
+// forgetStats drops the stats of a removed rule
+func (m *Manager) forgetStats(ruleID string) {
+	m.statsMutex.Lock()
+	defer m.statsMutex.Unlock()
+	delete(m.ruleStats, ruleID)
+}
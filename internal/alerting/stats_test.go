@@ -0,0 +1,67 @@
+package alerting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
+)
+
+// TestRuleStats_RecordsEvaluationsAndFires tests that evaluating a rule counts it and
+// updates its last-evaluated and last-fired times
+func TestRuleStats_RecordsEvaluationsAndFires(t *testing.T) {
+	manager := NewManager(config.AlertingConfig{Enabled: true, QueueBufferSize: 10})
+	clock := time.Unix(1700000000, 0)
+	manager.now = func() time.Time { return clock }
+
+	rule := AlertRule{
+		ID:         "low_balance",
+		Name:       "Low Balance",
+		MetricName: "account_balance",
+		Condition:  "<",
+		Threshold:  100,
+		Enabled:    true,
+		Severity:   "warning",
+	}
+	if err := manager.AddRule(rule); err != nil {
+		t.Fatalf("AddRule failed: %v", err)
+	}
+	if _, ok := manager.RuleStats()["low_balance"]; ok {
+		t.Fatal("Expected no stats before the rule is evaluated")
+	}
+
+	check := func(name string, value float64) {
+		t.Helper()
+		if err := manager.CheckMetric(types.Metric{Name: name, Value: value}); err != nil {
+			t.Fatalf("CheckMetric failed: %v", err)
+		}
+	}
+
+	check("account_balance", 500)
+	stats := manager.RuleStats()["low_balance"]
+	if stats.Evaluations != 1 || !stats.LastEvaluated.Equal(clock) {
+		t.Errorf("Expected 1 evaluation at %v, got %+v", clock, stats)
+	}
+	if !stats.LastFired.IsZero() {
+		t.Errorf("Expected no fire while the condition is false, got %v", stats.LastFired)
+	}
+
+	// Other metrics don't count as evaluations of the rule
+	check("hbar_price", 1)
+
+	fired := clock.Add(time.Minute)
+	clock = fired
+	check("account_balance", 50)
+	stats = manager.RuleStats()["low_balance"]
+	if stats.Evaluations != 2 || !stats.LastEvaluated.Equal(fired) || !stats.LastFired.Equal(fired) {
+		t.Errorf("Expected 2 evaluations and a fire at %v, got %+v", fired, stats)
+	}
+
+	if err := manager.RemoveRule("low_balance"); err != nil {
+		t.Fatalf("RemoveRule failed: %v", err)
+	}
+	if _, ok := manager.RuleStats()["low_balance"]; ok {
+		t.Error("Expected stats to be dropped with the rule")
+	}
+}
@@ -0,0 +1,61 @@
+package alerting
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+// MessageData is the data a rule's MessageTemplate is rendered with, e.g.
+// "{{.RuleName}}: {{.MetricID}} is {{.Value}} (threshold {{.Threshold}})"
+type MessageData struct {
+	RuleID     string
+	RuleName   string
+	Severity   string // After escalation, if any
+	MetricName string
+	MetricID   string // Series the alert fired on, e.g. "account_balance[0.0.5000]"
+	Value      float64
+	Threshold  float64
+	Condition  string
+	Labels     map[string]string
+	Escalated  bool
+	Timestamp  int64
+}
+
+// parseMessageTemplate compiles a rule's MessageTemplate
+// Returns nil without error when the rule has no template
+func parseMessageTemplate(rule AlertRule) (*template.Template, error) {
+	if rule.MessageTemplate == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New(rule.ID).Option("missingkey=error").Parse(rule.MessageTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message template for rule %s: %w", rule.ID, err)
+	}
+	return tmpl, nil
+}
+
+// renderMessage renders tmpl with the alert's fields
+func renderMessage(tmpl *template.Template, rule AlertRule, metric types.Metric, alert AlertEvent) (string, error) {
+	data := MessageData{
+		RuleID:     alert.RuleID,
+		RuleName:   alert.RuleName,
+		Severity:   alert.Severity.String(),
+		MetricName: metric.Name,
+		MetricID:   alert.MetricID,
+		Value:      alert.Value,
+		Threshold:  rule.Threshold,
+		Condition:  rule.Condition.String(),
+		Labels:     metric.Labels,
+		Escalated:  alert.Escalated,
+		Timestamp:  alert.Timestamp,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,109 @@
+package alerting
+
+import (
+	"testing"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
+)
+
+// queueTemplatedAlert adds a rule with the given message template, fires it once and
+// returns the queued alert's message
+func queueTemplatedAlert(t *testing.T, messageTemplate string) string {
+	t.Helper()
+	manager := NewManager(config.AlertingConfig{
+		Enabled:         true,
+		QueueBufferSize: 10,
+		CooldownSeconds: 300,
+	})
+
+	rule := AlertRule{
+		ID:              "low_balance",
+		Name:            "Low Balance",
+		Description:     "Balance is low",
+		MetricName:      "account_balance",
+		Condition:       "<",
+		Threshold:       100,
+		Enabled:         true,
+		Severity:        "warning",
+		MessageTemplate: messageTemplate,
+	}
+	if err := manager.AddRule(rule); err != nil {
+		t.Fatalf("AddRule failed: %v", err)
+	}
+
+	metric := types.Metric{
+		Name:   "account_balance",
+		Value:  42,
+		Labels: map[string]string{"account_id": "0.0.5000"},
+	}
+	if err := manager.CheckMetric(metric); err != nil {
+		t.Fatalf("CheckMetric failed: %v", err)
+	}
+
+	select {
+	case alert := <-manager.alertQueue:
+		return alert.Message
+	default:
+		t.Fatal("Expected an alert to be queued")
+		return ""
+	}
+}
+
+// TestMessageTemplate_Rendered tests that a valid template is rendered with the alert's fields
+func TestMessageTemplate_Rendered(t *testing.T) {
+	message := queueTemplatedAlert(t,
+		"{{.RuleName}}: {{.MetricID}} is {{.Value}} (threshold {{.Threshold}}, account {{.Labels.account_id}})")
+
+	expected := "Low Balance: account_balance[0.0.5000] is 42 (threshold 100, account 0.0.5000)"
+	if message != expected {
+		t.Errorf("Expected message %q, got %q", expected, message)
+	}
+}
+
+// TestMessageTemplate_Malformed tests that a template that doesn't parse falls back to the description
+func TestMessageTemplate_Malformed(t *testing.T) {
+	message := queueTemplatedAlert(t, "{{.RuleName")
+	if message != "Balance is low" {
+		t.Errorf("Expected fallback to description, got %q", message)
+	}
+}
+
+// TestMessageTemplate_ExecutionError tests that a template that fails to render falls back to the description
+func TestMessageTemplate_ExecutionError(t *testing.T) {
+	message := queueTemplatedAlert(t, "{{.Unknown}}")
+	if message != "Balance is low" {
+		t.Errorf("Expected fallback to description, got %q", message)
+	}
+}
+
+// TestMessageTemplate_Empty tests that rules without a template keep using the description
+func TestMessageTemplate_Empty(t *testing.T) {
+	message := queueTemplatedAlert(t, "")
+	if message != "Balance is low" {
+		t.Errorf("Expected description, got %q", message)
+	}
+}
+
+// TestMessageTemplate_RemovedWithRule tests that removing a rule drops its cached template
+func TestMessageTemplate_RemovedWithRule(t *testing.T) {
+	manager := NewManager(config.AlertingConfig{
+		QueueBufferSize: 1,
+		Rules: []config.AlertRule{{
+			ID:              "templated",
+			MetricName:      "account_balance",
+			Condition:       "<",
+			MessageTemplate: "{{.Value}}",
+		}},
+	})
+	if manager.templates["templated"] == nil {
+		t.Fatal("Expected the config rule's template to be cached")
+	}
+
+	if err := manager.RemoveRule("templated"); err != nil {
+		t.Fatalf("RemoveRule failed: %v", err)
+	}
+	if _, ok := manager.templates["templated"]; ok {
+		t.Error("Expected the template to be removed with its rule")
+	}
+}
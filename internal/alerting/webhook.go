@@ -2,114 +2,278 @@ package alerting
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
 )
 
+// WebhookSchemaVersion is the current WebhookPayload schema version
+// Bump it when payload fields change meaning or are removed; added fields stay within a version
+const WebhookSchemaVersion = 1
+
 // WebhookPayload represents the JSON payload sent to webhooks
 type WebhookPayload struct {
-	RuleID    string  `json:"rule_id"`
-	RuleName  string  `json:"rule_name"`
-	Severity  string  `json:"severity"`
-	Message   string  `json:"message"`
-	Value     float64 `json:"value"`
-	Timestamp int64   `json:"timestamp"`
-	MetricID  string  `json:"metric_id"`
+	// SchemaVersion identifies the payload layout so receivers can branch on it.
+	// A webhook URL can pin a version with a "version" query parameter
+	SchemaVersion int     `json:"schema_version"`
+	RuleID        string  `json:"rule_id"`
+	RuleName      string  `json:"rule_name"`
+	Severity      string  `json:"severity"`
+	Message       string  `json:"message"`
+	Value         float64 `json:"value"`
+	Timestamp     int64   `json:"timestamp"`
+	MetricID      string  `json:"metric_id"`
+	Escalated     bool    `json:"escalated"`
+
+	// Annotations are the firing rule's key/value context, e.g. runbook_url; omitted when the rule has none
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// WebhookSchemaVersionFor returns the payload schema version a webhook URL asks for
+// through its "version" query parameter, or WebhookSchemaVersion when it doesn't pin one
+func WebhookSchemaVersionFor(webhookURL string) (int, error) {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	raw := parsed.Query().Get("version")
+	if raw == "" {
+		return WebhookSchemaVersion, nil
+	}
+	version, err := strconv.Atoi(raw)
+	if err != nil || version < 1 || WebhookSchemaVersion < version {
+		return 0, fmt.Errorf("unsupported webhook schema version %q: supported versions are 1-%d", raw, WebhookSchemaVersion)
+	}
+	return version, nil
 }
 
+// webhookDeliveryURL returns webhookURL without its "version" query parameters, which
+// are read by the monitor and not meant for the receiver. The rest of the query is
+// kept as written, since receivers may verify signed or token-bearing URLs
+func webhookDeliveryURL(webhookURL string) string {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || parsed.RawQuery == "" {
+		return webhookURL
+	}
+	params := strings.Split(parsed.RawQuery, "&")
+	kept := params[:0]
+	for _, param := range params {
+		key, _, _ := strings.Cut(param, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil && unescaped == "version" {
+			continue
+		}
+		kept = append(kept, param)
+	}
+	parsed.RawQuery = strings.Join(kept, "&")
+	return parsed.String()
+}
+
+// ErrWebhookMaxElapsed is wrapped by SendWebhookRequest when it stops retrying because
+// the next attempt would start after WebhookConfig.MaxElapsed
+var ErrWebhookMaxElapsed = errors.New("webhook gave up after max elapsed time")
+
 // WebhookConfig holds configuration for webhook sending
 type WebhookConfig struct {
 	Timeout        time.Duration
 	MaxRetries     int
 	InitialBackoff time.Duration
 	MaxBackoff     time.Duration
+
+	// MaxElapsed bounds the total time spent on one delivery, including backoff;
+	// retries stop early once waiting for the next attempt would exceed it (0 = no limit)
+	MaxElapsed time.Duration
+
+	// Connection pool settings used by NewWebhookClient
+	MaxIdleConns        int           // Idle connections kept across all hosts
+	MaxIdleConnsPerHost int           // Idle connections kept per webhook host
+	IdleConnTimeout     time.Duration // How long an idle connection stays in the pool
+
+	// TLS settings used by NewWebhookClient for receivers with self-signed or internal certs
+	RootCAs            *x509.CertPool // Trusted CAs (nil = system roots)
+	InsecureSkipVerify bool           // Skip certificate verification entirely; for testing only
+
+	// Client is shared across sends so connections are reused
+	// When nil, a package-level client built from DefaultWebhookConfig is used
+	Client *http.Client
 }
 
 // DefaultWebhookConfig returns sensible defaults for webhook sending
 func DefaultWebhookConfig() WebhookConfig {
 	return WebhookConfig{
-		Timeout:        10 * time.Second,
-		MaxRetries:     5,
-		InitialBackoff: 1 * time.Second,
-		MaxBackoff:     32 * time.Second,
+		Timeout:             10 * time.Second,
+		MaxRetries:          5,
+		InitialBackoff:      1 * time.Second,
+		MaxBackoff:          32 * time.Second,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
 	}
 }
 
+// defaultWebhookClient is used by sends whose config has no Client
+var defaultWebhookClient = NewWebhookClient(DefaultWebhookConfig())
+
+// NewWebhookClient creates an HTTP client with a pooled transport for webhook delivery
+// The client has no overall timeout; SendWebhookRequest bounds each attempt via context
+func NewWebhookClient(config WebhookConfig) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = config.MaxIdleConns
+	transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	transport.IdleConnTimeout = config.IdleConnTimeout
+	if config.RootCAs != nil || config.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:            config.RootCAs,
+			InsecureSkipVerify: config.InsecureSkipVerify, //nolint:gosec // Explicit opt-in for self-signed receivers
+			MinVersion:         tls.VersionTLS12,
+		}
+	}
+	return &http.Client{Transport: transport}
+}
+
+// LoadWebhookCA builds a cert pool from the system roots plus the PEM certificates in caFile
+// System roots are kept so public receivers (Slack, Discord) still verify alongside internal ones
+func LoadWebhookCA(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook CA file %s: %w", caFile, err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found in webhook CA file %s", caFile)
+	}
+	return pool, nil
+}
+
+// httpClient returns the configured shared client or the package default
+func (c WebhookConfig) httpClient() *http.Client {
+	if c.Client != nil {
+		return c.Client
+	}
+	return defaultWebhookClient
+}
+
 // SendWebhookRequest sends a webhook request with retry logic
-// Uses exponential backoff for retries
+// Uses exponential backoff for retries, giving up early once MaxElapsed would be exceeded
 // Returns error if all retries fail
 func SendWebhookRequest(webhookURL string, payload WebhookPayload, config WebhookConfig) error {
-	client := &http.Client{
-		Timeout: config.Timeout,
+	return SendWebhookRequestCtx(context.Background(), webhookURL, payload, config)
+}
+
+// SendWebhookRequestCtx is SendWebhookRequest bound to ctx
+// Cancelling ctx aborts the in-flight attempt and any remaining retries
+func SendWebhookRequestCtx(ctx context.Context, webhookURL string, payload WebhookPayload, config WebhookConfig) error {
+	// Payloads built elsewhere (e.g. replayed from a file) may predate schema_version
+	if payload.SchemaVersion == 0 {
+		version, err := WebhookSchemaVersionFor(webhookURL)
+		if err != nil {
+			return err
+		}
+		payload.SchemaVersion = version
 	}
 
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal webhook payload: %w", err)
 	}
+	return sendWebhookJSON(ctx, webhookURL, jsonData, config)
+}
 
-	var lastErr error
-	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
-		req, err := http.NewRequest("POST", webhookURL, bytes.NewReader(jsonData))
-		if err != nil {
-			return fmt.Errorf("failed to create request: %w", err)
-		}
+// SendWebhookDigestCtx sends payloads to webhookURL as a single JSON array
+// Retries, backoff and cancellation behave as in SendWebhookRequestCtx
+func SendWebhookDigestCtx(ctx context.Context, webhookURL string, payloads []WebhookPayload, config WebhookConfig) error {
+	jsonData, err := json.Marshal(payloads)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook digest: %w", err)
+	}
+	return sendWebhookJSON(ctx, webhookURL, jsonData, config)
+}
 
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "hedera-network-monitor/1.0")
+// sendWebhookJSON POSTs jsonData to webhookURL, retrying with exponential backoff
+func sendWebhookJSON(ctx context.Context, webhookURL string, jsonData []byte, config WebhookConfig) error {
+	client := config.httpClient()
 
-		resp, err := client.Do(req)
+	req, err := http.NewRequest("POST", webhookDeliveryURL(webhookURL), bytes.NewReader(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "hedera-network-monitor/1.0")
+
+	start := time.Now()
+	// exceedsMaxElapsed reports whether waiting backoff before the next attempt would run past MaxElapsed
+	exceedsMaxElapsed := func(backoff time.Duration) bool {
+		return 0 < config.MaxElapsed && config.MaxElapsed < time.Since(start)+backoff
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
+		statusCode, body, err := sendWebhookAttempt(ctx, client, req, config.Timeout)
 		if err != nil {
 			lastErr = err
 			if attempt < config.MaxRetries {
 				backoff := calculateBackoff(attempt, config.InitialBackoff, config.MaxBackoff)
+				if exceedsMaxElapsed(backoff) {
+					return fmt.Errorf("%w (%s, %d attempts): %w", ErrWebhookMaxElapsed, config.MaxElapsed, attempt+1, lastErr)
+				}
 				logger.Warn("Webhook request failed, retrying",
 					"component", "AlertManager",
 					"attempt", attempt+1,
 					"max_attempts", config.MaxRetries+1,
 					"backoff", backoff,
 					"error", err)
-				time.Sleep(backoff)
+				if err := waitBackoff(ctx, backoff); err != nil {
+					return fmt.Errorf("webhook request cancelled after %d attempts: %w", attempt+1, err)
+				}
 				continue
 			}
 			return fmt.Errorf("webhook request failed after %d retries: %w", config.MaxRetries+1, lastErr)
 		}
 
 		// Check if response status is success (2xx)
-		if 200 <= resp.StatusCode && resp.StatusCode < 300 {
-			// Read and discard response body to close connection
-			_, _ = io.ReadAll(resp.Body)
+		if 200 <= statusCode && statusCode < 300 {
 			logger.Info("Webhook sent successfully",
 				"component", "AlertManager",
 				"webhook_url", webhookURL,
-				"status_code", resp.StatusCode)
-			_ = resp.Body.Close()
+				"status_code", statusCode)
 			return nil
 		}
 
 		// Non-2xx response
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		err = resp.Body.Close()
-		if err != nil {
-			lastErr = fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
-		}
+		lastErr = fmt.Errorf("unexpected status code: %d, body: %s", statusCode, string(body))
 
 		if attempt < config.MaxRetries {
 			// Retry on non-2xx responses
 			backoff := calculateBackoff(attempt, config.InitialBackoff, config.MaxBackoff)
+			if exceedsMaxElapsed(backoff) {
+				return fmt.Errorf("%w (%s, %d attempts): %w", ErrWebhookMaxElapsed, config.MaxElapsed, attempt+1, lastErr)
+			}
 			logger.Warn("Webhook returned non-success status, retrying",
 				"component", "AlertManager",
-				"status_code", resp.StatusCode,
+				"status_code", statusCode,
 				"attempt", attempt+1,
 				"max_attempts", config.MaxRetries+1,
 				"backoff", backoff)
-			time.Sleep(backoff)
+			if err := waitBackoff(ctx, backoff); err != nil {
+				return fmt.Errorf("webhook request cancelled after %d attempts: %w", attempt+1, err)
+			}
 			continue
 		}
 	}
@@ -117,6 +281,48 @@ func SendWebhookRequest(webhookURL string, payload WebhookPayload, config Webhoo
 	return fmt.Errorf("webhook failed after %d retries: %w", config.MaxRetries+1, lastErr)
 }
 
+// waitBackoff sleeps for backoff, returning early with ctx's error if it is cancelled first
+func waitBackoff(ctx context.Context, backoff time.Duration) error {
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// sendWebhookAttempt performs a single attempt of req bounded by timeout and ctx
+// The response body is always read to EOF and closed so the connection returns to the pool
+func sendWebhookAttempt(ctx context.Context, client *http.Client, req *http.Request, timeout time.Duration) (int, []byte, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// Each attempt needs its own context and a fresh copy of the body
+	attemptReq := req.Clone(ctx)
+	body, err := req.GetBody()
+	if err != nil {
+		return 0, nil, err
+	}
+	attemptReq.Body = body
+
+	resp, err := client.Do(attemptReq)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return resp.StatusCode, respBody, nil
+}
+
 // calculateBackoff returns exponential backoff duration with jitter
 func calculateBackoff(attempt int, initialBackoff, maxBackoff time.Duration) time.Duration {
 	// Exponential backoff: initialBackoff * 2^attempt
@@ -1,9 +1,12 @@
 package alerting
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -200,6 +203,43 @@ func TestSendWebhookRequestExhaustedRetries(t *testing.T) {
 	}
 }
 
+// TestSendWebhookRequestCtxCancelledMidRetry tests cancelling the context during backoff returns promptly
+func TestSendWebhookRequestCtxCancelledMidRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Cancel once the first attempt fails, while the sender waits to retry
+		if attempts.Add(1) == 1 {
+			cancel()
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := WebhookConfig{
+		Timeout:        5 * time.Second,
+		MaxRetries:     3,
+		InitialBackoff: 10 * time.Second,
+		MaxBackoff:     10 * time.Second,
+	}
+
+	start := time.Now()
+	err := SendWebhookRequestCtx(ctx, server.URL, WebhookPayload{RuleID: "test_rule"}, config)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if elapsed > 2*time.Second {
+		t.Errorf("Expected prompt return after cancellation, took %s", elapsed)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("Expected 1 attempt before cancellation, got %d", got)
+	}
+}
+
 // TestSendWebhookRequestSuccess201 tests success with 201 status code
 func TestSendWebhookRequestSuccess201(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
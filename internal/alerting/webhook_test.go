@@ -2,12 +2,18 @@ package alerting
 
 import (
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
 )
 
 // newTestPayload creates a standard webhook payload for testing
@@ -140,12 +146,120 @@ func TestWebhookPayloadJSONKeys(t *testing.T) {
 		t.Fatalf("Failed to unmarshal as map: %v", err)
 	}
 
-	expectedKeys := []string{"rule_id", "rule_name", "severity", "message", "value", "timestamp", "metric_id"}
+	expectedKeys := []string{"schema_version", "rule_id", "rule_name", "severity", "message", "value", "timestamp", "metric_id", "escalated"}
 	for _, key := range expectedKeys {
 		if _, exists := jsonMap[key]; !exists {
 			t.Errorf("Expected key %s not found in JSON", key)
 		}
 	}
+
+	// Annotations are omitted unless the rule has some
+	if _, exists := jsonMap["annotations"]; exists {
+		t.Error("Expected annotations to be omitted when empty")
+	}
+}
+
+// TestSendWebhookRequest_SetsSchemaVersion tests a payload without a version is sent as the current version
+func TestSendWebhookRequest_SetsSchemaVersion(t *testing.T) {
+	var received WebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if err := SendWebhookRequest(server.URL, newTestPayload(), newTestConfig()); err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	if received.SchemaVersion != WebhookSchemaVersion {
+		t.Errorf("Expected schema_version %d, got %d", WebhookSchemaVersion, received.SchemaVersion)
+	}
+}
+
+// TestWebhookSchemaVersionFor tests the version query parameter on webhook URLs
+func TestWebhookSchemaVersionFor(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    int
+		wantErr bool
+	}{
+		{"https://example.com/hook", WebhookSchemaVersion, false},
+		{"https://example.com/hook?version=1", 1, false},
+		{"https://example.com/hook?token=abc&version=1", 1, false},
+		{"https://example.com/hook?version=99", 0, true},
+		{"https://example.com/hook?version=0", 0, true},
+		{"https://example.com/hook?version=v1", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := WebhookSchemaVersionFor(tt.url)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Expected error for %s", tt.url)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Expected no error for %s, got: %v", tt.url, err)
+		}
+		if got != tt.want {
+			t.Errorf("Expected version %d for %s, got %d", tt.want, tt.url, got)
+		}
+	}
+}
+
+// TestWebhookDeliveryURL tests the version query parameter is removed before delivery
+func TestWebhookDeliveryURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://example.com/hook", "https://example.com/hook"},
+		{"https://example.com/hook?version=1", "https://example.com/hook"},
+		{"https://example.com/hook?token=a%2Fb&version=1&sig=xyz", "https://example.com/hook?token=a%2Fb&sig=xyz"},
+		{"https://example.com/hook?%76ersion=1&token=abc", "https://example.com/hook?token=abc"},
+		{"https://example.com/hook?versions=2", "https://example.com/hook?versions=2"},
+	}
+
+	for _, tt := range tests {
+		if got := webhookDeliveryURL(tt.url); got != tt.want {
+			t.Errorf("Expected %s for %s, got %s", tt.want, tt.url, got)
+		}
+	}
+}
+
+// TestSendWebhookRequest_StripsVersion tests the receiver doesn't see the version parameter
+func TestSendWebhookRequest_StripsVersion(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if err := SendWebhookRequest(server.URL+"?token=abc&version=1", newTestPayload(), newTestConfig()); err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	if query != "token=abc" {
+		t.Errorf("Expected query token=abc, got %q", query)
+	}
+}
+
+// TestSendWebhookRequest_UnsupportedVersion tests a URL pinning an unknown version isn't called
+func TestSendWebhookRequest_UnsupportedVersion(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if err := SendWebhookRequest(server.URL+"?version=2", newTestPayload(), newTestConfig()); err == nil {
+		t.Error("Expected error for unsupported schema version")
+	}
+	if callCount != 0 {
+		t.Errorf("Expected no webhook calls, got %d", callCount)
+	}
 }
 
 // TestSendWebhookRequest_SuccessOnFirstAttempt tests webhook succeeds immediately
@@ -473,3 +587,209 @@ func TestSendWebhookRequest_ResponseBodyRead(t *testing.T) {
 		}
 	}
 }
+
+// TestSendWebhookRequest_ConnectionReuse tests that sends share pooled keep-alive connections
+func TestSendWebhookRequest_ConnectionReuse(t *testing.T) {
+	var connMutex sync.Mutex
+	newConns := 0
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connMutex.Lock()
+			newConns++
+			connMutex.Unlock()
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	config := newTestConfig()
+	config.Timeout = 5 * time.Second
+	config.MaxIdleConns = 10
+	config.MaxIdleConnsPerHost = 2
+	config.IdleConnTimeout = 30 * time.Second
+	config.Client = NewWebhookClient(config)
+
+	for i := 0; i < 50; i++ {
+		if err := SendWebhookRequest(server.URL, newTestPayload(), config); err != nil {
+			t.Fatalf("Request %d failed: %v", i+1, err)
+		}
+	}
+
+	connMutex.Lock()
+	defer connMutex.Unlock()
+	if newConns != 1 {
+		t.Errorf("Expected 1 connection reused across sends, got %d", newConns)
+	}
+}
+
+// TestNewManager_SharedWebhookClient tests the manager builds one pooled client from config
+func TestNewManager_SharedWebhookClient(t *testing.T) {
+	m := NewManager(config.AlertingConfig{
+		QueueBufferSize:               10,
+		WebhookMaxIdleConns:           4,
+		WebhookIdleConnTimeoutSeconds: 15,
+	})
+
+	if m.webhookConfig.Client == nil {
+		t.Fatal("Expected manager to create a shared webhook client")
+	}
+	transport, ok := m.webhookConfig.Client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected *http.Transport, got %T", m.webhookConfig.Client.Transport)
+	}
+	if transport.MaxIdleConnsPerHost != 4 {
+		t.Errorf("Expected MaxIdleConnsPerHost 4, got %d", transport.MaxIdleConnsPerHost)
+	}
+	if transport.IdleConnTimeout != 15*time.Second {
+		t.Errorf("Expected IdleConnTimeout 15s, got %v", transport.IdleConnTimeout)
+	}
+	if m.webhookConfig.Client.Timeout != 0 {
+		t.Errorf("Expected no client-wide timeout, got %v", m.webhookConfig.Client.Timeout)
+	}
+}
+
+// writeServerCA writes a TLS test server's self-signed certificate to a PEM file
+func writeServerCA(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("Failed to write CA file: %v", err)
+	}
+	return path
+}
+
+// TestSendWebhookRequest_CustomCA tests that a self-signed receiver is only trusted with its CA
+func TestSendWebhookRequest_CustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := newTestConfig()
+	config.MaxRetries = 0
+
+	// Default verification rejects the self-signed certificate
+	config.Client = NewWebhookClient(config)
+	if err := SendWebhookRequest(server.URL, newTestPayload(), config); err == nil {
+		t.Fatal("Expected certificate verification to fail without the CA")
+	}
+
+	pool, err := LoadWebhookCA(writeServerCA(t, server))
+	if err != nil {
+		t.Fatalf("Failed to load CA: %v", err)
+	}
+	config.RootCAs = pool
+	config.Client = NewWebhookClient(config)
+	if err := SendWebhookRequest(server.URL, newTestPayload(), config); err != nil {
+		t.Errorf("Expected success with the custom CA, got: %v", err)
+	}
+}
+
+// TestSendWebhookRequest_InsecureSkipVerify tests that verification can be disabled explicitly
+func TestSendWebhookRequest_InsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := newTestConfig()
+	config.MaxRetries = 0
+	config.InsecureSkipVerify = true
+	config.Client = NewWebhookClient(config)
+
+	if err := SendWebhookRequest(server.URL, newTestPayload(), config); err != nil {
+		t.Errorf("Expected success with verification skipped, got: %v", err)
+	}
+}
+
+// TestLoadWebhookCA_Invalid tests that missing or non-PEM CA files are rejected
+func TestLoadWebhookCA_Invalid(t *testing.T) {
+	if _, err := LoadWebhookCA(filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Error("Expected error for missing CA file")
+	}
+
+	path := filepath.Join(t.TempDir(), "bad.pem")
+	if err := os.WriteFile(path, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("Failed to write CA file: %v", err)
+	}
+	if _, err := LoadWebhookCA(path); err == nil {
+		t.Error("Expected error for CA file without certificates")
+	}
+}
+
+// TestSetWebhookTLS_BadCAFile tests that the manager surfaces CA load errors
+func TestSetWebhookTLS_BadCAFile(t *testing.T) {
+	manager := NewManager(config.AlertingConfig{QueueBufferSize: 1})
+	if err := manager.SetWebhookTLS(filepath.Join(t.TempDir(), "missing.pem"), false); err == nil {
+		t.Error("Expected error for missing CA file")
+	}
+}
+
+// TestSendWebhookRequest_MaxElapsedGivesUp tests that retries stop once the next backoff would pass MaxElapsed
+func TestSendWebhookRequest_MaxElapsedGivesUp(t *testing.T) {
+	var attempts int
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		mu.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := newTestConfig()
+	config.MaxRetries = 10
+	config.InitialBackoff = 50 * time.Millisecond
+	config.MaxBackoff = 50 * time.Millisecond
+	config.MaxElapsed = 120 * time.Millisecond
+
+	start := time.Now()
+	err := SendWebhookRequest(server.URL, newTestPayload(), config)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, ErrWebhookMaxElapsed) {
+		t.Fatalf("Expected ErrWebhookMaxElapsed, got: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts < 2 || config.MaxRetries+1 <= attempts {
+		t.Errorf("Expected an early give-up after a few attempts, got %d attempts", attempts)
+	}
+	if config.MaxElapsed < elapsed {
+		t.Errorf("Expected to give up within %s, took %s", config.MaxElapsed, elapsed)
+	}
+}
+
+// TestSendWebhookRequest_MaxElapsedAllowsSuccess tests that retries within MaxElapsed still succeed
+func TestSendWebhookRequest_MaxElapsedAllowsSuccess(t *testing.T) {
+	var attempts int
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := newTestConfig()
+	config.MaxElapsed = 5 * time.Second
+
+	if err := SendWebhookRequest(server.URL, newTestPayload(), config); err != nil {
+		t.Fatalf("Expected success within max elapsed, got: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
+)
+
+// corsMaxAgeSeconds is how long browsers may cache a preflight response
+const corsMaxAgeSeconds = "600"
+
+// SetCORS lets browsers on cfg.AllowedOrigins call the API. Empty methods or
+// headers fall back to config.DefaultCORSMethods and config.DefaultCORSHeaders
+// With no origins CORS stays disabled and no headers are added
+func (s *Server) SetCORS(cfg config.CORSConfig) {
+	if len(cfg.AllowedMethods) == 0 {
+		cfg.AllowedMethods = config.DefaultCORSMethods
+	}
+	if len(cfg.AllowedHeaders) == 0 {
+		cfg.AllowedHeaders = config.DefaultCORSHeaders
+	}
+	s.cors = cfg
+}
+
+// withCORS adds CORS headers for allowed origins and answers their preflight
+// requests with 204. It runs before withAuth because browsers send preflights
+// without credentials. Requests from other origins are served without CORS
+// headers, so the browser withholds the response; their preflights get 403
+func (s *Server) withCORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if len(s.cors.AllowedOrigins) == 0 || origin == "" || !s.corsPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+		if !s.corsOrigin(origin) {
+			if preflight {
+				s.writeError(w, http.StatusForbidden, "origin not allowed")
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		if preflight {
+			w.Header().Set("Access-Control-Allow-Methods", strings.Join(s.cors.AllowedMethods, ", "))
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(s.cors.AllowedHeaders, ", "))
+			w.Header().Set("Access-Control-Max-Age", corsMaxAgeSeconds)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Access-Control-Expose-Headers", requestIDHeader)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// corsOrigin reports whether origin is allowed, either listed or covered by "*"
+func (s *Server) corsOrigin(origin string) bool {
+	return slices.Contains(s.cors.AllowedOrigins, "*") || slices.Contains(s.cors.AllowedOrigins, origin)
+}
+
+// corsPath reports whether CORS applies to path: every path when no prefixes are
+// configured, otherwise a prefix or anything below it
+func (s *Server) corsPath(path string) bool {
+	if len(s.cors.Paths) == 0 {
+		return true
+	}
+	for _, prefix := range s.cors.Paths {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
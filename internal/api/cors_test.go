@@ -0,0 +1,134 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
+)
+
+// newCORSServer returns routes allowing https://dashboard.example.com behind an auth token
+func newCORSServer(paths ...string) http.Handler {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+	server.SetAuthTokens("secret", "")
+	server.SetCORS(config.CORSConfig{
+		AllowedOrigins: []string{"https://dashboard.example.com"},
+		Paths:          paths,
+	})
+	return server.routes()
+}
+
+// corsRequest builds an authenticated request from origin
+func corsRequest(method, path, origin string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Origin", origin)
+	return req
+}
+
+// TestCORS_AllowedOrigin tests responses to an allowed origin carry CORS headers
+func TestCORS_AllowedOrigin(t *testing.T) {
+	handler := newCORSServer()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, corsRequest("GET", "/api/v1/metrics", "https://dashboard.example.com"))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://dashboard.example.com" {
+		t.Errorf("expected the origin to be allowed, got %q", got)
+	}
+	if got := w.Header().Get("Vary"); got != "Origin" {
+		t.Errorf("expected Vary: Origin, got %q", got)
+	}
+}
+
+// TestCORS_DisallowedOrigin tests other origins are served without CORS headers
+// and their preflights are refused
+func TestCORS_DisallowedOrigin(t *testing.T) {
+	handler := newCORSServer()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, corsRequest("GET", "/api/v1/metrics", "https://evil.example.com"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected no Access-Control-Allow-Origin, got %q", got)
+	}
+
+	req := httptest.NewRequest("OPTIONS", "/api/v1/alerts", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for a disallowed preflight, got %d", w.Code)
+	}
+}
+
+// TestCORS_Preflight tests preflights get 204 with the allowed methods and headers,
+// without needing the bearer token browsers don't send on preflights
+func TestCORS_Preflight(t *testing.T) {
+	handler := newCORSServer()
+
+	req := httptest.NewRequest("OPTIONS", "/api/v1/alerts", nil)
+	req.Header.Set("Origin", "https://dashboard.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "authorization, content-type")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", w.Code)
+	}
+	expected := map[string]string{
+		"Access-Control-Allow-Origin":  "https://dashboard.example.com",
+		"Access-Control-Allow-Methods": "GET, POST, DELETE",
+		"Access-Control-Allow-Headers": "Authorization, Content-Type, Idempotency-Key, X-Request-ID",
+		"Access-Control-Max-Age":       "600",
+	}
+	for header, want := range expected {
+		if got := w.Header().Get(header); got != want {
+			t.Errorf("expected %s %q, got %q", header, want, got)
+		}
+	}
+}
+
+// TestCORS_Paths tests CORS only applies under the configured path prefixes
+func TestCORS_Paths(t *testing.T) {
+	handler := newCORSServer("/api/v1/metrics")
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/api/v1/metrics", "https://dashboard.example.com"},
+		{"/api/v1/metrics/latest", "https://dashboard.example.com"},
+		{"/api/v1/metricsx", ""},
+		{"/api/v1/alerts", ""},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, corsRequest("GET", tt.path, "https://dashboard.example.com"))
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.expected {
+			t.Errorf("%s: expected Access-Control-Allow-Origin %q, got %q", tt.path, tt.expected, got)
+		}
+	}
+}
+
+// TestCORS_DisabledByDefault tests no CORS headers are added until origins are configured
+func TestCORS_DisabledByDefault(t *testing.T) {
+	handler := NewServer(8080, &MockStorage{}, &MockAlertManager{}).routes()
+
+	req := httptest.NewRequest("GET", "/health", nil)
+	req.Header.Set("Origin", "https://dashboard.example.com")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected no CORS headers by default, got %q", got)
+	}
+}
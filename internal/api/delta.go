@@ -0,0 +1,66 @@
+package api
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+// SeriesDelta is the change between the two most recent samples of a series,
+// so clients can compute a counter's rate (Delta / Seconds) without keeping history
+type SeriesDelta struct {
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels"`
+	Timestamp int64             `json:"timestamp"` // Timestamp of the newest sample
+	Value     float64           `json:"value"`     // Value of the newest sample
+	Delta     float64           `json:"delta"`     // Increase since the previous sample
+	Seconds   int64             `json:"seconds"`   // Time between the two samples
+	Reset     bool              `json:"reset"`     // The value dropped, so the counter restarted
+}
+
+// seriesDeltas returns a SeriesDelta for every series with at least two samples
+// Samples are ordered by timestamp, with ties keeping their stored order. A value
+// lower than the previous one is treated as a counter reset: the counter is assumed
+// to have restarted from zero, so the delta is the new value
+// Results are ordered by series key so responses are stable between calls
+func seriesDeltas(metrics []types.Metric) []SeriesDelta {
+	series := make(map[string][]types.Metric)
+	for _, metric := range metrics {
+		key := storage.SeriesKey(metric)
+		series[key] = append(series[key], metric)
+	}
+
+	keys := make([]string, 0, len(series))
+	for key, samples := range series {
+		if len(samples) >= 2 {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	deltas := make([]SeriesDelta, 0, len(keys))
+	for _, key := range keys {
+		samples := series[key]
+		slices.SortStableFunc(samples, func(a, b types.Metric) int {
+			return cmp.Compare(a.Timestamp, b.Timestamp)
+		})
+		previous, latest := samples[len(samples)-2], samples[len(samples)-1]
+
+		delta := SeriesDelta{
+			Name:      latest.Name,
+			Labels:    latest.Labels,
+			Timestamp: latest.Timestamp,
+			Value:     latest.Value,
+			Delta:     latest.Value - previous.Value,
+			Seconds:   latest.Timestamp - previous.Timestamp,
+		}
+		if latest.Value < previous.Value {
+			delta.Delta = latest.Value
+			delta.Reset = true
+		}
+		deltas = append(deltas, delta)
+	}
+	return deltas
+}
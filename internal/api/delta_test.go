@@ -0,0 +1,80 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+// TestSeriesDeltas_IncreasingCounter tests the delta and gap use the two newest samples
+func TestSeriesDeltas_IncreasingCounter(t *testing.T) {
+	labels := map[string]string{"account_id": "0.0.5000"}
+	metrics := []types.Metric{
+		{Name: "account_transactions", Timestamp: 1060, Value: 15, Labels: labels},
+		{Name: "account_transactions", Timestamp: 1000, Value: 10, Labels: labels},
+		{Name: "account_transactions", Timestamp: 1120, Value: 27, Labels: labels},
+	}
+
+	deltas := seriesDeltas(metrics)
+
+	if len(deltas) != 1 {
+		t.Fatalf("expected 1 series, got %d", len(deltas))
+	}
+	delta := deltas[0]
+	if delta.Timestamp != 1120 || delta.Value != 27 {
+		t.Errorf("expected newest sample 27 at 1120, got %v at %d", delta.Value, delta.Timestamp)
+	}
+	if delta.Delta != 12 || delta.Seconds != 60 {
+		t.Errorf("expected delta 12 over 60s, got %v over %ds", delta.Delta, delta.Seconds)
+	}
+	if delta.Reset {
+		t.Error("expected no reset for an increasing counter")
+	}
+	if delta.Labels["account_id"] != "0.0.5000" {
+		t.Errorf("expected series labels, got %v", delta.Labels)
+	}
+}
+
+// TestSeriesDeltas_CounterReset tests a dropped value is reported as a reset from zero
+func TestSeriesDeltas_CounterReset(t *testing.T) {
+	metrics := []types.Metric{
+		{Name: "account_transactions", Timestamp: 1000, Value: 50},
+		{Name: "account_transactions", Timestamp: 1030, Value: 4},
+	}
+
+	deltas := seriesDeltas(metrics)
+
+	if len(deltas) != 1 {
+		t.Fatalf("expected 1 series, got %d", len(deltas))
+	}
+	if !deltas[0].Reset {
+		t.Error("expected a reset when the value dropped")
+	}
+	if deltas[0].Delta != 4 || deltas[0].Seconds != 30 {
+		t.Errorf("expected delta 4 (counted from zero) over 30s, got %v over %ds", deltas[0].Delta, deltas[0].Seconds)
+	}
+}
+
+// TestSeriesDeltas_PerSeries tests each label set gets its own delta and single samples are skipped
+func TestSeriesDeltas_PerSeries(t *testing.T) {
+	metrics := []types.Metric{
+		{Name: "account_transactions", Timestamp: 1000, Value: 1, Labels: map[string]string{"account_id": "0.0.5001"}},
+		{Name: "account_transactions", Timestamp: 1000, Value: 5, Labels: map[string]string{"account_id": "0.0.5000"}},
+		{Name: "account_transactions", Timestamp: 1010, Value: 3, Labels: map[string]string{"account_id": "0.0.5001"}},
+		{Name: "account_transactions", Timestamp: 1010, Value: 9, Labels: map[string]string{"account_id": "0.0.5000"}},
+		{Name: "account_transactions", Timestamp: 1010, Value: 7, Labels: map[string]string{"account_id": "0.0.5002"}},
+	}
+
+	deltas := seriesDeltas(metrics)
+
+	if len(deltas) != 2 {
+		t.Fatalf("expected 2 series with two samples, got %d", len(deltas))
+	}
+	// Ordered by series key
+	if deltas[0].Labels["account_id"] != "0.0.5000" || deltas[0].Delta != 4 {
+		t.Errorf("expected 0.0.5000 first with delta 4, got %v", deltas[0])
+	}
+	if deltas[1].Labels["account_id"] != "0.0.5001" || deltas[1].Delta != 2 {
+		t.Errorf("expected 0.0.5001 second with delta 2, got %v", deltas[1])
+	}
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+// DefaultGroupByFn is the aggregate used when fn is not given
+const DefaultGroupByFn = "sum"
+
+// groupByFns aggregates the latest values of a group's series
+// values is never empty
+var groupByFns = map[string]func(values []float64) float64{
+	"sum": func(values []float64) float64 {
+		total := 0.0
+		for _, v := range values {
+			total += v
+		}
+		return total
+	},
+	"avg": func(values []float64) float64 {
+		total := 0.0
+		for _, v := range values {
+			total += v
+		}
+		return total / float64(len(values))
+	},
+	"min":   func(values []float64) float64 { return slices.Min(values) },
+	"max":   func(values []float64) float64 { return slices.Max(values) },
+	"count": func(values []float64) float64 { return float64(len(values)) },
+}
+
+// GroupAggregate is the aggregated latest value of every series sharing one label value
+type GroupAggregate struct {
+	Group  string  `json:"group"`  // Value of the grouping label
+	Value  float64 `json:"value"`  // Aggregate of the group's latest samples
+	Series int     `json:"series"` // Number of series in the group
+}
+
+// parseGroupByFn validates an aggregate name, defaulting to DefaultGroupByFn
+func parseGroupByFn(fn string) (string, error) {
+	if fn == "" {
+		return DefaultGroupByFn, nil
+	}
+	if _, ok := groupByFns[fn]; !ok {
+		return "", fmt.Errorf("invalid fn %q: must be one of %s",
+			fn, strings.Join(slices.Sorted(maps.Keys(groupByFns)), ", "))
+	}
+	return fn, nil
+}
+
+// groupMetrics takes the newest sample of each series, groups the series by the
+// value of label and aggregates each group with fn (a key of groupByFns)
+// Series without the label are left out, so one metric can mix grouped and
+// ungrouped accounts. Results are ordered by group value
+func groupMetrics(metrics []types.Metric, label, fn string) []GroupAggregate {
+	aggregate := groupByFns[fn]
+
+	groups := make(map[string][]float64)
+	for _, metric := range storage.LatestPerSeries(metrics) {
+		group, ok := metric.Labels[label]
+		if !ok {
+			continue
+		}
+		groups[group] = append(groups[group], metric.Value)
+	}
+
+	results := make([]GroupAggregate, 0, len(groups))
+	for group, values := range groups {
+		results = append(results, GroupAggregate{
+			Group:  group,
+			Value:  aggregate(values),
+			Series: len(values),
+		})
+	}
+	slices.SortFunc(results, func(a, b GroupAggregate) int {
+		return cmp.Compare(a.Group, b.Group)
+	})
+	return results
+}
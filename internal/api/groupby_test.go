@@ -0,0 +1,64 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+// teamBalances returns balances for two teams, with an older sample that must be ignored
+func teamBalances() []types.Metric {
+	return []types.Metric{
+		{Name: "account_balance", Timestamp: 1000, Value: 999, Labels: map[string]string{"account_id": "0.0.1", "team": "treasury"}},
+		{Name: "account_balance", Timestamp: 1060, Value: 100, Labels: map[string]string{"account_id": "0.0.1", "team": "treasury"}},
+		{Name: "account_balance", Timestamp: 1060, Value: 300, Labels: map[string]string{"account_id": "0.0.2", "team": "treasury"}},
+		{Name: "account_balance", Timestamp: 1060, Value: 50, Labels: map[string]string{"account_id": "0.0.3", "team": "ops"}},
+		{Name: "account_balance", Timestamp: 1060, Value: 7, Labels: map[string]string{"account_id": "0.0.4"}},
+	}
+}
+
+// TestGroupMetrics_SumPerTeam tests each team's latest balances are summed independently
+func TestGroupMetrics_SumPerTeam(t *testing.T) {
+	groups := groupMetrics(teamBalances(), "team", "sum")
+
+	expected := []GroupAggregate{
+		{Group: "ops", Value: 50, Series: 1},
+		{Group: "treasury", Value: 400, Series: 2},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("expected %+v, got %+v", expected, groups)
+	}
+}
+
+// TestGroupMetrics_Fns tests the other aggregates over the same groups
+func TestGroupMetrics_Fns(t *testing.T) {
+	tests := []struct {
+		fn       string
+		expected float64
+	}{
+		{"avg", 200},
+		{"min", 100},
+		{"max", 300},
+		{"count", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fn, func(t *testing.T) {
+			groups := groupMetrics(teamBalances(), "team", tt.fn)
+			if len(groups) != 2 || groups[1].Group != "treasury" || groups[1].Value != tt.expected {
+				t.Errorf("expected treasury %s of %v, got %+v", tt.fn, tt.expected, groups)
+			}
+		})
+	}
+}
+
+// TestParseGroupByFn tests the default and rejected aggregate names
+func TestParseGroupByFn(t *testing.T) {
+	if fn, err := parseGroupByFn(""); err != nil || fn != DefaultGroupByFn {
+		t.Errorf("expected default %q, got %q (%v)", DefaultGroupByFn, fn, err)
+	}
+	if _, err := parseGroupByFn("median"); err == nil {
+		t.Error("expected error for unknown fn")
+	}
+}
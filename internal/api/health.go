@@ -0,0 +1,59 @@
+package api
+
+import (
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+)
+
+// HealthComponents reports subsystem health for GET /health?verbose=true
+type HealthComponents struct {
+	Storage          string `json:"storage"`                   // "ok" or "unreachable"
+	ActiveCollectors int    `json:"active_collectors"`         // Collectors running a collection loop
+	AlertQueueDepth  *int   `json:"alert_queue_depth"`         // Alerts awaiting dispatch (null = alerting disabled)
+	LastCollection   int64  `json:"last_collection,omitempty"` // Unix time the most recent collection cycle finished
+}
+
+// queueDepthReporter is implemented by alert managers that can report their queue backlog
+type queueDepthReporter interface {
+	QueueDepth() int
+}
+
+// lastCollectionReporter is implemented by collectors that record when they last ran
+type lastCollectionReporter interface {
+	LastCollection() time.Time
+}
+
+// healthComponents checks each subsystem for the verbose health view
+func (s *Server) healthComponents() *HealthComponents {
+	components := &HealthComponents{
+		Storage:          "ok",
+		ActiveCollectors: len(s.collectors),
+	}
+
+	if _, err := s.store.GetMetrics("", 1); err != nil {
+		logger.Warn("Health check could not reach storage",
+			"component", "APIServer",
+			"error", err)
+		components.Storage = "unreachable"
+	}
+
+	if reporter, ok := s.alertManager.(queueDepthReporter); ok {
+		depth := reporter.QueueDepth()
+		components.AlertQueueDepth = &depth
+	}
+
+	var last time.Time
+	for _, c := range s.collectors {
+		if reporter, ok := c.(lastCollectionReporter); ok {
+			if t := reporter.LastCollection(); t.After(last) {
+				last = t
+			}
+		}
+	}
+	if !last.IsZero() {
+		components.LastCollection = last.Unix()
+	}
+
+	return components
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// queueingAlertManager is a MockAlertManager that reports a queue depth
+type queueingAlertManager struct {
+	MockAlertManager
+	depth int
+}
+
+func (m *queueingAlertManager) QueueDepth() int {
+	return m.depth
+}
+
+// timedCollector is a mockCollector that reports when it last ran
+type timedCollector struct {
+	mockCollector
+	last time.Time
+}
+
+func (c *timedCollector) LastCollection() time.Time {
+	return c.last
+}
+
+// TestHandleHealth_Verbose tests that ?verbose=true adds component statuses and the default doesn't
+func TestHandleHealth_Verbose(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &queueingAlertManager{depth: 3})
+	last := time.Unix(1700000000, 0)
+	server.AddCollector(&timedCollector{mockCollector: mockCollector{name: "AccountCollector"}, last: last})
+	server.AddCollector(&timedCollector{mockCollector: mockCollector{name: "NetworkCollector"}})
+
+	w := httptest.NewRecorder()
+	server.handleHealth(w, httptest.NewRequest("GET", "/health", nil))
+	var raw map[string]any
+	if err := json.NewDecoder(w.Body).Decode(&raw); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if _, ok := raw["components"]; ok {
+		t.Errorf("expected no components in the default response, got %v", raw)
+	}
+
+	w = httptest.NewRecorder()
+	server.handleHealth(w, httptest.NewRequest("GET", "/health?verbose=true", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var response HealthResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Status != "healthy" {
+		t.Errorf("expected status 'healthy', got '%s'", response.Status)
+	}
+	components := response.Components
+	if components == nil {
+		t.Fatal("expected components in the verbose response")
+	}
+	if components.Storage != "ok" {
+		t.Errorf("expected storage 'ok', got '%s'", components.Storage)
+	}
+	if components.ActiveCollectors != 2 {
+		t.Errorf("expected 2 active collectors, got %d", components.ActiveCollectors)
+	}
+	if components.AlertQueueDepth == nil || *components.AlertQueueDepth != 3 {
+		t.Errorf("expected alert queue depth 3, got %v", components.AlertQueueDepth)
+	}
+	if components.LastCollection != last.Unix() {
+		t.Errorf("expected last collection %d, got %d", last.Unix(), components.LastCollection)
+	}
+}
+
+// TestHandleHealth_VerboseStorageUnreachable tests that a failing storage read degrades the status
+func TestHandleHealth_VerboseStorageUnreachable(t *testing.T) {
+	server := NewServer(8080, &MockStorage{getMetricsErr: errors.New("disk gone")}, &MockAlertManager{})
+
+	w := httptest.NewRecorder()
+	server.handleHealth(w, httptest.NewRequest("GET", "/health?verbose=1", nil))
+	var response HealthResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Status != "degraded" {
+		t.Errorf("expected status 'degraded', got '%s'", response.Status)
+	}
+	if response.Components == nil || response.Components.Storage != "unreachable" {
+		t.Fatalf("expected storage 'unreachable', got %+v", response.Components)
+	}
+	if response.Components.AlertQueueDepth != nil {
+		t.Errorf("expected no alert queue depth without a queueing manager, got %d", *response.Components.AlertQueueDepth)
+	}
+}
+
+// TestHandleHealth_InvalidVerbose tests that a non-boolean verbose value is rejected
+func TestHandleHealth_InvalidVerbose(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+
+	w := httptest.NewRecorder()
+	server.handleHealth(w, httptest.NewRequest("GET", "/health?verbose=maybe", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strconv"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/alerting"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+)
+
+// HistoryProvider is implemented by alert managers that keep recently dispatched alerts
+type HistoryProvider interface {
+	History() []alerting.AlertEvent
+}
+
+// AlertHistoryEntry is one dispatched alert in GET /api/v1/alerts/history
+type AlertHistoryEntry struct {
+	Timestamp  int64   `json:"timestamp"`
+	RuleID     string  `json:"rule_id"`
+	RuleName   string  `json:"rule_name"`
+	Severity   string  `json:"severity"`
+	MetricID   string  `json:"metric_id"`
+	Value      float64 `json:"value"`
+	Message    string  `json:"message"`
+	Escalated  bool    `json:"escalated"`
+	Suppressed bool    `json:"suppressed"`
+}
+
+// AlertHistoryResponse lists recently dispatched alerts, oldest first
+type AlertHistoryResponse struct {
+	Alerts []AlertHistoryEntry `json:"alerts"`
+	Count  int                 `json:"count"`
+}
+
+// alertHistoryCSVHeader names the columns of the CSV history export
+var alertHistoryCSVHeader = []string{"timestamp", "rule_id", "rule_name", "severity", "metric_id", "value"}
+
+// handleAlertHistory returns recently dispatched alerts, including suppressed ones
+// GET /api/v1/alerts/history
+// Query parameters:
+//   - format: "json" (default) or "csv" (optional)
+//
+// Returns: AlertHistoryResponse, or CSV with a header row and one row per alert
+func (s *Server) handleAlertHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, http.StatusMethodNotAllowed, "only GET allowed")
+		return
+	}
+	if !s.checkParams(w, r, "format") {
+		return
+	}
+	if _, disabled := s.alertManager.(NoopAlertManager); disabled {
+		s.writeError(w, http.StatusServiceUnavailable, ErrAlertingDisabled.Error())
+		return
+	}
+	provider, ok := s.alertManager.(HistoryProvider)
+	if !ok {
+		s.writeError(w, http.StatusNotImplemented, "alert history not supported")
+		return
+	}
+
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "json":
+		history := provider.History()
+		entries := make([]AlertHistoryEntry, 0, len(history))
+		for _, alert := range history {
+			entries = append(entries, AlertHistoryEntry{
+				Timestamp:  alert.Timestamp,
+				RuleID:     alert.RuleID,
+				RuleName:   alert.RuleName,
+				Severity:   string(alert.Severity),
+				MetricID:   alert.MetricID,
+				Value:      alert.Value,
+				Message:    alert.Message,
+				Escalated:  alert.Escalated,
+				Suppressed: alert.Suppressed,
+			})
+		}
+		s.writeJSON(w, http.StatusOK, AlertHistoryResponse{Alerts: entries, Count: len(entries)})
+	case "csv":
+		s.writeAlertHistoryCSV(w, provider.History())
+	default:
+		s.writeError(w, http.StatusBadRequest, "invalid format, must be json or csv")
+	}
+}
+
+// writeAlertHistoryCSV writes history as CSV; encoding/csv quotes fields containing
+// commas, quotes or newlines
+func (s *Server) writeAlertHistoryCSV(w http.ResponseWriter, history []alerting.AlertEvent) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="alert-history.csv"`)
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(alertHistoryCSVHeader); err == nil {
+		for _, alert := range history {
+			if err := writer.Write([]string{
+				strconv.FormatInt(alert.Timestamp, 10),
+				alert.RuleID,
+				alert.RuleName,
+				string(alert.Severity),
+				alert.MetricID,
+				strconv.FormatFloat(alert.Value, 'f', -1, 64),
+			}); err != nil {
+				break
+			}
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		logger.Error("Error writing alert history CSV",
+			"component", "APIServer",
+			"error", err)
+	}
+}
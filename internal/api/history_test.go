@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/alerting"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+// historyAlertManager is a MockAlertManager that keeps an alert history
+type historyAlertManager struct {
+	MockAlertManager
+	history []alerting.AlertEvent
+}
+
+func (m *historyAlertManager) History() []alerting.AlertEvent {
+	return m.history
+}
+
+// firedAlert is a dispatched alert whose name needs CSV quoting
+var firedAlert = alerting.AlertEvent{
+	RuleID:    "low_balance",
+	RuleName:  `Balance "low", treasury`,
+	Severity:  types.SeverityWarning,
+	Message:   "balance dropped",
+	Timestamp: 1700000000,
+	MetricID:  "account_balance",
+	Value:     99.5,
+}
+
+// TestHandleAlertHistory_CSV tests the CSV export header and a row for a fired alert
+func TestHandleAlertHistory_CSV(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &historyAlertManager{history: []alerting.AlertEvent{firedAlert}})
+
+	w := httptest.NewRecorder()
+	server.handleAlertHistory(w, httptest.NewRequest("GET", "/api/v1/alerts/history?format=csv", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "text/csv; charset=utf-8" {
+		t.Errorf("expected CSV content type, got %q", contentType)
+	}
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected a header and 1 row, got %d records", len(records))
+	}
+	header := []string{"timestamp", "rule_id", "rule_name", "severity", "metric_id", "value"}
+	for i, column := range header {
+		if records[0][i] != column {
+			t.Errorf("expected header column %d to be %s, got %s", i, column, records[0][i])
+		}
+	}
+	row := []string{"1700000000", "low_balance", `Balance "low", treasury`, "warning", "account_balance", "99.5"}
+	for i, value := range row {
+		if records[1][i] != value {
+			t.Errorf("expected row column %d to be %q, got %q", i, value, records[1][i])
+		}
+	}
+}
+
+// TestHandleAlertHistory_JSON tests that JSON is the default format
+func TestHandleAlertHistory_JSON(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &historyAlertManager{history: []alerting.AlertEvent{firedAlert}})
+
+	w := httptest.NewRecorder()
+	server.handleAlertHistory(w, httptest.NewRequest("GET", "/api/v1/alerts/history", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var response AlertHistoryResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Count != 1 || response.Alerts[0].RuleID != "low_balance" || response.Alerts[0].Severity != "warning" {
+		t.Errorf("expected the fired alert, got %+v", response)
+	}
+}
+
+// TestHandleAlertHistory_InvalidFormat tests that an unknown format is rejected
+func TestHandleAlertHistory_InvalidFormat(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &historyAlertManager{})
+
+	w := httptest.NewRecorder()
+	server.handleAlertHistory(w, httptest.NewRequest("GET", "/api/v1/alerts/history?format=xml", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// DefaultIdempotencyTTL is how long an Idempotency-Key is remembered after its rule is created
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// idempotencyState is the outcome of looking up an Idempotency-Key
+type idempotencyState int
+
+const (
+	idempotencyNew      idempotencyState = iota // Key unseen; caller must complete or abort it
+	idempotencyReplay                           // Key completed with the same request; replay the response
+	idempotencyPending                          // Another request with this key is still being processed
+	idempotencyMismatch                         // Key was used with a different request body
+)
+
+// idempotencyEntry records the request and response for one key
+type idempotencyEntry struct {
+	request  CreateAlertRequest
+	response AlertRuleResponse
+	done     bool
+	expires  time.Time
+}
+
+// idempotencyCache remembers recently used Idempotency-Keys for POST /api/v1/alerts
+// so a client retrying after a timeout gets the original rule instead of a duplicate
+type idempotencyCache struct {
+	mu      sync.Mutex
+	entries map[string]idempotencyEntry
+	ttl     time.Duration
+	now     func() time.Time // Injectable for tests
+}
+
+// newIdempotencyCache creates a cache that forgets keys ttl after they complete
+func newIdempotencyCache(ttl time.Duration) *idempotencyCache {
+	return &idempotencyCache{
+		entries: make(map[string]idempotencyEntry),
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// begin looks up key for request. When the key is new it is reserved as pending
+// so a concurrent retry can't create a second rule
+func (c *idempotencyCache) begin(key string, request CreateAlertRequest) (AlertRuleResponse, idempotencyState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pruneLocked()
+
+	entry, exists := c.entries[key]
+	switch {
+	case !exists:
+		c.entries[key] = idempotencyEntry{request: request}
+		return AlertRuleResponse{}, idempotencyNew
+	case !reflect.DeepEqual(entry.request, request):
+		return AlertRuleResponse{}, idempotencyMismatch
+	case !entry.done:
+		return AlertRuleResponse{}, idempotencyPending
+	default:
+		return entry.response, idempotencyReplay
+	}
+}
+
+// complete stores the response for a reserved key and starts its TTL
+func (c *idempotencyCache) complete(key string, response AlertRuleResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.entries[key]
+	entry.response = response
+	entry.done = true
+	entry.expires = c.now().Add(c.ttl)
+	c.entries[key] = entry
+}
+
+// abort releases a reserved key whose request failed so it can be retried
+func (c *idempotencyCache) abort(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// pruneLocked drops completed keys past their TTL; callers must hold mu
+func (c *idempotencyCache) pruneLocked() {
+	now := c.now()
+	for key, entry := range c.entries {
+		if entry.done && now.After(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/alerting"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+)
+
+// MaintenanceManager is implemented by alert managers that can suppress alert
+// delivery during a maintenance window
+type MaintenanceManager interface {
+	Maintenance() alerting.MaintenanceWindow
+	SetMaintenance(window alerting.MaintenanceWindow)
+}
+
+// MaintenanceRequest is the payload for POST /api/v1/maintenance
+// Enabled false ends any window. Enabled true opens a window at Start (Unix
+// seconds, 0 = now) that closes at End or after DurationSeconds; with neither it
+// stays open until ended
+type MaintenanceRequest struct {
+	Enabled         *bool `json:"enabled"`
+	Start           int64 `json:"start,omitempty"`
+	End             int64 `json:"end,omitempty"`
+	DurationSeconds int64 `json:"duration_seconds,omitempty"`
+}
+
+// MaintenanceResponse describes the scheduled maintenance window
+// Start and End are Unix seconds, omitted when unset
+type MaintenanceResponse struct {
+	Active bool  `json:"active"`
+	Start  int64 `json:"start,omitempty"`
+	End    int64 `json:"end,omitempty"`
+}
+
+// Window converts the request to a maintenance window starting no earlier than now
+func (r MaintenanceRequest) Window(now time.Time) (alerting.MaintenanceWindow, error) {
+	if r.Enabled == nil {
+		return alerting.MaintenanceWindow{}, errors.New("enabled is required")
+	}
+	if !*r.Enabled {
+		if r.Start != 0 || r.End != 0 || r.DurationSeconds != 0 {
+			return alerting.MaintenanceWindow{}, errors.New("start, end and duration_seconds require enabled")
+		}
+		return alerting.MaintenanceWindow{}, nil
+	}
+	if r.Start < 0 || r.End < 0 || r.DurationSeconds < 0 {
+		return alerting.MaintenanceWindow{}, errors.New("start, end and duration_seconds cannot be negative")
+	}
+	if r.End != 0 && r.DurationSeconds != 0 {
+		return alerting.MaintenanceWindow{}, errors.New("set end or duration_seconds, not both")
+	}
+
+	window := alerting.MaintenanceWindow{Start: now}
+	if r.Start != 0 {
+		window.Start = time.Unix(r.Start, 0)
+	}
+	if r.End != 0 {
+		window.End = time.Unix(r.End, 0)
+	}
+	if r.DurationSeconds != 0 {
+		window.End = window.Start.Add(time.Duration(r.DurationSeconds) * time.Second)
+	}
+	if !window.End.IsZero() && !window.End.After(window.Start) {
+		return alerting.MaintenanceWindow{}, errors.New("end must be after start")
+	}
+	if !window.End.IsZero() && !window.End.After(now) {
+		return alerting.MaintenanceWindow{}, errors.New("end must be in the future")
+	}
+	return window, nil
+}
+
+// maintenanceResponse describes window as seen at now
+func maintenanceResponse(window alerting.MaintenanceWindow, now time.Time) MaintenanceResponse {
+	response := MaintenanceResponse{Active: window.Active(now)}
+	if !window.Start.IsZero() {
+		response.Start = window.Start.Unix()
+	}
+	if !window.End.IsZero() {
+		response.End = window.End.Unix()
+	}
+	return response
+}
+
+// handleMaintenance reads or changes the alert maintenance window
+// Supports:
+//   - GET /api/v1/maintenance - Show the scheduled window
+//   - POST /api/v1/maintenance - Start, schedule or end a window (MaintenanceRequest)
+//
+// Returns: MaintenanceResponse with the window now in effect
+func (s *Server) handleMaintenance(w http.ResponseWriter, r *http.Request) {
+	if _, disabled := s.alertManager.(NoopAlertManager); disabled {
+		s.writeError(w, http.StatusServiceUnavailable, ErrAlertingDisabled.Error())
+		return
+	}
+	manager, ok := s.alertManager.(MaintenanceManager)
+	if !ok {
+		s.writeError(w, http.StatusNotImplemented, "maintenance windows not supported")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.writeJSON(w, http.StatusOK, maintenanceResponse(manager.Maintenance(), time.Now()))
+	case http.MethodPost:
+		var request MaintenanceRequest
+		if err := decodeJSONBody(r.Body, &request); err != nil {
+			s.writeError(w, decodeErrorStatus(err), err.Error())
+			return
+		}
+		now := time.Now()
+		window, err := request.Window(now)
+		if err != nil {
+			s.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		manager.SetMaintenance(window)
+
+		logger.Info("Maintenance window updated via API",
+			"component", "APIServer",
+			"enabled", !window.IsZero())
+
+		s.writeJSON(w, http.StatusOK, maintenanceResponse(window, now))
+	default:
+		s.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/alerting"
+)
+
+// maintenanceAlertManager is a MockAlertManager that also stores a maintenance window
+type maintenanceAlertManager struct {
+	MockAlertManager
+	window alerting.MaintenanceWindow
+}
+
+func (m *maintenanceAlertManager) Maintenance() alerting.MaintenanceWindow {
+	return m.window
+}
+
+func (m *maintenanceAlertManager) SetMaintenance(window alerting.MaintenanceWindow) {
+	m.window = window
+}
+
+// TestMaintenanceRequest_Window tests converting requests to windows
+func TestMaintenanceRequest_Window(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	enabled, disabled := true, false
+
+	tests := []struct {
+		name          string
+		request       MaintenanceRequest
+		expected      alerting.MaintenanceWindow
+		expectedError bool
+	}{
+		{"toggle on", MaintenanceRequest{Enabled: &enabled}, alerting.MaintenanceWindow{Start: now}, false},
+		{"toggle off", MaintenanceRequest{Enabled: &disabled}, alerting.MaintenanceWindow{}, false},
+		{"duration", MaintenanceRequest{Enabled: &enabled, DurationSeconds: 600},
+			alerting.MaintenanceWindow{Start: now, End: now.Add(10 * time.Minute)}, false},
+		{"scheduled", MaintenanceRequest{Enabled: &enabled, Start: 1700003600, End: 1700007200},
+			alerting.MaintenanceWindow{Start: time.Unix(1700003600, 0), End: time.Unix(1700007200, 0)}, false},
+		{"missing enabled", MaintenanceRequest{DurationSeconds: 600}, alerting.MaintenanceWindow{}, true},
+		{"end and duration", MaintenanceRequest{Enabled: &enabled, End: 1700007200, DurationSeconds: 600}, alerting.MaintenanceWindow{}, true},
+		{"end before start", MaintenanceRequest{Enabled: &enabled, Start: 1700007200, End: 1700003600}, alerting.MaintenanceWindow{}, true},
+		{"end in the past", MaintenanceRequest{Enabled: &enabled, Start: 1699990000, End: 1699999999}, alerting.MaintenanceWindow{}, true},
+		{"negative duration", MaintenanceRequest{Enabled: &enabled, DurationSeconds: -1}, alerting.MaintenanceWindow{}, true},
+		{"bounds while disabling", MaintenanceRequest{Enabled: &disabled, End: 1700007200}, alerting.MaintenanceWindow{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window, err := tt.request.Window(now)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected error, got window %+v", window)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !window.Start.Equal(tt.expected.Start) || !window.End.Equal(tt.expected.End) {
+				t.Errorf("expected window %+v, got %+v", tt.expected, window)
+			}
+		})
+	}
+}
+
+// TestHandleMaintenance tests starting, reading and ending a maintenance window
+func TestHandleMaintenance(t *testing.T) {
+	manager := &maintenanceAlertManager{}
+	server := NewServer(8080, &MockStorage{}, manager)
+
+	req := httptest.NewRequest("POST", "/api/v1/maintenance", strings.NewReader(`{"enabled": true, "duration_seconds": 3600}`))
+	w := httptest.NewRecorder()
+	server.handleMaintenance(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response MaintenanceResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !response.Active || response.End-response.Start != 3600 {
+		t.Errorf("expected an active one hour window, got %+v", response)
+	}
+	if manager.window.IsZero() {
+		t.Fatal("expected the window to be set on the manager")
+	}
+
+	req = httptest.NewRequest("GET", "/api/v1/maintenance", nil)
+	w = httptest.NewRecorder()
+	server.handleMaintenance(w, req)
+	response = MaintenanceResponse{}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if w.Code != http.StatusOK || !response.Active {
+		t.Errorf("expected GET to report the active window, got %d %+v", w.Code, response)
+	}
+
+	req = httptest.NewRequest("POST", "/api/v1/maintenance", strings.NewReader(`{"enabled": false}`))
+	w = httptest.NewRecorder()
+	server.handleMaintenance(w, req)
+	response = MaintenanceResponse{}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if w.Code != http.StatusOK || response.Active || !manager.window.IsZero() {
+		t.Errorf("expected the window to be ended, got %d %+v", w.Code, response)
+	}
+
+	req = httptest.NewRequest("POST", "/api/v1/maintenance", strings.NewReader(`{"duration_seconds": 60}`))
+	w = httptest.NewRecorder()
+	server.handleMaintenance(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 without enabled, got %d", w.Code)
+	}
+}
+
+// TestHandleMaintenance_Unsupported tests managers without maintenance support
+func TestHandleMaintenance_Unsupported(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+	req := httptest.NewRequest("GET", "/api/v1/maintenance", nil)
+	w := httptest.NewRecorder()
+	server.handleMaintenance(w, req)
+	if w.Code != http.StatusNotImplemented {
+		t.Errorf("expected status 501, got %d", w.Code)
+	}
+
+	server = NewServer(8080, &MockStorage{}, nil)
+	w = httptest.NewRecorder()
+	server.handleMaintenance(w, req)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503 with alerting disabled, got %d", w.Code)
+	}
+}
@@ -0,0 +1,236 @@
+package api
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+)
+
+// metricsReadPrefix covers the endpoints that read stored metrics. Requests to them
+// aren't recorded so polling the request metrics doesn't generate more of them
+const metricsReadPrefix = "/api/v1/metrics"
+
+// tokenScope is the access granted by a bearer token
+type tokenScope int
+
+const (
+	scopeNone   tokenScope = iota // Missing or unknown token
+	scopeScrape                   // Scrape token: metric reads only
+	scopeFull                     // Main auth token: every endpoint
+)
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status code before writing it
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Hijack hands the connection to the handler, as WebSocket upgrades need, recording
+// the switch of protocols as the status
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	r.status = http.StatusSwitchingProtocols
+	return http.NewResponseController(r.ResponseWriter).Hijack()
+}
+
+// metricChecker is implemented by alert managers that can evaluate metrics
+type metricChecker interface {
+	CheckMetric(metric types.Metric) error
+}
+
+// withRequestMetrics logs each request and records api_request_total and
+// api_request_duration_ms into storage, labelled by route and status
+func (s *Server) withRequestMetrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		duration := time.Since(start)
+
+		logger.Debug("API request",
+			"component", "APIServer",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"duration", duration.String())
+
+		if strings.HasPrefix(r.URL.Path, metricsReadPrefix) {
+			return
+		}
+		s.recordRequest(requestRoute(r), rec.status, duration)
+	})
+}
+
+// requestIDHeader carries the ID that ties a request to its log lines
+const requestIDHeader = "X-Request-ID"
+
+// withRecovery turns a panicking handler into a 500 response instead of letting it
+// take down the connection. The panic and its stack are logged with the request ID,
+// taken from the X-Request-ID header or generated, which is also sent back to the
+// client; the response body stays generic so internals aren't leaked
+func (s *Server) withRecovery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// net/http uses this panic to abort a response on purpose
+			if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(recovered)
+			}
+
+			requestID := r.Header.Get(requestIDHeader)
+			if requestID == "" {
+				requestID = uuid.New().String()
+			}
+			logger.Error("Recovered panic in API handler",
+				"component", "APIServer",
+				"request_id", requestID,
+				"method", r.Method,
+				"path", r.URL.Path,
+				"panic", fmt.Sprint(recovered),
+				"stack", string(debug.Stack()))
+
+			w.Header().Set(requestIDHeader, requestID)
+			s.writeError(w, http.StatusInternalServerError, "internal server error")
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withBodyLimit caps how much of a request body handlers can read, so a client
+// can't exhaust memory with a huge payload. Reads past the limit fail with
+// *http.MaxBytesError, which handlers report as 413
+func (s *Server) withBodyLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.maxBodyBytes > 0 && r.Body != nil {
+			r.Body = http.MaxBytesReader(w, r.Body, s.maxBodyBytes)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requestRoute returns the mux pattern that served the request so the path label
+// stays bounded; unmatched paths share a single label
+func requestRoute(r *http.Request) string {
+	if r.Pattern == "" {
+		return "unmatched"
+	}
+	return r.Pattern
+}
+
+// recordRequest stores the request count and duration metrics and checks them against alert rules
+func (s *Server) recordRequest(path string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+
+	s.requestMu.Lock()
+	key := path + "|" + statusLabel
+	s.requestCounts[key]++
+	count := s.requestCounts[key]
+	s.requestMu.Unlock()
+
+	now := time.Now().Unix()
+	metrics := []types.Metric{
+		{
+			Name:      "api_request_total",
+			Timestamp: now,
+			Value:     float64(count),
+			Labels:    map[string]string{"path": path, "status": statusLabel},
+		},
+		{
+			Name:      "api_request_duration_ms",
+			Timestamp: now,
+			Value:     float64(duration.Microseconds()) / 1000,
+			Labels:    map[string]string{"path": path, "status": statusLabel},
+		},
+	}
+
+	checker, canCheck := s.alertManager.(metricChecker)
+	for _, metric := range metrics {
+		if err := s.metricsStore.StoreMetric(metric); err != nil {
+			logger.Error("Error storing request metric",
+				"component", "APIServer",
+				"metric_name", metric.Name,
+				"error", err)
+		}
+		if canCheck {
+			if err := checker.CheckMetric(metric); err != nil {
+				logger.Error("Error checking alerts",
+					"component", "APIServer",
+					"metric_name", metric.Name,
+					"error", err)
+			}
+		}
+	}
+}
+
+// withAuth enforces bearer tokens once an auth token has been set with SetAuthTokens.
+// /health stays open so load balancers can probe without credentials
+func (s *Server) withAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.authToken == "" || r.URL.Path == "/health" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		switch s.tokenScope(r) {
+		case scopeFull:
+			next.ServeHTTP(w, r)
+		case scopeScrape:
+			if !isScrapeRequest(r) {
+				s.writeError(w, http.StatusForbidden, "scrape token can only read metrics")
+				return
+			}
+			next.ServeHTTP(w, r)
+		default:
+			w.Header().Set("WWW-Authenticate", `Bearer realm="hmon"`)
+			s.writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+		}
+	})
+}
+
+// tokenScope matches the request's bearer token against the configured tokens
+func (s *Server) tokenScope(r *http.Request) tokenScope {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return scopeNone
+	}
+	if tokenEqual(token, s.authToken) {
+		return scopeFull
+	}
+	if s.scrapeToken != "" && tokenEqual(token, s.scrapeToken) {
+		return scopeScrape
+	}
+	return scopeNone
+}
+
+// tokenEqual compares tokens in constant time so response timing doesn't leak them
+func tokenEqual(given, want string) bool {
+	return subtle.ConstantTimeCompare([]byte(given), []byte(want)) == 1
+}
+
+// isScrapeRequest reports whether a request only reads metrics, which is all a scrape token allows
+func isScrapeRequest(r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	path := r.URL.Path
+	return path == "/metrics" || path == metricsReadPrefix || strings.HasPrefix(path, metricsReadPrefix+"/")
+}
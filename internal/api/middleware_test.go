@@ -0,0 +1,309 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/alerting"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+// findRequestMetric returns the last stored metric with the given name, path and status
+func findRequestMetric(metrics []types.Metric, name, path, status string) (types.Metric, bool) {
+	var found types.Metric
+	ok := false
+	for _, metric := range metrics {
+		if metric.Name == name && metric.Labels["path"] == path && metric.Labels["status"] == status {
+			found = metric
+			ok = true
+		}
+	}
+	return found, ok
+}
+
+// TestRequestMetrics_HealthIncrementsCounter tests that hitting /health records request metrics
+func TestRequestMetrics_HealthIncrementsCounter(t *testing.T) {
+	store := &MockStorage{}
+	server := NewServer(8080, store, &MockAlertManager{})
+	handler := server.routes()
+
+	for i := 1; i <= 2; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/health", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", w.Code)
+		}
+
+		counter, ok := findRequestMetric(store.metrics, "api_request_total", "/health", "200")
+		if !ok {
+			t.Fatal("expected api_request_total for /health to be stored")
+		}
+		if counter.Value != float64(i) {
+			t.Errorf("expected api_request_total %d after %d requests, got %v", i, i, counter.Value)
+		}
+	}
+
+	duration, ok := findRequestMetric(store.metrics, "api_request_duration_ms", "/health", "200")
+	if !ok {
+		t.Fatal("expected api_request_duration_ms for /health to be stored")
+	}
+	if duration.Value < 0 {
+		t.Errorf("expected non-negative duration, got %v", duration.Value)
+	}
+}
+
+// TestRequestMetrics_CountsByStatus tests that counters are kept separately per status code
+func TestRequestMetrics_CountsByStatus(t *testing.T) {
+	store := &MockStorage{}
+	server := NewServer(8080, store, &MockAlertManager{})
+	handler := server.routes()
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/health", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/health", nil))
+
+	if counter, ok := findRequestMetric(store.metrics, "api_request_total", "/health", "405"); !ok || counter.Value != 1 {
+		t.Errorf("expected api_request_total 1 for status 405, got %v (found=%v)", counter.Value, ok)
+	}
+	if counter, ok := findRequestMetric(store.metrics, "api_request_total", "/health", "200"); !ok || counter.Value != 1 {
+		t.Errorf("expected api_request_total 1 for status 200, got %v (found=%v)", counter.Value, ok)
+	}
+}
+
+// TestRequestMetrics_SkipsMetricsEndpoints tests that reading metrics doesn't record request metrics
+func TestRequestMetrics_SkipsMetricsEndpoints(t *testing.T) {
+	store := &MockStorage{}
+	server := NewServer(8080, store, &MockAlertManager{})
+	handler := server.routes()
+
+	for _, path := range []string{"/api/v1/metrics", "/api/v1/metrics/summary?name=x"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	if len(store.metrics) != 0 {
+		t.Errorf("expected no metrics recorded for metrics endpoints, got %d", len(store.metrics))
+	}
+}
+
+// TestRequestMetrics_UnmatchedPath tests that unknown paths share one label
+func TestRequestMetrics_UnmatchedPath(t *testing.T) {
+	store := &MockStorage{}
+	server := NewServer(8080, store, &MockAlertManager{})
+	handler := server.routes()
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/no/such/path", nil))
+
+	if _, ok := findRequestMetric(store.metrics, "api_request_total", "unmatched", "404"); !ok {
+		t.Error("expected api_request_total with path 'unmatched' for an unknown path")
+	}
+}
+
+// TestRequestMetrics_GlobalLabels tests that request metrics go through the request metrics store
+func TestRequestMetrics_GlobalLabels(t *testing.T) {
+	store := &MockStorage{}
+	server := NewServer(8080, store, &MockAlertManager{})
+	server.SetRequestMetricsStore(storage.NewLabelingStorage(store, map[string]string{"env": "prod"}))
+	handler := server.routes()
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/health", nil))
+
+	for _, name := range []string{"api_request_total", "api_request_duration_ms"} {
+		metric, ok := findRequestMetric(store.metrics, name, "/health", "200")
+		if !ok {
+			t.Fatalf("expected %s for /health to be stored", name)
+		}
+		if metric.Labels["env"] != "prod" {
+			t.Errorf("expected %s to carry global label env=prod, got %v", name, metric.Labels)
+		}
+	}
+}
+
+// serveWithToken sends a request through the full handler with an optional bearer token
+func serveWithToken(handler http.Handler, method, path, token string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+// TestAuth_Disabled tests that the API stays open when no auth token is set
+func TestAuth_Disabled(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+	handler := server.routes()
+
+	if w := serveWithToken(handler, "GET", "/api/v1/alerts", "", ""); w.Code != http.StatusOK {
+		t.Errorf("expected status 200 without auth configured, got %d", w.Code)
+	}
+}
+
+// TestAuth_ScrapeTokenReadsMetrics tests that the scrape token can read metric endpoints
+func TestAuth_ScrapeTokenReadsMetrics(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+	server.SetAuthTokens("admin", "scrape")
+	handler := server.routes()
+
+	for _, path := range []string{"/api/v1/metrics", "/api/v1/metrics/search?name=account_balance"} {
+		if w := serveWithToken(handler, "GET", path, "scrape", ""); w.Code != http.StatusOK {
+			t.Errorf("expected status 200 for scrape token on %s, got %d: %s", path, w.Code, w.Body.String())
+		}
+	}
+}
+
+// TestAuth_ScrapeTokenRejectedOnMutations tests that the scrape token can't change alerts or delete metrics
+func TestAuth_ScrapeTokenRejectedOnMutations(t *testing.T) {
+	alertManager := &MockAlertManager{rules: []alerting.AlertRule{{ID: "rule-1", Name: "Rule"}}}
+	server := NewServer(8080, &MockStorage{}, alertManager)
+	server.SetAuthTokens("admin", "scrape")
+	handler := server.routes()
+
+	createBody := `{"name":"Low","metric_name":"account_balance","condition":"<","threshold":1,"severity":"warning"}`
+	tests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{"POST", "/api/v1/alerts", createBody},
+		{"DELETE", "/api/v1/alerts/rule-1", ""},
+		{"GET", "/api/v1/alerts", ""},
+		{"DELETE", "/api/v1/metrics?name=account_balance", ""},
+		{"POST", "/api/v1/collect", ""},
+	}
+	for _, tt := range tests {
+		if w := serveWithToken(handler, tt.method, tt.path, "scrape", tt.body); w.Code != http.StatusForbidden {
+			t.Errorf("expected status 403 for scrape token on %s %s, got %d", tt.method, tt.path, w.Code)
+		}
+	}
+
+	if alertManager.addRuleCalls != 0 || alertManager.removeRuleCalls != 0 {
+		t.Error("expected no alert rule changes with the scrape token")
+	}
+}
+
+// TestAuth_MainToken tests that the main token grants full access and bad tokens are rejected
+func TestAuth_MainToken(t *testing.T) {
+	alertManager := &MockAlertManager{}
+	server := NewServer(8080, &MockStorage{}, alertManager)
+	server.SetAuthTokens("admin", "scrape")
+	handler := server.routes()
+
+	createBody := `{"name":"Low","metric_name":"account_balance","condition":"<","threshold":1,"severity":"warning"}`
+	if w := serveWithToken(handler, "POST", "/api/v1/alerts", "admin", createBody); w.Code != http.StatusCreated {
+		t.Errorf("expected status 201 with main token, got %d: %s", w.Code, w.Body.String())
+	}
+
+	for _, token := range []string{"", "wrong"} {
+		w := serveWithToken(handler, "GET", "/api/v1/metrics", token, "")
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("expected status 401 for token %q, got %d", token, w.Code)
+		}
+		if w.Header().Get("WWW-Authenticate") == "" {
+			t.Error("expected WWW-Authenticate header on 401")
+		}
+	}
+
+	if w := serveWithToken(handler, "GET", "/health", "", ""); w.Code != http.StatusOK {
+		t.Errorf("expected /health to stay open, got %d", w.Code)
+	}
+}
+
+// TestBodyLimit_OverLimitReturns413 tests that an oversized alert body is rejected with 413
+func TestBodyLimit_OverLimitReturns413(t *testing.T) {
+	alertMgr := &MockAlertManager{}
+	server := NewServer(8080, &MockStorage{}, alertMgr)
+	server.SetMaxBodyBytes(256)
+	handler := server.routes()
+
+	body := `{"name":"Low","metric_name":"account_balance","condition":"<","threshold":1,"severity":"warning",` +
+		`"description":"` + strings.Repeat("x", 512) + `"}`
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/alerts", strings.NewReader(body)))
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status 413, got %d: %s", w.Code, w.Body.String())
+	}
+	if alertMgr.addRuleCalls != 0 {
+		t.Errorf("expected AddRule not to be called, got %d calls", alertMgr.addRuleCalls)
+	}
+
+	// The same rule fits once the limit is raised
+	server.SetMaxBodyBytes(DefaultMaxBodyBytes)
+	w = httptest.NewRecorder()
+	server.routes().ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/alerts", strings.NewReader(body)))
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected status 201 under the limit, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// panickingAlertManager is a MockAlertManager whose GetRules panics
+type panickingAlertManager struct {
+	MockAlertManager
+}
+
+func (m *panickingAlertManager) GetRules() []alerting.AlertRule {
+	panic("rules corrupted")
+}
+
+// TestRecovery_PanicReturns500 tests that a panicking handler gets a generic 500
+// and the server keeps serving other requests
+func TestRecovery_PanicReturns500(t *testing.T) {
+	store := &MockStorage{}
+	server := NewServer(8080, store, &panickingAlertManager{})
+	ts := httptest.NewServer(server.routes())
+	defer ts.Close()
+
+	req, _ := http.NewRequest("GET", ts.URL+"/api/v1/alerts", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("expected a response, got error: %v", err)
+	}
+	var body ErrorResponse
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", resp.StatusCode)
+	}
+	if body.Error != "internal server error" {
+		t.Errorf("expected a generic error message, got %q", body.Error)
+	}
+	if got := resp.Header.Get("X-Request-ID"); got != "req-123" {
+		t.Errorf("expected the request ID to be echoed, got %q", got)
+	}
+	if _, ok := findRequestMetric(store.metrics, "api_request_total", "/api/v1/alerts", "500"); !ok {
+		t.Error("expected the 500 to be recorded in request metrics")
+	}
+
+	resp, err = http.Get(ts.URL + "/health")
+	if err != nil {
+		t.Fatalf("expected the server to stay up, got error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200 after a panic, got %d", resp.StatusCode)
+	}
+}
+
+// TestRecovery_GeneratesRequestID tests a request ID is generated when the client sends none
+func TestRecovery_GeneratesRequestID(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+	handler := server.withRecovery(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/alerts", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+	if w.Header().Get("X-Request-ID") == "" {
+		t.Error("expected a generated X-Request-ID header")
+	}
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// SetStrictParams makes handlers reject query parameters they don't recognize
+// with 400, so a typo like ?nme=foo fails instead of silently matching everything
+func (s *Server) SetStrictParams(strict bool) {
+	s.strictParams = strict
+}
+
+// checkParams reports whether the request may proceed. In strict mode any query
+// parameter outside allowed gets a 400 listing the unrecognized names
+func (s *Server) checkParams(w http.ResponseWriter, r *http.Request, allowed ...string) bool {
+	if !s.strictParams {
+		return true
+	}
+	var unknown []string
+	for param := range r.URL.Query() {
+		if !slices.Contains(allowed, param) {
+			unknown = append(unknown, param)
+		}
+	}
+	if len(unknown) == 0 {
+		return true
+	}
+	slices.Sort(unknown)
+	s.writeError(w, http.StatusBadRequest, "unrecognized query parameters: "+strings.Join(unknown, ", "))
+	return false
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestStrictParams_Recognized tests known query parameters pass in strict mode
+func TestStrictParams_Recognized(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+	server.SetStrictParams(true)
+
+	w := httptest.NewRecorder()
+	server.routes().ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/metrics?name=account_balance&limit=10", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// TestStrictParams_Unknown tests unknown query parameters are rejected in strict mode
+func TestStrictParams_Unknown(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+	server.SetStrictParams(true)
+
+	w := httptest.NewRecorder()
+	server.routes().ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/metrics?nme=account_balance&limit=10&zzz=1", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "nme, zzz") {
+		t.Errorf("expected unrecognized parameters to be listed, got %s", w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "limit") {
+		t.Errorf("expected recognized parameters not to be listed, got %s", w.Body.String())
+	}
+}
+
+// TestStrictParams_LenientByDefault tests unknown query parameters are ignored unless strict mode is on
+func TestStrictParams_LenientByDefault(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+
+	w := httptest.NewRecorder()
+	server.routes().ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/metrics?nme=account_balance", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+)
+
+// ReloadResponse summarizes what a configuration reload changed
+// Rule lists hold rule IDs and are empty, not null, when nothing changed
+type ReloadResponse struct {
+	RulesAdded      []string `json:"rules_added"`
+	RulesRemoved    []string `json:"rules_removed"`
+	RulesUpdated    []string `json:"rules_updated"`
+	WebhooksChanged bool     `json:"webhooks_changed"`
+	LogLevel        string   `json:"log_level"`
+}
+
+// ReloadFunc re-reads the configuration file and applies the settings that can
+// change at runtime. On error nothing is applied
+type ReloadFunc func() (ReloadResponse, error)
+
+// SetReloader enables POST /api/v1/reload, which runs reload
+func (s *Server) SetReloader(reload ReloadFunc) {
+	s.reload = reload
+}
+
+// handleReload re-reads the configuration file, like a restart would, without restarting
+// POST /api/v1/reload
+//
+// Returns: ReloadResponse listing the changed rules
+func (s *Server) handleReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.writeError(w, http.StatusMethodNotAllowed, "only POST allowed")
+		return
+	}
+	if s.reload == nil {
+		s.writeError(w, http.StatusNotImplemented, "config reload not supported")
+		return
+	}
+
+	// Reloads share the config loader's global state, so run one at a time
+	s.reloadMu.Lock()
+	response, err := s.reload()
+	s.reloadMu.Unlock()
+	if err != nil {
+		logger.Error("Config reload failed",
+			"component", "APIServer",
+			"error", err)
+		s.writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	for _, ids := range []*[]string{&response.RulesAdded, &response.RulesRemoved, &response.RulesUpdated} {
+		if *ids == nil {
+			*ids = []string{}
+		}
+	}
+	logger.Info("Config reloaded via API",
+		"component", "APIServer",
+		"rules_added", len(response.RulesAdded),
+		"rules_removed", len(response.RulesRemoved),
+		"rules_updated", len(response.RulesUpdated),
+		"webhooks_changed", response.WebhooksChanged,
+		"log_level", response.LogLevel)
+	s.writeJSON(w, http.StatusOK, response)
+}
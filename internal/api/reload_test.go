@@ -0,0 +1,86 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestHandleReload tests that POST /api/v1/reload runs the reloader and returns its summary
+func TestHandleReload(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+	calls := 0
+	server.SetReloader(func() (ReloadResponse, error) {
+		calls++
+		return ReloadResponse{RulesAdded: []string{"new_rule"}, LogLevel: "debug"}, nil
+	})
+
+	w := httptest.NewRecorder()
+	server.routes().ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/reload", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if calls != 1 {
+		t.Errorf("expected the reloader to run once, got %d", calls)
+	}
+	var response ReloadResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.RulesAdded) != 1 || response.RulesAdded[0] != "new_rule" {
+		t.Errorf("expected new_rule added, got %v", response.RulesAdded)
+	}
+	if response.RulesRemoved == nil || response.RulesUpdated == nil {
+		t.Errorf("expected empty lists rather than null, got %+v", response)
+	}
+}
+
+// TestHandleReload_Errors tests reload failures, unsupported reloads and wrong methods
+func TestHandleReload_Errors(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+
+	w := httptest.NewRecorder()
+	server.routes().ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/reload", nil))
+	if w.Code != http.StatusNotImplemented {
+		t.Errorf("expected status 501 without a reloader, got %d", w.Code)
+	}
+
+	server.SetReloader(func() (ReloadResponse, error) {
+		return ReloadResponse{}, errors.New("invalid configuration")
+	})
+	w = httptest.NewRecorder()
+	server.routes().ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/reload", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500 for a failed reload, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	server.routes().ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/reload", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405 for GET, got %d", w.Code)
+	}
+}
+
+// TestHandleReload_RequiresAuth tests that reload is gated by the auth token, and the scrape token can't use it
+func TestHandleReload_RequiresAuth(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+	server.SetAuthTokens("secret", "scrape")
+	server.SetReloader(func() (ReloadResponse, error) {
+		return ReloadResponse{}, nil
+	})
+
+	for token, want := range map[string]int{"": http.StatusUnauthorized, "scrape": http.StatusForbidden, "secret": http.StatusOK} {
+		req := httptest.NewRequest("POST", "/api/v1/reload", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		server.routes().ServeHTTP(w, req)
+		if w.Code != want {
+			t.Errorf("expected status %d with token %q, got %d", want, token, w.Code)
+		}
+	}
+}
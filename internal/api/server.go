@@ -1,18 +1,26 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/kaldun-tech/hedera-network-monitor/internal/alerting"
 	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
 	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
 	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+	"gopkg.in/yaml.v3"
 )
 
 // Response types for standardized API responses
@@ -22,12 +30,31 @@ type MetricsResponse struct {
 	Metrics []types.Metric `json:"metrics"`
 	Count   int            `json:"count"`
 	Error   string         `json:"error,omitempty"`
+
+	// NextCursor is the "after" value for the next page of a cursor query; omitted on the last page
+	NextCursor *int64 `json:"next_cursor,omitempty"`
+}
+
+// DeltaResponse holds the change since the previous sample of each series
+type DeltaResponse struct {
+	Deltas []SeriesDelta `json:"deltas"`
+	Count  int           `json:"count"`
+}
+
+// GroupByResponse holds one aggregate per distinct value of the grouping label
+type GroupByResponse struct {
+	Name   string           `json:"name"`
+	Label  string           `json:"label"`
+	Fn     string           `json:"fn"`
+	Groups []GroupAggregate `json:"groups"`
+	Count  int              `json:"count"`
 }
 
 // HealthResponse represents the service health status
 type HealthResponse struct {
-	Status  string `json:"status"`
-	Version string `json:"version"`
+	Status     string            `json:"status"`
+	Version    string            `json:"version"`
+	Components *HealthComponents `json:"components,omitempty"` // Only with ?verbose=true
 }
 
 // StatsResponse represents storage statistics
@@ -37,6 +64,36 @@ type StatsResponse struct {
 	Utilization string `json:"utilization"`
 }
 
+// StorageInfoResponse describes the storage backend and what it supports
+type StorageInfoResponse struct {
+	Backend      string `json:"backend"`
+	Persistent   bool   `json:"persistent"`
+	RangeQueries bool   `json:"range_queries"`
+	Capacity     int    `json:"capacity"` // 0 = unknown or unbounded
+}
+
+// DeleteMetricsResponse reports how many metrics were removed
+type DeleteMetricsResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// DeleteAlertsResponse reports how many alert rules were removed
+type DeleteAlertsResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// CollectResponse lists the collectors signalled to run an on-demand cycle
+type CollectResponse struct {
+	Triggered []string `json:"triggered"`
+}
+
+// SummaryResponse holds bucketed aggregates for a metric over its stored time range
+type SummaryResponse struct {
+	Name    string          `json:"name"`
+	Samples int             `json:"samples"`
+	Buckets []SummaryBucket `json:"buckets"`
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error string `json:"error"`
@@ -44,15 +101,42 @@ type ErrorResponse struct {
 
 // AlertRuleResponse represents an alert rule in API responses
 type AlertRuleResponse struct {
-	ID              string  `json:"id"`
-	Name            string  `json:"name"`
-	Description     string  `json:"description"`
-	MetricName      string  `json:"metric_name"`
-	Condition       string  `json:"condition"`
-	Threshold       float64 `json:"threshold"`
-	Severity        string  `json:"severity"`
-	Enabled         bool    `json:"enabled"`
-	CooldownSeconds int     `json:"cooldown_seconds"`
+	ID              string          `json:"id"`
+	Name            string          `json:"name"`
+	Description     string          `json:"description"`
+	MetricName      string          `json:"metric_name"`
+	Condition       types.Condition `json:"condition"`
+	Threshold       float64         `json:"threshold"`
+	Severity        types.Severity  `json:"severity"`
+	Enabled         bool            `json:"enabled"`
+	CooldownSeconds int             `json:"cooldown_seconds"`
+	ForSeconds      int             `json:"for_seconds"`
+	MinChange       float64         `json:"min_change,omitempty"`
+	WarmupSeconds   int             `json:"warmup_seconds,omitempty"`
+
+	EscalateAfter         int `json:"escalate_after"`
+	EscalateWindowSeconds int `json:"escalate_window_seconds"`
+
+	MinChangePercent float64 `json:"min_change_percent,omitempty"` // "changed" ignores moves below this percent of the previous value
+
+	DenominatorMetric string `json:"denominator_metric,omitempty"` // Makes this a ratio rule: metric_name / denominator_metric
+
+	Tags        []string          `json:"tags,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Webhooks    []string          `json:"webhooks,omitempty"` // Overrides the global webhooks for this rule
+
+	MessageTemplate string `json:"message_template,omitempty"` // Go text/template for the alert message
+
+	// Evaluation stats, listed by GET /api/v1/alerts when the manager tracks them;
+	// times are Unix seconds, omitted until the rule is evaluated or fires
+	Evaluations   int64 `json:"evaluations"`
+	LastEvaluated int64 `json:"last_evaluated,omitempty"`
+	LastFired     int64 `json:"last_fired,omitempty"`
+}
+
+// RuleStatsProvider is implemented by alert managers that track per-rule evaluation stats
+type RuleStatsProvider interface {
+	RuleStats() map[string]alerting.RuleStats
 }
 
 // AlertListResponse wraps a list of alert rules
@@ -63,13 +147,29 @@ type AlertListResponse struct {
 
 // CreateAlertRequest represents the payload for creating an alert rule
 type CreateAlertRequest struct {
-	Name            string  `json:"name"`
-	Description     string  `json:"description"`
-	MetricName      string  `json:"metric_name"`
-	Condition       string  `json:"condition"`
-	Threshold       float64 `json:"threshold"`
-	Severity        string  `json:"severity"`
-	CooldownSeconds int     `json:"cooldown_seconds"`
+	Name            string          `json:"name"`
+	Description     string          `json:"description"`
+	MetricName      string          `json:"metric_name"`
+	Condition       types.Condition `json:"condition"`
+	Threshold       float64         `json:"threshold"`
+	Severity        types.Severity  `json:"severity"`
+	CooldownSeconds int             `json:"cooldown_seconds"`
+	ForSeconds      int             `json:"for_seconds"`
+	MinChange       float64         `json:"min_change,omitempty"`
+	WarmupSeconds   int             `json:"warmup_seconds,omitempty"`
+
+	EscalateAfter         int `json:"escalate_after"`
+	EscalateWindowSeconds int `json:"escalate_window_seconds"`
+
+	MinChangePercent float64 `json:"min_change_percent,omitempty"` // "changed" ignores moves below this percent of the previous value
+
+	DenominatorMetric string `json:"denominator_metric,omitempty"` // Makes this a ratio rule: metric_name / denominator_metric
+
+	Tags        []string          `json:"tags,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Webhooks    []string          `json:"webhooks,omitempty"` // Overrides the global webhooks for this rule
+
+	MessageTemplate string `json:"message_template,omitempty"` // Go text/template for the alert message
 }
 
 // AlertingManager interface defines the contract for alert management
@@ -80,21 +180,126 @@ type AlertingManager interface {
 	RemoveRule(ruleID string) error
 }
 
+// ErrAlertingDisabled is returned by NoopAlertManager for rule changes
+var ErrAlertingDisabled = errors.New("alerting disabled")
+
+// NoopAlertManager is an AlertingManager for metrics-only deployments without alerting
+// The alert endpoints respond 503 instead of managing rules while it is in use
+type NoopAlertManager struct{}
+
+// GetRules returns no rules
+func (NoopAlertManager) GetRules() []alerting.AlertRule {
+	return nil
+}
+
+// AddRule rejects the rule with ErrAlertingDisabled
+func (NoopAlertManager) AddRule(rule alerting.AlertRule) error {
+	return ErrAlertingDisabled
+}
+
+// RemoveRule rejects the removal with ErrAlertingDisabled
+func (NoopAlertManager) RemoveRule(ruleID string) error {
+	return ErrAlertingDisabled
+}
+
+// CollectTrigger is a collector that can run a collection cycle on demand
+type CollectTrigger interface {
+	Name() string
+	Trigger()
+}
+
+// DefaultMaxBodyBytes is the largest request body the API accepts unless configured otherwise
+const DefaultMaxBodyBytes int64 = 1 << 20
+
 // Server represents the HTTP API server
 type Server struct {
 	port         int
 	store        storage.Storage
 	alertManager AlertingManager
+	collectors   []CollectTrigger
+	tlsConfig    *tls.Config       // Non-nil when serving HTTPS
+	idempotency  *idempotencyCache // Idempotency-Keys seen on POST /api/v1/alerts
 	server       *http.Server
+
+	requestCounts map[string]int64 // Running api_request_total per route and status
+	requestMu     sync.Mutex
+	metricsStore  storage.Storage // Where api_request_* metrics are written; defaults to store
+
+	authToken   string // Bearer token required for every endpoint except /health (empty = open)
+	scrapeToken string // Narrower bearer token that may only read metrics
+
+	maxBodyBytes int64 // Largest accepted request body; larger ones get 413 (0 = unlimited)
+
+	cors config.CORSConfig // Cross-origin access; disabled while it has no origins
+
+	strictParams bool // Reject unrecognized query parameters with 400
+
+	reload   ReloadFunc // Applies a re-read config file for POST /api/v1/reload; nil disables it
+	reloadMu sync.Mutex
+
+	streamInterval time.Duration // How often metric streams poll storage for new samples
 }
 
 // NewServer creates a new API server
+// A nil alertManager is replaced with NoopAlertManager, disabling the alert endpoints
 func NewServer(port int, store storage.Storage, alertManager AlertingManager) *Server {
+	if alertManager == nil {
+		alertManager = NoopAlertManager{}
+	}
 	return &Server{
 		port:         port,
 		store:        store,
 		alertManager: alertManager,
+		idempotency:  newIdempotencyCache(DefaultIdempotencyTTL),
+
+		requestCounts: make(map[string]int64),
+		metricsStore:  store,
+
+		maxBodyBytes: DefaultMaxBodyBytes,
+
+		streamInterval: DefaultStreamInterval,
+	}
+}
+
+// SetTLS loads a PEM certificate and key so the server serves HTTPS
+// The pair is validated immediately so misconfiguration fails at startup
+func (s *Server) SetTLS(certFile, keyFile string) error {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate %s and key %s: %w", certFile, keyFile, err)
 	}
+	s.tlsConfig = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	return nil
+}
+
+// SetAuthTokens requires bearer tokens on the API. authToken grants full access;
+// scrapeToken, if set, only grants reads of /metrics and /api/v1/metrics
+func (s *Server) SetAuthTokens(authToken, scrapeToken string) {
+	s.authToken = authToken
+	s.scrapeToken = scrapeToken
+}
+
+// SetMaxBodyBytes limits request bodies to n bytes so oversized payloads can't
+// exhaust memory. Non-positive values remove the limit
+func (s *Server) SetMaxBodyBytes(n int64) {
+	if n < 0 {
+		n = 0
+	}
+	s.maxBodyBytes = n
+}
+
+// SetRequestMetricsStore records api_request_total and api_request_duration_ms into
+// store instead of the store the API reads from, e.g. one that adds global labels
+func (s *Server) SetRequestMetricsStore(store storage.Storage) {
+	s.metricsStore = store
+}
+
+// AddCollector registers a collector that can be triggered via POST /api/v1/collect
+func (s *Server) AddCollector(c CollectTrigger) {
+	s.collectors = append(s.collectors, c)
 }
 
 // Helper functions for JSON response handling
@@ -122,28 +327,69 @@ func (s *Server) writeError(w http.ResponseWriter, code int, message string) {
 
 // Start starts the HTTP server and blocks until context is cancelled
 func (s *Server) Start(ctx context.Context) error {
+	addr := fmt.Sprintf(":%d", s.port)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	return s.serve(ctx, listener)
+}
+
+// routes builds the request handler: every endpoint wrapped in request metrics
+func (s *Server) routes() http.Handler {
 	mux := http.NewServeMux()
 
 	// Register handlers
 	mux.HandleFunc("/health", s.handleHealth)
 	mux.HandleFunc("/api/v1/metrics", s.handleMetrics)
 	mux.HandleFunc("/api/v1/metrics/account", s.handleMetricsByLabel)
+	mux.HandleFunc("/api/v1/metrics/search", s.handleSearchMetrics)
+	mux.HandleFunc("/api/v1/metrics/summary", s.handleMetricsSummary)
+	mux.HandleFunc("/api/v1/metrics/latest", s.handleLatestMetrics)
+	mux.HandleFunc("/api/v1/metrics/delta", s.handleMetricsDelta)
+	mux.HandleFunc("/api/v1/metrics/groupby", s.handleMetricsGroupBy)
 	mux.HandleFunc("/api/v1/storage/stats", s.handleStorageStats)
+	mux.HandleFunc("/api/v1/storage/info", s.handleStorageInfo)
+	mux.HandleFunc("/api/v1/collect", s.handleCollect)
 	mux.HandleFunc("/api/v1/alerts", s.handleAlerts)
-	// TODO: Add more handlers:
-	// - WebSocket endpoint for real-time metrics
+	mux.HandleFunc("/api/v1/alerts/config", s.handleAlertsConfig)
+	mux.HandleFunc("/api/v1/alerts/history", s.handleAlertHistory)
+	mux.HandleFunc("/api/v1/maintenance", s.handleMaintenance)
+	mux.HandleFunc("/api/v1/reload", s.handleReload)
+	mux.HandleFunc("/api/v1/metrics/stream", s.handleMetricsStream)
+
+	return s.withRequestMetrics(s.withRecovery(s.withCORS(s.withAuth(s.withBodyLimit(mux)))))
+}
 
+// serve runs the HTTP server on the listener until the context is cancelled
+// Uses TLS when a certificate has been configured with SetTLS
+func (s *Server) serve(ctx context.Context, listener net.Listener) error {
 	s.server = &http.Server{
-		Addr:    fmt.Sprintf(":%d", s.port),
-		Handler: mux,
+		Addr:      listener.Addr().String(),
+		Handler:   s.routes(),
+		TLSConfig: s.tlsConfig,
+		// Requests see ctx cancelled on shutdown, which also ends metric streams
+		// (hijacked connections aren't closed by Shutdown)
+		BaseContext: func(net.Listener) context.Context { return ctx },
 	}
 
 	// Start server in a goroutine
 	go func() {
-		logger.Info("HTTP API server listening",
-			"component", "APIServer",
-			"address", s.server.Addr)
-		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if s.tlsConfig != nil {
+			logger.Info("HTTPS API server listening",
+				"component", "APIServer",
+				"address", listener.Addr().String())
+			// Certificates come from TLSConfig, so no files are passed here
+			err = s.server.ServeTLS(listener, "", "")
+		} else {
+			logger.Info("HTTP API server listening",
+				"component", "APIServer",
+				"address", listener.Addr().String())
+			err = s.server.Serve(listener)
+		}
+		if err != nil && err != http.ErrServerClosed {
 			logger.Error("API server error",
 				"component", "APIServer",
 				"error", err)
@@ -163,59 +409,109 @@ func (s *Server) Start(ctx context.Context) error {
 
 // handleHealth returns service health status
 // GET /health
-// No query parameters required
-// Returns: HealthResponse with status and version
+// Query parameters:
+//   - verbose: "true" adds component statuses (optional)
+//
+// Returns: HealthResponse with status and version; the status is "degraded" when a
+// verbose check finds storage unreachable
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	// Check if request method is GET
 	if r.Method != http.MethodGet {
 		s.writeError(w, http.StatusMethodNotAllowed, "only GET allowed")
 		return
 	}
+	if !s.checkParams(w, r, "verbose") {
+		return
+	}
+
+	verbose := false
+	if v := r.URL.Query().Get("verbose"); v != "" {
+		var err error
+		if verbose, err = strconv.ParseBool(v); err != nil {
+			s.writeError(w, http.StatusBadRequest, "invalid verbose parameter, must be true or false")
+			return
+		}
+	}
 
 	// Create HealthResponse struct and call s.writeJSON() with 200 status and response
-	s.writeJSON(w, http.StatusOK, HealthResponse{
+	response := HealthResponse{
 		Status:  "healthy",
 		Version: "0.1.0",
-	})
+	}
+	if verbose {
+		response.Components = s.healthComponents()
+		if response.Components.Storage != "ok" {
+			response.Status = "degraded"
+		}
+	}
+	s.writeJSON(w, http.StatusOK, response)
 }
 
 const DefaultLimit = 100
 const MaxLimit = 10000
 
-// handleMetrics returns metrics based on query parameters
+// handleMetrics handles metric query and deletion endpoints
+// Supports:
+//   - GET /api/v1/metrics - Query metrics
+//   - DELETE /api/v1/metrics - Delete metrics by name and/or label
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.handleGetMetrics(w, r)
+	case http.MethodDelete:
+		s.handleDeleteMetrics(w, r)
+	default:
+		s.writeError(w, http.StatusMethodNotAllowed, "only GET and DELETE allowed")
+	}
+}
+
+// handleGetMetrics returns metrics based on query parameters
 // GET /api/v1/metrics
 // Query parameters:
 //   - name: metric name filter (optional, empty string = all)
+//   - prefix: metric name prefix filter, e.g. network_node_ (optional, cannot be combined with name)
 //   - limit: maximum number of results (optional, default 100, max 10000)
+//   - after: timestamp cursor; returns samples strictly after it in ascending time,
+//     with next_cursor set while more pages may remain (optional, cannot be combined with prefix)
 //
 // Returns: MetricsResponse with metrics slice and count
-func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
-	// Check method is GET
-	if r.Method != http.MethodGet {
-		s.writeError(w, http.StatusMethodNotAllowed, "only GET allowed")
+func (s *Server) handleGetMetrics(w http.ResponseWriter, r *http.Request) {
+	if !s.checkParams(w, r, "name", "prefix", "limit", "after") {
 		return
 	}
 
 	// Parse query parameters:
 	name := r.URL.Query().Get("name")
-	limitStr := r.URL.Query().Get("limit")
+	prefix := r.URL.Query().Get("prefix")
+	limit := parseLimit(r.URL.Query().Get("limit"))
 
-	// Parse limit as integer
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 0 {
-		logger.Debug("Invalid limit, using default",
-			"component", "APIServer",
-			"default_limit", DefaultLimit)
-		limit = DefaultLimit
-	} else if MaxLimit < limit {
-		logger.Debug("Limit too high, using max",
-			"component", "APIServer",
-			"max_limit", MaxLimit)
-		limit = MaxLimit
+	if name != "" && prefix != "" {
+		s.writeError(w, http.StatusBadRequest, "name and prefix cannot be combined")
+		return
+	}
+
+	if r.URL.Query().Has("after") {
+		if prefix != "" {
+			s.writeError(w, http.StatusBadRequest, "after and prefix cannot be combined")
+			return
+		}
+		after, err := strconv.ParseInt(r.URL.Query().Get("after"), 10, 64)
+		if err != nil {
+			s.writeError(w, http.StatusBadRequest, "after must be a unix timestamp")
+			return
+		}
+		s.handleGetMetricsAfter(w, name, after, limit)
+		return
 	}
 
 	// Query storage
-	metrics, err := s.store.GetMetrics(name, limit)
+	var metrics []types.Metric
+	var err error
+	if prefix != "" {
+		metrics, err = s.store.GetMetricsByPrefix(prefix, limit)
+	} else {
+		metrics, err = s.store.GetMetrics(name, limit)
+	}
 	if err != nil {
 		logger.Error("Error retrieving metrics",
 			"component", "APIServer",
@@ -228,6 +524,7 @@ func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 	if metrics == nil {
 		metrics = []types.Metric{}
 	}
+	metrics = truncateToLimit(metrics, limit)
 
 	// Create MetricsResponse with 200 status, metrics slice and len(metrics)
 	s.writeJSON(w, http.StatusOK, MetricsResponse{
@@ -236,6 +533,123 @@ func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleGetMetricsAfter returns one cursor page of metrics named name (empty = all)
+// A page that reaches limit carries next_cursor, its last timestamp; pages may run past
+// limit so samples sharing that timestamp are never split between pages
+func (s *Server) handleGetMetricsAfter(w http.ResponseWriter, name string, after int64, limit int) {
+	metrics, err := s.store.GetMetricsAfter(name, after, limit)
+	if err != nil {
+		logger.Error("Error retrieving metrics",
+			"component", "APIServer",
+			"error", err)
+		s.writeError(w, http.StatusInternalServerError, "failed to retrieve metrics")
+		return
+	}
+	if metrics == nil {
+		metrics = []types.Metric{}
+	}
+
+	response := MetricsResponse{
+		Metrics: metrics,
+		Count:   len(metrics),
+	}
+	if 0 < limit && limit <= len(metrics) {
+		next := metrics[len(metrics)-1].Timestamp
+		response.NextCursor = &next
+	}
+	s.writeJSON(w, http.StatusOK, response)
+}
+
+// truncateToLimit drops metrics past limit (0 = unlimited)
+// Storage backends must honour limit, but the API doesn't rely on it, so a backend
+// that returns too many can't make a response exceed the requested page size
+func truncateToLimit(metrics []types.Metric, limit int) []types.Metric {
+	if limit <= 0 || len(metrics) <= limit {
+		return metrics
+	}
+	logger.Warn("Storage returned more metrics than the limit, truncating",
+		"component", "APIServer",
+		"returned", len(metrics),
+		"limit", limit)
+	return metrics[:limit]
+}
+
+// parseLimit parses a limit query parameter
+// Invalid or negative values fall back to DefaultLimit and values above MaxLimit are capped
+func parseLimit(limitStr string) int {
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 0 {
+		logger.Debug("Invalid limit, using default",
+			"component", "APIServer",
+			"default_limit", DefaultLimit)
+		return DefaultLimit
+	}
+	if MaxLimit < limit {
+		logger.Debug("Limit too high, using max",
+			"component", "APIServer",
+			"max_limit", MaxLimit)
+		return MaxLimit
+	}
+	return limit
+}
+
+// handleDeleteMetrics deletes metrics matching a name and/or label
+// DELETE /api/v1/metrics
+// Query parameters:
+//   - name: metric name filter (optional, empty = all names)
+//   - label_key: label key filter (optional, empty = all labels)
+//   - label_value: label value filter (optional, requires label_key)
+//   - before: only delete metrics older than this unix timestamp (optional, empty = any age)
+//
+// Returns: DeleteMetricsResponse with the number of metrics deleted
+func (s *Server) handleDeleteMetrics(w http.ResponseWriter, r *http.Request) {
+	if !s.checkParams(w, r, "name", "label_key", "label_value", "before") {
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	labelKey := r.URL.Query().Get("label_key")
+	labelValue := r.URL.Query().Get("label_value")
+
+	if labelValue != "" && labelKey == "" {
+		s.writeError(w, http.StatusBadRequest, "label_value requires label_key")
+		return
+	}
+
+	var before int64
+	if r.URL.Query().Has("before") {
+		var err error
+		before, err = strconv.ParseInt(r.URL.Query().Get("before"), 10, 64)
+		if err != nil || before <= 0 {
+			s.writeError(w, http.StatusBadRequest, "before must be a positive unix timestamp")
+			return
+		}
+	}
+
+	deleted, err := s.store.DeleteMetrics(name, labelKey, labelValue, before)
+	if err != nil {
+		logger.Error("Error deleting metrics",
+			"component", "APIServer",
+			"name", name,
+			"label_key", labelKey,
+			"label_value", labelValue,
+			"before", before,
+			"error", err)
+		s.writeError(w, http.StatusInternalServerError, "failed to delete metrics")
+		return
+	}
+
+	logger.Info("Deleted metrics",
+		"component", "APIServer",
+		"name", name,
+		"label_key", labelKey,
+		"label_value", labelValue,
+		"before", before,
+		"deleted", deleted)
+
+	s.writeJSON(w, http.StatusOK, DeleteMetricsResponse{Deleted: deleted})
+}
+
 // handleMetricsByLabel returns metrics filtered by label
 // GET /api/v1/metrics/account
 // Query parameters:
@@ -250,6 +664,10 @@ func (s *Server) handleMetricsByLabel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.checkParams(w, r, "key", "value") {
+		return
+	}
+
 	// Extract label key and value
 	key := r.URL.Query().Get("key")
 	value := r.URL.Query().Get("value")
@@ -284,6 +702,311 @@ func (s *Server) handleMetricsByLabel(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleSearchMetrics returns metrics matching a name and a set of labels
+// GET /api/v1/metrics/search
+// Query parameters:
+//   - labels: comma-separated key=value pairs that must all match
+//     (optional, e.g. "account_id=0.0.5000,type=balance")
+//   - name: metric name filter (optional, empty string = all)
+//   - limit: maximum number of results (optional, default 100, max 10000)
+//
+// Returns: MetricsResponse with matching metrics
+func (s *Server) handleSearchMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, http.StatusMethodNotAllowed, "only GET allowed")
+		return
+	}
+
+	if !s.checkParams(w, r, "name", "limit", "labels") {
+		return
+	}
+	name := r.URL.Query().Get("name")
+	limit := parseLimit(r.URL.Query().Get("limit"))
+
+	labels, err := parseLabelSelector(r.URL.Query().Get("labels"))
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	metrics, err := s.store.GetMetricsMatching(name, labels, limit)
+	if err != nil {
+		logger.Error("Error searching metrics",
+			"component", "APIServer",
+			"name", name,
+			"labels", labels,
+			"error", err)
+		s.writeError(w, http.StatusInternalServerError, "failed to retrieve metrics")
+		return
+	}
+
+	if metrics == nil {
+		metrics = []types.Metric{}
+	}
+	metrics = truncateToLimit(metrics, limit)
+
+	s.writeJSON(w, http.StatusOK, MetricsResponse{
+		Metrics: metrics,
+		Count:   len(metrics),
+	})
+}
+
+// DefaultSummaryBuckets and MaxSummaryBuckets bound the summary bucket count
+const DefaultSummaryBuckets = 20
+const MaxSummaryBuckets = 1000
+
+// handleMetricsSummary returns avg/min/max per time bucket for a metric
+// GET /api/v1/metrics/summary
+// Query parameters:
+//   - name: metric name (required)
+//   - buckets: number of buckets (optional, default 20, max 1000)
+//   - labels: comma-separated key=value pairs that must all match (optional)
+//
+// Returns: SummaryResponse with one entry per bucket (empty buckets have a zero count)
+func (s *Server) handleMetricsSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, http.StatusMethodNotAllowed, "only GET allowed")
+		return
+	}
+
+	if !s.checkParams(w, r, "name", "buckets", "labels") {
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		s.writeError(w, http.StatusBadRequest, "name query parameter required")
+		return
+	}
+
+	buckets := DefaultSummaryBuckets
+	if bucketsStr := r.URL.Query().Get("buckets"); bucketsStr != "" {
+		parsed, err := strconv.Atoi(bucketsStr)
+		if err != nil || parsed < 1 || MaxSummaryBuckets < parsed {
+			s.writeError(w, http.StatusBadRequest,
+				fmt.Sprintf("buckets must be an integer between 1 and %d", MaxSummaryBuckets))
+			return
+		}
+		buckets = parsed
+	}
+
+	labels, err := parseLabelSelector(r.URL.Query().Get("labels"))
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	metrics, err := s.store.GetMetricsMatching(name, labels, 0)
+	if err != nil {
+		logger.Error("Error retrieving metrics for summary",
+			"component", "APIServer",
+			"name", name,
+			"error", err)
+		s.writeError(w, http.StatusInternalServerError, "failed to retrieve metrics")
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, SummaryResponse{
+		Name:    name,
+		Samples: len(metrics),
+		Buckets: summarizeMetrics(metrics, buckets),
+	})
+}
+
+// handleLatestMetrics returns only the newest sample of each series, for dashboards
+// that want current values rather than history
+// GET /api/v1/metrics/latest
+// Query parameters:
+//   - name: metric name filter (optional, empty string = all)
+//
+// Returns: MetricsResponse with one metric per distinct name and label set
+func (s *Server) handleLatestMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, http.StatusMethodNotAllowed, "only GET allowed")
+		return
+	}
+
+	if !s.checkParams(w, r, "name") {
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	metrics, err := s.store.GetMetrics(name, 0)
+	if err != nil {
+		logger.Error("Error retrieving latest metrics",
+			"component", "APIServer",
+			"name", name,
+			"error", err)
+		s.writeError(w, http.StatusInternalServerError, "failed to retrieve metrics")
+		return
+	}
+
+	latest := storage.LatestPerSeries(metrics)
+	s.writeJSON(w, http.StatusOK, MetricsResponse{
+		Metrics: latest,
+		Count:   len(latest),
+	})
+}
+
+// handleMetricsDelta returns the change between the two most recent samples of each
+// series, for counters whose rate matters more than their cumulative value
+// GET /api/v1/metrics/delta
+// Query parameters:
+//   - name: metric name filter (optional, empty string = all)
+//
+// Returns: DeltaResponse with one entry per series that has at least two samples
+func (s *Server) handleMetricsDelta(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, http.StatusMethodNotAllowed, "only GET allowed")
+		return
+	}
+
+	if !s.checkParams(w, r, "name") {
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	metrics, err := s.store.GetMetrics(name, 0)
+	if err != nil {
+		logger.Error("Error retrieving metrics for deltas",
+			"component", "APIServer",
+			"name", name,
+			"error", err)
+		s.writeError(w, http.StatusInternalServerError, "failed to retrieve metrics")
+		return
+	}
+
+	deltas := seriesDeltas(metrics)
+	s.writeJSON(w, http.StatusOK, DeltaResponse{
+		Deltas: deltas,
+		Count:  len(deltas),
+	})
+}
+
+// handleMetricsGroupBy aggregates the latest value of each series by a label,
+// e.g. total balance per team
+// GET /api/v1/metrics/groupby
+// Query parameters:
+//   - name: metric name (required)
+//   - label: label to group by (required)
+//   - fn: aggregate, one of sum, avg, min, max, count (optional, default sum)
+//
+// Returns: GroupByResponse with one entry per label value; series without the label are skipped
+func (s *Server) handleMetricsGroupBy(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, http.StatusMethodNotAllowed, "only GET allowed")
+		return
+	}
+
+	if !s.checkParams(w, r, "name", "label", "fn") {
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		s.writeError(w, http.StatusBadRequest, "name query parameter required")
+		return
+	}
+	label := r.URL.Query().Get("label")
+	if label == "" {
+		s.writeError(w, http.StatusBadRequest, "label query parameter required")
+		return
+	}
+	fn, err := parseGroupByFn(r.URL.Query().Get("fn"))
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	metrics, err := s.store.GetMetrics(name, 0)
+	if err != nil {
+		logger.Error("Error retrieving metrics for grouping",
+			"component", "APIServer",
+			"name", name,
+			"error", err)
+		s.writeError(w, http.StatusInternalServerError, "failed to retrieve metrics")
+		return
+	}
+
+	groups := groupMetrics(metrics, label, fn)
+	s.writeJSON(w, http.StatusOK, GroupByResponse{
+		Name:   name,
+		Label:  label,
+		Fn:     fn,
+		Groups: groups,
+		Count:  len(groups),
+	})
+}
+
+// parseLabelSelector parses a comma-separated list of key=value pairs
+// Each pair is split on its first '=' so values may contain '=' or '.'
+// (e.g. "account_id=0.0.5000"). An empty selector returns an empty map.
+func parseLabelSelector(selector string) (map[string]string, error) {
+	labels := make(map[string]string)
+	if selector == "" {
+		return labels, nil
+	}
+
+	for _, pair := range strings.Split(selector, ",") {
+		pair = strings.TrimSpace(pair)
+		key, value, found := strings.Cut(pair, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid label selector %q: expected key=value", pair)
+		}
+		if existing, exists := labels[key]; exists && existing != value {
+			return nil, fmt.Errorf("invalid label selector: conflicting values for label %q", key)
+		}
+		labels[key] = value
+	}
+
+	return labels, nil
+}
+
+// handleCollect signals collectors to run a collection cycle immediately
+// POST /api/v1/collect
+// Query parameters:
+//   - collector: collector to trigger (optional, e.g. "account" or "AccountCollector";
+//     empty = all collectors)
+//
+// Returns: 202 Accepted with CollectResponse naming the triggered collectors
+func (s *Server) handleCollect(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.writeError(w, http.StatusMethodNotAllowed, "only POST allowed")
+		return
+	}
+
+	if !s.checkParams(w, r, "collector") {
+		return
+	}
+
+	target := r.URL.Query().Get("collector")
+	triggered := make([]string, 0, len(s.collectors))
+	for _, c := range s.collectors {
+		if target != "" && !strings.EqualFold(c.Name(), target) &&
+			!strings.EqualFold(c.Name(), target+"Collector") {
+			continue
+		}
+		c.Trigger()
+		triggered = append(triggered, c.Name())
+	}
+
+	if len(triggered) == 0 {
+		if target == "" {
+			s.writeError(w, http.StatusServiceUnavailable, "no collectors registered")
+		} else {
+			s.writeError(w, http.StatusNotFound, fmt.Sprintf("collector %q not found", target))
+		}
+		return
+	}
+
+	logger.Info("Triggered on-demand collection",
+		"component", "APIServer",
+		"collectors", triggered)
+
+	s.writeJSON(w, http.StatusAccepted, CollectResponse{Triggered: triggered})
+}
+
 // handleStorageStats returns storage statistics
 // GET /api/v1/storage/stats
 // No query parameters
@@ -316,10 +1039,18 @@ func (s *Server) handleStorageStats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Create StatsResponse from returned map
-	metricCount := stats["metric_count"].(int)
-	maxSize := stats["max_size"].(int)
-	utilization := stats["utilization"].(string)
+	// Create StatsResponse from returned map, rejecting backends that report
+	// unexpected types rather than panicking on them
+	metricCount, countOK := stats["metric_count"].(int)
+	maxSize, sizeOK := stats["max_size"].(int)
+	utilization, utilOK := stats["utilization"].(string)
+	if !countOK || !sizeOK || !utilOK {
+		logger.Error("Storage stats have unexpected types",
+			"component", "APIServer",
+			"stats", stats)
+		s.writeError(w, http.StatusInternalServerError, "storage returned malformed stats")
+		return
+	}
 
 	response := StatsResponse{
 		MetricCount: metricCount,
@@ -330,12 +1061,41 @@ func (s *Server) handleStorageStats(w http.ResponseWriter, r *http.Request) {
 	s.writeJSON(w, http.StatusOK, response)
 }
 
+// handleStorageInfo describes the storage backend and its capabilities
+// GET /api/v1/storage/info
+// No query parameters
+// Returns: StorageInfoResponse; backends that don't implement storage.InfoProvider
+// are reported as "unknown" with no capabilities rather than as an error
+func (s *Server) handleStorageInfo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, http.StatusMethodNotAllowed, "only GET allowed")
+		return
+	}
+
+	info := storage.StorageInfo{Backend: "unknown"}
+	if provider, ok := s.store.(storage.InfoProvider); ok {
+		info = provider.Info()
+	}
+
+	s.writeJSON(w, http.StatusOK, StorageInfoResponse{
+		Backend:      info.Backend,
+		Persistent:   info.Persistent,
+		RangeQueries: info.RangeQueries,
+		Capacity:     info.Capacity,
+	})
+}
+
 // handleAlerts handles alert rule management endpoints
 // Supports:
 //   - GET /api/v1/alerts - List all alert rules
 //   - POST /api/v1/alerts - Create a new alert rule
 //   - DELETE /api/v1/alerts/{id} - Delete an alert rule
 func (s *Server) handleAlerts(w http.ResponseWriter, r *http.Request) {
+	if _, disabled := s.alertManager.(NoopAlertManager); disabled {
+		s.writeError(w, http.StatusServiceUnavailable, ErrAlertingDisabled.Error())
+		return
+	}
+
 	switch r.Method {
 	case http.MethodGet:
 		s.handleListAlerts(w, r)
@@ -349,16 +1109,28 @@ func (s *Server) handleAlerts(w http.ResponseWriter, r *http.Request) {
 }
 
 // handleListAlerts returns all configured alert rules
-// GET /api/v1/alerts
+// GET /api/v1/alerts?tag=balances
+// Query parameter: tag - only return rules in this group (optional)
 // Returns: AlertListResponse with all alert rules
 func (s *Server) handleListAlerts(w http.ResponseWriter, r *http.Request) {
 	// Get all rules from alertManager using GetRules()
 	logger.Debug("GET /api/v1/alerts", "component", "APIServer")
+	if !s.checkParams(w, r, "tag") {
+		return
+	}
 	allRules := s.alertManager.GetRules()
+	tag := r.URL.Query().Get("tag")
+	var ruleStats map[string]alerting.RuleStats
+	if provider, ok := s.alertManager.(RuleStatsProvider); ok {
+		ruleStats = provider.RuleStats()
+	}
 
 	// Convert alerting.AlertRule to AlertRuleResponse
-	alertResponseList := make([]AlertRuleResponse, len(allRules))
-	for i, rule := range allRules {
+	alertResponseList := make([]AlertRuleResponse, 0, len(allRules))
+	for _, rule := range allRules {
+		if tag != "" && !rule.HasTag(tag) {
+			continue
+		}
 		ruleResponse := AlertRuleResponse{
 			ID:              rule.ID,
 			Name:            rule.Name,
@@ -369,8 +1141,33 @@ func (s *Server) handleListAlerts(w http.ResponseWriter, r *http.Request) {
 			Severity:        rule.Severity,
 			Enabled:         rule.Enabled,
 			CooldownSeconds: rule.CooldownSeconds,
+			ForSeconds:      rule.ForSeconds,
+			MinChange:       rule.MinChange,
+			WarmupSeconds:   rule.WarmupSeconds,
+
+			EscalateAfter:         rule.EscalateAfter,
+			EscalateWindowSeconds: rule.EscalateWindowSeconds,
+
+			MinChangePercent: rule.MinChangePercent,
+
+			DenominatorMetric: rule.DenominatorMetric,
+
+			Tags:        rule.Tags,
+			Annotations: rule.Annotations,
+			Webhooks:    rule.Webhooks,
+
+			MessageTemplate: rule.MessageTemplate,
 		}
-		alertResponseList[i] = ruleResponse
+		if stats, ok := ruleStats[rule.ID]; ok {
+			ruleResponse.Evaluations = stats.Evaluations
+			if !stats.LastEvaluated.IsZero() {
+				ruleResponse.LastEvaluated = stats.LastEvaluated.Unix()
+			}
+			if !stats.LastFired.IsZero() {
+				ruleResponse.LastFired = stats.LastFired.Unix()
+			}
+		}
+		alertResponseList = append(alertResponseList, ruleResponse)
 	}
 
 	// Return AlertListResponse with count
@@ -386,46 +1183,66 @@ func (r *CreateAlertRequest) Validate() error {
 	// Name, MetricName, Condition, Severity validation
 	// Similar to config.AlertRule.Validate
 	if r.Name == "" {
-		return fmt.Errorf("rule name cannot be empty")
+		return fmt.Errorf("field \"name\" is required")
 	}
 	if r.MetricName == "" {
-		return fmt.Errorf("rule metric name cannot be empty")
+		return fmt.Errorf("field \"metric_name\" is required")
 	}
 	if r.Condition == "" {
-		return fmt.Errorf("rule condition name cannot be empty")
+		return fmt.Errorf("field \"condition\" is required")
 	}
 	if r.Severity == "" {
-		return fmt.Errorf("rule severity name cannot be empty")
+		return fmt.Errorf("field \"severity\" is required")
 	}
 
-	// Validate condition is supported
-	validConditions := []string{">", "<", ">=", "<=", "==", "!=", "changed", "increased", "decreased"}
-	isValid := false
-	for _, vc := range validConditions {
-		if r.Condition == vc {
-			isValid = true
-			break
-		}
+	if !r.Condition.Valid() {
+		return fmt.Errorf("field \"condition\" has invalid value %q: must be one of %s",
+			r.Condition, types.ConditionNames())
 	}
-	if !isValid {
-		return fmt.Errorf("invalid condition: %s", r.Condition)
+	if !r.Severity.Valid() {
+		return fmt.Errorf("field \"severity\" has invalid value %q: must be one of %s",
+			r.Severity, types.SeverityNames())
 	}
 
-	// Validate severity
-	validSeverities := []string{"info", "warning", "critical"}
-	isSevere := false
-	for _, vs := range validSeverities {
-		if r.Severity == vs {
-			isSevere = true
-			break
-		}
+	if r.CooldownSeconds < 0 {
+		return fmt.Errorf("field \"cooldown_seconds\" cannot be negative: %d", r.CooldownSeconds)
 	}
-	if !isSevere {
-		return fmt.Errorf("invalid severity: %s", r.Severity)
+	if r.ForSeconds < 0 {
+		return fmt.Errorf("field \"for_seconds\" cannot be negative: %d", r.ForSeconds)
 	}
-
-	if r.CooldownSeconds < 0 {
-		return fmt.Errorf("cooldown seconds cannot be negative: %d", r.CooldownSeconds)
+	if r.WarmupSeconds < 0 {
+		return fmt.Errorf("field \"warmup_seconds\" cannot be negative: %d", r.WarmupSeconds)
+	}
+	if r.MinChange < 0 {
+		return fmt.Errorf("field \"min_change\" cannot be negative: %g", r.MinChange)
+	}
+	if r.MinChange != 0 && r.Condition != types.ConditionChanged {
+		return fmt.Errorf("field \"min_change\" only applies to condition \"changed\", not %q", r.Condition)
+	}
+	if r.MinChangePercent < 0 {
+		return fmt.Errorf("field \"min_change_percent\" cannot be negative: %g", r.MinChangePercent)
+	}
+	if r.MinChangePercent != 0 && r.Condition != types.ConditionChanged {
+		return fmt.Errorf("field \"min_change_percent\" only applies to condition \"changed\", not %q", r.Condition)
+	}
+	if r.EscalateAfter < 0 {
+		return fmt.Errorf("field \"escalate_after\" cannot be negative: %d", r.EscalateAfter)
+	}
+	if r.EscalateWindowSeconds < 0 {
+		return fmt.Errorf("field \"escalate_window_seconds\" cannot be negative: %d", r.EscalateWindowSeconds)
+	}
+	if r.DenominatorMetric != "" && r.DenominatorMetric == r.MetricName {
+		return fmt.Errorf("field \"denominator_metric\" must differ from \"metric_name\": %s", r.DenominatorMetric)
+	}
+	for i, tag := range r.Tags {
+		if strings.TrimSpace(tag) == "" {
+			return fmt.Errorf("field \"tags\" has an empty tag at index %d", i)
+		}
+	}
+	for i, webhookURL := range r.Webhooks {
+		if err := config.ValidateWebhookURL(webhookURL); err != nil {
+			return fmt.Errorf("field \"webhooks\" has an invalid URL at index %d: %w", i, err)
+		}
 	}
 	return nil
 }
@@ -433,14 +1250,17 @@ func (r *CreateAlertRequest) Validate() error {
 // handleCreateAlert creates a new alert rule
 // POST /api/v1/alerts
 // Request body: CreateAlertRequest
+// Optional header: Idempotency-Key - retries with the same key and body return the
+// originally created rule instead of creating another
 // Returns: AlertRuleResponse with created rule
 func (s *Server) handleCreateAlert(w http.ResponseWriter, r *http.Request) {
 	logger.Debug("POST /api/v1/alerts", "component", "APIServer")
 	// Parse JSON body into CreateAlertRequest
+	// Decode strictly so typos and wrong types are rejected rather than silently dropped
 	createRequest := CreateAlertRequest{}
-	err := json.NewDecoder(r.Body).Decode(&createRequest)
+	err := decodeJSONBody(r.Body, &createRequest)
 	if err != nil {
-		s.writeError(w, http.StatusBadRequest, err.Error())
+		s.writeError(w, decodeErrorStatus(err), err.Error())
 		return
 	}
 
@@ -451,6 +1271,24 @@ func (s *Server) handleCreateAlert(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Replay the original result for a retried request
+	idempotencyKey := r.Header.Get("Idempotency-Key")
+	if idempotencyKey != "" {
+		cached, state := s.idempotency.begin(idempotencyKey, createRequest)
+		switch state {
+		case idempotencyReplay:
+			w.Header().Set("Idempotent-Replayed", "true")
+			s.writeJSON(w, http.StatusCreated, cached)
+			return
+		case idempotencyPending:
+			s.writeError(w, http.StatusConflict, "a request with this Idempotency-Key is still being processed")
+			return
+		case idempotencyMismatch:
+			s.writeError(w, http.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request body")
+			return
+		}
+	}
+
 	// Generate unique ID (UUID or sequential)
 	newUUID := uuid.New().String()
 	fmt.Printf("Generated UUID for new rule: %s\n", newUUID)
@@ -466,11 +1304,31 @@ func (s *Server) handleCreateAlert(w http.ResponseWriter, r *http.Request) {
 		Enabled:         true,
 		Severity:        createRequest.Severity,
 		CooldownSeconds: createRequest.CooldownSeconds,
+		ForSeconds:      createRequest.ForSeconds,
+		MinChange:       createRequest.MinChange,
+		WarmupSeconds:   createRequest.WarmupSeconds,
+
+		EscalateAfter:         createRequest.EscalateAfter,
+		EscalateWindowSeconds: createRequest.EscalateWindowSeconds,
+
+		MinChangePercent: createRequest.MinChangePercent,
+
+		DenominatorMetric: createRequest.DenominatorMetric,
+
+		Tags:        createRequest.Tags,
+		Annotations: createRequest.Annotations,
+		Webhooks:    createRequest.Webhooks,
+
+		MessageTemplate: createRequest.MessageTemplate,
 	}
 
 	err = s.alertManager.AddRule(rule)
 	if err != nil {
+		if idempotencyKey != "" {
+			s.idempotency.abort(idempotencyKey)
+		}
 		s.writeError(w, http.StatusInternalServerError, err.Error())
+		return
 	}
 
 	// Return created rule as AlertRuleResponse with 201 status
@@ -484,22 +1342,57 @@ func (s *Server) handleCreateAlert(w http.ResponseWriter, r *http.Request) {
 		Severity:        rule.Severity,
 		Enabled:         rule.Enabled,
 		CooldownSeconds: rule.CooldownSeconds,
+		ForSeconds:      rule.ForSeconds,
+		MinChange:       rule.MinChange,
+		WarmupSeconds:   rule.WarmupSeconds,
+
+		EscalateAfter:         rule.EscalateAfter,
+		EscalateWindowSeconds: rule.EscalateWindowSeconds,
+
+		MinChangePercent: rule.MinChangePercent,
+
+		DenominatorMetric: rule.DenominatorMetric,
+
+		Tags:        rule.Tags,
+		Annotations: rule.Annotations,
+		Webhooks:    rule.Webhooks,
+
+		MessageTemplate: rule.MessageTemplate,
+	}
+	if idempotencyKey != "" {
+		s.idempotency.complete(idempotencyKey, response)
 	}
 	s.writeJSON(w, http.StatusCreated, response)
 }
 
-// handleDeleteAlert deletes an alert rule
-// DELETE /api/v1/alerts/{id}
-// Query parameter: id - the alert rule ID to delete
-// Returns: 204 No Content on success
+// handleDeleteAlert deletes an alert rule, or every rule in a group
+// DELETE /api/v1/alerts?id=<id> or DELETE /api/v1/alerts?tag=<tag>
+// Query parameters (exactly one):
+//   - id - the alert rule ID to delete
+//   - tag - delete all rules in this group
+//
+// Returns: 204 No Content on success for id; DeleteAlertsResponse for tag
 func (s *Server) handleDeleteAlert(w http.ResponseWriter, r *http.Request) {
 	logger.Debug("DELETE /api/v1/alerts", "component", "APIServer")
+	if !s.checkParams(w, r, "id", "tag") {
+		return
+	}
 	// Extract rule ID from URL query parameter
 	ruleID := r.URL.Query().Get("id")
+	tag := r.URL.Query().Get("tag")
+
+	if ruleID != "" && tag != "" {
+		s.writeError(w, http.StatusBadRequest, "specify either id or tag, not both")
+		return
+	}
+	if tag != "" {
+		s.handleDeleteAlertsByTag(w, tag)
+		return
+	}
 
 	// Validate ID is not empty
 	if ruleID == "" {
-		s.writeError(w, http.StatusBadRequest, "rule ID query parameter is required")
+		s.writeError(w, http.StatusBadRequest, "rule ID or tag query parameter is required")
 		return
 	}
 
@@ -513,3 +1406,71 @@ func (s *Server) handleDeleteAlert(w http.ResponseWriter, r *http.Request) {
 	// Return 204 No Content on success
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// handleDeleteAlertsByTag removes every rule in a group
+// Returns: DeleteAlertsResponse with the number of rules removed (0 if none matched)
+func (s *Server) handleDeleteAlertsByTag(w http.ResponseWriter, tag string) {
+	deleted := 0
+	for _, rule := range s.alertManager.GetRules() {
+		if !rule.HasTag(tag) {
+			continue
+		}
+		if err := s.alertManager.RemoveRule(rule.ID); err != nil {
+			// The rule may have been removed concurrently; keep deleting the rest
+			if errors.Is(err, alerting.ErrRuleNotFound) {
+				continue
+			}
+			s.writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		deleted++
+	}
+
+	s.writeJSON(w, http.StatusOK, DeleteAlertsResponse{Deleted: deleted})
+}
+
+// alertsConfigYAML is the config.yaml shape of the runtime alert rules
+type alertsConfigYAML struct {
+	Alerting struct {
+		Rules []config.AlertRule `yaml:"rules"`
+	} `yaml:"alerting"`
+}
+
+// handleAlertsConfig returns the runtime alert rules as a config.yaml snippet,
+// so rules added or removed through the API can be persisted to the config file
+// GET /api/v1/alerts/config
+// Returns: application/yaml with an alerting.rules block
+func (s *Server) handleAlertsConfig(w http.ResponseWriter, r *http.Request) {
+	if _, disabled := s.alertManager.(NoopAlertManager); disabled {
+		s.writeError(w, http.StatusServiceUnavailable, ErrAlertingDisabled.Error())
+		return
+	}
+	if r.Method != http.MethodGet {
+		s.writeError(w, http.StatusMethodNotAllowed, "only GET allowed")
+		return
+	}
+
+	var snippet alertsConfigYAML
+	rules := s.alertManager.GetRules()
+	snippet.Alerting.Rules = make([]config.AlertRule, 0, len(rules))
+	for _, rule := range rules {
+		snippet.Alerting.Rules = append(snippet.Alerting.Rules, rule.ConfigRule())
+	}
+
+	// Indent like config.example.yaml so the snippet can be pasted in as-is
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(snippet); err != nil {
+		s.writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to encode rules: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logger.Error("Failed to write alert rules config",
+			"component", "APIServer",
+			"error", err)
+	}
+}
@@ -2,15 +2,33 @@ package api
 
 import (
 	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/kaldun-tech/hedera-network-monitor/internal/alerting"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
 	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
 )
 
 // MockStorage is a mock implementation of the Storage interface for testing
@@ -20,6 +38,7 @@ type MockStorage struct {
 	getByLabelErr  error
 	storeMetricErr error
 	deleteOldErr   error
+	deleteErr      error
 	closeErr       error
 	statsErr       error
 }
@@ -104,6 +123,38 @@ func (m *MockStorage) GetMetrics(name string, limit int) ([]types.Metric, error)
 	return result, nil
 }
 
+func (m *MockStorage) GetMetricsAfter(name string, after int64, limit int) ([]types.Metric, error) {
+	if m.getMetricsErr != nil {
+		return nil, m.getMetricsErr
+	}
+
+	named := make([]types.Metric, 0)
+	for _, metric := range m.metrics {
+		if name == "" || metric.Name == name {
+			named = append(named, metric)
+		}
+	}
+	return storage.PageAfter(named, after, limit), nil
+}
+
+func (m *MockStorage) GetMetricsByPrefix(prefix string, limit int) ([]types.Metric, error) {
+	if m.getMetricsErr != nil {
+		return nil, m.getMetricsErr
+	}
+
+	result := make([]types.Metric, 0)
+	for _, metric := range m.metrics {
+		if !strings.HasPrefix(metric.Name, prefix) {
+			continue
+		}
+		result = append(result, metric)
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result, nil
+}
+
 func (m *MockStorage) GetMetricsByLabel(key, value string) ([]types.Metric, error) {
 	if m.getByLabelErr != nil {
 		return nil, m.getByLabelErr
@@ -118,10 +169,66 @@ func (m *MockStorage) GetMetricsByLabel(key, value string) ([]types.Metric, erro
 	return result, nil
 }
 
+func (m *MockStorage) GetMetricsMatching(name string, labels map[string]string, limit int) ([]types.Metric, error) {
+	if m.getByLabelErr != nil {
+		return nil, m.getByLabelErr
+	}
+
+	result := make([]types.Metric, 0)
+	for _, metric := range m.metrics {
+		if name != "" && metric.Name != name {
+			continue
+		}
+		matched := true
+		for key, value := range labels {
+			if metricValue, exists := metric.Labels[key]; !exists || metricValue != value {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		result = append(result, metric)
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result, nil
+}
+
 func (m *MockStorage) DeleteOldMetrics(beforeTimestamp int64) error {
 	return m.deleteOldErr
 }
 
+func (m *MockStorage) DeleteOldMetricsByName(cutoffs map[string]int64, defaultCutoff int64) (int, error) {
+	return 0, m.deleteOldErr
+}
+
+func (m *MockStorage) DeleteMetrics(name, labelKey, labelValue string, before int64) (int, error) {
+	if m.deleteErr != nil {
+		return 0, m.deleteErr
+	}
+
+	kept := make([]types.Metric, 0, len(m.metrics))
+	deleted := 0
+	for _, metric := range m.metrics {
+		nameMatch := name == "" || metric.Name == name
+		labelMatch := labelKey == ""
+		if metricValue, exists := metric.Labels[labelKey]; labelKey != "" && exists {
+			labelMatch = labelValue == "" || metricValue == labelValue
+		}
+		oldEnough := before == 0 || metric.Timestamp < before
+		if nameMatch && labelMatch && oldEnough {
+			deleted++
+			continue
+		}
+		kept = append(kept, metric)
+	}
+	m.metrics = kept
+	return deleted, nil
+}
+
 func (m *MockStorage) Close() error {
 	return m.closeErr
 }
@@ -299,6 +406,137 @@ func TestHandleMetrics_WithNameFilter(t *testing.T) {
 	}
 }
 
+// TestHandleMetrics_WithPrefixFilter tests retrieving metrics across names sharing a prefix
+func TestHandleMetrics_WithPrefixFilter(t *testing.T) {
+	store := &MockStorage{
+		metrics: []types.Metric{
+			{Name: "network_node_latency", Value: 120, Timestamp: 1234567890},
+			{Name: "network_node_unreachable", Value: 1, Timestamp: 1234567891},
+			{Name: "account_balance", Value: 1000000, Timestamp: 1234567892},
+			{Name: "network_nodes_available", Value: 28, Timestamp: 1234567893},
+		},
+	}
+	server := NewServer(8080, store, &MockAlertManager{})
+
+	req := httptest.NewRequest("GET", "/api/v1/metrics?prefix=network_node_", nil)
+	w := httptest.NewRecorder()
+
+	server.handleMetrics(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var response MetricsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Count != 2 {
+		t.Fatalf("expected 2 metrics, got %d", response.Count)
+	}
+	for _, metric := range response.Metrics {
+		if !strings.HasPrefix(metric.Name, "network_node_") {
+			t.Errorf("expected only network_node_* metrics, got '%s'", metric.Name)
+		}
+	}
+
+	// Exact name still matches only that metric
+	req = httptest.NewRequest("GET", "/api/v1/metrics?name=network_node_latency", nil)
+	w = httptest.NewRecorder()
+
+	server.handleMetrics(w, req)
+
+	response = MetricsResponse{}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Count != 1 || response.Metrics[0].Name != "network_node_latency" {
+		t.Errorf("expected only network_node_latency, got %+v", response.Metrics)
+	}
+}
+
+// TestHandleMetrics_NameAndPrefix tests that name and prefix are mutually exclusive
+func TestHandleMetrics_NameAndPrefix(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+
+	req := httptest.NewRequest("GET", "/api/v1/metrics?name=account_balance&prefix=account_", nil)
+	w := httptest.NewRecorder()
+
+	server.handleMetrics(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+// TestHandleMetrics_AfterCursor tests walking a metric through successive next_cursor pages
+// returns every sample once, in ascending time
+func TestHandleMetrics_AfterCursor(t *testing.T) {
+	var metrics []types.Metric
+	for i := 0; i < 25; i++ {
+		// Pairs of samples share a timestamp, stored newest first, so pages must sort and not split them
+		metrics = append(metrics, types.Metric{Name: "account_balance", Timestamp: int64(1000 - i/2), Value: float64(i)})
+	}
+	metrics = append(metrics, types.Metric{Name: "network_nodes_available", Timestamp: 999, Value: 7})
+	server := NewServer(8080, &MockStorage{metrics: metrics}, &MockAlertManager{})
+
+	seen := make(map[float64]int)
+	lastTimestamp := int64(0)
+	cursor := "0"
+	for pages := 0; ; pages++ {
+		if pages > len(metrics) {
+			t.Fatal("cursor walk did not terminate")
+		}
+		req := httptest.NewRequest("GET", "/api/v1/metrics?name=account_balance&limit=4&after="+cursor, nil)
+		w := httptest.NewRecorder()
+		server.handleMetrics(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var response MetricsResponse
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		for _, metric := range response.Metrics {
+			if metric.Timestamp < lastTimestamp {
+				t.Errorf("expected ascending timestamps, got %d after %d", metric.Timestamp, lastTimestamp)
+			}
+			lastTimestamp = metric.Timestamp
+			seen[metric.Value]++
+		}
+		if response.NextCursor == nil {
+			break
+		}
+		cursor = strconv.FormatInt(*response.NextCursor, 10)
+	}
+
+	if len(seen) != 25 {
+		t.Errorf("expected all 25 samples, got %d", len(seen))
+	}
+	for value, count := range seen {
+		if count != 1 {
+			t.Errorf("expected sample %v once, got %d times", value, count)
+		}
+	}
+}
+
+// TestHandleMetrics_AfterInvalid tests malformed cursors and cursors combined with prefix are rejected
+func TestHandleMetrics_AfterInvalid(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+
+	for _, query := range []string{"after=yesterday", "after=100&prefix=network_"} {
+		req := httptest.NewRequest("GET", "/api/v1/metrics?"+query, nil)
+		w := httptest.NewRecorder()
+		server.handleMetrics(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", query, w.Code)
+		}
+	}
+}
+
 // TestHandleMetrics_WithLimit tests retrieving metrics with limit
 func TestHandleMetrics_WithLimit(t *testing.T) {
 	store := &MockStorage{
@@ -325,6 +563,47 @@ func TestHandleMetrics_WithLimit(t *testing.T) {
 	}
 }
 
+// limitIgnoringStorage is a MockStorage whose GetMetrics returns every match regardless of limit
+type limitIgnoringStorage struct {
+	MockStorage
+}
+
+func (s *limitIgnoringStorage) GetMetrics(name string, limit int) ([]types.Metric, error) {
+	return s.MockStorage.GetMetrics(name, 0)
+}
+
+// TestHandleMetrics_StorageExceedsLimit tests the handler truncates a backend result that ignores limit
+func TestHandleMetrics_StorageExceedsLimit(t *testing.T) {
+	store := &limitIgnoringStorage{MockStorage{
+		metrics: []types.Metric{
+			{Name: "m1", Value: 1, Timestamp: 1, Labels: map[string]string{}},
+			{Name: "m2", Value: 2, Timestamp: 2, Labels: map[string]string{}},
+			{Name: "m3", Value: 3, Timestamp: 3, Labels: map[string]string{}},
+		},
+	}}
+	server := NewServer(8080, store, &MockAlertManager{})
+
+	req := httptest.NewRequest("GET", "/api/v1/metrics?limit=2", nil)
+	w := httptest.NewRecorder()
+
+	server.handleMetrics(w, req)
+
+	var response MetricsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(response.Metrics) != 2 {
+		t.Fatalf("expected 2 metrics returned, got %d", len(response.Metrics))
+	}
+	if response.Count != len(response.Metrics) {
+		t.Errorf("expected count to match the returned metrics, got %d for %d", response.Count, len(response.Metrics))
+	}
+	if response.Metrics[0].Name != "m1" || response.Metrics[1].Name != "m2" {
+		t.Errorf("expected the first 2 metrics to be kept, got %v", response.Metrics)
+	}
+}
+
 // TestHandleMetrics_InvalidLimit_Default tests invalid limit uses default
 func TestHandleMetrics_InvalidLimit_Default(t *testing.T) {
 	store := &MockStorage{
@@ -440,126 +719,330 @@ func TestHandleMetrics_MethodNotAllowed(t *testing.T) {
 	}
 }
 
-// TestHandleMetricsByLabel_Success tests retrieving metrics by label
-func TestHandleMetricsByLabel_Success(t *testing.T) {
+// TestHandleDeleteMetrics_ByName tests deleting metrics by name
+func TestHandleDeleteMetrics_ByName(t *testing.T) {
 	store := &MockStorage{
 		metrics: []types.Metric{
-			{
-				Name:      "account_balance",
-				Value:     1000000,
-				Timestamp: 1234567890,
-				Labels: map[string]string{
-					"account_id": "0.0.5000",
-					"label":      "Account 1",
-				},
-			},
-			{
-				Name:      "account_balance",
-				Value:     2000000,
-				Timestamp: 1234567891,
-				Labels: map[string]string{
-					"account_id": "0.0.5001",
-					"label":      "Account 2",
-				},
-			},
+			{Name: "account_balance", Labels: map[string]string{"account_id": "0.0.5000"}},
+			{Name: "account_balance", Labels: map[string]string{"account_id": "0.0.5001"}},
+			{Name: "network_nodes_available", Labels: map[string]string{"network": "testnet"}},
 		},
 	}
 	server := NewServer(8080, store, &MockAlertManager{})
 
-	req := httptest.NewRequest("GET", "/api/v1/metrics/account?key=account_id&value=0.0.5000", nil)
+	req := httptest.NewRequest("DELETE", "/api/v1/metrics?name=account_balance", nil)
 	w := httptest.NewRecorder()
 
-	server.handleMetricsByLabel(w, req)
+	server.handleMetrics(w, req)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("expected status 200, got %d", w.Code)
 	}
 
-	var response MetricsResponse
+	var response DeleteMetricsResponse
 	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
 
-	if response.Count != 1 {
-		t.Errorf("expected 1 metric, got %d", response.Count)
+	if response.Deleted != 2 {
+		t.Errorf("expected 2 metrics deleted, got %d", response.Deleted)
 	}
-
-	if response.Metrics[0].Labels["account_id"] != "0.0.5000" {
-		t.Errorf("expected account_id '0.0.5000', got '%s'", response.Metrics[0].Labels["account_id"])
+	if len(store.metrics) != 1 {
+		t.Errorf("expected 1 metric remaining, got %d", len(store.metrics))
 	}
 }
 
-// TestHandleMetricsByLabel_MissingKey tests endpoint with missing key parameter
-func TestHandleMetricsByLabel_MissingKey(t *testing.T) {
-	store := &MockStorage{}
-	server := NewServer(8080, store, &MockAlertManager{})
+// TestHandleDeleteMetrics_Before tests deleting old metrics of one name or of every name
+func TestHandleDeleteMetrics_Before(t *testing.T) {
+	newStore := func() *MockStorage {
+		return &MockStorage{
+			metrics: []types.Metric{
+				{Name: "account_balance", Timestamp: 100},
+				{Name: "account_balance", Timestamp: 300},
+				{Name: "network_nodes_available", Timestamp: 100},
+			},
+		}
+	}
 
-	req := httptest.NewRequest("GET", "/api/v1/metrics/account?value=0.0.5000", nil)
-	w := httptest.NewRecorder()
+	tests := []struct {
+		name      string
+		query     string
+		deleted   int
+		remaining int
+	}{
+		{"name scoped", "?before=200&name=account_balance", 1, 2},
+		{"all names", "?before=200", 2, 1},
+	}
 
-	server.handleMetricsByLabel(w, req)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newStore()
+			server := NewServer(8080, store, &MockAlertManager{})
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected status 400, got %d", w.Code)
-	}
+			req := httptest.NewRequest("DELETE", "/api/v1/metrics"+tt.query, nil)
+			w := httptest.NewRecorder()
+			server.handleMetrics(w, req)
 
-	var response ErrorResponse
-	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
-		t.Fatalf("failed to decode response: %v", err)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d", w.Code)
+			}
+			var response DeleteMetricsResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if response.Deleted != tt.deleted {
+				t.Errorf("expected %d metrics deleted, got %d", tt.deleted, response.Deleted)
+			}
+			if len(store.metrics) != tt.remaining {
+				t.Errorf("expected %d metrics remaining, got %d", tt.remaining, len(store.metrics))
+			}
+		})
 	}
 
-	if response.Error == "" {
-		t.Error("expected error message in response")
+	for _, before := range []string{"yesterday", "0", "-5"} {
+		server := NewServer(8080, newStore(), &MockAlertManager{})
+		req := httptest.NewRequest("DELETE", "/api/v1/metrics?before="+before, nil)
+		w := httptest.NewRecorder()
+		server.handleMetrics(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for before=%s, got %d", before, w.Code)
+		}
 	}
 }
 
-// TestHandleMetricsByLabel_MissingValue tests endpoint with missing value parameter
-func TestHandleMetricsByLabel_MissingValue(t *testing.T) {
+// TestHandleDeleteMetrics_LabelValueWithoutKey tests that label_value requires label_key
+func TestHandleDeleteMetrics_LabelValueWithoutKey(t *testing.T) {
 	store := &MockStorage{}
 	server := NewServer(8080, store, &MockAlertManager{})
 
-	req := httptest.NewRequest("GET", "/api/v1/metrics/account?key=account_id", nil)
+	req := httptest.NewRequest("DELETE", "/api/v1/metrics?label_value=0.0.5000", nil)
 	w := httptest.NewRecorder()
 
-	server.handleMetricsByLabel(w, req)
+	server.handleMetrics(w, req)
 
 	if w.Code != http.StatusBadRequest {
 		t.Errorf("expected status 400, got %d", w.Code)
 	}
 }
 
-// TestHandleMetricsByLabel_NoMatches tests endpoint when no metrics match
-func TestHandleMetricsByLabel_NoMatches(t *testing.T) {
+// TestHandleDeleteMetrics_StorageError tests handling of storage errors on delete
+func TestHandleDeleteMetrics_StorageError(t *testing.T) {
+	store := &MockStorage{deleteErr: fmt.Errorf("storage error")}
+	server := NewServer(8080, store, &MockAlertManager{})
+
+	req := httptest.NewRequest("DELETE", "/api/v1/metrics?name=account_balance", nil)
+	w := httptest.NewRecorder()
+
+	server.handleMetrics(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+}
+
+// TestHandleSearchMetrics_MultiLabel tests searching with several labels that must all match
+func TestHandleSearchMetrics_MultiLabel(t *testing.T) {
 	store := &MockStorage{
 		metrics: []types.Metric{
-			{
-				Name:      "account_balance",
-				Value:     1000000,
-				Timestamp: 1234567890,
-				Labels: map[string]string{
-					"account_id": "0.0.5000",
-				},
-			},
+			{Name: "account_balance", Labels: map[string]string{"account_id": "0.0.5000", "type": "balance"}},
+			{Name: "account_balance", Labels: map[string]string{"account_id": "0.0.5001", "type": "balance"}},
+			{Name: "account_balance", Labels: map[string]string{"account_id": "0.0.5000", "type": "transaction"}},
 		},
 	}
 	server := NewServer(8080, store, &MockAlertManager{})
 
-	req := httptest.NewRequest("GET", "/api/v1/metrics/account?key=account_id&value=0.0.9999", nil)
+	req := httptest.NewRequest("GET", "/api/v1/metrics/search?labels=account_id=0.0.5000,type=balance&name=account_balance", nil)
 	w := httptest.NewRecorder()
 
-	server.handleMetricsByLabel(w, req)
+	server.handleSearchMetrics(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
 
 	var response MetricsResponse
 	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
 
-	if response.Count != 0 {
-		t.Errorf("expected 0 metrics, got %d", response.Count)
+	if response.Count != 1 {
+		t.Errorf("expected 1 metric, got %d", response.Count)
 	}
+}
 
-	if response.Metrics == nil {
-		t.Error("expected metrics array to not be nil")
+// TestHandleSearchMetrics_InvalidSelector tests rejection of malformed label selectors
+func TestHandleSearchMetrics_InvalidSelector(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+
+	req := httptest.NewRequest("GET", "/api/v1/metrics/search?labels=account_id", nil)
+	w := httptest.NewRecorder()
+
+	server.handleSearchMetrics(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+// TestParseLabelSelector tests parsing of comma-separated key=value label selectors
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     map[string]string
+		wantErr  bool
+	}{
+		{"empty", "", map[string]string{}, false},
+		{"single with dots", "account_id=0.0.5000", map[string]string{"account_id": "0.0.5000"}, false},
+		{"multiple", "account_id=0.0.5000, type=balance", map[string]string{"account_id": "0.0.5000", "type": "balance"}, false},
+		{"value with equals", "query=a=b", map[string]string{"query": "a=b"}, false},
+		{"missing equals", "account_id", nil, true},
+		{"empty key", "=0.0.5000", nil, true},
+		{"empty pair", "account_id=0.0.5000,", nil, true},
+		{"conflicting values", "type=a,type=b", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLabelSelector(tt.selector)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLabelSelector(%q) error = %v, wantErr %v", tt.selector, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseLabelSelector(%q) = %v, want %v", tt.selector, got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("label %q = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+// TestHandleMetricsByLabel_Success tests retrieving metrics by label
+func TestHandleMetricsByLabel_Success(t *testing.T) {
+	store := &MockStorage{
+		metrics: []types.Metric{
+			{
+				Name:      "account_balance",
+				Value:     1000000,
+				Timestamp: 1234567890,
+				Labels: map[string]string{
+					"account_id": "0.0.5000",
+					"label":      "Account 1",
+				},
+			},
+			{
+				Name:      "account_balance",
+				Value:     2000000,
+				Timestamp: 1234567891,
+				Labels: map[string]string{
+					"account_id": "0.0.5001",
+					"label":      "Account 2",
+				},
+			},
+		},
+	}
+	server := NewServer(8080, store, &MockAlertManager{})
+
+	req := httptest.NewRequest("GET", "/api/v1/metrics/account?key=account_id&value=0.0.5000", nil)
+	w := httptest.NewRecorder()
+
+	server.handleMetricsByLabel(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+
+	var response MetricsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Count != 1 {
+		t.Errorf("expected 1 metric, got %d", response.Count)
+	}
+
+	if response.Metrics[0].Labels["account_id"] != "0.0.5000" {
+		t.Errorf("expected account_id '0.0.5000', got '%s'", response.Metrics[0].Labels["account_id"])
+	}
+}
+
+// TestHandleMetricsByLabel_MissingKey tests endpoint with missing key parameter
+func TestHandleMetricsByLabel_MissingKey(t *testing.T) {
+	store := &MockStorage{}
+	server := NewServer(8080, store, &MockAlertManager{})
+
+	req := httptest.NewRequest("GET", "/api/v1/metrics/account?value=0.0.5000", nil)
+	w := httptest.NewRecorder()
+
+	server.handleMetricsByLabel(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+
+	var response ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Error == "" {
+		t.Error("expected error message in response")
+	}
+}
+
+// TestHandleMetricsByLabel_MissingValue tests endpoint with missing value parameter
+func TestHandleMetricsByLabel_MissingValue(t *testing.T) {
+	store := &MockStorage{}
+	server := NewServer(8080, store, &MockAlertManager{})
+
+	req := httptest.NewRequest("GET", "/api/v1/metrics/account?key=account_id", nil)
+	w := httptest.NewRecorder()
+
+	server.handleMetricsByLabel(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+// TestHandleMetricsByLabel_NoMatches tests endpoint when no metrics match
+func TestHandleMetricsByLabel_NoMatches(t *testing.T) {
+	store := &MockStorage{
+		metrics: []types.Metric{
+			{
+				Name:      "account_balance",
+				Value:     1000000,
+				Timestamp: 1234567890,
+				Labels: map[string]string{
+					"account_id": "0.0.5000",
+				},
+			},
+		},
+	}
+	server := NewServer(8080, store, &MockAlertManager{})
+
+	req := httptest.NewRequest("GET", "/api/v1/metrics/account?key=account_id&value=0.0.9999", nil)
+	w := httptest.NewRecorder()
+
+	server.handleMetricsByLabel(w, req)
+
+	var response MetricsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Count != 0 {
+		t.Errorf("expected 0 metrics, got %d", response.Count)
+	}
+
+	if response.Metrics == nil {
+		t.Error("expected metrics array to not be nil")
 	}
 }
 
@@ -595,6 +1078,292 @@ func TestHandleMetricsByLabel_MethodNotAllowed(t *testing.T) {
 	}
 }
 
+// TestHandleMetricsSummary_Success tests summarizing a metric into buckets
+func TestHandleMetricsSummary_Success(t *testing.T) {
+	store := &MockStorage{
+		metrics: []types.Metric{
+			{Name: "account_balance", Timestamp: 1000, Value: 10},
+			{Name: "account_balance", Timestamp: 1001, Value: 20},
+			{Name: "account_balance", Timestamp: 1002, Value: 30},
+			{Name: "account_balance", Timestamp: 1003, Value: 40},
+			{Name: "network_nodes_available", Timestamp: 1000, Value: 28},
+		},
+	}
+	server := NewServer(8080, store, &MockAlertManager{})
+
+	req := httptest.NewRequest("GET", "/api/v1/metrics/summary?name=account_balance&buckets=2", nil)
+	w := httptest.NewRecorder()
+
+	server.handleMetricsSummary(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var response SummaryResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Samples != 4 {
+		t.Errorf("expected 4 samples, got %d", response.Samples)
+	}
+	if len(response.Buckets) != 2 {
+		t.Fatalf("expected 2 buckets, got %d", len(response.Buckets))
+	}
+	if response.Buckets[0].Avg == nil || *response.Buckets[0].Avg != 15 {
+		t.Errorf("expected first bucket avg 15, got %v", response.Buckets[0].Avg)
+	}
+}
+
+// TestHandleMetricsSummary_InvalidParams tests rejection of missing name and bad bucket counts
+func TestHandleMetricsSummary_InvalidParams(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+
+	for _, query := range []string{
+		"?buckets=10",
+		"?name=account_balance&buckets=0",
+		"?name=account_balance&buckets=abc",
+		"?name=account_balance&buckets=100000",
+	} {
+		req := httptest.NewRequest("GET", "/api/v1/metrics/summary"+query, nil)
+		w := httptest.NewRecorder()
+
+		server.handleMetricsSummary(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", query, w.Code)
+		}
+	}
+}
+
+// TestHandleLatestMetrics_NewestPerSeries tests that only the newest sample of each series is returned
+func TestHandleLatestMetrics_NewestPerSeries(t *testing.T) {
+	store := &MockStorage{
+		metrics: []types.Metric{
+			{Name: "account_balance", Timestamp: 1000, Value: 10, Labels: map[string]string{"account_id": "0.0.5000"}},
+			{Name: "account_balance", Timestamp: 1002, Value: 30, Labels: map[string]string{"account_id": "0.0.5000"}},
+			{Name: "account_balance", Timestamp: 1001, Value: 20, Labels: map[string]string{"account_id": "0.0.5000"}},
+			{Name: "account_balance", Timestamp: 1001, Value: 200, Labels: map[string]string{"account_id": "0.0.5001"}},
+			{Name: "account_balance", Timestamp: 999, Value: 100, Labels: map[string]string{"account_id": "0.0.5001"}},
+			{Name: "network_nodes_available", Timestamp: 1003, Value: 28},
+		},
+	}
+	server := NewServer(8080, store, &MockAlertManager{})
+
+	req := httptest.NewRequest("GET", "/api/v1/metrics/latest?name=account_balance", nil)
+	w := httptest.NewRecorder()
+
+	server.handleLatestMetrics(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var response MetricsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Count != 2 {
+		t.Fatalf("expected 2 series, got %d", response.Count)
+	}
+
+	want := map[string]float64{"0.0.5000": 30, "0.0.5001": 200}
+	for _, metric := range response.Metrics {
+		account := metric.Labels["account_id"]
+		if metric.Value != want[account] {
+			t.Errorf("expected newest value %v for %s, got %v", want[account], account, metric.Value)
+		}
+	}
+}
+
+// TestHandleLatestMetrics_Errors tests method and storage error handling
+func TestHandleLatestMetrics_Errors(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+
+	req := httptest.NewRequest("POST", "/api/v1/metrics/latest", nil)
+	w := httptest.NewRecorder()
+	server.handleLatestMetrics(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", w.Code)
+	}
+
+	server = NewServer(8080, &MockStorage{getMetricsErr: fmt.Errorf("storage error")}, &MockAlertManager{})
+	req = httptest.NewRequest("GET", "/api/v1/metrics/latest", nil)
+	w = httptest.NewRecorder()
+	server.handleLatestMetrics(w, req)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+}
+
+// TestHandleMetricsDelta tests the endpoint filters by name and returns per-series deltas
+func TestHandleMetricsDelta(t *testing.T) {
+	store := &MockStorage{
+		metrics: []types.Metric{
+			{Name: "account_transactions", Timestamp: 1000, Value: 10},
+			{Name: "account_transactions", Timestamp: 1060, Value: 16},
+			{Name: "account_balance", Timestamp: 1000, Value: 500},
+			{Name: "account_balance", Timestamp: 1060, Value: 400},
+		},
+	}
+	server := NewServer(8080, store, &MockAlertManager{})
+
+	req := httptest.NewRequest("GET", "/api/v1/metrics/delta?name=account_transactions", nil)
+	w := httptest.NewRecorder()
+	server.handleMetricsDelta(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var response DeltaResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Count != 1 || len(response.Deltas) != 1 {
+		t.Fatalf("expected 1 series, got %d", response.Count)
+	}
+	if d := response.Deltas[0]; d.Name != "account_transactions" || d.Delta != 6 || d.Seconds != 60 {
+		t.Errorf("expected account_transactions delta 6 over 60s, got %+v", d)
+	}
+
+	req = httptest.NewRequest("POST", "/api/v1/metrics/delta", nil)
+	w = httptest.NewRecorder()
+	server.handleMetricsDelta(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", w.Code)
+	}
+}
+
+// TestHandleMetricsGroupBy tests the grouped aggregates and parameter validation
+func TestHandleMetricsGroupBy(t *testing.T) {
+	server := NewServer(8080, &MockStorage{metrics: teamBalances()}, &MockAlertManager{})
+
+	req := httptest.NewRequest("GET", "/api/v1/metrics/groupby?name=account_balance&label=team&fn=sum", nil)
+	w := httptest.NewRecorder()
+	server.handleMetricsGroupBy(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var response GroupByResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Count != 2 || response.Fn != "sum" || response.Label != "team" {
+		t.Fatalf("expected 2 sum groups by team, got %+v", response)
+	}
+	if response.Groups[0].Group != "ops" || response.Groups[0].Value != 50 ||
+		response.Groups[1].Group != "treasury" || response.Groups[1].Value != 400 {
+		t.Errorf("expected ops=50 and treasury=400, got %+v", response.Groups)
+	}
+
+	for _, query := range []string{"label=team", "name=account_balance", "name=account_balance&label=team&fn=median"} {
+		req = httptest.NewRequest("GET", "/api/v1/metrics/groupby?"+query, nil)
+		w = httptest.NewRecorder()
+		server.handleMetricsGroupBy(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %q, got %d", query, w.Code)
+		}
+	}
+}
+
+// mockCollector is a mock collector that records on-demand triggers
+type mockCollector struct {
+	name     string
+	triggers int
+}
+
+func (m *mockCollector) Name() string {
+	return m.name
+}
+
+func (m *mockCollector) Trigger() {
+	m.triggers++
+}
+
+// TestHandleCollect_All tests triggering all registered collectors
+func TestHandleCollect_All(t *testing.T) {
+	account := &mockCollector{name: "AccountCollector"}
+	network := &mockCollector{name: "NetworkCollector"}
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+	server.AddCollector(account)
+	server.AddCollector(network)
+
+	req := httptest.NewRequest("POST", "/api/v1/collect", nil)
+	w := httptest.NewRecorder()
+
+	server.handleCollect(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("expected status 202, got %d", w.Code)
+	}
+
+	var response CollectResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Triggered) != 2 {
+		t.Errorf("expected 2 collectors triggered, got %v", response.Triggered)
+	}
+	if account.triggers != 1 || network.triggers != 1 {
+		t.Errorf("expected each collector triggered once, got account=%d network=%d",
+			account.triggers, network.triggers)
+	}
+}
+
+// TestHandleCollect_ByName tests triggering a single collector by short name
+func TestHandleCollect_ByName(t *testing.T) {
+	account := &mockCollector{name: "AccountCollector"}
+	network := &mockCollector{name: "NetworkCollector"}
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+	server.AddCollector(account)
+	server.AddCollector(network)
+
+	req := httptest.NewRequest("POST", "/api/v1/collect?collector=account", nil)
+	w := httptest.NewRecorder()
+
+	server.handleCollect(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("expected status 202, got %d", w.Code)
+	}
+	if account.triggers != 1 {
+		t.Errorf("expected account collector triggered once, got %d", account.triggers)
+	}
+	if network.triggers != 0 {
+		t.Errorf("expected network collector not triggered, got %d", network.triggers)
+	}
+}
+
+// TestHandleCollect_UnknownCollector tests that an unknown collector returns 404
+func TestHandleCollect_UnknownCollector(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+	server.AddCollector(&mockCollector{name: "AccountCollector"})
+
+	req := httptest.NewRequest("POST", "/api/v1/collect?collector=mirror", nil)
+	w := httptest.NewRecorder()
+
+	server.handleCollect(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
+
+// TestHandleCollect_MethodNotAllowed tests that only POST is allowed
+func TestHandleCollect_MethodNotAllowed(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+
+	req := httptest.NewRequest("GET", "/api/v1/collect", nil)
+	w := httptest.NewRecorder()
+
+	server.handleCollect(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", w.Code)
+	}
+}
+
 // TestHandleStorageStats_Success tests getting storage stats
 func TestHandleStorageStats_Success(t *testing.T) {
 	store := &MockStorage{
@@ -694,6 +1463,56 @@ func TestHandleStorageStats_NotSupported(t *testing.T) {
 	}
 }
 
+// TestHandleStorageInfo tests describing a backend that implements Info
+func TestHandleStorageInfo(t *testing.T) {
+	t.Setenv("COLLECTOR_MEMORY_MAX_SIZE", "")
+	server := NewServer(8080, storage.NewMemoryStorage(), &MockAlertManager{})
+
+	req := httptest.NewRequest("GET", "/api/v1/storage/info", nil)
+	w := httptest.NewRecorder()
+	server.handleStorageInfo(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var response StorageInfoResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	expected := StorageInfoResponse{Backend: "memory", RangeQueries: true, Capacity: storage.DefaultMaxSize}
+	if response != expected {
+		t.Errorf("expected %+v, got %+v", expected, response)
+	}
+}
+
+// TestHandleStorageInfo_NotSupported tests the default for backends without Info
+func TestHandleStorageInfo_NotSupported(t *testing.T) {
+	server := NewServer(8080, &simpleStorage{}, &MockAlertManager{})
+
+	req := httptest.NewRequest("GET", "/api/v1/storage/info", nil)
+	w := httptest.NewRecorder()
+	server.handleStorageInfo(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var response StorageInfoResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	expected := StorageInfoResponse{Backend: "unknown"}
+	if response != expected {
+		t.Errorf("expected %+v, got %+v", expected, response)
+	}
+
+	req = httptest.NewRequest("POST", "/api/v1/storage/info", nil)
+	w = httptest.NewRecorder()
+	server.handleStorageInfo(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", w.Code)
+	}
+}
+
 // TestHandleStorageStats_MethodNotAllowed tests stats endpoint with wrong method
 func TestHandleStorageStats_MethodNotAllowed(t *testing.T) {
 	store := &MockStorage{}
@@ -784,6 +1603,39 @@ func TestHandleMetrics_NegativeLimit(t *testing.T) {
 	}
 }
 
+// malformedStatsStorage reports stats with unexpected value types
+type malformedStatsStorage struct {
+	simpleStorage
+	stats map[string]interface{}
+}
+
+func (s *malformedStatsStorage) Stats() (map[string]interface{}, error) {
+	return s.stats, nil
+}
+
+// TestHandleStorageStats_MalformedStats tests unexpected stat types return 500 instead of panicking
+func TestHandleStorageStats_MalformedStats(t *testing.T) {
+	malformed := []map[string]interface{}{
+		{"metric_count": int64(5), "max_size": 10000, "utilization": "0.05%"},
+		{"metric_count": 5, "max_size": "10000", "utilization": "0.05%"},
+		{"metric_count": 5, "max_size": 10000, "utilization": 0.05},
+		{},
+		nil,
+	}
+
+	for i, stats := range malformed {
+		server := NewServer(8080, &malformedStatsStorage{stats: stats}, &MockAlertManager{})
+		req := httptest.NewRequest("GET", "/api/v1/storage/stats", nil)
+		w := httptest.NewRecorder()
+
+		server.handleStorageStats(w, req)
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("case %d: expected status 500, got %d", i, w.Code)
+		}
+	}
+}
+
 // simpleStorage is a minimal storage implementation without Stats() for testing
 type simpleStorage struct{}
 
@@ -795,14 +1647,34 @@ func (s *simpleStorage) GetMetrics(name string, limit int) ([]types.Metric, erro
 	return []types.Metric{}, nil
 }
 
+func (s *simpleStorage) GetMetricsAfter(name string, after int64, limit int) ([]types.Metric, error) {
+	return []types.Metric{}, nil
+}
+
+func (s *simpleStorage) GetMetricsByPrefix(prefix string, limit int) ([]types.Metric, error) {
+	return []types.Metric{}, nil
+}
+
 func (s *simpleStorage) GetMetricsByLabel(key, value string) ([]types.Metric, error) {
 	return []types.Metric{}, nil
 }
 
+func (s *simpleStorage) GetMetricsMatching(name string, labels map[string]string, limit int) ([]types.Metric, error) {
+	return []types.Metric{}, nil
+}
+
 func (s *simpleStorage) DeleteOldMetrics(beforeTimestamp int64) error {
 	return nil
 }
 
+func (s *simpleStorage) DeleteOldMetricsByName(cutoffs map[string]int64, defaultCutoff int64) (int, error) {
+	return 0, nil
+}
+
+func (s *simpleStorage) DeleteMetrics(name, labelKey, labelValue string, before int64) (int, error) {
+	return 0, nil
+}
+
 func (s *simpleStorage) Close() error {
 	return nil
 }
@@ -990,6 +1862,50 @@ func TestHandleListAlerts_Success(t *testing.T) {
 	}
 }
 
+// statsAlertManager is a MockAlertManager that tracks rule evaluation stats
+type statsAlertManager struct {
+	MockAlertManager
+	stats map[string]alerting.RuleStats
+}
+
+func (m *statsAlertManager) RuleStats() map[string]alerting.RuleStats {
+	return m.stats
+}
+
+// TestHandleListAlerts_RuleStats tests that evaluation stats are included in the listing
+func TestHandleListAlerts_RuleStats(t *testing.T) {
+	evaluated := time.Unix(1700000060, 0)
+	fired := time.Unix(1700000000, 0)
+	alertMgr := &statsAlertManager{
+		MockAlertManager: MockAlertManager{rules: []alerting.AlertRule{
+			{ID: "evaluated", Name: "Evaluated", MetricName: "account_balance", Condition: "<", Severity: "warning"},
+			{ID: "idle", Name: "Idle", MetricName: "never_sent", Condition: ">", Severity: "info"},
+		}},
+		stats: map[string]alerting.RuleStats{
+			"evaluated": {Evaluations: 5, LastEvaluated: evaluated, LastFired: fired},
+		},
+	}
+	server := NewServer(8080, &MockStorage{}, alertMgr)
+
+	w := httptest.NewRecorder()
+	server.handleAlerts(w, httptest.NewRequest("GET", "/api/v1/alerts", nil))
+	var response AlertListResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Alerts) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(response.Alerts))
+	}
+	got := response.Alerts[0]
+	if got.Evaluations != 5 || got.LastEvaluated != evaluated.Unix() || got.LastFired != fired.Unix() {
+		t.Errorf("expected 5 evaluations, last evaluated %d and fired %d, got %+v", evaluated.Unix(), fired.Unix(), got)
+	}
+	idle := response.Alerts[1]
+	if idle.Evaluations != 0 || idle.LastEvaluated != 0 || idle.LastFired != 0 {
+		t.Errorf("expected no stats for a rule never evaluated, got %+v", idle)
+	}
+}
+
 // TestHandleListAlerts_Empty tests listing when no rules exist
 func TestHandleListAlerts_Empty(t *testing.T) {
 	// Create MockAlertManager with no rules
@@ -1222,149 +2138,605 @@ func TestHandleCreateAlert_InvalidSeverity(t *testing.T) {
 		Severity:        "FAKE NEWS",
 		CooldownSeconds: 300,
 	}
-	jsonBody, _ := json.Marshal(request)
+	jsonBody, _ := json.Marshal(request)
+
+	// Make POST request to /api/v1/alerts
+	req := httptest.NewRequest("POST", "/api/v1/alerts", bytes.NewReader(jsonBody))
+	w := httptest.NewRecorder()
+	server.handleAlerts(w, req)
+
+	// Verify status 400 (Bad Request)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+
+	// Verify error message mentions severity
+	var response ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Error == "" {
+		t.Error("expected error message in response")
+	}
+	if !strings.Contains(response.Error, "severity") {
+		t.Errorf("expected error to mention 'severity', got: %s", response.Error)
+	}
+
+	// Verify AddRule was NOT called
+	if alertMgr.addRuleCalls != 0 {
+		t.Errorf("expected AddRule not to be called, but it was called %d times", alertMgr.addRuleCalls)
+	}
+}
+
+// TestHandleCreateAlert_InvalidJSON tests creating alert with malformed JSON
+func TestHandleCreateAlert_InvalidJSON(t *testing.T) {
+	alertMgr := &MockAlertManager{}
+	store := &MockStorage{}
+	server := NewServer(8080, store, alertMgr)
+
+	req := httptest.NewRequest("POST", "/api/v1/alerts", bytes.NewReader([]byte("not json")))
+	w := httptest.NewRecorder()
+	server.handleAlerts(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+
+	var response ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Error == "" {
+		t.Error("expected error message in response")
+	}
+
+	if alertMgr.addRuleCalls != 0 {
+		t.Errorf("expected AddRule not to be called, but it was called %d times", alertMgr.addRuleCalls)
+	}
+}
+
+// TestHandleCreateAlert_ManagerError tests when AddRule fails
+func TestHandleCreateAlert_ManagerError(t *testing.T) {
+	// Create MockAlertManager with addRuleErr set to an error
+	alertMgr := &MockAlertManager{
+		addRuleErr: fmt.Errorf("database error"),
+	}
+	store := &MockStorage{}
+	server := NewServer(8080, store, alertMgr)
+
+	// Create valid CreateAlertRequest
+	req := CreateAlertRequest{
+		Name:            "Test Alert",
+		Description:     "Test Description",
+		MetricName:      "account_balance",
+		Condition:       "<",
+		Threshold:       1000000,
+		Severity:        "warning",
+		CooldownSeconds: 300,
+	}
+	jsonBody, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	// Make POST request to /api/v1/alerts
+	post := httptest.NewRequest("POST", "/api/v1/alerts", bytes.NewReader(jsonBody))
+	w := httptest.NewRecorder()
+	server.handleAlerts(w, post)
+
+	// Verify status 500 (Internal Server Error)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+
+	// Verify error message in response
+	var response ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Error == "" {
+		t.Error("expected error message in response")
+	}
+
+	if alertMgr.addRuleCalls != 1 {
+		t.Errorf("expected AddRule to be called once, but it was called %d times", alertMgr.addRuleCalls)
+	}
+}
+
+// TestHandleCreateAlert_NegativeCooldown tests creating alert with negative cooldown
+func TestHandleCreateAlert_NegativeCooldown(t *testing.T) {
+	alertMgr := &MockAlertManager{}
+	store := &MockStorage{}
+	server := NewServer(8080, store, alertMgr)
+
+	request := &CreateAlertRequest{
+		Name:            "Test Alert",
+		MetricName:      "account_balance",
+		Condition:       "<",
+		Threshold:       1000000,
+		Severity:        "warning",
+		CooldownSeconds: -10,
+	}
+	jsonBody, _ := json.Marshal(request)
+
+	req := httptest.NewRequest("POST", "/api/v1/alerts", bytes.NewReader(jsonBody))
+	w := httptest.NewRecorder()
+	server.handleAlerts(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+
+	var response ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Error == "" {
+		t.Error("expected error message in response")
+	}
+
+	if alertMgr.addRuleCalls != 0 {
+		t.Errorf("expected AddRule not to be called, but it was called %d times", alertMgr.addRuleCalls)
+	}
+}
+
+// TestHandleCreateAlert_StrictDecoding tests that malformed-but-parseable payloads are rejected with the offending field named
+func TestHandleCreateAlert_StrictDecoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			body:    `{"name":"Test","metric_name":"account_balance","condition":"<","threshold":1,"severity":"warning","treshold":5}`,
+			wantErr: `unknown field "treshold"`,
+		},
+		{
+			name:    "threshold as string",
+			body:    `{"name":"Test","metric_name":"account_balance","condition":"<","threshold":"1000","severity":"warning"}`,
+			wantErr: `field "threshold" must be of type number`,
+		},
+		{
+			name:    "fractional cooldown",
+			body:    `{"name":"Test","metric_name":"account_balance","condition":"<","threshold":1,"severity":"warning","cooldown_seconds":1.5}`,
+			wantErr: `field "cooldown_seconds" must be of type integer`,
+		},
+		{
+			name:    "name as number",
+			body:    `{"name":42,"metric_name":"account_balance","condition":"<","threshold":1,"severity":"warning"}`,
+			wantErr: `field "name" must be of type string`,
+		},
+		{
+			name:    "array body",
+			body:    `[{"name":"Test"}]`,
+			wantErr: "request body must be of type object",
+		},
+		{
+			name:    "trailing data",
+			body:    `{"name":"Test","metric_name":"account_balance","condition":"<","threshold":1,"severity":"warning"} {}`,
+			wantErr: "single JSON object",
+		},
+		{
+			name:    "empty body",
+			body:    ``,
+			wantErr: "request body is empty",
+		},
+		{
+			name:    "negative for_seconds",
+			body:    `{"name":"Test","metric_name":"account_balance","condition":"<","threshold":1,"severity":"warning","for_seconds":-1}`,
+			wantErr: `field "for_seconds" cannot be negative`,
+		},
+		{
+			name:    "negative warmup_seconds",
+			body:    `{"name":"Test","metric_name":"account_balance","condition":"<","threshold":1,"severity":"warning","warmup_seconds":-1}`,
+			wantErr: `field "warmup_seconds" cannot be negative`,
+		},
+		{
+			name:    "denominator same as metric",
+			body:    `{"name":"Test","metric_name":"account_balance","condition":"<","threshold":1,"severity":"warning","denominator_metric":"account_balance"}`,
+			wantErr: `field "denominator_metric" must differ from "metric_name"`,
+		},
+		{
+			name:    "invalid severity",
+			body:    `{"name":"Test","metric_name":"account_balance","condition":"<","threshold":1,"severity":"urgent"}`,
+			wantErr: `field "severity" has invalid value "urgent"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alertMgr := &MockAlertManager{}
+			server := NewServer(8080, &MockStorage{}, alertMgr)
+
+			req := httptest.NewRequest("POST", "/api/v1/alerts", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			server.handleAlerts(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d", w.Code)
+			}
+
+			var response ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if !strings.Contains(response.Error, tt.wantErr) {
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, response.Error)
+			}
+			if alertMgr.addRuleCalls != 0 {
+				t.Errorf("expected AddRule not to be called, but it was called %d times", alertMgr.addRuleCalls)
+			}
+		})
+	}
+}
+
+// postAlertWithKey sends a create-alert request with an Idempotency-Key header
+func postAlertWithKey(server *Server, key string, body []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/api/v1/alerts", bytes.NewReader(body))
+	req.Header.Set("Idempotency-Key", key)
+	w := httptest.NewRecorder()
+	server.handleAlerts(w, req)
+	return w
+}
+
+// TestHandleCreateAlert_IdempotencyKey tests that a repeated key creates one rule and replays the response
+func TestHandleCreateAlert_IdempotencyKey(t *testing.T) {
+	alertMgr := &MockAlertManager{}
+	server := NewServer(8080, &MockStorage{}, alertMgr)
+
+	body, _ := json.Marshal(&CreateAlertRequest{
+		Name:       "Low Balance Alert",
+		MetricName: "account_balance",
+		Condition:  "<",
+		Threshold:  1000000,
+		Severity:   "warning",
+	})
+
+	first := postAlertWithKey(server, "retry-123", body)
+	second := postAlertWithKey(server, "retry-123", body)
+
+	if first.Code != http.StatusCreated || second.Code != http.StatusCreated {
+		t.Fatalf("expected both responses 201, got %d and %d", first.Code, second.Code)
+	}
+	if first.Body.String() != second.Body.String() {
+		t.Errorf("expected identical responses, got:\n%s\n%s", first.Body.String(), second.Body.String())
+	}
+	if second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Error("expected replayed response to set Idempotent-Replayed header")
+	}
+	if alertMgr.addRuleCalls != 1 {
+		t.Errorf("expected AddRule to be called once, got %d calls", alertMgr.addRuleCalls)
+	}
+
+	// A different key creates a new rule
+	third := postAlertWithKey(server, "retry-456", body)
+	if third.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", third.Code)
+	}
+	if alertMgr.addRuleCalls != 2 {
+		t.Errorf("expected AddRule to be called twice, got %d calls", alertMgr.addRuleCalls)
+	}
+}
+
+// TestHandleCreateAlert_IdempotencyKeyMismatch tests reusing a key with a different body
+func TestHandleCreateAlert_IdempotencyKeyMismatch(t *testing.T) {
+	alertMgr := &MockAlertManager{}
+	server := NewServer(8080, &MockStorage{}, alertMgr)
+
+	request := &CreateAlertRequest{
+		Name:       "Low Balance Alert",
+		MetricName: "account_balance",
+		Condition:  "<",
+		Threshold:  1000000,
+		Severity:   "warning",
+	}
+	body, _ := json.Marshal(request)
+	postAlertWithKey(server, "retry-123", body)
+
+	request.Threshold = 5
+	changed, _ := json.Marshal(request)
+	w := postAlertWithKey(server, "retry-123", changed)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422, got %d", w.Code)
+	}
+	if alertMgr.addRuleCalls != 1 {
+		t.Errorf("expected AddRule to be called once, got %d calls", alertMgr.addRuleCalls)
+	}
+}
+
+// TestHandleCreateAlert_IdempotencyKeyAfterFailure tests that a failed create can be retried with the same key
+func TestHandleCreateAlert_IdempotencyKeyAfterFailure(t *testing.T) {
+	alertMgr := &MockAlertManager{addRuleErr: fmt.Errorf("database error")}
+	server := NewServer(8080, &MockStorage{}, alertMgr)
+
+	body, _ := json.Marshal(&CreateAlertRequest{
+		Name:       "Low Balance Alert",
+		MetricName: "account_balance",
+		Condition:  "<",
+		Threshold:  1000000,
+		Severity:   "warning",
+	})
+
+	if w := postAlertWithKey(server, "retry-123", body); w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d", w.Code)
+	}
+
+	alertMgr.addRuleErr = nil
+	if w := postAlertWithKey(server, "retry-123", body); w.Code != http.StatusCreated {
+		t.Errorf("expected retry to create the rule with status 201, got %d", w.Code)
+	}
+	if alertMgr.addRuleCalls != 2 {
+		t.Errorf("expected AddRule to be called twice, got %d calls", alertMgr.addRuleCalls)
+	}
+}
+
+// TestIdempotencyCache_Expiry tests that completed keys are forgotten after the TTL
+func TestIdempotencyCache_Expiry(t *testing.T) {
+	cache := newIdempotencyCache(time.Minute)
+	clock := time.Unix(1700000000, 0)
+	cache.now = func() time.Time { return clock }
+
+	request := CreateAlertRequest{Name: "Test"}
+	if _, state := cache.begin("key", request); state != idempotencyNew {
+		t.Fatalf("expected new key, got state %d", state)
+	}
+	if _, state := cache.begin("key", request); state != idempotencyPending {
+		t.Errorf("expected pending key before completion, got state %d", state)
+	}
+
+	cache.complete("key", AlertRuleResponse{ID: "rule-1"})
+	clock = clock.Add(30 * time.Second)
+	if resp, state := cache.begin("key", request); state != idempotencyReplay || resp.ID != "rule-1" {
+		t.Errorf("expected replay of rule-1 within TTL, got state %d id %q", state, resp.ID)
+	}
+
+	clock = clock.Add(time.Minute)
+	if _, state := cache.begin("key", request); state != idempotencyNew {
+		t.Errorf("expected key to be forgotten after TTL, got state %d", state)
+	}
+}
+
+// newTaggedAlertManager returns a mock manager with rules in "balances" and "network" groups
+func newTaggedAlertManager() *MockAlertManager {
+	return &MockAlertManager{
+		rules: []alerting.AlertRule{
+			{ID: "low-balance", Name: "Low Balance", MetricName: "account_balance", Tags: []string{"balances"}},
+			{ID: "balance-drop", Name: "Balance Drop", MetricName: "account_balance", Tags: []string{"balances", "critical-path"}},
+			{ID: "nodes-down", Name: "Nodes Down", MetricName: "network_nodes_available", Tags: []string{"network"}},
+			{ID: "untagged", Name: "Untagged", MetricName: "account_balance"},
+		},
+	}
+}
+
+// TestHandleListAlerts_FilterByTag tests listing only rules in a group
+func TestHandleListAlerts_FilterByTag(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, newTaggedAlertManager())
+
+	req := httptest.NewRequest("GET", "/api/v1/alerts?tag=balances", nil)
+	w := httptest.NewRecorder()
+	server.handleAlerts(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var response AlertListResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Count != 2 || len(response.Alerts) != 2 {
+		t.Fatalf("expected 2 rules tagged balances, got %d", response.Count)
+	}
+	for _, rule := range response.Alerts {
+		if rule.ID != "low-balance" && rule.ID != "balance-drop" {
+			t.Errorf("unexpected rule %q in balances group", rule.ID)
+		}
+		if len(rule.Tags) == 0 || rule.Tags[0] != "balances" {
+			t.Errorf("expected tags in response, got %v", rule.Tags)
+		}
+	}
 
-	// Make POST request to /api/v1/alerts
-	req := httptest.NewRequest("POST", "/api/v1/alerts", bytes.NewReader(jsonBody))
+	// Unknown tags return an empty list rather than an error
+	req = httptest.NewRequest("GET", "/api/v1/alerts?tag=missing", nil)
+	w = httptest.NewRecorder()
+	server.handleAlerts(w, req)
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Count != 0 || response.Alerts == nil {
+		t.Errorf("expected empty non-null list for unknown tag, got %+v", response)
+	}
+}
+
+// TestHandleDeleteAlert_ByTag tests deleting every rule in a group
+func TestHandleDeleteAlert_ByTag(t *testing.T) {
+	alertMgr := newTaggedAlertManager()
+	server := NewServer(8080, &MockStorage{}, alertMgr)
+
+	req := httptest.NewRequest("DELETE", "/api/v1/alerts?tag=balances", nil)
 	w := httptest.NewRecorder()
 	server.handleAlerts(w, req)
 
-	// Verify status 400 (Bad Request)
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected status 400, got %d", w.Code)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
 	}
 
-	// Verify error message mentions severity
-	var response ErrorResponse
+	var response DeleteAlertsResponse
 	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
+	if response.Deleted != 2 {
+		t.Errorf("expected 2 rules deleted, got %d", response.Deleted)
+	}
 
-	if response.Error == "" {
-		t.Error("expected error message in response")
+	remaining := alertMgr.GetRules()
+	if len(remaining) != 2 {
+		t.Fatalf("expected 2 rules remaining, got %d", len(remaining))
 	}
-	if !strings.Contains(response.Error, "severity") {
-		t.Errorf("expected error to mention 'severity', got: %s", response.Error)
+	for _, rule := range remaining {
+		if rule.HasTag("balances") {
+			t.Errorf("expected rule %q to be deleted", rule.ID)
+		}
 	}
+}
 
-	// Verify AddRule was NOT called
-	if alertMgr.addRuleCalls != 0 {
-		t.Errorf("expected AddRule not to be called, but it was called %d times", alertMgr.addRuleCalls)
+// TestHandleDeleteAlert_IDAndTag tests that id and tag can't be combined
+func TestHandleDeleteAlert_IDAndTag(t *testing.T) {
+	alertMgr := newTaggedAlertManager()
+	server := NewServer(8080, &MockStorage{}, alertMgr)
+
+	req := httptest.NewRequest("DELETE", "/api/v1/alerts?id=low-balance&tag=network", nil)
+	w := httptest.NewRecorder()
+	server.handleAlerts(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+	if alertMgr.removeRuleCalls != 0 {
+		t.Errorf("expected no rules removed, got %d calls", alertMgr.removeRuleCalls)
 	}
 }
 
-// TestHandleCreateAlert_InvalidJSON tests creating alert with malformed JSON
-func TestHandleCreateAlert_InvalidJSON(t *testing.T) {
+// TestHandleCreateAlert_EmptyTag tests that blank tags are rejected
+func TestHandleCreateAlert_EmptyTag(t *testing.T) {
 	alertMgr := &MockAlertManager{}
-	store := &MockStorage{}
-	server := NewServer(8080, store, alertMgr)
+	server := NewServer(8080, &MockStorage{}, alertMgr)
 
-	req := httptest.NewRequest("POST", "/api/v1/alerts", bytes.NewReader([]byte("not json")))
+	body := `{"name":"Test","metric_name":"account_balance","condition":"<","threshold":1,"severity":"warning","tags":["balances"," "]}`
+	req := httptest.NewRequest("POST", "/api/v1/alerts", strings.NewReader(body))
 	w := httptest.NewRecorder()
 	server.handleAlerts(w, req)
 
 	if w.Code != http.StatusBadRequest {
 		t.Errorf("expected status 400, got %d", w.Code)
 	}
-
-	var response ErrorResponse
-	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
-		t.Fatalf("failed to decode response: %v", err)
+	if alertMgr.addRuleCalls != 0 {
+		t.Errorf("expected AddRule not to be called, got %d calls", alertMgr.addRuleCalls)
 	}
+}
 
-	if response.Error == "" {
-		t.Error("expected error message in response")
+func TestHandleCreateAlert_MinChange(t *testing.T) {
+	alertMgr := &MockAlertManager{}
+	server := NewServer(8080, &MockStorage{}, alertMgr)
+
+	body := `{"name":"Moved","metric_name":"account_balance","condition":"changed","severity":"info","min_change":100000000}`
+	req := httptest.NewRequest("POST", "/api/v1/alerts", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	server.handleAlerts(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if alertMgr.lastAddedRule.MinChange != 100000000 {
+		t.Errorf("expected min change on the added rule, got %v", alertMgr.lastAddedRule.MinChange)
 	}
 
-	if alertMgr.addRuleCalls != 0 {
-		t.Errorf("expected AddRule not to be called, but it was called %d times", alertMgr.addRuleCalls)
+	// min_change only makes sense for "changed"
+	body = `{"name":"Low","metric_name":"account_balance","condition":"<","threshold":1,"severity":"warning","min_change":5}`
+	req = httptest.NewRequest("POST", "/api/v1/alerts", strings.NewReader(body))
+	w = httptest.NewRecorder()
+	server.handleAlerts(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
 	}
 }
 
-// TestHandleCreateAlert_ManagerError tests when AddRule fails
-func TestHandleCreateAlert_ManagerError(t *testing.T) {
-	// Create MockAlertManager with addRuleErr set to an error
-	alertMgr := &MockAlertManager{
-		addRuleErr: fmt.Errorf("database error"),
-	}
-	store := &MockStorage{}
-	server := NewServer(8080, store, alertMgr)
+func TestHandleCreateAlert_MinChangePercent(t *testing.T) {
+	alertMgr := &MockAlertManager{}
+	server := NewServer(8080, &MockStorage{}, alertMgr)
 
-	// Create valid CreateAlertRequest
-	req := CreateAlertRequest{
-		Name:            "Test Alert",
-		Description:     "Test Description",
-		MetricName:      "account_balance",
-		Condition:       "<",
-		Threshold:       1000000,
-		Severity:        "warning",
-		CooldownSeconds: 300,
+	body := `{"name":"Moved","metric_name":"account_balance","condition":"changed","severity":"info","min_change_percent":2.5}`
+	req := httptest.NewRequest("POST", "/api/v1/alerts", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	server.handleAlerts(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
 	}
-	jsonBody, err := json.Marshal(req)
-	if err != nil {
-		t.Fatalf("failed to marshal request: %v", err)
+	if alertMgr.lastAddedRule.MinChangePercent != 2.5 {
+		t.Errorf("expected min change percent on the added rule, got %v", alertMgr.lastAddedRule.MinChangePercent)
 	}
 
-	// Make POST request to /api/v1/alerts
-	post := httptest.NewRequest("POST", "/api/v1/alerts", bytes.NewReader(jsonBody))
-	w := httptest.NewRecorder()
-	server.handleAlerts(w, post)
+	body = `{"name":"Moved","metric_name":"account_balance","condition":"changed","severity":"info","min_change_percent":-1}`
+	req = httptest.NewRequest("POST", "/api/v1/alerts", strings.NewReader(body))
+	w = httptest.NewRecorder()
+	server.handleAlerts(w, req)
 
-	// Verify status 500 (Internal Server Error)
-	if w.Code != http.StatusInternalServerError {
-		t.Errorf("expected status 500, got %d", w.Code)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
 	}
+}
 
-	// Verify error message in response
-	var response ErrorResponse
-	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
-		t.Fatalf("failed to decode response: %v", err)
+// TestHandleCreateAlert_ThresholdNotation tests scientific notation and fractional thresholds are accepted exactly
+func TestHandleCreateAlert_ThresholdNotation(t *testing.T) {
+	tests := []struct {
+		threshold string
+		want      float64
+	}{
+		{"1e9", 1000000000},
+		{"2.5E+10", 25000000000},
+		{"0.25", 0.25},
+		{"123456789012345", 123456789012345},
 	}
 
-	if response.Error == "" {
-		t.Error("expected error message in response")
-	}
+	for _, tt := range tests {
+		t.Run(tt.threshold, func(t *testing.T) {
+			alertMgr := &MockAlertManager{}
+			server := NewServer(8080, &MockStorage{}, alertMgr)
 
-	if alertMgr.addRuleCalls != 1 {
-		t.Errorf("expected AddRule to be called once, but it was called %d times", alertMgr.addRuleCalls)
+			body := `{"name":"Low","metric_name":"account_balance","condition":"<","threshold":` + tt.threshold + `,"severity":"warning"}`
+			req := httptest.NewRequest("POST", "/api/v1/alerts", strings.NewReader(body))
+			w := httptest.NewRecorder()
+			server.handleAlerts(w, req)
+
+			if w.Code != http.StatusCreated {
+				t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+			}
+			if alertMgr.lastAddedRule.Threshold != tt.want {
+				t.Errorf("expected threshold %v, got %v", tt.want, alertMgr.lastAddedRule.Threshold)
+			}
+		})
 	}
 }
 
-// TestHandleCreateAlert_NegativeCooldown tests creating alert with negative cooldown
-func TestHandleCreateAlert_NegativeCooldown(t *testing.T) {
+// TestHandleCreateAlert_Webhooks tests a rule's own webhooks are validated and passed to the manager
+func TestHandleCreateAlert_Webhooks(t *testing.T) {
 	alertMgr := &MockAlertManager{}
-	store := &MockStorage{}
-	server := NewServer(8080, store, alertMgr)
-
-	request := &CreateAlertRequest{
-		Name:            "Test Alert",
-		MetricName:      "account_balance",
-		Condition:       "<",
-		Threshold:       1000000,
-		Severity:        "warning",
-		CooldownSeconds: -10,
-	}
-	jsonBody, _ := json.Marshal(request)
+	server := NewServer(8080, &MockStorage{}, alertMgr)
 
-	req := httptest.NewRequest("POST", "/api/v1/alerts", bytes.NewReader(jsonBody))
+	body := `{"name":"Low","metric_name":"account_balance","condition":"<","threshold":1,"severity":"warning","webhooks":["https://hooks.example.com/oncall"]}`
+	req := httptest.NewRequest("POST", "/api/v1/alerts", strings.NewReader(body))
 	w := httptest.NewRecorder()
 	server.handleAlerts(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected status 400, got %d", w.Code)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
 	}
-
-	var response ErrorResponse
+	if !reflect.DeepEqual(alertMgr.lastAddedRule.Webhooks, []string{"https://hooks.example.com/oncall"}) {
+		t.Errorf("expected rule webhooks on the added rule, got %v", alertMgr.lastAddedRule.Webhooks)
+	}
+	var response AlertRuleResponse
 	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
-
-	if response.Error == "" {
-		t.Error("expected error message in response")
+	if len(response.Webhooks) != 1 {
+		t.Errorf("expected webhooks in the response, got %v", response.Webhooks)
 	}
 
-	if alertMgr.addRuleCalls != 0 {
-		t.Errorf("expected AddRule not to be called, but it was called %d times", alertMgr.addRuleCalls)
+	body = `{"name":"Low","metric_name":"account_balance","condition":"<","threshold":1,"severity":"warning","webhooks":["ftp://hooks.example.com"]}`
+	req = httptest.NewRequest("POST", "/api/v1/alerts", strings.NewReader(body))
+	w = httptest.NewRecorder()
+	server.handleAlerts(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an invalid webhook, got %d", w.Code)
 	}
 }
 
@@ -1519,3 +2891,257 @@ func TestHandleDeleteAlert_ManagerError(t *testing.T) {
 		t.Errorf("expected RemoveRule to be called once, but it was called %d times", alertMgr.removeRuleCalls)
 	}
 }
+
+// writeSelfSignedCert writes a self-signed certificate for 127.0.0.1 and its key
+// as PEM files, returning their paths and the parsed certificate
+func writeSelfSignedCert(t *testing.T) (certFile, keyFile string, cert *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "hedera-network-monitor-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err = x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	return certFile, keyFile, cert
+}
+
+// TestServe_TLS tests that the server serves /health over HTTPS with a configured certificate
+func TestServe_TLS(t *testing.T) {
+	certFile, keyFile, cert := writeSelfSignedCert(t)
+
+	server := NewServer(0, &MockStorage{}, &MockAlertManager{})
+	if err := server.SetTLS(certFile, keyFile); err != nil {
+		t.Fatalf("SetTLS failed: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- server.serve(ctx, listener)
+	}()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	client := &http.Client{
+		Timeout:   5 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+	}
+
+	resp, err := client.Get("https://" + listener.Addr().String() + "/health")
+	if err != nil {
+		t.Fatalf("HTTPS request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+	if resp.TLS == nil {
+		t.Error("expected response over TLS")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("expected clean shutdown, got %v", err)
+	}
+}
+
+// TestSetTLS_InvalidPair tests that an unreadable certificate/key pair fails fast
+func TestSetTLS_InvalidPair(t *testing.T) {
+	certFile, _, _ := writeSelfSignedCert(t)
+	server := NewServer(0, &MockStorage{}, &MockAlertManager{})
+
+	if err := server.SetTLS(certFile, filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Error("expected error for missing key file")
+	}
+	if err := server.SetTLS(certFile, certFile); err == nil {
+		t.Error("expected error when the key file holds a certificate")
+	}
+}
+
+// TestHandleCreateAlert_Annotations tests that annotations are stored on the rule and returned
+func TestHandleCreateAlert_Annotations(t *testing.T) {
+	alertMgr := &MockAlertManager{}
+	server := NewServer(8080, &MockStorage{}, alertMgr)
+
+	body := `{"name":"Low","metric_name":"account_balance","condition":"<","threshold":1,"severity":"warning",
+		"annotations":{"runbook_url":"https://wiki.example.com/low-balance","team":"treasury"}}`
+	req := httptest.NewRequest("POST", "/api/v1/alerts", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	server.handleAlerts(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if alertMgr.lastAddedRule.Annotations["runbook_url"] != "https://wiki.example.com/low-balance" {
+		t.Errorf("expected annotations on the added rule, got %v", alertMgr.lastAddedRule.Annotations)
+	}
+
+	var response AlertRuleResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Annotations["team"] != "treasury" {
+		t.Errorf("expected annotations in response, got %v", response.Annotations)
+	}
+}
+
+// TestNilAlertManager_AlertEndpointsDisabled tests that alert endpoints report 503 without alerting
+func TestNilAlertManager_AlertEndpointsDisabled(t *testing.T) {
+	store := &MockStorage{
+		metrics: []types.Metric{{Name: "account_balance", Value: 100, Timestamp: 1234567890}},
+	}
+
+	for name, alertMgr := range map[string]AlertingManager{
+		"nil":  nil,
+		"noop": NoopAlertManager{},
+	} {
+		handler := NewServer(8080, store, alertMgr).routes()
+
+		for _, method := range []string{"GET", "POST", "DELETE"} {
+			body := `{"name":"Low","metric_name":"account_balance","condition":"<","threshold":1,"severity":"warning"}`
+			req := httptest.NewRequest(method, "/api/v1/alerts?id=rule-1", strings.NewReader(body))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != http.StatusServiceUnavailable {
+				t.Errorf("%s manager, %s /api/v1/alerts: expected status 503, got %d", name, method, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), "alerting disabled") {
+				t.Errorf("%s manager, %s /api/v1/alerts: expected 'alerting disabled', got %s", name, method, w.Body.String())
+			}
+		}
+
+		// Metrics endpoints keep working
+		req := httptest.NewRequest("GET", "/api/v1/metrics?name=account_balance", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s manager: expected metrics status 200, got %d", name, w.Code)
+		}
+		var response MetricsResponse
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.Count != 1 {
+			t.Errorf("%s manager: expected 1 metric, got %d", name, response.Count)
+		}
+	}
+}
+
+// TestNoopAlertManager tests that the no-op manager has no rules and rejects changes
+func TestNoopAlertManager(t *testing.T) {
+	var mgr NoopAlertManager
+
+	if rules := mgr.GetRules(); len(rules) != 0 {
+		t.Errorf("expected no rules, got %d", len(rules))
+	}
+	if err := mgr.AddRule(alerting.AlertRule{ID: "rule-1"}); !errors.Is(err, ErrAlertingDisabled) {
+		t.Errorf("expected ErrAlertingDisabled from AddRule, got %v", err)
+	}
+	if err := mgr.RemoveRule("rule-1"); !errors.Is(err, ErrAlertingDisabled) {
+		t.Errorf("expected ErrAlertingDisabled from RemoveRule, got %v", err)
+	}
+}
+
+// TestHandleAlertsConfig_RoundTrip tests that the YAML snippet loads back as valid config rules
+func TestHandleAlertsConfig_RoundTrip(t *testing.T) {
+	alertMgr := &MockAlertManager{
+		rules: []alerting.AlertRule{
+			{
+				ID: "low-balance", Name: "Low Balance", MetricName: "account_balance",
+				Condition: "<", Threshold: 1000000000, Severity: "warning", Enabled: true,
+				ForSeconds: 120, Tags: []string{"balances"},
+				Annotations:     map[string]string{"team": "treasury"},
+				MessageTemplate: "{{.MetricID}} is {{.Value}}",
+			},
+			{
+				ID: "moved", Name: "Balance Moved", MetricName: "account_balance",
+				Condition: "changed", Severity: "info", Enabled: true, MinChange: 100000000,
+			},
+		},
+	}
+	server := NewServer(8080, &MockStorage{}, alertMgr)
+
+	req := httptest.NewRequest("GET", "/api/v1/alerts/config", nil)
+	w := httptest.NewRecorder()
+	server.routes().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/yaml" {
+		t.Errorf("expected Content-Type application/yaml, got %s", ct)
+	}
+
+	// Paste the snippet into a config file and load it like the monitor would
+	content := "network:\n  name: testnet\naccounts:\n  - id: \"0.0.5000\"\n" + w.Body.String()
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("expected snippet to load as valid config, got: %v\n%s", err, content)
+	}
+
+	if len(cfg.Alerting.Rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(cfg.Alerting.Rules))
+	}
+	for i, rule := range alertMgr.rules {
+		if got, want := cfg.Alerting.Rules[i], rule.ConfigRule(); !reflect.DeepEqual(got, want) {
+			t.Errorf("rule %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+}
+
+// TestHandleAlertsConfig_MethodNotAllowed tests that only GET is accepted
+func TestHandleAlertsConfig_MethodNotAllowed(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+
+	req := httptest.NewRequest("POST", "/api/v1/alerts/config", nil)
+	w := httptest.NewRecorder()
+	server.handleAlertsConfig(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", w.Code)
+	}
+}
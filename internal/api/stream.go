@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+	"golang.org/x/net/websocket"
+)
+
+// DefaultStreamInterval is how often the metrics stream checks storage for new samples
+const DefaultStreamInterval = time.Second
+
+// handleMetricsStream upgrades to a WebSocket that pushes metrics as they are stored
+// GET /api/v1/metrics/stream
+// Query parameters:
+//   - name: only stream metrics with this name (optional)
+//
+// Each message is one metric as JSON. A second's samples are sent once that second
+// has passed, so samples stored late in the current second aren't skipped
+func (s *Server) handleMetricsStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, http.StatusMethodNotAllowed, "only GET allowed")
+		return
+	}
+	if !s.checkParams(w, r, "name") {
+		return
+	}
+	// The WebSocket server takes over the connection before checking the handshake,
+	// so plain requests are turned away here with a normal JSON error
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		s.writeError(w, http.StatusBadRequest, "metrics stream requires a WebSocket upgrade")
+		return
+	}
+	// WebSockets aren't covered by CORS, so without this any page the operator visits
+	// could read the stream while auth is off
+	if origin := r.Header.Get("Origin"); origin != "" && !s.streamOrigin(r, origin) {
+		s.writeError(w, http.StatusForbidden, "origin not allowed")
+		return
+	}
+	name := r.URL.Query().Get("name")
+
+	websocket.Server{
+		// The Origin was checked above, before the connection is taken over
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			s.streamMetrics(conn, name)
+		},
+	}.ServeHTTP(w, r)
+}
+
+// streamOrigin reports whether a browser on origin may open the stream: pages served
+// from the API's own host, and origins allowed by the CORS config
+func (s *Server) streamOrigin(r *http.Request, origin string) bool {
+	if parsed, err := url.Parse(origin); err == nil && strings.EqualFold(parsed.Host, r.Host) {
+		return true
+	}
+	return s.corsOrigin(origin)
+}
+
+// streamMetrics sends metrics named name (empty = all) stored from now on until the
+// client disconnects, a send fails, or the server shuts down
+func (s *Server) streamMetrics(conn *websocket.Conn, name string) {
+	ctx, cancel := context.WithCancel(conn.Request().Context())
+	defer cancel()
+
+	// Clients don't send anything; a failed read means they went away
+	go func() {
+		var discard string
+		for websocket.Message.Receive(conn, &discard) == nil {
+		}
+		cancel()
+	}()
+
+	// Everything up to the cursor has been sent
+	cursor := time.Now().Unix() - 1
+	ticker := time.NewTicker(s.streamInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now().Unix()
+		metrics, err := s.store.GetMetricsAfter(name, cursor, 0)
+		if err != nil {
+			logger.Error("Error reading metrics for stream",
+				"component", "APIServer",
+				"error", err)
+			return
+		}
+		for _, metric := range metrics {
+			if now <= metric.Timestamp {
+				continue
+			}
+			if err := websocket.JSON.Send(conn, metric); err != nil {
+				return
+			}
+		}
+		cursor = now - 1
+	}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/config"
+)
+
+// TestMetricsStream_PushesNewMetrics tests that the stream sends metrics stored after
+// connecting, filtered by name
+func TestMetricsStream_PushesNewMetrics(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	server := NewServer(8080, store, &MockAlertManager{})
+	server.streamInterval = 10 * time.Millisecond
+	httpServer := httptest.NewServer(server.routes())
+	defer httpServer.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/api/v1/metrics/stream?name=account_balance"
+	conn, err := websocket.Dial(wsURL, "", httpServer.URL)
+	if err != nil {
+		t.Fatalf("failed to connect to stream: %v", err)
+	}
+	defer conn.Close()
+
+	now := time.Now().Unix()
+	_ = store.StoreMetric(types.Metric{Name: "hbar_price_usd", Timestamp: now, Value: 0.08})
+	_ = store.StoreMetric(types.Metric{Name: "account_balance", Timestamp: now, Value: 42, Labels: map[string]string{"account_id": "0.0.5000"}})
+
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("failed to set deadline: %v", err)
+	}
+	var metric types.Metric
+	if err := websocket.JSON.Receive(conn, &metric); err != nil {
+		t.Fatalf("expected a streamed metric, got: %v", err)
+	}
+	if metric.Name != "account_balance" || metric.Value != 42 || metric.Labels["account_id"] != "0.0.5000" {
+		t.Errorf("expected the account_balance metric, got %+v", metric)
+	}
+}
+
+// TestMetricsStream_RequiresWebSocket tests that a plain GET isn't upgraded
+func TestMetricsStream_RequiresWebSocket(t *testing.T) {
+	server := NewServer(8080, &MockStorage{}, &MockAlertManager{})
+
+	w := httptest.NewRecorder()
+	server.routes().ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/metrics/stream", nil))
+	if w.Code != 400 {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+// TestMetricsStream_RejectsCrossOrigin tests that a page on another site can't open the
+// stream even when no token is configured, while allowed and same-host origins can
+func TestMetricsStream_RejectsCrossOrigin(t *testing.T) {
+	server := NewServer(8080, storage.NewMemoryStorage(), &MockAlertManager{})
+	server.SetCORS(config.CORSConfig{AllowedOrigins: []string{"https://dashboard.example.com"}})
+	httpServer := httptest.NewServer(server.routes())
+	defer httpServer.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/api/v1/metrics/stream"
+	tests := []struct {
+		origin string
+		status int
+	}{
+		{"https://evil.example.com", http.StatusForbidden},
+		{"https://dashboard.example.com", http.StatusSwitchingProtocols},
+		{httpServer.URL, http.StatusSwitchingProtocols},
+	}
+
+	for _, tt := range tests {
+		req, _ := http.NewRequest("GET", httpServer.URL+"/api/v1/metrics/stream", nil)
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", "websocket")
+		req.Header.Set("Sec-WebSocket-Version", "13")
+		req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+		req.Header.Set("Origin", tt.origin)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request from %s failed: %v", tt.origin, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.status {
+			t.Errorf("expected status %d for origin %s, got %d", tt.status, tt.origin, resp.StatusCode)
+		}
+	}
+
+	if _, err := websocket.Dial(wsURL, "", "https://evil.example.com"); err == nil {
+		t.Error("expected a cross-origin WebSocket dial to fail")
+	}
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+// SummaryBucket holds aggregate statistics for one time bucket
+// Avg, Min and Max are nil when no samples fall in the bucket
+type SummaryBucket struct {
+	Start int64    `json:"start"` // Inclusive start timestamp (Unix seconds)
+	End   int64    `json:"end"`   // Exclusive end timestamp (Unix seconds)
+	Count int      `json:"count"`
+	Avg   *float64 `json:"avg"`
+	Min   *float64 `json:"min"`
+	Max   *float64 `json:"max"`
+}
+
+// summarizeMetrics divides the time range spanned by the samples into n equal
+// buckets and aggregates the values falling in each one
+// Buckets without samples are returned with a zero count so the result always
+// has n entries; no samples (or n <= 0) returns an empty slice
+func summarizeMetrics(metrics []types.Metric, n int) []SummaryBucket {
+	if len(metrics) == 0 || n <= 0 {
+		return []SummaryBucket{}
+	}
+
+	first, last := metrics[0].Timestamp, metrics[0].Timestamp
+	for _, metric := range metrics[1:] {
+		first = min(first, metric.Timestamp)
+		last = max(last, metric.Timestamp)
+	}
+
+	// span is inclusive of the last timestamp so it lands in the final bucket
+	span := last - first + 1
+	buckets := make([]SummaryBucket, n)
+	sums := make([]float64, n)
+	for i := range buckets {
+		// Boundaries round up so each sample's bucket index matches its [Start, End) range
+		buckets[i].Start = first + ceilDiv(int64(i)*span, int64(n))
+		buckets[i].End = first + ceilDiv(int64(i+1)*span, int64(n))
+	}
+
+	for _, metric := range metrics {
+		i := int((metric.Timestamp - first) * int64(n) / span)
+		bucket := &buckets[i]
+		value := metric.Value
+		if bucket.Count == 0 {
+			bucket.Min = &value
+			bucket.Max = &value
+		} else {
+			if value < *bucket.Min {
+				bucket.Min = &value
+			}
+			if *bucket.Max < value {
+				bucket.Max = &value
+			}
+		}
+		bucket.Count++
+		sums[i] += value
+	}
+
+	for i := range buckets {
+		if buckets[i].Count > 0 {
+			avg := sums[i] / float64(buckets[i].Count)
+			buckets[i].Avg = &avg
+		}
+	}
+
+	return buckets
+}
+
+// ceilDiv returns a / b rounded up for non-negative a and positive b
+func ceilDiv(a, b int64) int64 {
+	return (a + b - 1) / b
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+// TestSummarizeMetrics_EvenDistribution tests aggregates when samples fill every bucket
+func TestSummarizeMetrics_EvenDistribution(t *testing.T) {
+	// 8 samples one second apart -> 4 buckets of 2 samples each
+	metrics := make([]types.Metric, 0, 8)
+	for i := 0; i < 8; i++ {
+		metrics = append(metrics, types.Metric{
+			Name:      "account_balance",
+			Timestamp: 1000 + int64(i),
+			Value:     float64(i + 1),
+		})
+	}
+
+	buckets := summarizeMetrics(metrics, 4)
+
+	if len(buckets) != 4 {
+		t.Fatalf("expected 4 buckets, got %d", len(buckets))
+	}
+	for i, bucket := range buckets {
+		if bucket.Count != 2 {
+			t.Errorf("bucket %d: expected 2 samples, got %d", i, bucket.Count)
+			continue
+		}
+		wantMin := float64(2*i + 1)
+		wantMax := float64(2*i + 2)
+		if *bucket.Min != wantMin || *bucket.Max != wantMax {
+			t.Errorf("bucket %d: expected min %v max %v, got min %v max %v",
+				i, wantMin, wantMax, *bucket.Min, *bucket.Max)
+		}
+		if *bucket.Avg != (wantMin+wantMax)/2 {
+			t.Errorf("bucket %d: expected avg %v, got %v", i, (wantMin+wantMax)/2, *bucket.Avg)
+		}
+		if bucket.Start != 1000+int64(2*i) || bucket.End != 1000+int64(2*i+2) {
+			t.Errorf("bucket %d: expected range [%d, %d), got [%d, %d)",
+				i, 1000+2*i, 1000+2*i+2, bucket.Start, bucket.End)
+		}
+	}
+}
+
+// TestSummarizeMetrics_Sparse tests that buckets without samples are empty
+func TestSummarizeMetrics_Sparse(t *testing.T) {
+	metrics := []types.Metric{
+		{Name: "account_balance", Timestamp: 1000, Value: 10},
+		{Name: "account_balance", Timestamp: 1099, Value: 30},
+	}
+
+	buckets := summarizeMetrics(metrics, 10)
+
+	if len(buckets) != 10 {
+		t.Fatalf("expected 10 buckets, got %d", len(buckets))
+	}
+	if buckets[0].Count != 1 || *buckets[0].Avg != 10 {
+		t.Errorf("expected first bucket to hold the first sample, got %+v", buckets[0])
+	}
+	if buckets[9].Count != 1 || *buckets[9].Avg != 30 {
+		t.Errorf("expected last bucket to hold the last sample, got %+v", buckets[9])
+	}
+	for i := 1; i < 9; i++ {
+		if buckets[i].Count != 0 || buckets[i].Avg != nil || buckets[i].Min != nil || buckets[i].Max != nil {
+			t.Errorf("bucket %d: expected empty bucket, got %+v", i, buckets[i])
+		}
+	}
+}
+
+// TestSummarizeMetrics_FewerSamplesThanBuckets tests a single sample spread over many buckets
+func TestSummarizeMetrics_FewerSamplesThanBuckets(t *testing.T) {
+	metrics := []types.Metric{
+		{Name: "account_balance", Timestamp: 1000, Value: 5},
+	}
+
+	buckets := summarizeMetrics(metrics, 4)
+
+	if len(buckets) != 4 {
+		t.Fatalf("expected 4 buckets, got %d", len(buckets))
+	}
+	total := 0
+	for _, bucket := range buckets {
+		total += bucket.Count
+		if bucket.Count > 0 && (bucket.Start > 1000 || 1000 >= bucket.End) {
+			t.Errorf("sample at 1000 placed in bucket [%d, %d)", bucket.Start, bucket.End)
+		}
+	}
+	if total != 1 {
+		t.Errorf("expected 1 sample across buckets, got %d", total)
+	}
+}
+
+// TestSummarizeMetrics_Empty tests that no samples yield no buckets
+func TestSummarizeMetrics_Empty(t *testing.T) {
+	if buckets := summarizeMetrics(nil, 10); len(buckets) != 0 {
+		t.Errorf("expected no buckets, got %d", len(buckets))
+	}
+}
@@ -2,6 +2,8 @@ package collector
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -22,9 +24,15 @@ type AccountConfig struct {
 // AccountCollector collects metrics for specified Hedera accounts
 type AccountCollector struct {
 	*BaseCollector
-	client   hedera.Client
-	accounts []AccountConfig
-	interval time.Duration
+	client           hedera.Client
+	accounts         []AccountConfig
+	interval         time.Duration
+	includeZeroTypes bool // Emit 0-valued metrics for transaction types absent from the records
+	recordsLimit     int  // Maximum records queried per account each cycle
+
+	transactionTypes map[hedera.TransactionType]bool // Types emitted as metrics (nil = all)
+
+	portfolioAccounts []string // Accounts summed into portfolio_balance_total (empty = disabled)
 }
 
 const DefaultInterval = 30 * time.Second
@@ -68,7 +76,70 @@ func NewAccountCollector(client hedera.Client, accounts []AccountConfig) *Accoun
 		client:        client,
 		accounts:      accounts,
 		interval:      ParseInterval(os.Getenv("COLLECTOR_INTERVAL")),
+		recordsLimit:  hedera.DefaultRecordsLimit,
+	}
+}
+
+// SetRecordsLimit sets the maximum number of records queried per account
+// Non-positive values restore hedera.DefaultRecordsLimit; the client caps values above hedera.MaxRecordsLimit
+func (ac *AccountCollector) SetRecordsLimit(limit int) {
+	if limit <= 0 {
+		limit = hedera.DefaultRecordsLimit
+	}
+	ac.recordsLimit = limit
+}
+
+// SetIncludeZeroTypes enables emitting a 0-valued transaction type metric for every
+// known type with no records, so each type's series stays continuous
+func (ac *AccountCollector) SetIncludeZeroTypes(include bool) {
+	ac.includeZeroTypes = include
+}
+
+// SetTransactionTypes limits transaction type metrics to the named types, to reduce
+// metric cardinality for high-volume accounts. An empty list emits every type
+func (ac *AccountCollector) SetTransactionTypes(names []string) {
+	if len(names) == 0 {
+		ac.transactionTypes = nil
+		return
+	}
+	ac.transactionTypes = make(map[hedera.TransactionType]bool, len(names))
+	for _, name := range names {
+		ac.transactionTypes[hedera.TransactionType(name)] = true
+	}
+}
+
+// tracksTransactionType reports whether metrics are emitted for txType
+func (ac *AccountCollector) tracksTransactionType(txType hedera.TransactionType) bool {
+	return ac.transactionTypes == nil || ac.transactionTypes[txType]
+}
+
+// SetPortfolioAccounts sets the accounts whose balances are summed into a
+// portfolio_balance_total metric each cycle. An empty list disables the metric
+func (ac *AccountCollector) SetPortfolioAccounts(accountIDs []string) {
+	ac.portfolioAccounts = accountIDs
+}
+
+// buildPortfolioMetric sums the balances of the portfolio accounts collected this cycle
+// Returns false if any portfolio account has no balance, since a partial sum would
+// look like a drop in funds and trigger false alerts
+func buildPortfolioMetric(accountIDs []string, balances map[string]float64, now int64) (types.Metric, bool) {
+	total := 0.0
+	for _, id := range accountIDs {
+		balance, ok := balances[id]
+		if !ok {
+			return types.Metric{}, false
+		}
+		total += balance
 	}
+
+	return types.Metric{
+		Name:      "portfolio_balance_total",
+		Timestamp: now,
+		Value:     total,
+		Labels: map[string]string{
+			"accounts": strconv.Itoa(len(accountIDs)),
+		},
+	}, true
 }
 
 func (ac *AccountCollector) buildTransactionTypeMetric(accountRecords []hedera.Record,
@@ -76,9 +147,20 @@ func (ac *AccountCollector) buildTransactionTypeMetric(accountRecords []hedera.R
 
 	typeCounts := make(map[hedera.TransactionType]int)
 
-	// Count transactions by type
+	// Seed every known type so types with no records are reported as 0
+	if ac.includeZeroTypes {
+		for _, txType := range hedera.KnownTransactionTypes() {
+			if ac.tracksTransactionType(txType) {
+				typeCounts[txType] = 0
+			}
+		}
+	}
+
+	// Count transactions by type, skipping types outside the allowlist
 	for _, record := range accountRecords {
-		typeCounts[record.Type]++
+		if ac.tracksTransactionType(record.Type) {
+			typeCounts[record.Type]++
+		}
 	}
 
 	// Build the metrics
@@ -99,108 +181,195 @@ func (ac *AccountCollector) buildTransactionTypeMetric(accountRecords []hedera.R
 	return metrics
 }
 
+// buildExpiryMetric builds the seconds remaining until an account's auto-renew expiry
+// Already-expired accounts report a negative value
+func buildExpiryMetric(expiry, now int64, accountID, label string) types.Metric {
+	return types.Metric{
+		Name:      "account_expiry_seconds_remaining",
+		Timestamp: now,
+		Value:     float64(expiry - now),
+		Labels: map[string]string{
+			"account_id": accountID,
+			"label":      label,
+		},
+	}
+}
+
+// CollectionError summarizes per-item failures from a single collection cycle
+// Metrics for items that succeeded are still stored when this error is returned
+type CollectionError struct {
+	Collector string   // Name of the collector that reported the failures
+	Failed    []string // Identifiers of the items that failed (e.g. account IDs)
+	Total     int      // Total number of items attempted in the cycle
+	Err       error    // Joined per-item errors
+}
+
+// Error implements the error interface
+func (e *CollectionError) Error() string {
+	return fmt.Sprintf("%s: %d of %d items failed [%s]: %v",
+		e.Collector, len(e.Failed), e.Total, strings.Join(e.Failed, ", "), e.Err)
+}
+
+// Unwrap returns the joined per-item errors
+func (e *CollectionError) Unwrap() error {
+	return e.Err
+}
+
+// collectAccount queries a single account and builds its metrics
+// Metrics gathered before a failure are returned alongside the error
+func (ac *AccountCollector) collectAccount(ctx context.Context, accountCfg AccountConfig) ([]types.Metric, error) {
+	allMetrics := make([]types.Metric, 0)
+
+	// 1. Query account balance
+	balance, err := callWithTimeout(ctx, ac.queryTimeout, "GetAccountBalance", func() (int64, error) {
+		return ac.client.GetAccountBalance(accountCfg.ID)
+	})
+	if err != nil {
+		return allMetrics, fmt.Errorf("error getting balance: %w", err)
+	}
+
+	allMetrics = append(allMetrics, types.Metric{
+		Name:      "account_balance",
+		Timestamp: time.Now().Unix(),
+		Value:     float64(balance),
+		Labels: map[string]string{
+			"account_id": accountCfg.ID,
+			"label":      accountCfg.Label,
+		},
+	})
+
+	// Auto-renew expiry: alert with "<" before the account is due to expire
+	// A failed expiry query is reported with the account but doesn't skip its records
+	var expiryErr error
+	expiry, err := callWithTimeout(ctx, ac.queryTimeout, "GetAccountExpiry", func() (int64, error) {
+		return ac.client.GetAccountExpiry(accountCfg.ID)
+	})
+	if err != nil {
+		expiryErr = fmt.Errorf("error getting account expiry: %w", err)
+	} else {
+		allMetrics = append(allMetrics, buildExpiryMetric(expiry, time.Now().Unix(),
+			accountCfg.ID, accountCfg.Label))
+	}
+
+	// 2. Query recent transactions
+	accountRecords, err := callWithTimeout(ctx, ac.queryTimeout, "GetAccountRecords", func() ([]hedera.Record, error) {
+		return ac.client.GetAccountRecords(accountCfg.ID, ac.recordsLimit)
+	})
+	if err != nil {
+		return allMetrics, errors.Join(expiryErr, fmt.Errorf("error getting account records: %w", err))
+	}
+
+	// 3. Calculate derived metrics from transaction records
+	transactionCount := len(accountRecords)
+	allMetrics = append(allMetrics, types.Metric{
+		Name:      "account_transaction_count",
+		Timestamp: time.Now().Unix(),
+		Value:     float64(transactionCount),
+		Labels: map[string]string{
+			"account_id": accountCfg.ID,
+			"label":      accountCfg.Label,
+		},
+	})
+
+	// TASK 2 - Transaction type breakdown
+	typeMetrics := ac.buildTransactionTypeMetric(accountRecords,
+		accountCfg.ID, accountCfg.Label)
+	allMetrics = append(allMetrics, typeMetrics...)
+
+	// TASK 3 - Volume metrics
+	// Sum the total amount transferred in this interval
+	total := int64(0)
+	for _, rec := range accountRecords {
+		total += rec.AmountTinyBar
+	}
+	allMetrics = append(allMetrics, types.Metric{
+		Name:      "account_total_volume",
+		Timestamp: time.Now().Unix(),
+		Value:     float64(total),
+		Labels: map[string]string{
+			"account_id": accountCfg.ID,
+			"label":      accountCfg.Label,
+		},
+	})
+
+	// Bonus idea: track inflows vs outflows separately if possible
+	return allMetrics, expiryErr
+}
+
+// collectOnce runs a single collection cycle across all accounts
+// A failing account does not stop the others; successful metrics are always stored
+// Queries that exceed the query timeout fail that account and record collector_query_timeout
+// Returns a *CollectionError naming the failed accounts, or nil if all succeeded
+func (ac *AccountCollector) collectOnce(ctx context.Context, store storage.Storage, alertMgr AlertManager) error {
+	var failed []string
+	var errs []error
+	balances := make(map[string]float64, len(ac.accounts))
+
+	for _, accountCfg := range ac.accounts {
+		metrics, err := ac.collectAccount(ctx, accountCfg)
+		if err != nil {
+			logger.Error("Error collecting account metrics",
+				"component", ac.Name(),
+				"account_id", accountCfg.ID,
+				"error_class", hedera.ClassifyError(err).String(),
+				"error", err)
+			failed = append(failed, accountCfg.ID)
+			errs = append(errs, fmt.Errorf("account %s: %w", accountCfg.ID, err))
+			ac.recordQueryError(store, alertMgr, err)
+		}
+
+		// Store and check all metrics
+		for _, metric := range metrics {
+			if metric.Name == "account_balance" {
+				balances[accountCfg.ID] = metric.Value
+			}
+			ac.storeAndCheck(store, alertMgr, metric)
+		}
+	}
+
+	// Derived metrics are built once every account has been collected
+	if len(ac.portfolioAccounts) > 0 {
+		if metric, ok := buildPortfolioMetric(ac.portfolioAccounts, balances, time.Now().Unix()); ok {
+			ac.storeAndCheck(store, alertMgr, metric)
+		} else {
+			logger.Warn("Skipping portfolio balance, not every portfolio account was collected",
+				"component", ac.Name())
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+	return &CollectionError{
+		Collector: ac.Name(),
+		Failed:    failed,
+		Total:     len(ac.accounts),
+		Err:       errors.Join(errs...),
+	}
+}
+
+// CollectOnce implements the OneShotCollector interface
+func (ac *AccountCollector) CollectOnce(ctx context.Context, store storage.Storage, alertMgr AlertManager) error {
+	return ac.collectOnce(ctx, store, alertMgr)
+}
+
 // Collect implements the Collector interface
 func (ac *AccountCollector) Collect(ctx context.Context, store storage.Storage, alertMgr AlertManager) error {
-	ticker := time.NewTicker(ac.interval)
-	defer ticker.Stop()
-
 	logger.Info("Starting account collector",
 		"component", ac.Name(),
 		"interval", ac.interval,
+		"jitter", ac.Jitter(),
 		"accounts", len(ac.accounts))
 
-	for {
-		select {
-		case <-ctx.Done():
-			logger.Info("Stopping collector", "component", ac.Name())
-			return ctx.Err()
-		case <-ticker.C:
-			// Collect metrics for each account
-			for _, accountCfg := range ac.accounts {
-				allMetrics := make([]types.Metric, 0)
-
-				// 1. Query account balance
-				balance, err := ac.client.GetAccountBalance(accountCfg.ID)
-				if err != nil {
-					logger.Error("Error getting balance",
-						"component", ac.Name(),
-						"account_id", accountCfg.ID,
-						"error", err)
-					return err
-				}
-
-				allMetrics = append(allMetrics, types.Metric{
-					Name:      "account_balance",
-					Timestamp: time.Now().Unix(),
-					Value:     float64(balance),
-					Labels: map[string]string{
-						"account_id": accountCfg.ID,
-						"label":      accountCfg.Label,
-					},
-				})
-
-				// 2. Query recent transactions (limit to 50 records per query)
-				accountRecords, err := ac.client.GetAccountRecords(accountCfg.ID, 50)
-				if err != nil {
-					logger.Error("Error getting account records",
-						"component", ac.Name(),
-						"account_id", accountCfg.ID,
-						"error", err)
-					// Continue to next account on error instead of failing entire collection
-					continue
-				}
-
-				// 3. Calculate derived metrics from transaction records
-				transactionCount := len(accountRecords)
-				allMetrics = append(allMetrics, types.Metric{
-					Name:      "account_transaction_count",
-					Timestamp: time.Now().Unix(),
-					Value:     float64(transactionCount),
-					Labels: map[string]string{
-						"account_id": accountCfg.ID,
-						"label":      accountCfg.Label,
-					},
-				})
-
-				// TASK 2 - Transaction type breakdown
-				typeMetrics := ac.buildTransactionTypeMetric(accountRecords,
-					accountCfg.ID, accountCfg.Label)
-				allMetrics = append(allMetrics, typeMetrics...)
-
-				// TASK 3 - Volume metrics
-				// Sum the total amount transferred in this interval
-				total := int64(0)
-				for _, rec := range accountRecords {
-					total += rec.AmountTinyBar
-				}
-				allMetrics = append(allMetrics, types.Metric{
-					Name:      "account_total_volume",
-					Timestamp: time.Now().Unix(),
-					Value:     float64(total),
-					Labels: map[string]string{
-						"account_id": accountCfg.ID,
-						"label":      accountCfg.Label,
-					},
-				})
-
-				// Store and check all metrics
-				for _, metric := range allMetrics {
-					if err := store.StoreMetric(metric); err != nil {
-						logger.Error("Error storing metric",
-							"component", ac.Name(),
-							"metric_name", metric.Name,
-							"error", err)
-					}
-					if err := alertMgr.CheckMetric(metric); err != nil {
-						logger.Error("Error checking alerts",
-							"component", ac.Name(),
-							"metric_name", metric.Name,
-							"error", err)
-					}
-				}
-
-				// Bonus idea: track inflows vs outflows separately if possible
-			}
-		}
+	return ac.runLoop(ctx, store, alertMgr, ac.interval, func() { ac.runCycle(ctx, store, alertMgr) })
+}
+
+// runCycle runs one collection cycle, logging rather than returning failures
+// Partial failures are reported but never stop the collection loop
+func (ac *AccountCollector) runCycle(ctx context.Context, store storage.Storage, alertMgr AlertManager) {
+	if err := ac.collectOnce(ctx, store, alertMgr); err != nil {
+		logger.Warn("Collection cycle completed with failures",
+			"component", ac.Name(),
+			"summary", err)
 	}
 }
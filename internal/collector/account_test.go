@@ -1,11 +1,15 @@
 package collector
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
 
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
 	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
 	"github.com/kaldun-tech/hedera-network-monitor/pkg/hedera"
 )
@@ -214,6 +218,103 @@ func TestBuildTransactionTypeMetric_AllTypes(t *testing.T) {
 	}
 }
 
+// TestBuildTransactionTypeMetric_IncludeZeroTypes tests that every known type is
+// emitted, with zeros for types that have no records
+func TestBuildTransactionTypeMetric_IncludeZeroTypes(t *testing.T) {
+	collector := &AccountCollector{}
+	collector.SetIncludeZeroTypes(true)
+	records := []hedera.Record{
+		{Type: hedera.TransactionTypeCryptoTransfer},
+		{Type: hedera.TransactionTypeCryptoTransfer},
+		{Type: hedera.TransactionTypeTokenMint},
+	}
+
+	metrics := collector.buildTransactionTypeMetric(records, "0.0.5000", "Test Account")
+
+	known := hedera.KnownTransactionTypes()
+	if len(metrics) != len(known) {
+		t.Fatalf("expected %d metrics, got %d", len(known), len(metrics))
+	}
+
+	counts := make(map[string]float64)
+	for _, metric := range metrics {
+		counts[metric.Labels["transaction_type"]] = metric.Value
+	}
+
+	for _, txType := range known {
+		want := 0.0
+		switch txType {
+		case hedera.TransactionTypeCryptoTransfer:
+			want = 2.0
+		case hedera.TransactionTypeTokenMint:
+			want = 1.0
+		}
+		got, ok := counts[txType.String()]
+		if !ok {
+			t.Errorf("expected metric for type %s", txType)
+			continue
+		}
+		if got != want {
+			t.Errorf("expected %s count %v, got %v", txType, want, got)
+		}
+	}
+}
+
+// TestBuildTransactionTypeMetric_IncludeZeroTypes_NoRecords tests that an account with
+// no records still reports a zero for each known type
+func TestBuildTransactionTypeMetric_IncludeZeroTypes_NoRecords(t *testing.T) {
+	collector := &AccountCollector{}
+	collector.SetIncludeZeroTypes(true)
+
+	metrics := collector.buildTransactionTypeMetric(nil, "0.0.5000", "Test Account")
+
+	if len(metrics) != len(hedera.KnownTransactionTypes()) {
+		t.Errorf("expected %d zero metrics, got %d", len(hedera.KnownTransactionTypes()), len(metrics))
+	}
+	for _, metric := range metrics {
+		if metric.Value != 0 {
+			t.Errorf("expected 0 for %s, got %v", metric.Labels["transaction_type"], metric.Value)
+		}
+	}
+}
+
+// TestBuildTransactionTypeMetric_Allowlist tests that only allowlisted types produce metrics,
+// including zero-valued ones
+func TestBuildTransactionTypeMetric_Allowlist(t *testing.T) {
+	collector := &AccountCollector{}
+	collector.SetIncludeZeroTypes(true)
+	collector.SetTransactionTypes([]string{"CryptoTransfer", "TokenBurn"})
+	records := []hedera.Record{
+		{Type: hedera.TransactionTypeCryptoTransfer},
+		{Type: hedera.TransactionTypeCryptoTransfer},
+		{Type: hedera.TransactionTypeTokenMint},
+		{Type: hedera.TransactionTypeContractCall},
+	}
+
+	metrics := collector.buildTransactionTypeMetric(records, "0.0.5000", "Test Account")
+
+	counts := make(map[string]float64)
+	for _, metric := range metrics {
+		counts[metric.Labels["transaction_type"]] = metric.Value
+	}
+	expected := map[string]float64{"CryptoTransfer": 2, "TokenBurn": 0}
+	if len(counts) != len(expected) {
+		t.Fatalf("Expected metrics only for %v, got %v", expected, counts)
+	}
+	for txType, want := range expected {
+		if got, ok := counts[txType]; !ok || got != want {
+			t.Errorf("Expected %s count %v, got %v (present: %v)", txType, want, got, ok)
+		}
+	}
+
+	// An empty allowlist restores every type
+	collector.SetTransactionTypes(nil)
+	collector.SetIncludeZeroTypes(false)
+	if metrics := collector.buildTransactionTypeMetric(records, "0.0.5000", "Test Account"); len(metrics) != 3 {
+		t.Errorf("Expected 3 metrics without an allowlist, got %d", len(metrics))
+	}
+}
+
 // TestBuildTransactionTypeMetric_Labels tests that metrics have correct labels
 func TestBuildTransactionTypeMetric_Labels(t *testing.T) {
 	collector := &AccountCollector{}
@@ -303,12 +404,27 @@ func TestBuildTransactionTypeMetric_MetricType(t *testing.T) {
 
 // MockClient is a mock implementation of the hedera.Client interface for testing
 type MockClient struct {
-	mockRecords []hedera.Record
-	mockErr     error
+	mockRecords  []hedera.Record
+	mockBalance  int64
+	mockExpiry   int64 // Unix expiry timestamp returned by GetAccountExpiry
+	mockErr      error
+	expiryErr    error            // Returned by GetAccountExpiry instead of mockErr when set
+	failAccounts map[string]error // Per-account errors returned by GetAccountBalance
+	balances     map[string]int64 // Per-account balances overriding mockBalance
+	block        chan struct{}    // When set, GetAccountBalance blocks until it is closed
 }
 
 func (m *MockClient) GetAccountBalance(accountID string) (int64, error) {
-	return 0, m.mockErr
+	if m.block != nil {
+		<-m.block
+	}
+	if err, ok := m.failAccounts[accountID]; ok {
+		return 0, err
+	}
+	if balance, ok := m.balances[accountID]; ok {
+		return balance, m.mockErr
+	}
+	return m.mockBalance, m.mockErr
 }
 
 func (m *MockClient) GetAccountInfo(accountID string) (*hiero.AccountInfo, error) {
@@ -327,13 +443,20 @@ func (m *MockClient) GetTransactionReceipt(transactionID string) (*hiero.Transac
 }
 
 func (m *MockClient) GetAccountExpiry(accountID string) (int64, error) {
-	return 0, m.mockErr
+	if m.expiryErr != nil {
+		return 0, m.expiryErr
+	}
+	return m.mockExpiry, m.mockErr
 }
 
 func (m *MockClient) GetNodeAddressBook() (*hiero.NodeAddressBook, error) {
 	return nil, m.mockErr
 }
 
+func (m *MockClient) GetTokenInfo(tokenID string) (hedera.TokenInfo, error) {
+	return hedera.TokenInfo{TokenID: tokenID}, m.mockErr
+}
+
 func (m *MockClient) Close() error {
 	return m.mockErr
 }
@@ -365,3 +488,315 @@ func TestNewAccountCollector_Initialization(t *testing.T) {
 		t.Error("expected interval to be set")
 	}
 }
+
+// mockAlertManager records metrics passed to CheckMetric
+type mockAlertManager struct {
+	checked []types.Metric
+}
+
+func (m *mockAlertManager) CheckMetric(metric types.Metric) error {
+	m.checked = append(m.checked, metric)
+	return nil
+}
+
+// TestCollectOnce_PartialFailure tests that one failing account doesn't block the others
+func TestCollectOnce_PartialFailure(t *testing.T) {
+	mockClient := &MockClient{
+		mockBalance: 5000,
+		failAccounts: map[string]error{
+			"0.0.5001": errors.New("account not found"),
+		},
+	}
+	accounts := []AccountConfig{
+		{ID: "0.0.5000", Label: "Account 1"},
+		{ID: "0.0.5001", Label: "Account 2"},
+		{ID: "0.0.5002", Label: "Account 3"},
+	}
+	collector := NewAccountCollector(mockClient, accounts)
+	store := storage.NewMemoryStorage()
+	alertMgr := &mockAlertManager{}
+
+	err := collector.collectOnce(context.Background(), store, alertMgr)
+	if err == nil {
+		t.Fatal("expected summary error for failed account")
+	}
+
+	var collErr *CollectionError
+	if !errors.As(err, &collErr) {
+		t.Fatalf("expected *CollectionError, got %T", err)
+	}
+	if len(collErr.Failed) != 1 || collErr.Failed[0] != "0.0.5001" {
+		t.Errorf("expected failed accounts [0.0.5001], got %v", collErr.Failed)
+	}
+	if collErr.Total != 3 {
+		t.Errorf("expected total 3, got %d", collErr.Total)
+	}
+	if !strings.Contains(err.Error(), "0.0.5001") {
+		t.Errorf("expected summary to name failed account, got: %s", err.Error())
+	}
+
+	// Successful accounts still have their balances stored
+	for _, id := range []string{"0.0.5000", "0.0.5002"} {
+		metrics, _ := store.GetMetricsByLabel("account_id", id)
+		found := false
+		for _, m := range metrics {
+			if m.Name == "account_balance" && m.Value == 5000 {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected account_balance stored for %s", id)
+		}
+	}
+
+	failedMetrics, _ := store.GetMetricsByLabel("account_id", "0.0.5001")
+	if len(failedMetrics) != 0 {
+		t.Errorf("expected no metrics for failed account, got %d", len(failedMetrics))
+	}
+
+	if len(alertMgr.checked) == 0 {
+		t.Error("expected successful metrics to be checked against alerts")
+	}
+}
+
+// TestCollectOnce_AllSucceed tests that a clean cycle returns no error
+func TestCollectOnce_AllSucceed(t *testing.T) {
+	mockClient := &MockClient{mockBalance: 100}
+	accounts := []AccountConfig{
+		{ID: "0.0.5000", Label: "Account 1"},
+		{ID: "0.0.5001", Label: "Account 2"},
+	}
+	collector := NewAccountCollector(mockClient, accounts)
+	store := storage.NewMemoryStorage()
+
+	if err := collector.collectOnce(context.Background(), store, &mockAlertManager{}); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+
+	metrics, _ := store.GetMetrics("account_balance", 0)
+	if len(metrics) != 2 {
+		t.Errorf("expected 2 balance metrics, got %d", len(metrics))
+	}
+}
+
+// TestCollectOnce_PortfolioBalance tests the portfolio total sums only the configured accounts
+func TestCollectOnce_PortfolioBalance(t *testing.T) {
+	mockClient := &MockClient{
+		balances: map[string]int64{
+			"0.0.5000": 1_000_000_000,
+			"0.0.5001": 250_000_000,
+			"0.0.5002": 75_000_000,
+		},
+	}
+	accounts := []AccountConfig{
+		{ID: "0.0.5000", Label: "Treasury"},
+		{ID: "0.0.5001", Label: "Trading"},
+		{ID: "0.0.5002", Label: "Unrelated"},
+	}
+	collector := NewAccountCollector(mockClient, accounts)
+	collector.SetPortfolioAccounts([]string{"0.0.5000", "0.0.5001"})
+	store := storage.NewMemoryStorage()
+	alertMgr := &mockAlertManager{}
+
+	if err := collector.collectOnce(context.Background(), store, alertMgr); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	metrics, _ := store.GetMetrics("portfolio_balance_total", 0)
+	if len(metrics) != 1 {
+		t.Fatalf("expected 1 portfolio metric, got %d", len(metrics))
+	}
+	if metrics[0].Value != 1_250_000_000 {
+		t.Errorf("expected portfolio total 1250000000, got %v", metrics[0].Value)
+	}
+	if metrics[0].Labels["accounts"] != "2" {
+		t.Errorf("expected accounts label 2, got %q", metrics[0].Labels["accounts"])
+	}
+
+	checked := false
+	for _, m := range alertMgr.checked {
+		if m.Name == "portfolio_balance_total" {
+			checked = true
+		}
+	}
+	if !checked {
+		t.Error("expected portfolio metric to be checked against alerts")
+	}
+}
+
+// TestCollectOnce_PortfolioBalanceSkippedOnFailure tests no partial total is stored
+func TestCollectOnce_PortfolioBalanceSkippedOnFailure(t *testing.T) {
+	mockClient := &MockClient{
+		mockBalance:  100,
+		failAccounts: map[string]error{"0.0.5001": errors.New("account not found")},
+	}
+	accounts := []AccountConfig{
+		{ID: "0.0.5000", Label: "Treasury"},
+		{ID: "0.0.5001", Label: "Trading"},
+	}
+	collector := NewAccountCollector(mockClient, accounts)
+	collector.SetPortfolioAccounts([]string{"0.0.5000", "0.0.5001"})
+	store := storage.NewMemoryStorage()
+
+	_ = collector.collectOnce(context.Background(), store, &mockAlertManager{})
+
+	if metrics, _ := store.GetMetrics("portfolio_balance_total", 0); len(metrics) != 0 {
+		t.Errorf("expected no portfolio metric when an account fails, got %v", metrics)
+	}
+}
+
+// TestBuildExpiryMetric tests the remaining-seconds math and labels
+func TestBuildExpiryMetric(t *testing.T) {
+	now := int64(1700000000)
+
+	metric := buildExpiryMetric(now+7*24*3600, now, "0.0.5000", "Treasury")
+
+	if metric.Name != "account_expiry_seconds_remaining" {
+		t.Errorf("expected name account_expiry_seconds_remaining, got %s", metric.Name)
+	}
+	if metric.Value != 7*24*3600 {
+		t.Errorf("expected %d seconds remaining, got %v", 7*24*3600, metric.Value)
+	}
+	if metric.Timestamp != now {
+		t.Errorf("expected timestamp %d, got %d", now, metric.Timestamp)
+	}
+	if metric.Labels["account_id"] != "0.0.5000" || metric.Labels["label"] != "Treasury" {
+		t.Errorf("unexpected labels: %v", metric.Labels)
+	}
+
+	expired := buildExpiryMetric(now-60, now, "0.0.5000", "Treasury")
+	if expired.Value != -60 {
+		t.Errorf("expected -60 for expired account, got %v", expired.Value)
+	}
+}
+
+// TestCollectOnce_ExpiryMetric tests that each account reports seconds until expiry
+func TestCollectOnce_ExpiryMetric(t *testing.T) {
+	expiry := time.Now().Add(30 * 24 * time.Hour).Unix()
+	mockClient := &MockClient{mockBalance: 100, mockExpiry: expiry}
+	collector := NewAccountCollector(mockClient, []AccountConfig{{ID: "0.0.5000", Label: "Account 1"}})
+	store := storage.NewMemoryStorage()
+
+	if err := collector.collectOnce(context.Background(), store, &mockAlertManager{}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	metrics, _ := store.GetMetrics("account_expiry_seconds_remaining", 0)
+	if len(metrics) != 1 {
+		t.Fatalf("expected 1 expiry metric, got %d", len(metrics))
+	}
+	// Allow a little slack for the time elapsed during collection
+	want := float64(30 * 24 * 3600)
+	if metrics[0].Value > want || metrics[0].Value < want-5 {
+		t.Errorf("expected about %v seconds remaining, got %v", want, metrics[0].Value)
+	}
+	if metrics[0].Labels["account_id"] != "0.0.5000" {
+		t.Errorf("expected account_id label 0.0.5000, got %s", metrics[0].Labels["account_id"])
+	}
+}
+
+// TestCollectOnce_ExpiryFailureKeepsRecords tests that a failed expiry query is reported
+// without dropping the account's transaction metrics
+func TestCollectOnce_ExpiryFailureKeepsRecords(t *testing.T) {
+	mockClient := &MockClient{
+		mockBalance: 100,
+		mockRecords: []hedera.Record{{AmountTinyBar: 5}, {AmountTinyBar: 7}},
+		expiryErr:   errors.New("expiry unavailable"),
+	}
+	collector := NewAccountCollector(mockClient, []AccountConfig{{ID: "0.0.5000", Label: "Account 1"}})
+	store := storage.NewMemoryStorage()
+
+	err := collector.collectOnce(context.Background(), store, &mockAlertManager{})
+	var collErr *CollectionError
+	if !errors.As(err, &collErr) || len(collErr.Failed) != 1 || collErr.Failed[0] != "0.0.5000" {
+		t.Fatalf("expected a CollectionError for 0.0.5000, got %v", err)
+	}
+
+	for _, name := range []string{"account_balance", "account_transaction_count", "account_total_volume"} {
+		if metrics, _ := store.GetMetrics(name, 0); len(metrics) != 1 {
+			t.Errorf("expected 1 %s metric after an expiry failure, got %d", name, len(metrics))
+		}
+	}
+	if metrics, _ := store.GetMetrics("account_expiry_seconds_remaining", 0); len(metrics) != 0 {
+		t.Errorf("expected no expiry metric after an expiry failure, got %d", len(metrics))
+	}
+	if volume, _ := store.GetMetrics("account_total_volume", 0); len(volume) == 1 && volume[0].Value != 12 {
+		t.Errorf("expected total volume 12, got %v", volume[0].Value)
+	}
+}
+
+// TestCollectOnce_QueryTimeout tests that a blocked query times out instead of stalling the cycle
+func TestCollectOnce_QueryTimeout(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	mockClient := &MockClient{mockBalance: 100, block: block}
+	collector := NewAccountCollector(mockClient, []AccountConfig{{ID: "0.0.5000", Label: "Account 1"}})
+	collector.SetQueryTimeout(20 * time.Millisecond)
+	store := storage.NewMemoryStorage()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- collector.collectOnce(context.Background(), store, &mockAlertManager{})
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected collection to time out, but it is still blocked")
+	}
+
+	var timeoutErr *QueryTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("Expected a QueryTimeoutError, got: %v", err)
+	}
+	if timeoutErr.Query != "GetAccountBalance" {
+		t.Errorf("Expected GetAccountBalance to time out, got %s", timeoutErr.Query)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Expected the error to match context.DeadlineExceeded")
+	}
+
+	metrics, _ := store.GetMetrics("collector_query_timeout", 0)
+	if len(metrics) != 1 {
+		t.Fatalf("Expected 1 collector_query_timeout metric, got %d", len(metrics))
+	}
+	if metrics[0].Labels["collector"] != "AccountCollector" || metrics[0].Labels["query"] != "GetAccountBalance" {
+		t.Errorf("Unexpected timeout metric labels: %v", metrics[0].Labels)
+	}
+}
+
+// TestCollectOnce_RecordsErrorClass tests failed accounts record collector_query_error
+// labelled with the error's class
+func TestCollectOnce_RecordsErrorClass(t *testing.T) {
+	mockClient := &MockClient{
+		mockBalance: 100,
+		failAccounts: map[string]error{
+			"0.0.5000": hedera.NewQueryError(hiero.ErrHederaPreCheckStatus{Status: hiero.StatusAccountDeleted}),
+			"0.0.5001": hedera.NewQueryError(hiero.ErrHederaPreCheckStatus{Status: hiero.StatusBusy}),
+		},
+	}
+	collector := NewAccountCollector(mockClient, []AccountConfig{
+		{ID: "0.0.5000", Label: "Deleted"},
+		{ID: "0.0.5001", Label: "Busy"},
+		{ID: "0.0.5002", Label: "Healthy"},
+	})
+	store := storage.NewMemoryStorage()
+
+	if err := collector.collectOnce(context.Background(), store, &mockAlertManager{}); err == nil {
+		t.Fatal("Expected a CollectionError for the failed accounts")
+	}
+
+	metrics, _ := store.GetMetrics("collector_query_error", 0)
+	classes := make(map[string]int)
+	for _, metric := range metrics {
+		if metric.Labels["collector"] != "AccountCollector" {
+			t.Errorf("Expected collector label AccountCollector, got %v", metric.Labels)
+		}
+		classes[metric.Labels["class"]]++
+	}
+	if len(metrics) != 2 || classes["permanent"] != 1 || classes["transient"] != 1 {
+		t.Errorf("Expected one permanent and one transient error, got %v", classes)
+	}
+}
@@ -2,11 +2,23 @@ package collector
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"math/rand"
+	"sync/atomic"
+	"time"
 
 	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
 	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/hedera"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
 )
 
+// DefaultQueryTimeout bounds each SDK query so one stuck call can't stall a cycle
+const DefaultQueryTimeout = 30 * time.Second
+
 // AlertManager is an interface for alert management
 // This interface allows collectors to depend on abstraction rather than concrete alerting.Manager
 type AlertManager interface {
@@ -24,9 +36,54 @@ type Collector interface {
 	Collect(ctx context.Context, store storage.Storage, alertMgr AlertManager) error
 }
 
+// OneShotCollector is a Collector that can also run a single collection cycle and
+// return, for cron-style collection and smoke tests
+type OneShotCollector interface {
+	Collector
+
+	// CollectOnce runs one collection cycle without jitter, then returns
+	CollectOnce(ctx context.Context, store storage.Storage, alertMgr AlertManager) error
+}
+
+// RunOnce runs a single cycle of each collector in order, so derived collectors
+// (e.g. USD prices from stored balances) see what earlier ones stored
+// A failing collector doesn't stop the rest; the failures are returned joined
+func RunOnce(ctx context.Context, collectors []Collector, store storage.Storage, alertMgr AlertManager) error {
+	var errs []error
+	for _, c := range collectors {
+		oneShot, ok := c.(OneShotCollector)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: one-shot collection not supported", c.Name()))
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		logger.Info("Running one-shot collection", "component", c.Name())
+		if err := oneShot.CollectOnce(ctx, store, alertMgr); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // BaseCollector provides common functionality for collectors
 type BaseCollector struct {
-	name string
+	name         string
+	jitter       time.Duration // Maximum random delay added before the first and each subsequent collection
+	queryTimeout time.Duration // Maximum time a single client query may take (0 = no timeout)
+	namespace    string        // Prefix joined to every emitted metric name with "_" (empty = none)
+	network      string        // Value of the "network" label added to every emitted metric (empty = none)
+	collected    atomic.Int64  // Metrics emitted since start, for the shutdown summary
+	lastCycle    atomic.Int64  // Unix time the last collection cycle finished (0 = never)
+	trigger      chan struct{} // Signals an on-demand collection cycle outside the ticker
+
+	roundDecimals int            // Decimal places for every emitted value (negative = no rounding)
+	roundByName   map[string]int // Decimal places by metric name, overriding roundDecimals
+
+	// Injectable for tests
+	randDuration func(max time.Duration) time.Duration
+	after        func(d time.Duration) <-chan time.Time
 }
 
 // Name returns the collector's name
@@ -34,7 +91,322 @@ func (bc *BaseCollector) Name() string {
 	return bc.name
 }
 
+// SetJitter sets the maximum random delay applied at startup and on each tick
+// Spreading queries across the interval avoids all collectors hitting the network at once
+func (bc *BaseCollector) SetJitter(jitter time.Duration) {
+	if jitter < 0 {
+		jitter = 0
+	}
+	bc.jitter = jitter
+}
+
+// Jitter returns the configured maximum jitter
+func (bc *BaseCollector) Jitter() time.Duration {
+	return bc.jitter
+}
+
+// SetQueryTimeout sets the maximum time a single client query may take
+// A non-positive timeout disables it, letting queries block until the SDK gives up
+func (bc *BaseCollector) SetQueryTimeout(timeout time.Duration) {
+	if timeout < 0 {
+		timeout = 0
+	}
+	bc.queryTimeout = timeout
+}
+
+// QueryTimeout returns the configured per-query timeout
+func (bc *BaseCollector) QueryTimeout() time.Duration {
+	return bc.queryTimeout
+}
+
+// SetNamespace sets a prefix applied to every metric name this collector emits, e.g.
+// "prod" turns account_balance into prod_account_balance, so several monitors can
+// share one push target without their series colliding. Empty disables the prefix
+func (bc *BaseCollector) SetNamespace(namespace string) {
+	bc.namespace = namespace
+}
+
+// Namespace returns the configured metric name prefix
+func (bc *BaseCollector) Namespace() string {
+	return bc.namespace
+}
+
+// SetNetwork labels every metric this collector emits with network=name, so metrics
+// from several monitored networks in one process stay distinguishable. Empty disables it
+func (bc *BaseCollector) SetNetwork(name string) {
+	bc.network = name
+}
+
+// Network returns the network label value added to emitted metrics
+func (bc *BaseCollector) Network() string {
+	return bc.network
+}
+
+// SetRounding rounds emitted values to byName[name] decimal places, or decimals for
+// metrics not in byName, before they are stored and checked against alert rules, so
+// float noise in derived values doesn't trip "changed" or equality conditions.
+// A negative decimals rounds only the named metrics
+func (bc *BaseCollector) SetRounding(decimals int, byName map[string]int) {
+	bc.roundDecimals = decimals
+	bc.roundByName = byName
+}
+
+// round applies the configured precision for the metric name to value
+func (bc *BaseCollector) round(name string, value float64) float64 {
+	decimals, ok := bc.roundByName[name]
+	if !ok {
+		decimals = bc.roundDecimals
+	}
+	if decimals < 0 {
+		return value
+	}
+	scale := math.Pow10(decimals)
+	return math.Round(value*scale) / scale
+}
+
+// MetricsCollected returns how many metrics this collector has emitted since it was created
+func (bc *BaseCollector) MetricsCollected() int64 {
+	return bc.collected.Load()
+}
+
+// LastCollection returns when the last collection cycle finished, or the zero time
+// if none has run yet
+func (bc *BaseCollector) LastCollection() time.Time {
+	if last := bc.lastCycle.Load(); last != 0 {
+		return time.Unix(last, 0)
+	}
+	return time.Time{}
+}
+
+// metricName applies the collector's namespace to a metric name
+func (bc *BaseCollector) metricName(name string) string {
+	if bc.namespace == "" {
+		return name
+	}
+	return bc.namespace + "_" + name
+}
+
+// QueryTimeoutError is returned when a client query doesn't finish within the query timeout
+type QueryTimeoutError struct {
+	Query   string        // Name of the client method that timed out, e.g. "GetAccountBalance"
+	Timeout time.Duration // The timeout that expired
+}
+
+// Error implements the error interface
+func (e *QueryTimeoutError) Error() string {
+	return fmt.Sprintf("%s timed out after %s", e.Query, e.Timeout)
+}
+
+// Unwrap returns context.DeadlineExceeded so callers can use errors.Is
+func (e *QueryTimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// callWithTimeout runs a blocking client query under a context bounded by timeout
+// The SDK queries don't accept a context, so the call runs in its own goroutine and
+// is abandoned on timeout; its result is discarded whenever it eventually returns
+// Returns a *QueryTimeoutError on timeout, or ctx's error if ctx is cancelled first
+func callWithTimeout[T any](ctx context.Context, timeout time.Duration, query string, call func() (T, error)) (T, error) {
+	if timeout <= 0 {
+		return call()
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1) // Buffered so an abandoned call doesn't leak a blocked goroutine
+	go func() {
+		value, err := call()
+		done <- result{value, err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.value, res.err
+	case <-queryCtx.Done():
+		var zero T
+		if ctx.Err() != nil {
+			return zero, ctx.Err()
+		}
+		return zero, &QueryTimeoutError{Query: query, Timeout: timeout}
+	}
+}
+
+// recordQueryError stores a collector_query_error metric labelled with the error's
+// class, so permanent failures (bad account, auth) can be alerted on separately from
+// transient ones, and a collector_query_timeout metric when err is a *QueryTimeoutError
+// Errors from the collector shutting down are not recorded
+func (bc *BaseCollector) recordQueryError(store storage.Storage, alertMgr AlertManager, err error) {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	bc.storeAndCheck(store, alertMgr, types.Metric{
+		Name:      "collector_query_error",
+		Timestamp: time.Now().Unix(),
+		Value:     1,
+		Labels: map[string]string{
+			"collector": bc.Name(),
+			"class":     hedera.ClassifyError(err).String(),
+		},
+	})
+	bc.recordQueryTimeout(store, alertMgr, err)
+}
+
+// recordQueryTimeout stores a collector_query_timeout metric when err is a *QueryTimeoutError
+// so stuck queries are visible and alertable rather than only logged
+func (bc *BaseCollector) recordQueryTimeout(store storage.Storage, alertMgr AlertManager, err error) {
+	var timeoutErr *QueryTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		return
+	}
+
+	logger.Warn("Client query timed out",
+		"component", bc.Name(),
+		"query", timeoutErr.Query,
+		"timeout", timeoutErr.Timeout)
+	bc.storeAndCheck(store, alertMgr, types.Metric{
+		Name:      "collector_query_timeout",
+		Timestamp: time.Now().Unix(),
+		Value:     1,
+		Labels: map[string]string{
+			"collector": bc.Name(),
+			"query":     timeoutErr.Query,
+		},
+	})
+}
+
+// runLoop runs cycle every interval until ctx is cancelled, plus whenever Trigger is
+// called. Scheduled cycles, including the first, wait a random jitter first so
+// collectors started together don't query simultaneously; triggered cycles run at once
+// Each cycle is timed with timeCycle. Returns the context error once stopped
+func (bc *BaseCollector) runLoop(ctx context.Context, store storage.Storage, alertMgr AlertManager, interval time.Duration, cycle func()) error {
+	if _, err := bc.waitJitter(ctx); err != nil {
+		logger.Info("Stopping collector", "component", bc.Name())
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Stopping collector", "component", bc.Name())
+			return ctx.Err()
+		case <-ticker.C:
+			if _, err := bc.waitJitter(ctx); err != nil {
+				logger.Info("Stopping collector", "component", bc.Name())
+				return err
+			}
+			bc.timeCycle(store, alertMgr, interval, cycle)
+		case <-bc.triggered():
+			logger.Info("Running triggered collection", "component", bc.Name())
+			bc.timeCycle(store, alertMgr, interval, cycle)
+		}
+	}
+}
+
+// timeCycle runs one collection cycle and stores how long it took as collector_cycle_duration_ms,
+// warning when it ran longer than interval so collectors falling behind can be alerted on
+func (bc *BaseCollector) timeCycle(store storage.Storage, alertMgr AlertManager, interval time.Duration, cycle func()) {
+	start := time.Now()
+	cycle()
+	elapsed := time.Since(start)
+	bc.lastCycle.Store(time.Now().Unix())
+
+	if 0 < interval && interval < elapsed {
+		logger.Warn("Collection cycle took longer than the interval",
+			"component", bc.Name(),
+			"duration", elapsed,
+			"interval", interval)
+	}
+	bc.storeAndCheck(store, alertMgr, types.Metric{
+		Name:      "collector_cycle_duration_ms",
+		Timestamp: time.Now().Unix(),
+		Value:     float64(elapsed) / float64(time.Millisecond),
+		Labels:    map[string]string{"collector": bc.Name()},
+	})
+}
+
+// storeAndCheck namespaces, rounds and network-labels a metric, stores it and checks it
+// against alert rules, logging failures
+func (bc *BaseCollector) storeAndCheck(store storage.Storage, alertMgr AlertManager, metric types.Metric) {
+	metric.Name = bc.metricName(metric.Name)
+	metric.Value = bc.round(metric.Name, metric.Value)
+	if bc.network != "" {
+		// Copy so a labels map shared between metrics isn't modified
+		labels := make(map[string]string, len(metric.Labels)+1)
+		maps.Copy(labels, metric.Labels)
+		labels["network"] = bc.network
+		metric.Labels = labels
+	}
+	bc.collected.Add(1)
+	// A series limit rejection is already counted and logged once by the limiter
+	if err := store.StoreMetric(metric); err != nil && !errors.Is(err, storage.ErrSeriesLimit) {
+		logger.Error("Error storing metric",
+			"component", bc.Name(),
+			"metric_name", metric.Name,
+			"error", err)
+	}
+	if err := alertMgr.CheckMetric(metric); err != nil {
+		logger.Error("Error checking alerts",
+			"component", bc.Name(),
+			"metric_name", metric.Name,
+			"error", err)
+	}
+}
+
+// Trigger requests an immediate collection cycle, independent of the ticker
+// Requests made while one is already pending are coalesced into a single cycle
+func (bc *BaseCollector) Trigger() {
+	select {
+	case bc.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// triggered returns the channel the Collect loop selects on for on-demand cycles
+func (bc *BaseCollector) triggered() <-chan struct{} {
+	return bc.trigger
+}
+
+// waitJitter blocks for a random duration in [0, jitter]
+// Returns the delay waited, or the context error if cancelled while waiting
+func (bc *BaseCollector) waitJitter(ctx context.Context) (time.Duration, error) {
+	if bc.jitter <= 0 {
+		return 0, nil
+	}
+
+	delay := bc.randDuration(bc.jitter)
+	select {
+	case <-ctx.Done():
+		return delay, ctx.Err()
+	case <-bc.after(delay):
+		return delay, nil
+	}
+}
+
+// randomDuration returns a uniformly distributed duration in [0, max]
+func randomDuration(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max) + 1))
+}
+
 // NewBaseCollector creates a new base collector
 func NewBaseCollector(name string) *BaseCollector {
-	return &BaseCollector{name: name}
+	return &BaseCollector{
+		name:         name,
+		queryTimeout: DefaultQueryTimeout,
+		trigger:      make(chan struct{}, 1),
+		randDuration: randomDuration,
+		after:        time.After,
+
+		roundDecimals: -1,
+	}
 }
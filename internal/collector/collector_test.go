@@ -0,0 +1,492 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+// TestWaitJitter_WithinBound tests the startup delay stays within the jitter bound
+func TestWaitJitter_WithinBound(t *testing.T) {
+	bc := NewBaseCollector("TestCollector")
+	bc.SetJitter(5 * time.Second)
+
+	var requested []time.Duration
+	bc.after = func(d time.Duration) <-chan time.Time {
+		requested = append(requested, d)
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	}
+
+	for i := 0; i < 100; i++ {
+		delay, err := bc.waitJitter(context.Background())
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if delay < 0 || 5*time.Second < delay {
+			t.Errorf("expected delay within [0, 5s], got %v", delay)
+		}
+	}
+
+	if len(requested) != 100 {
+		t.Errorf("expected 100 waits, got %d", len(requested))
+	}
+}
+
+// TestWaitJitter_Disabled tests that zero jitter never waits
+func TestWaitJitter_Disabled(t *testing.T) {
+	bc := NewBaseCollector("TestCollector")
+	bc.after = func(d time.Duration) <-chan time.Time {
+		t.Error("expected no wait when jitter is disabled")
+		return time.After(0)
+	}
+
+	delay, err := bc.waitJitter(context.Background())
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if delay != 0 {
+		t.Errorf("expected zero delay, got %v", delay)
+	}
+}
+
+// TestSetJitter_Negative tests that negative jitter is clamped to zero
+func TestSetJitter_Negative(t *testing.T) {
+	bc := NewBaseCollector("TestCollector")
+	bc.SetJitter(-time.Second)
+
+	if bc.Jitter() != 0 {
+		t.Errorf("expected jitter 0, got %v", bc.Jitter())
+	}
+}
+
+// TestCollect_FirstRunDelayedByJitter tests that Collect waits for the startup jitter
+// before its first collection and stops cleanly if cancelled while waiting
+func TestCollect_FirstRunDelayedByJitter(t *testing.T) {
+	nc := NewNetworkCollector(&MockClient{})
+	nc.SetJitter(10 * time.Second)
+	nc.randDuration = func(max time.Duration) time.Duration {
+		return max / 2
+	}
+
+	requested := make(chan time.Duration, 1)
+	nc.after = func(d time.Duration) <-chan time.Time {
+		requested <- d
+		// Never fire; the test cancels the context instead
+		return make(chan time.Time)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- nc.Collect(ctx, nil, nil)
+	}()
+
+	select {
+	case d := <-requested:
+		if d < 0 || 10*time.Second < d {
+			t.Errorf("expected startup delay within [0, 10s], got %v", d)
+		}
+		if d != 5*time.Second {
+			t.Errorf("expected startup delay 5s, got %v", d)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Collect to wait for startup jitter")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Collect to return after cancellation")
+	}
+}
+
+// TestCollect_TriggeredRun tests that Trigger runs a collection cycle immediately,
+// without waiting for the ticker
+func TestCollect_TriggeredRun(t *testing.T) {
+	mockClient := &MockClient{mockBalance: 5000}
+	ac := NewAccountCollector(mockClient, []AccountConfig{{ID: "0.0.5000", Label: "Account 1"}})
+	ac.interval = time.Hour
+	store := storage.NewMemoryStorage()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- ac.Collect(ctx, store, &mockAlertManager{})
+	}()
+
+	ac.Trigger()
+
+	deadline := time.After(time.Second)
+	for {
+		metrics, _ := store.GetMetrics("account_balance", 0)
+		if len(metrics) == 1 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("expected triggered run to store 1 balance metric, got %d", len(metrics))
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+// slowPriceSource returns a fixed price after a delay
+type slowPriceSource struct {
+	delay time.Duration
+}
+
+func (s slowPriceSource) GetHbarPriceUSD() (float64, error) {
+	time.Sleep(s.delay)
+	return 0.08, nil
+}
+
+// TestCollect_RecordsCycleDuration tests that each loop cycle stores collector_cycle_duration_ms
+// labelled with the collector's name
+func TestCollect_RecordsCycleDuration(t *testing.T) {
+	pc := NewPriceCollector(slowPriceSource{delay: 20 * time.Millisecond})
+	pc.interval = time.Hour
+	store := storage.NewMemoryStorage()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- pc.Collect(ctx, store, &mockAlertManager{})
+	}()
+
+	pc.Trigger()
+
+	var metrics []types.Metric
+	deadline := time.After(time.Second)
+	for len(metrics) == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("expected a collector_cycle_duration_ms metric after the triggered run")
+		case <-time.After(10 * time.Millisecond):
+		}
+		metrics, _ = store.GetMetrics("collector_cycle_duration_ms", 0)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	metric := metrics[0]
+	if metric.Labels["collector"] != "PriceCollector" {
+		t.Errorf("expected collector label PriceCollector, got %v", metric.Labels)
+	}
+	if metric.Value < 20 || 1000 < metric.Value {
+		t.Errorf("expected a duration between 20ms and 1s, got %vms", metric.Value)
+	}
+	if pc.LastCollection().IsZero() {
+		t.Error("expected LastCollection to be set after a cycle")
+	}
+}
+
+// TestCollectOnce_GlobalLabels tests that global labels are merged onto metrics from
+// different collectors without replacing labels the collectors set
+func TestCollectOnce_GlobalLabels(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	labeled := storage.NewLabelingStorage(store, map[string]string{"env": "prod", "region": "eu", "network": "global"})
+
+	ac := NewAccountCollector(&MockClient{mockBalance: 100}, []AccountConfig{{ID: "0.0.5000", Label: "Treasury"}})
+	ac.SetNetwork("testnet")
+	if err := ac.collectOnce(context.Background(), labeled, &mockAlertManager{}); err != nil {
+		t.Fatalf("account collectOnce failed: %v", err)
+	}
+	pc := NewPriceCollector(slowPriceSource{})
+	if err := pc.collectOnce(context.Background(), labeled, &mockAlertManager{}); err != nil {
+		t.Fatalf("price collectOnce failed: %v", err)
+	}
+
+	balances, _ := store.GetMetrics("account_balance", 0)
+	prices, _ := store.GetMetrics("hbar_price_usd", 0)
+	if len(balances) != 1 || len(prices) != 1 {
+		t.Fatalf("expected 1 balance and 1 price metric, got %d and %d", len(balances), len(prices))
+	}
+	for _, metric := range []types.Metric{balances[0], prices[0]} {
+		if metric.Labels["env"] != "prod" || metric.Labels["region"] != "eu" {
+			t.Errorf("expected env and region on %s, got %v", metric.Name, metric.Labels)
+		}
+	}
+	if balances[0].Labels["network"] != "testnet" || balances[0].Labels["account_id"] != "0.0.5000" {
+		t.Errorf("expected the collector's labels to win, got %v", balances[0].Labels)
+	}
+	if prices[0].Labels["network"] != "global" {
+		t.Errorf("expected the global network label where the collector sets none, got %v", prices[0].Labels)
+	}
+}
+
+// TestStoreAndCheck_Rounds tests that the configured precision applies to both the
+// stored metric and the one checked against alert rules
+func TestStoreAndCheck_Rounds(t *testing.T) {
+	bc := NewBaseCollector("test")
+	bc.SetRounding(2, map[string]int{"account_balance": 0})
+	store := storage.NewMemoryStorage()
+	alertMgr := &mockAlertManager{}
+
+	bc.storeAndCheck(store, alertMgr, types.Metric{Name: "account_balance_usd", Value: 12.345})
+	bc.storeAndCheck(store, alertMgr, types.Metric{Name: "account_balance", Value: 500000000.4})
+
+	usd, _ := store.GetMetrics("account_balance_usd", 0)
+	balance, _ := store.GetMetrics("account_balance", 0)
+	if len(usd) != 1 || usd[0].Value != 12.35 || len(balance) != 1 || balance[0].Value != 500000000 {
+		t.Errorf("expected stored values 12.35 and 500000000, got %v and %v", usd, balance)
+	}
+	if len(alertMgr.checked) != 2 || alertMgr.checked[0].Value != 12.35 || alertMgr.checked[1].Value != 500000000 {
+		t.Errorf("expected alerts to check the rounded values, got %v", alertMgr.checked)
+	}
+
+	// Without rounding configured values pass through unchanged
+	tenth, fifth := 0.1, 0.2
+	raw := NewBaseCollector("raw")
+	raw.storeAndCheck(store, alertMgr, types.Metric{Name: "hbar_price_usd", Value: tenth + fifth})
+	if got := alertMgr.checked[2].Value; got != tenth+fifth {
+		t.Errorf("expected an unrounded value by default, got %v", got)
+	}
+}
+
+// TestTrigger_Coalesces tests that repeated triggers before a run don't block
+func TestTrigger_Coalesces(t *testing.T) {
+	bc := NewBaseCollector("test")
+
+	bc.Trigger()
+	bc.Trigger()
+
+	select {
+	case <-bc.triggered():
+	default:
+		t.Fatal("expected a pending trigger")
+	}
+	select {
+	case <-bc.triggered():
+		t.Error("expected repeated triggers to be coalesced")
+	default:
+	}
+}
+
+// TestCallWithTimeout_Completes tests that a fast query returns its result
+func TestCallWithTimeout_Completes(t *testing.T) {
+	value, err := callWithTimeout(context.Background(), time.Second, "Query", func() (int, error) {
+		return 42, nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if value != 42 {
+		t.Errorf("Expected 42, got %d", value)
+	}
+}
+
+// TestCallWithTimeout_ParentCancelled tests that cancelling the parent context isn't reported as a timeout
+func TestCallWithTimeout_ParentCancelled(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := callWithTimeout(ctx, time.Minute, "Query", func() (int, error) {
+		<-block
+		return 0, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got: %v", err)
+	}
+	var timeoutErr *QueryTimeoutError
+	if errors.As(err, &timeoutErr) {
+		t.Error("Expected cancellation not to be reported as a query timeout")
+	}
+}
+
+// TestSetQueryTimeout_Default tests collectors start with the default query timeout
+func TestSetQueryTimeout_Default(t *testing.T) {
+	bc := NewBaseCollector("TestCollector")
+	if bc.QueryTimeout() != DefaultQueryTimeout {
+		t.Errorf("Expected default timeout %s, got %s", DefaultQueryTimeout, bc.QueryTimeout())
+	}
+
+	bc.SetQueryTimeout(-time.Second)
+	if bc.QueryTimeout() != 0 {
+		t.Errorf("Expected negative timeout to disable it, got %s", bc.QueryTimeout())
+	}
+}
+
+// TestSetNamespace_PrefixesMetricNames tests the namespace is applied to account and network metrics
+func TestSetNamespace_PrefixesMetricNames(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	alertMgr := &mockAlertManager{}
+
+	account := NewAccountCollector(&MockClient{mockBalance: 100}, []AccountConfig{{ID: "0.0.5000", Label: "Main"}})
+	account.SetNamespace("prod")
+	if err := account.collectOnce(context.Background(), store, alertMgr); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	network := NewNetworkCollector(&MockClient{mockErr: errors.New("network down")})
+	network.SetNamespace("prod")
+	network.collectOnce(context.Background(), store, alertMgr)
+
+	for _, name := range []string{"prod_account_balance", "prod_account_transaction_count", "prod_network_consensus_active"} {
+		metrics, _ := store.GetMetrics(name, 0)
+		if len(metrics) == 0 {
+			t.Errorf("Expected metrics named %s", name)
+		}
+	}
+
+	all, _ := store.GetMetrics("", 0)
+	for _, metric := range all {
+		if !strings.HasPrefix(metric.Name, "prod_") {
+			t.Errorf("Expected every metric to be namespaced, got %s", metric.Name)
+		}
+	}
+}
+
+// TestSetNetwork_LabelsMetrics tests every emitted metric carries the network label
+func TestSetNetwork_LabelsMetrics(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	account := NewAccountCollector(&MockClient{mockBalance: 100}, []AccountConfig{{ID: "0.0.5000", Label: "Main"}})
+	account.SetNetwork("mainnet")
+	if err := account.collectOnce(context.Background(), store, &mockAlertManager{}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	all, _ := store.GetMetrics("", 0)
+	if len(all) == 0 {
+		t.Fatal("Expected metrics to be stored")
+	}
+	for _, metric := range all {
+		if metric.Labels["network"] != "mainnet" {
+			t.Errorf("Expected %s to have network=mainnet, got labels %v", metric.Name, metric.Labels)
+		}
+	}
+}
+
+// TestSetNamespace_DefaultEmpty tests metric names are unchanged without a namespace
+func TestSetNamespace_DefaultEmpty(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	account := NewAccountCollector(&MockClient{mockBalance: 100}, []AccountConfig{{ID: "0.0.5000", Label: "Main"}})
+	if account.Namespace() != "" {
+		t.Errorf("Expected no default namespace, got %q", account.Namespace())
+	}
+
+	if err := account.collectOnce(context.Background(), store, &mockAlertManager{}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	metrics, _ := store.GetMetrics("account_balance", 0)
+	if len(metrics) != 1 {
+		t.Errorf("Expected 1 account_balance metric, got %d", len(metrics))
+	}
+}
+
+// TestMetricsCollected_Counts tests the collected counter increments for every emitted metric
+func TestMetricsCollected_Counts(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	account := NewAccountCollector(&MockClient{mockBalance: 100}, []AccountConfig{{ID: "0.0.5000", Label: "Main"}})
+	if account.MetricsCollected() != 0 {
+		t.Fatalf("Expected 0 metrics before collecting, got %d", account.MetricsCollected())
+	}
+
+	if err := account.collectOnce(context.Background(), store, &mockAlertManager{}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	stored, _ := store.GetMetrics("", 0)
+	if account.MetricsCollected() != int64(len(stored)) {
+		t.Errorf("Expected %d metrics collected, got %d", len(stored), account.MetricsCollected())
+	}
+
+	if err := account.collectOnce(context.Background(), store, &mockAlertManager{}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if account.MetricsCollected() != 2*int64(len(stored)) {
+		t.Errorf("Expected %d metrics after two cycles, got %d", 2*len(stored), account.MetricsCollected())
+	}
+}
+
+// loopOnlyCollector is a collector without one-shot support
+type loopOnlyCollector struct{}
+
+func (loopOnlyCollector) Name() string { return "LoopOnlyCollector" }
+
+func (loopOnlyCollector) Collect(ctx context.Context, store storage.Storage, alertMgr AlertManager) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// TestRunOnce_StoresMetricsAndReturns tests that a one-shot run stores each collector's
+// metrics and returns without waiting for a ticker
+func TestRunOnce_StoresMetricsAndReturns(t *testing.T) {
+	accounts := NewAccountCollector(&MockClient{mockBalance: 100}, []AccountConfig{{ID: "0.0.5000", Label: "Main"}})
+	// A long interval and jitter would block if the loop ran instead of a single cycle
+	accounts.interval = time.Hour
+	accounts.SetJitter(time.Hour)
+	network := NewNetworkCollector(&MockClient{mockErr: errors.New("address book unavailable")})
+	store := storage.NewMemoryStorage()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- RunOnce(context.Background(), []Collector{accounts, network, loopOnlyCollector{}}, store, &mockAlertManager{})
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected RunOnce to return after a single cycle")
+	}
+
+	if err == nil || !strings.Contains(err.Error(), "LoopOnlyCollector") {
+		t.Errorf("Expected an error naming the collector without one-shot support, got: %v", err)
+	}
+	if balances, _ := store.GetMetrics("account_balance", 0); len(balances) != 1 {
+		t.Errorf("Expected 1 account_balance metric, got %d", len(balances))
+	}
+	if consensus, _ := store.GetMetrics("network_consensus_active", 0); len(consensus) != 1 {
+		t.Errorf("Expected 1 network_consensus_active metric, got %d", len(consensus))
+	}
+}
+
+// TestRunLoop_TriggerAndStop tests that the shared loop runs triggered cycles, times them,
+// and returns the context error once cancelled
+func TestRunLoop_TriggerAndStop(t *testing.T) {
+	bc := NewBaseCollector("LoopCollector")
+	store := storage.NewMemoryStorage()
+	ctx, cancel := context.WithCancel(context.Background())
+	cycles := make(chan struct{}, 1)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- bc.runLoop(ctx, store, &mockAlertManager{}, time.Hour, func() { cycles <- struct{}{} })
+	}()
+
+	bc.Trigger()
+	select {
+	case <-cycles:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a triggered cycle to run")
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if durations, _ := store.GetMetrics("collector_cycle_duration_ms", 0); len(durations) != 1 {
+		t.Errorf("expected 1 cycle duration metric, got %d", len(durations))
+	}
+}
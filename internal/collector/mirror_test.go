@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/hedera"
+)
+
+// TestMirrorHealthCollector_UpThenDown tests mirror_node_up follows the mirror node's
+// reachability and latency is only stored while it is up
+func TestMirrorHealthCollector_UpThenDown(t *testing.T) {
+	var down atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"total_supply":"5000000000000000000"}`))
+	}))
+	defer server.Close()
+
+	store := storage.NewMemoryStorage()
+	mc := NewMirrorHealthCollector(hedera.NewMirrorClient(server.URL))
+
+	mc.collectOnce(context.Background(), store, &mockAlertManager{})
+	down.Store(true)
+	mc.collectOnce(context.Background(), store, &mockAlertManager{})
+	// A closed server refuses connections, the other way a mirror node goes down
+	server.Close()
+	mc.collectOnce(context.Background(), store, &mockAlertManager{})
+
+	up, _ := store.GetMetrics("mirror_node_up", 0)
+	if len(up) != 3 {
+		t.Fatalf("Expected 3 mirror_node_up metrics, got %d", len(up))
+	}
+	for i, want := range []float64{1, 0, 0} {
+		if up[i].Value != want {
+			t.Errorf("Expected mirror_node_up %v on probe %d, got %v", want, i+1, up[i].Value)
+		}
+	}
+
+	latency, _ := store.GetMetrics("mirror_node_latency_ms", 0)
+	if len(latency) != 1 {
+		t.Errorf("Expected latency only for the successful probe, got %d metrics", len(latency))
+	}
+	if len(latency) == 1 && latency[0].Value < 0 {
+		t.Errorf("Expected non-negative latency, got %v", latency[0].Value)
+	}
+}
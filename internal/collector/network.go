@@ -73,81 +73,71 @@ func buildPerNodeMetrics(NodeAddresses []hiero.NodeAddress, networkName string)
 	return metrics
 }
 
+// CollectOnce implements the OneShotCollector interface
+// An unreachable network is reported through network_consensus_active, not an error
+func (nc *NetworkCollector) CollectOnce(ctx context.Context, store storage.Storage, alertMgr AlertManager) error {
+	nc.collectOnce(ctx, store, alertMgr)
+	return nil
+}
+
 // Collect implements the Collector interface
 func (nc *NetworkCollector) Collect(ctx context.Context, store storage.Storage, alertMgr AlertManager) error {
-	ticker := time.NewTicker(nc.interval)
-	defer ticker.Stop()
-
 	logger.Info("Starting network collector",
 		"component", nc.Name(),
-		"interval", nc.interval)
-
-	for {
-		select {
-		case <-ctx.Done():
-			logger.Info("Stopping collector", "component", nc.Name())
-			return ctx.Err()
-		case <-ticker.C:
-			logger.Debug("Collecting metrics", "component", nc.Name())
-
-			// Track if address book query was successful (for consensus status metric)
-			consensusValue := 0.0
-			allMetrics := make([]types.Metric, 0)
-
-			// 1. Query network info (available nodes, versions, etc.)
-			addressBook, err := nc.client.GetNodeAddressBook()
-			if err == nil {
-				// Network is up
-				consensusValue = 1.0
-
-				// TASK 1 - Node Count Metric
-				nodeCount := len(addressBook.NodeAddresses)
-				allMetrics = append(allMetrics, types.Metric{
-					Name:      "network_nodes_available",
-					Timestamp: time.Now().Unix(),
-					Value:     float64(nodeCount),
-					Labels: map[string]string{
-						"network": nc.Name(),
-					},
-				})
-
-				// TASK 2 & 3 - Per-Node Availability and Endpoint Metrics
-				perNodeMetrics := buildPerNodeMetrics(addressBook.NodeAddresses, nc.Name())
-				allMetrics = append(allMetrics, perNodeMetrics...)
-
-				logger.Info("Completed metric collection from address book",
-					"component", nc.Name(),
-					"nodes", len(addressBook.NodeAddresses))
-			} else {
-				logger.Error("Skipped metric collection due to address book error",
-					"component", nc.Name(),
-					"error", err)
-				// Network is down -> report 0 for consensus metric
-			}
-
-			// TASK 4 - Network Consensus Status
-			allMetrics = append(allMetrics, types.Metric{
-				Name:      "network_consensus_active",
-				Timestamp: time.Now().Unix(),
-				Value:     consensusValue,
-				Labels:    map[string]string{"network": nc.Name()},
-			})
-
-			// Store and check all metrics
-			for _, metric := range allMetrics {
-				if err := store.StoreMetric(metric); err != nil {
-					logger.Error("Error storing metric",
-						"component", nc.Name(),
-						"metric_name", metric.Name,
-						"error", err)
-				}
-				if err := alertMgr.CheckMetric(metric); err != nil {
-					logger.Error("Error checking alerts",
-						"component", nc.Name(),
-						"metric_name", metric.Name,
-						"error", err)
-				}
-			}
-		}
+		"interval", nc.interval,
+		"jitter", nc.Jitter())
+
+	return nc.runLoop(ctx, store, alertMgr, nc.interval, func() { nc.collectOnce(ctx, store, alertMgr) })
+}
+
+// collectOnce runs a single collection cycle, storing and checking all metrics
+// An address book query that exceeds the query timeout counts as the network being down
+func (nc *NetworkCollector) collectOnce(ctx context.Context, store storage.Storage, alertMgr AlertManager) {
+	logger.Debug("Collecting metrics", "component", nc.Name())
+
+	allMetrics := make([]types.Metric, 0)
+
+	// 1. Query network info (available nodes, versions, etc.)
+	addressBook, err := callWithTimeout(ctx, nc.queryTimeout, "GetNodeAddressBook", nc.client.GetNodeAddressBook)
+	// A successful address book query means the network is up (for the consensus status metric)
+	consensusActive := err == nil
+	if consensusActive {
+		// TASK 1 - Node Count Metric
+		nodeCount := len(addressBook.NodeAddresses)
+		allMetrics = append(allMetrics, types.Metric{
+			Name:      "network_nodes_available",
+			Timestamp: time.Now().Unix(),
+			Value:     float64(nodeCount),
+			Labels: map[string]string{
+				"network": nc.Name(),
+			},
+		})
+
+		// TASK 2 & 3 - Per-Node Availability and Endpoint Metrics
+		perNodeMetrics := buildPerNodeMetrics(addressBook.NodeAddresses, nc.Name())
+		allMetrics = append(allMetrics, perNodeMetrics...)
+
+		logger.Info("Completed metric collection from address book",
+			"component", nc.Name(),
+			"nodes", len(addressBook.NodeAddresses))
+	} else {
+		logger.Error("Skipped metric collection due to address book error",
+			"component", nc.Name(),
+			"error", err)
+		nc.recordQueryError(store, alertMgr, err)
+		// Network is down -> report 0 for consensus metric
+	}
+
+	// TASK 4 - Network Consensus Status
+	allMetrics = append(allMetrics, types.Metric{
+		Name:      "network_consensus_active",
+		Timestamp: time.Now().Unix(),
+		Value:     hedera.ConsensusValue(consensusActive),
+		Labels:    map[string]string{"network": nc.Name()},
+	})
+
+	// Store and check all metrics
+	for _, metric := range allMetrics {
+		nc.storeAndCheck(store, alertMgr, metric)
 	}
 }
@@ -0,0 +1,116 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/hedera"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+)
+
+// DefaultOperatorBalanceFloor is the operator balance (in tinybar) below which a warning is logged
+const DefaultOperatorBalanceFloor = 1 * hedera.TinybarPerHbar
+
+// OperatorCollector collects the balance of the operator account that pays for queries
+// If the operator runs out of HBAR all other collection stops, so a warning is logged
+// whenever the balance drops below a configured floor
+type OperatorCollector struct {
+	*BaseCollector
+	client     hedera.Client
+	operatorID string
+	floor      int64 // Tinybar; 0 disables the low-balance warning
+	interval   time.Duration
+	belowFloor bool // Whether the last observed balance was below the floor
+}
+
+// NewOperatorCollector creates a new operator balance collector
+func NewOperatorCollector(client hedera.Client, operatorID string, floor int64) *OperatorCollector {
+	return &OperatorCollector{
+		BaseCollector: NewBaseCollector("OperatorCollector"),
+		client:        client,
+		operatorID:    operatorID,
+		floor:         floor,
+		interval:      ParseInterval(os.Getenv("COLLECTOR_INTERVAL")),
+	}
+}
+
+// collectOnce queries the operator balance, stores it as operator_balance and
+// logs a warning when the balance first drops below the floor
+func (oc *OperatorCollector) collectOnce(ctx context.Context, store storage.Storage, alertMgr AlertManager) error {
+	balance, err := callWithTimeout(ctx, oc.queryTimeout, "GetAccountBalance", func() (int64, error) {
+		return oc.client.GetAccountBalance(oc.operatorID)
+	})
+	if err != nil {
+		oc.recordQueryError(store, alertMgr, err)
+		return fmt.Errorf("error getting operator balance: %w", err)
+	}
+
+	metric := types.Metric{
+		Name:      "operator_balance",
+		Timestamp: time.Now().Unix(),
+		Value:     float64(balance),
+		Labels: map[string]string{
+			"account_id": oc.operatorID,
+		},
+	}
+
+	oc.checkFloor(balance)
+
+	oc.storeAndCheck(store, alertMgr, metric)
+	return nil
+}
+
+// checkFloor logs when the balance crosses the floor in either direction
+// Only transitions are logged so a low balance doesn't warn on every cycle
+func (oc *OperatorCollector) checkFloor(balance int64) {
+	if oc.floor <= 0 {
+		return
+	}
+
+	below := balance < oc.floor
+	if below && !oc.belowFloor {
+		logger.Warn("Operator balance below floor; queries will fail once it runs out",
+			"component", oc.Name(),
+			"account_id", oc.operatorID,
+			"balance_tinybar", balance,
+			"floor_tinybar", oc.floor)
+	} else if !below && oc.belowFloor {
+		logger.Info("Operator balance recovered above floor",
+			"component", oc.Name(),
+			"account_id", oc.operatorID,
+			"balance_tinybar", balance,
+			"floor_tinybar", oc.floor)
+	}
+	oc.belowFloor = below
+}
+
+// CollectOnce implements the OneShotCollector interface
+func (oc *OperatorCollector) CollectOnce(ctx context.Context, store storage.Storage, alertMgr AlertManager) error {
+	return oc.collectOnce(ctx, store, alertMgr)
+}
+
+// Collect implements the Collector interface
+func (oc *OperatorCollector) Collect(ctx context.Context, store storage.Storage, alertMgr AlertManager) error {
+	logger.Info("Starting operator collector",
+		"component", oc.Name(),
+		"interval", oc.interval,
+		"jitter", oc.Jitter(),
+		"account_id", oc.operatorID,
+		"floor_tinybar", oc.floor)
+
+	return oc.runLoop(ctx, store, alertMgr, oc.interval, func() { oc.runCycle(ctx, store, alertMgr) })
+}
+
+// runCycle runs one collection cycle, logging rather than returning failures
+func (oc *OperatorCollector) runCycle(ctx context.Context, store storage.Storage, alertMgr AlertManager) {
+	if err := oc.collectOnce(ctx, store, alertMgr); err != nil {
+		logger.Error("Error collecting operator balance",
+			"component", oc.Name(),
+			"account_id", oc.operatorID,
+			"error", err)
+	}
+}
@@ -0,0 +1,92 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+)
+
+// captureLogs redirects the default logger to a buffer for the duration of a test
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	logger.Init(logger.LevelDebug, &buf)
+	t.Cleanup(func() { logger.Init(logger.LevelInfo, os.Stdout) })
+	return &buf
+}
+
+// TestOperatorCollector_FloorCrossing tests the metric and warning when the balance drops below the floor
+func TestOperatorCollector_FloorCrossing(t *testing.T) {
+	logs := captureLogs(t)
+	mockClient := &MockClient{mockBalance: 500}
+	collector := NewOperatorCollector(mockClient, "0.0.1001", 100)
+	store := storage.NewMemoryStorage()
+	alertMgr := &mockAlertManager{}
+
+	// Above the floor: metric stored, no warning
+	if err := collector.collectOnce(context.Background(), store, alertMgr); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if strings.Contains(logs.String(), "below floor") {
+		t.Errorf("expected no warning above floor, got: %s", logs.String())
+	}
+
+	// Drop below the floor twice; the warning is logged only on the crossing
+	mockClient.mockBalance = 50
+	for i := 0; i < 2; i++ {
+		if err := collector.collectOnce(context.Background(), store, alertMgr); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+	if n := strings.Count(logs.String(), "Operator balance below floor"); n != 1 {
+		t.Errorf("expected 1 low-balance warning, got %d: %s", n, logs.String())
+	}
+	if !strings.Contains(logs.String(), "level=WARN") {
+		t.Errorf("expected warning level log, got: %s", logs.String())
+	}
+
+	metrics, _ := store.GetMetrics("operator_balance", 0)
+	if len(metrics) != 3 {
+		t.Fatalf("expected 3 operator_balance metrics, got %d", len(metrics))
+	}
+	last := metrics[len(metrics)-1]
+	if last.Value != 50 {
+		t.Errorf("expected latest balance 50, got %f", last.Value)
+	}
+	if last.Labels["account_id"] != "0.0.1001" {
+		t.Errorf("expected account_id 0.0.1001, got %s", last.Labels["account_id"])
+	}
+	if len(alertMgr.checked) != 3 {
+		t.Errorf("expected 3 metrics checked against alerts, got %d", len(alertMgr.checked))
+	}
+
+	// Recovery is logged and re-arms the warning
+	mockClient.mockBalance = 200
+	if err := collector.collectOnce(context.Background(), store, alertMgr); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !strings.Contains(logs.String(), "Operator balance recovered above floor") {
+		t.Errorf("expected recovery log, got: %s", logs.String())
+	}
+	if collector.belowFloor {
+		t.Error("expected below-floor state to clear after recovery")
+	}
+}
+
+// TestOperatorCollector_FloorDisabled tests that a zero floor never warns
+func TestOperatorCollector_FloorDisabled(t *testing.T) {
+	logs := captureLogs(t)
+	collector := NewOperatorCollector(&MockClient{mockBalance: 0}, "0.0.1001", 0)
+
+	if err := collector.collectOnce(context.Background(), storage.NewMemoryStorage(), &mockAlertManager{}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if strings.Contains(logs.String(), "below floor") {
+		t.Errorf("expected no warning with floor disabled, got: %s", logs.String())
+	}
+}
@@ -0,0 +1,134 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/hedera"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+)
+
+// PriceSource provides the current USD price of one HBAR, e.g. *hedera.MirrorClient
+type PriceSource interface {
+	GetHbarPriceUSD() (float64, error)
+}
+
+// PriceCollector collects the HBAR price in USD and converts the latest stored
+// account balances to USD, so alerts can be written in business terms
+type PriceCollector struct {
+	*BaseCollector
+	source   PriceSource
+	interval time.Duration
+}
+
+// NewPriceCollector creates a new HBAR price collector
+func NewPriceCollector(source PriceSource) *PriceCollector {
+	return &PriceCollector{
+		BaseCollector: NewBaseCollector("PriceCollector"),
+		source:        source,
+		interval:      ParseInterval(os.Getenv("COLLECTOR_INTERVAL")),
+	}
+}
+
+// buildBalanceUSDMetric converts an account_balance metric (tinybar) to an
+// account_balance_usd metric at priceUSD per HBAR, keeping the balance's labels
+func buildBalanceUSDMetric(balance types.Metric, priceUSD float64, now int64) types.Metric {
+	return types.Metric{
+		Name:      "account_balance_usd",
+		Timestamp: now,
+		Value:     balance.Value / hedera.TinybarPerHbar * priceUSD,
+		Labels:    maps.Clone(balance.Labels),
+	}
+}
+
+// latestBalances returns the newest stored account_balance per account emitted
+// under this collector's namespace and network. Accounts whose newest balance is
+// older than one collection interval plus jitter are skipped, so a balance that
+// stopped updating isn't re-priced as if it were current
+func (pc *PriceCollector) latestBalances(store storage.Storage, now int64) ([]types.Metric, error) {
+	metrics, err := store.GetMetrics(pc.metricName("account_balance"), 0)
+	if err != nil {
+		return nil, err
+	}
+	if pc.network != "" {
+		onNetwork := metrics[:0:0]
+		for _, metric := range metrics {
+			if metric.Labels["network"] == pc.network {
+				onNetwork = append(onNetwork, metric)
+			}
+		}
+		metrics = onNetwork
+	}
+
+	cutoff := now - int64((pc.interval + pc.Jitter()).Seconds())
+	fresh := make([]types.Metric, 0, len(metrics))
+	for _, balance := range storage.LatestPerSeries(metrics) {
+		if balance.Timestamp < cutoff {
+			logger.Debug("Skipping stale balance for USD conversion",
+				"component", pc.Name(),
+				"account_id", balance.Labels["account_id"],
+				"timestamp", balance.Timestamp)
+			continue
+		}
+		fresh = append(fresh, balance)
+	}
+	return fresh, nil
+}
+
+// collectOnce stores hbar_price_usd and an account_balance_usd for every account
+// with a recent stored balance. A failed price query stores nothing, so USD values are
+// never derived from a missing price
+func (pc *PriceCollector) collectOnce(ctx context.Context, store storage.Storage, alertMgr AlertManager) error {
+	price, err := callWithTimeout(ctx, pc.queryTimeout, "GetHbarPriceUSD", pc.source.GetHbarPriceUSD)
+	if err != nil {
+		pc.recordQueryError(store, alertMgr, err)
+		return fmt.Errorf("error getting HBAR price: %w", err)
+	}
+
+	now := time.Now().Unix()
+	pc.storeAndCheck(store, alertMgr, types.Metric{
+		Name:      "hbar_price_usd",
+		Timestamp: now,
+		Value:     price,
+		Labels:    map[string]string{},
+	})
+
+	balances, err := pc.latestBalances(store, now)
+	if err != nil {
+		return fmt.Errorf("error reading account balances: %w", err)
+	}
+	for _, balance := range balances {
+		pc.storeAndCheck(store, alertMgr, buildBalanceUSDMetric(balance, price, now))
+	}
+	return nil
+}
+
+// CollectOnce implements the OneShotCollector interface
+func (pc *PriceCollector) CollectOnce(ctx context.Context, store storage.Storage, alertMgr AlertManager) error {
+	return pc.collectOnce(ctx, store, alertMgr)
+}
+
+// Collect implements the Collector interface
+func (pc *PriceCollector) Collect(ctx context.Context, store storage.Storage, alertMgr AlertManager) error {
+	logger.Info("Starting price collector",
+		"component", pc.Name(),
+		"interval", pc.interval,
+		"jitter", pc.Jitter())
+
+	return pc.runLoop(ctx, store, alertMgr, pc.interval, func() { pc.runCycle(ctx, store, alertMgr) })
+}
+
+// runCycle runs one collection cycle, logging rather than returning failures so
+// an unavailable price feed never stops the collector
+func (pc *PriceCollector) runCycle(ctx context.Context, store storage.Storage, alertMgr AlertManager) {
+	if err := pc.collectOnce(ctx, store, alertMgr); err != nil {
+		logger.Warn("Error collecting HBAR price",
+			"component", pc.Name(),
+			"error", err)
+	}
+}
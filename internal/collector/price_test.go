@@ -0,0 +1,143 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/hedera"
+)
+
+// mockPriceSource returns a fixed price or error
+type mockPriceSource struct {
+	price float64
+	err   error
+}
+
+func (m *mockPriceSource) GetHbarPriceUSD() (float64, error) {
+	return m.price, m.err
+}
+
+// TestBuildBalanceUSDMetric tests converting a tinybar balance to USD
+func TestBuildBalanceUSDMetric(t *testing.T) {
+	balance := types.Metric{
+		Name:   "account_balance",
+		Value:  250 * hedera.TinybarPerHbar,
+		Labels: map[string]string{"account_id": "0.0.5000", "label": "Main"},
+	}
+
+	metric := buildBalanceUSDMetric(balance, 0.08, 1700000000)
+
+	if metric.Name != "account_balance_usd" {
+		t.Errorf("Expected name account_balance_usd, got %s", metric.Name)
+	}
+	if math.Abs(metric.Value-20.0) > 1e-9 {
+		t.Errorf("Expected 250 HBAR at $0.08 to be $20, got %v", metric.Value)
+	}
+	if metric.Timestamp != 1700000000 {
+		t.Errorf("Expected timestamp 1700000000, got %d", metric.Timestamp)
+	}
+	if metric.Labels["account_id"] != "0.0.5000" || metric.Labels["label"] != "Main" {
+		t.Errorf("Expected balance labels to be kept, got %v", metric.Labels)
+	}
+
+	// The source metric's labels must not be shared
+	metric.Labels["account_id"] = "changed"
+	if balance.Labels["account_id"] != "0.0.5000" {
+		t.Error("Expected balance labels to be copied, not shared")
+	}
+}
+
+// TestPriceCollector_DerivesLatestBalances tests the price and USD balances are stored
+// using only the newest balance per account
+func TestPriceCollector_DerivesLatestBalances(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	now := time.Now().Unix()
+	for _, m := range []types.Metric{
+		{Name: "account_balance", Timestamp: now - 20, Value: 10 * hedera.TinybarPerHbar, Labels: map[string]string{"account_id": "0.0.5000"}},
+		{Name: "account_balance", Timestamp: now - 10, Value: 20 * hedera.TinybarPerHbar, Labels: map[string]string{"account_id": "0.0.5000"}},
+		{Name: "account_balance", Timestamp: now - 15, Value: 5 * hedera.TinybarPerHbar, Labels: map[string]string{"account_id": "0.0.6000"}},
+	} {
+		if err := store.StoreMetric(m); err != nil {
+			t.Fatalf("Failed to store metric: %v", err)
+		}
+	}
+
+	pc := NewPriceCollector(&mockPriceSource{price: 0.5})
+	if err := pc.collectOnce(context.Background(), store, &mockAlertManager{}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	prices, _ := store.GetMetrics("hbar_price_usd", 0)
+	if len(prices) != 1 || prices[0].Value != 0.5 {
+		t.Errorf("Expected one hbar_price_usd of 0.5, got %v", prices)
+	}
+
+	usd, _ := store.GetMetrics("account_balance_usd", 0)
+	values := make(map[string]float64)
+	for _, m := range usd {
+		values[m.Labels["account_id"]] = m.Value
+	}
+	if len(usd) != 2 || values["0.0.5000"] != 10 || values["0.0.6000"] != 2.5 {
+		t.Errorf("Expected USD balances 10 and 2.5, got %v", values)
+	}
+}
+
+// TestPriceCollector_FeedFailure tests a failed price query stores nothing
+func TestPriceCollector_FeedFailure(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	_ = store.StoreMetric(types.Metric{Name: "account_balance", Value: 100, Labels: map[string]string{"account_id": "0.0.5000"}})
+
+	pc := NewPriceCollector(&mockPriceSource{err: errors.New("feed down")})
+	if err := pc.collectOnce(context.Background(), store, &mockAlertManager{}); err == nil {
+		t.Error("Expected error when the price feed fails")
+	}
+
+	for _, name := range []string{"hbar_price_usd", "account_balance_usd"} {
+		if metrics, _ := store.GetMetrics(name, 0); len(metrics) != 0 {
+			t.Errorf("Expected no %s metrics after a feed failure, got %d", name, len(metrics))
+		}
+	}
+}
+
+// TestPriceCollector_NetworkFilter tests only balances from the collector's network are priced
+func TestPriceCollector_NetworkFilter(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	now := time.Now().Unix()
+	_ = store.StoreMetric(types.Metric{Name: "account_balance", Timestamp: now, Value: 100, Labels: map[string]string{"account_id": "0.0.5000", "network": "mainnet"}})
+	_ = store.StoreMetric(types.Metric{Name: "account_balance", Timestamp: now, Value: 100, Labels: map[string]string{"account_id": "0.0.5000", "network": "testnet"}})
+
+	pc := NewPriceCollector(&mockPriceSource{price: 1})
+	pc.SetNetwork("mainnet")
+	if err := pc.collectOnce(context.Background(), store, &mockAlertManager{}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	usd, _ := store.GetMetrics("account_balance_usd", 0)
+	if len(usd) != 1 || usd[0].Labels["network"] != "mainnet" {
+		t.Errorf("Expected one mainnet USD balance, got %v", usd)
+	}
+}
+
+// TestPriceCollector_SkipsStaleBalances tests a balance older than one interval isn't priced
+func TestPriceCollector_SkipsStaleBalances(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	now := time.Now().Unix()
+	_ = store.StoreMetric(types.Metric{Name: "account_balance", Timestamp: now - 5, Value: 100, Labels: map[string]string{"account_id": "0.0.5000"}})
+	_ = store.StoreMetric(types.Metric{Name: "account_balance", Timestamp: now - 3600, Value: 100, Labels: map[string]string{"account_id": "0.0.6000"}})
+
+	pc := NewPriceCollector(&mockPriceSource{price: 1})
+	pc.interval = time.Minute
+	if err := pc.collectOnce(context.Background(), store, &mockAlertManager{}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	usd, _ := store.GetMetrics("account_balance_usd", 0)
+	if len(usd) != 1 || usd[0].Labels["account_id"] != "0.0.5000" {
+		t.Errorf("Expected only the fresh balance to be priced, got %v", usd)
+	}
+}
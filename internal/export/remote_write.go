@@ -0,0 +1,279 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const DefaultInterval = 30 * time.Second
+
+// RemoteWriteExporter periodically pushes stored metrics to a Prometheus
+// remote-write endpoint using the protobuf+snappy wire format
+// All metrics are exported as gauges (remote-write v1 carries no type information)
+type RemoteWriteExporter struct {
+	url      string
+	interval time.Duration
+	store    storage.Storage
+	client   *http.Client
+
+	// accountLabel adds the account's human label as an account_label series label
+	accountLabel bool
+
+	// lastExported tracks the newest timestamp sent per series so each
+	// snapshot only pushes samples the endpoint hasn't seen yet
+	lastExported map[string]int64
+	mu           sync.Mutex
+}
+
+// NewRemoteWriteExporter creates a new remote-write exporter
+// A non-positive interval falls back to DefaultInterval
+func NewRemoteWriteExporter(url string, interval time.Duration, store storage.Storage) *RemoteWriteExporter {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &RemoteWriteExporter{
+		url:          url,
+		interval:     interval,
+		store:        store,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		lastExported: make(map[string]int64),
+	}
+}
+
+// SetAccountLabel enables exporting an account_label label carrying the human
+// label from the account config, for metrics that have both an account_id and a label
+func (e *RemoteWriteExporter) SetAccountLabel(enabled bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.accountLabel = enabled
+}
+
+// Run exports metrics on each interval until the context is cancelled
+// Export failures are logged and retried on the next interval
+func (e *RemoteWriteExporter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	logger.Info("Starting remote-write exporter",
+		"component", "RemoteWriteExporter",
+		"url", e.url,
+		"interval", e.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Stopping remote-write exporter", "component", "RemoteWriteExporter")
+			return ctx.Err()
+		case <-ticker.C:
+			if err := e.Export(ctx); err != nil {
+				logger.Error("Remote-write export failed",
+					"component", "RemoteWriteExporter",
+					"url", e.url,
+					"error", err)
+			}
+		}
+	}
+}
+
+// Export snapshots stored metrics and pushes any new samples to the endpoint
+func (e *RemoteWriteExporter) Export(ctx context.Context) error {
+	metrics, err := e.store.GetMetrics("", 0)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot metrics: %w", err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	series, newest := buildSeries(metrics, e.lastExported, e.accountLabel)
+	if len(series) == 0 {
+		logger.Debug("No new samples to export", "component", "RemoteWriteExporter")
+		return nil
+	}
+
+	body := snappy.Encode(nil, encodeWriteRequest(series))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	req.Header.Set("User-Agent", "hedera-network-monitor/1.0")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("remote-write request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || 300 <= resp.StatusCode {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("remote-write returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	// Only advance the high-water marks once the endpoint has accepted the samples
+	for key, ts := range newest {
+		e.lastExported[key] = ts
+	}
+
+	logger.Debug("Exported metrics via remote-write",
+		"component", "RemoteWriteExporter",
+		"series", len(series))
+	return nil
+}
+
+// label is a single remote-write label pair
+type label struct {
+	name  string
+	value string
+}
+
+// sample is a single remote-write sample (timestamp in milliseconds)
+type sample struct {
+	value     float64
+	timestamp int64
+}
+
+// timeSeries is a remote-write series: sorted labels plus samples in time order
+type timeSeries struct {
+	labels  []label
+	samples []sample
+}
+
+// buildSeries groups metrics into time series, skipping samples at or before
+// each series' last exported timestamp
+// Returns the series to send and the newest timestamp per series key
+func buildSeries(metrics []types.Metric, lastExported map[string]int64, accountLabel bool) ([]timeSeries, map[string]int64) {
+	bySeries := make(map[string]*timeSeries)
+	newest := make(map[string]int64)
+	keys := make([]string, 0)
+
+	for _, metric := range metrics {
+		labels := seriesLabels(metric, accountLabel)
+		key := seriesKey(labels)
+
+		if last, ok := lastExported[key]; ok && metric.Timestamp <= last {
+			continue
+		}
+
+		ts, ok := bySeries[key]
+		if !ok {
+			ts = &timeSeries{labels: labels}
+			bySeries[key] = ts
+			keys = append(keys, key)
+		}
+		ts.samples = append(ts.samples, sample{
+			value:     metric.Value,
+			timestamp: metric.Timestamp * 1000,
+		})
+		if newest[key] < metric.Timestamp {
+			newest[key] = metric.Timestamp
+		}
+	}
+
+	sort.Strings(keys)
+	series := make([]timeSeries, 0, len(keys))
+	for _, key := range keys {
+		ts := bySeries[key]
+		// Remote-write requires samples in timestamp order within a series
+		sort.SliceStable(ts.samples, func(i, j int) bool {
+			return ts.samples[i].timestamp < ts.samples[j].timestamp
+		})
+		series = append(series, *ts)
+	}
+	return series, newest
+}
+
+// seriesLabels returns the metric's labels plus __name__, sorted by name
+// With accountLabel set, account metrics also get an account_label copied from "label"
+func seriesLabels(metric types.Metric, accountLabel bool) []label {
+	labels := make([]label, 0, len(metric.Labels)+2)
+	labels = append(labels, label{name: "__name__", value: metric.Name})
+	for k, v := range metric.Labels {
+		if v == "" {
+			// Empty label values are equivalent to an absent label in Prometheus
+			continue
+		}
+		labels = append(labels, label{name: k, value: v})
+	}
+	if accountLabel && metric.Labels["account_id"] != "" && metric.Labels["label"] != "" {
+		if _, exists := metric.Labels["account_label"]; !exists {
+			labels = append(labels, label{name: "account_label", value: metric.Labels["label"]})
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].name < labels[j].name
+	})
+	return labels
+}
+
+// seriesKey builds a stable identifier from sorted labels
+func seriesKey(labels []label) string {
+	var b strings.Builder
+	for _, l := range labels {
+		b.WriteString(l.name)
+		b.WriteByte('=')
+		b.WriteString(l.value)
+		b.WriteByte(',')
+	}
+	return b.String()
+}
+
+// Protobuf field numbers from the Prometheus remote-write WriteRequest schema
+const (
+	fieldWriteRequestTimeseries = 1
+	fieldTimeSeriesLabels       = 1
+	fieldTimeSeriesSamples      = 2
+	fieldLabelName              = 1
+	fieldLabelValue             = 2
+	fieldSampleValue            = 1
+	fieldSampleTimestamp        = 2
+)
+
+// encodeWriteRequest encodes series as a prometheus.WriteRequest protobuf message
+func encodeWriteRequest(series []timeSeries) []byte {
+	var out []byte
+	for _, ts := range series {
+		var tsBytes []byte
+		for _, l := range ts.labels {
+			var lBytes []byte
+			lBytes = protowire.AppendTag(lBytes, fieldLabelName, protowire.BytesType)
+			lBytes = protowire.AppendString(lBytes, l.name)
+			lBytes = protowire.AppendTag(lBytes, fieldLabelValue, protowire.BytesType)
+			lBytes = protowire.AppendString(lBytes, l.value)
+
+			tsBytes = protowire.AppendTag(tsBytes, fieldTimeSeriesLabels, protowire.BytesType)
+			tsBytes = protowire.AppendBytes(tsBytes, lBytes)
+		}
+		for _, s := range ts.samples {
+			var sBytes []byte
+			sBytes = protowire.AppendTag(sBytes, fieldSampleValue, protowire.Fixed64Type)
+			sBytes = protowire.AppendFixed64(sBytes, math.Float64bits(s.value))
+			sBytes = protowire.AppendTag(sBytes, fieldSampleTimestamp, protowire.VarintType)
+			sBytes = protowire.AppendVarint(sBytes, uint64(s.timestamp))
+
+			tsBytes = protowire.AppendTag(tsBytes, fieldTimeSeriesSamples, protowire.BytesType)
+			tsBytes = protowire.AppendBytes(tsBytes, sBytes)
+		}
+
+		out = protowire.AppendTag(out, fieldWriteRequestTimeseries, protowire.BytesType)
+		out = protowire.AppendBytes(out, tsBytes)
+	}
+	return out
+}
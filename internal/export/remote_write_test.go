@@ -0,0 +1,320 @@
+package export
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/storage"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// decodeWriteRequest parses a prometheus.WriteRequest protobuf into timeSeries
+func decodeWriteRequest(t *testing.T, data []byte) []timeSeries {
+	t.Helper()
+	var series []timeSeries
+	forEachField(t, data, func(num protowire.Number, typ protowire.Type, v []byte, _ uint64) {
+		if num != fieldWriteRequestTimeseries {
+			return
+		}
+		var ts timeSeries
+		forEachField(t, v, func(num protowire.Number, typ protowire.Type, v []byte, _ uint64) {
+			switch num {
+			case fieldTimeSeriesLabels:
+				var l label
+				forEachField(t, v, func(num protowire.Number, typ protowire.Type, v []byte, _ uint64) {
+					if num == fieldLabelName {
+						l.name = string(v)
+					} else if num == fieldLabelValue {
+						l.value = string(v)
+					}
+				})
+				ts.labels = append(ts.labels, l)
+			case fieldTimeSeriesSamples:
+				var s sample
+				forEachField(t, v, func(num protowire.Number, typ protowire.Type, _ []byte, n uint64) {
+					if num == fieldSampleValue {
+						s.value = math.Float64frombits(n)
+					} else if num == fieldSampleTimestamp {
+						s.timestamp = int64(n)
+					}
+				})
+				ts.samples = append(ts.samples, s)
+			}
+		})
+		series = append(series, ts)
+	})
+	return series
+}
+
+// forEachField walks the fields of a protobuf message
+// Length-delimited fields are passed as bytes; numeric fields as n
+func forEachField(t *testing.T, data []byte, fn func(protowire.Number, protowire.Type, []byte, uint64)) {
+	t.Helper()
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			t.Fatalf("invalid tag: %v", protowire.ParseError(n))
+		}
+		data = data[n:]
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				t.Fatalf("invalid bytes: %v", protowire.ParseError(n))
+			}
+			fn(num, typ, v, 0)
+			data = data[n:]
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				t.Fatalf("invalid varint: %v", protowire.ParseError(n))
+			}
+			fn(num, typ, nil, v)
+			data = data[n:]
+		case protowire.Fixed64Type:
+			v, n := protowire.ConsumeFixed64(data)
+			if n < 0 {
+				t.Fatalf("invalid fixed64: %v", protowire.ParseError(n))
+			}
+			fn(num, typ, nil, v)
+			data = data[n:]
+		default:
+			t.Fatalf("unexpected wire type %v", typ)
+		}
+	}
+}
+
+// remoteWriteReceiver is an httptest handler that decodes remote-write requests
+type remoteWriteReceiver struct {
+	mu       sync.Mutex
+	requests [][]timeSeries
+	headers  []http.Header
+	t        *testing.T
+}
+
+func (rw *remoteWriteReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	compressed, err := io.ReadAll(r.Body)
+	if err != nil {
+		rw.t.Errorf("failed to read body: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		rw.t.Errorf("failed to snappy-decode body: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	rw.mu.Lock()
+	rw.requests = append(rw.requests, decodeWriteRequest(rw.t, data))
+	rw.headers = append(rw.headers, r.Header.Clone())
+	rw.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TestExport_SendsDecodableSamples tests that exported metrics decode as a valid WriteRequest
+func TestExport_SendsDecodableSamples(t *testing.T) {
+	receiver := &remoteWriteReceiver{t: t}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	store := storage.NewMemoryStorage()
+	_ = store.StoreMetric(types.Metric{
+		Name:      "account_balance",
+		Timestamp: 1700000000,
+		Value:     1500000000,
+		Labels:    map[string]string{"account_id": "0.0.5000", "label": "Main Account"},
+	})
+	_ = store.StoreMetric(types.Metric{
+		Name:      "network_nodes_available",
+		Timestamp: 1700000001,
+		Value:     28,
+		Labels:    map[string]string{"network": "testnet"},
+	})
+
+	exporter := NewRemoteWriteExporter(server.URL, 0, store)
+	if err := exporter.Export(context.Background()); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(receiver.requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(receiver.requests))
+	}
+
+	headers := receiver.headers[0]
+	if headers.Get("Content-Encoding") != "snappy" {
+		t.Errorf("expected snappy encoding, got %q", headers.Get("Content-Encoding"))
+	}
+	if headers.Get("Content-Type") != "application/x-protobuf" {
+		t.Errorf("expected protobuf content type, got %q", headers.Get("Content-Type"))
+	}
+
+	series := receiver.requests[0]
+	if len(series) != 2 {
+		t.Fatalf("expected 2 series, got %d", len(series))
+	}
+
+	// Series are sorted by key, so account_balance comes first
+	balance := series[0]
+	wantLabels := []label{
+		{name: "__name__", value: "account_balance"},
+		{name: "account_id", value: "0.0.5000"},
+		{name: "label", value: "Main Account"},
+	}
+	if len(balance.labels) != len(wantLabels) {
+		t.Fatalf("expected %d labels, got %v", len(wantLabels), balance.labels)
+	}
+	for i, want := range wantLabels {
+		if balance.labels[i] != want {
+			t.Errorf("label %d: expected %v, got %v", i, want, balance.labels[i])
+		}
+	}
+	if len(balance.samples) != 1 {
+		t.Fatalf("expected 1 sample, got %d", len(balance.samples))
+	}
+	if balance.samples[0].value != 1500000000 {
+		t.Errorf("expected value 1500000000, got %v", balance.samples[0].value)
+	}
+	if balance.samples[0].timestamp != 1700000000000 {
+		t.Errorf("expected timestamp in ms 1700000000000, got %d", balance.samples[0].timestamp)
+	}
+}
+
+// TestExport_AccountLabel tests that account_label is exported when enabled
+// and the metric carries both an account_id and a label
+func TestExport_AccountLabel(t *testing.T) {
+	receiver := &remoteWriteReceiver{t: t}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	store := storage.NewMemoryStorage()
+	_ = store.StoreMetric(types.Metric{
+		Name:      "account_balance",
+		Timestamp: 1700000000,
+		Value:     1500000000,
+		Labels:    map[string]string{"account_id": "0.0.5000", "label": "Main Account"},
+	})
+	_ = store.StoreMetric(types.Metric{
+		Name:      "account_balance",
+		Timestamp: 1700000000,
+		Value:     20,
+		Labels:    map[string]string{"account_id": "0.0.5001", "label": ""},
+	})
+
+	exporter := NewRemoteWriteExporter(server.URL, 0, store)
+	exporter.SetAccountLabel(true)
+	if err := exporter.Export(context.Background()); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(receiver.requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(receiver.requests))
+	}
+	series := receiver.requests[0]
+	if len(series) != 2 {
+		t.Fatalf("expected 2 series, got %d", len(series))
+	}
+
+	labelOf := func(ts timeSeries, name string) (string, bool) {
+		for _, l := range ts.labels {
+			if l.name == name {
+				return l.value, true
+			}
+		}
+		return "", false
+	}
+
+	for _, ts := range series {
+		accountID, _ := labelOf(ts, "account_id")
+		accountLabel, ok := labelOf(ts, "account_label")
+		switch accountID {
+		case "0.0.5000":
+			if !ok || accountLabel != "Main Account" {
+				t.Errorf("expected account_label %q, got %q (present=%v)", "Main Account", accountLabel, ok)
+			}
+		case "0.0.5001":
+			if ok {
+				t.Errorf("expected no account_label for unlabeled account, got %q", accountLabel)
+			}
+		default:
+			t.Errorf("unexpected series %v", ts.labels)
+		}
+	}
+}
+
+// TestExport_OnlySendsNewSamples tests that samples are not resent on later exports
+func TestExport_OnlySendsNewSamples(t *testing.T) {
+	receiver := &remoteWriteReceiver{t: t}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	store := storage.NewMemoryStorage()
+	metric := types.Metric{Name: "account_balance", Timestamp: 100, Value: 1, Labels: map[string]string{"account_id": "0.0.5000"}}
+	_ = store.StoreMetric(metric)
+
+	exporter := NewRemoteWriteExporter(server.URL, 0, store)
+	if err := exporter.Export(context.Background()); err != nil {
+		t.Fatalf("first export failed: %v", err)
+	}
+
+	// Nothing new: no request should be sent
+	if err := exporter.Export(context.Background()); err != nil {
+		t.Fatalf("second export failed: %v", err)
+	}
+	if len(receiver.requests) != 1 {
+		t.Fatalf("expected 1 request after no-op export, got %d", len(receiver.requests))
+	}
+
+	metric.Timestamp = 130
+	metric.Value = 2
+	_ = store.StoreMetric(metric)
+	if err := exporter.Export(context.Background()); err != nil {
+		t.Fatalf("third export failed: %v", err)
+	}
+	if len(receiver.requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(receiver.requests))
+	}
+	samples := receiver.requests[1][0].samples
+	if len(samples) != 1 || samples[0].value != 2 {
+		t.Errorf("expected only the new sample, got %v", samples)
+	}
+}
+
+// TestExport_ErrorStatusRetriesSamples tests that rejected samples are resent next time
+func TestExport_ErrorStatusRetriesSamples(t *testing.T) {
+	fail := true
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	store := storage.NewMemoryStorage()
+	_ = store.StoreMetric(types.Metric{Name: "account_balance", Timestamp: 100, Value: 1, Labels: map[string]string{}})
+
+	exporter := NewRemoteWriteExporter(server.URL, 0, store)
+	if err := exporter.Export(context.Background()); err == nil {
+		t.Fatal("expected error for 503 response")
+	}
+
+	fail = false
+	if err := exporter.Export(context.Background()); err != nil {
+		t.Fatalf("expected retry to succeed, got: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected samples to be resent after failure, got %d calls", calls)
+	}
+}
@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+)
+
+// ErrSeriesLimit is returned when storing a metric would create a series beyond
+// the per-name limit of a CardinalityLimitStorage
+var ErrSeriesLimit = errors.New("series limit reached")
+
+// DefaultSeriesResyncInterval is the least time between two rebuilds of a metric name's
+// series from the underlying store, so a name stuck at its limit isn't rescanned per sample
+const DefaultSeriesResyncInterval = time.Minute
+
+// CardinalityLimitStorage is a Storage decorator that caps how many distinct series
+// (label sets) each metric name may have, so a collector emitting unbounded label
+// values such as transaction IDs can't grow memory and the label index without bound
+// Samples of series already seen, and every read, pass straight through
+type CardinalityLimitStorage struct {
+	Storage
+
+	maxSeries int                            // Distinct series allowed per metric name
+	series    map[string]map[string]struct{} // Series accepted so far, by metric name
+	rejected  map[string]int64               // Running storage_series_rejected_total per metric name
+	mu        sync.Mutex
+
+	resyncEvery time.Duration        // Least time between rebuilds of a name's series
+	resynced    map[string]time.Time // When each name's series were last rebuilt
+}
+
+// NewCardinalityLimitStorage wraps store so each metric name accepts at most
+// maxSeries distinct series. Series whose samples have all expired or been deleted
+// from store stop counting once the name reaches its limit and is rebuilt from store
+// Wrap the store that is written to directly, so stored label sets match the ones seen here
+func NewCardinalityLimitStorage(store Storage, maxSeries int) *CardinalityLimitStorage {
+	return &CardinalityLimitStorage{
+		Storage:   store,
+		maxSeries: maxSeries,
+		series:    make(map[string]map[string]struct{}),
+		rejected:  make(map[string]int64),
+
+		resyncEvery: DefaultSeriesResyncInterval,
+		resynced:    make(map[string]time.Time),
+	}
+}
+
+// StoreMetric stores samples of known series and of new series while the name is
+// under its limit. A new series beyond the limit is rejected with ErrSeriesLimit and
+// counted in storage_series_rejected_total, labelled with the metric name
+func (c *CardinalityLimitStorage) StoreMetric(metric types.Metric) error {
+	rejected, ok := c.admit(metric)
+	if !ok {
+		if rejected == 1 {
+			logger.Warn("Metric reached its series limit, rejecting new label values",
+				"component", "Storage",
+				"metric_name", metric.Name,
+				"max_series", c.maxSeries)
+		}
+		if err := c.Storage.StoreMetric(types.Metric{
+			Name:      "storage_series_rejected_total",
+			Timestamp: time.Now().Unix(),
+			Value:     float64(rejected),
+			Labels:    map[string]string{"metric": metric.Name},
+		}); err != nil {
+			logger.Error("Error storing series rejection metric",
+				"component", "Storage",
+				"error", err)
+		}
+		return fmt.Errorf("%w: %s already has %d series", ErrSeriesLimit, metric.Name, c.maxSeries)
+	}
+	return c.Storage.StoreMetric(metric)
+}
+
+// admit records the metric's series if it is known or fits under the limit
+// Otherwise it counts the rejection and returns the running total
+func (c *CardinalityLimitStorage) admit(metric types.Metric) (int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := SeriesKey(metric)
+	if _, ok := c.series[metric.Name][key]; ok {
+		return 0, true
+	}
+	if len(c.series[metric.Name]) >= c.maxSeries {
+		c.resync(metric.Name)
+	}
+	known := c.series[metric.Name]
+	if len(known) < c.maxSeries {
+		if known == nil {
+			known = make(map[string]struct{})
+			c.series[metric.Name] = known
+		}
+		known[key] = struct{}{}
+		return 0, true
+	}
+	c.rejected[metric.Name]++
+	return c.rejected[metric.Name], false
+}
+
+// resync rebuilds name's series from the samples the underlying store still holds,
+// dropping series that expired or were deleted. It runs at most once per resyncEvery
+// Must be called with mu held
+func (c *CardinalityLimitStorage) resync(name string) {
+	now := time.Now()
+	if now.Sub(c.resynced[name]) < c.resyncEvery {
+		return
+	}
+	c.resynced[name] = now
+
+	metrics, err := c.Storage.GetMetrics(name, 0)
+	if err != nil {
+		logger.Error("Error reading series for limit",
+			"component", "Storage",
+			"metric_name", name,
+			"error", err)
+		return
+	}
+	known := make(map[string]struct{}, len(c.series[name]))
+	for _, metric := range metrics {
+		known[SeriesKey(metric)] = struct{}{}
+	}
+	c.series[name] = known
+}
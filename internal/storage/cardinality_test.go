@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+func TestCardinalityLimitStorage_RejectsNewSeriesOverLimit(t *testing.T) {
+	inner := NewMemoryStorage()
+	store := NewCardinalityLimitStorage(inner, 2)
+
+	tx := func(id string) types.Metric {
+		return types.Metric{Name: "tx_fee", Timestamp: 100, Value: 1, Labels: map[string]string{"tx": id}}
+	}
+	for _, id := range []string{"a", "b", "a"} {
+		if err := store.StoreMetric(tx(id)); err != nil {
+			t.Fatalf("expected series %s to be accepted, got %v", id, err)
+		}
+	}
+	for _, id := range []string{"c", "d"} {
+		if err := store.StoreMetric(tx(id)); !errors.Is(err, ErrSeriesLimit) {
+			t.Errorf("expected ErrSeriesLimit for series %s, got %v", id, err)
+		}
+	}
+
+	// Known series and other names are unaffected
+	if err := store.StoreMetric(tx("b")); err != nil {
+		t.Errorf("expected a known series to be accepted, got %v", err)
+	}
+	if err := store.StoreMetric(types.Metric{Name: "other", Timestamp: 100, Labels: map[string]string{"tx": "c"}}); err != nil {
+		t.Errorf("expected another metric name to be accepted, got %v", err)
+	}
+
+	fees, _ := inner.GetMetrics("tx_fee", 0)
+	if len(fees) != 4 {
+		t.Errorf("expected 4 stored tx_fee samples, got %d", len(fees))
+	}
+	rejected, _ := inner.GetMetrics("storage_series_rejected_total", 0)
+	if len(rejected) != 2 {
+		t.Fatalf("expected 2 rejection samples, got %d", len(rejected))
+	}
+	if last := rejected[len(rejected)-1]; last.Value != 2 || last.Labels["metric"] != "tx_fee" {
+		t.Errorf("expected rejection total 2 for tx_fee, got %v %v", last.Value, last.Labels)
+	}
+}
+
+func TestCardinalityLimitStorage_EvictsDeletedSeries(t *testing.T) {
+	inner := NewMemoryStorage()
+	store := NewCardinalityLimitStorage(inner, 2)
+
+	tx := func(id string) types.Metric {
+		return types.Metric{Name: "tx_fee", Timestamp: 100, Value: 1, Labels: map[string]string{"tx": id}}
+	}
+	for _, id := range []string{"a", "b"} {
+		if err := store.StoreMetric(tx(id)); err != nil {
+			t.Fatalf("expected series %s to be accepted, got %v", id, err)
+		}
+	}
+	if _, err := inner.DeleteMetrics("tx_fee", "tx", "a", 0); err != nil {
+		t.Fatalf("failed to delete series a: %v", err)
+	}
+
+	if err := store.StoreMetric(tx("c")); err != nil {
+		t.Errorf("expected series c to take the deleted series' place, got %v", err)
+	}
+	// Rebuilt within the last minute, so the cap holds until the next recheck
+	if err := store.StoreMetric(tx("d")); !errors.Is(err, ErrSeriesLimit) {
+		t.Errorf("expected ErrSeriesLimit for series d, got %v", err)
+	}
+
+	store.resyncEvery = 0
+	if _, err := inner.DeleteMetrics("tx_fee", "tx", "b", 0); err != nil {
+		t.Fatalf("failed to delete series b: %v", err)
+	}
+	if err := store.StoreMetric(tx("d")); err != nil {
+		t.Errorf("expected series d to be accepted after b was deleted, got %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"sort"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+// PageAfter returns the samples with a timestamp strictly after after, oldest first
+// Samples sharing a timestamp keep their stored order. A page is cut after limit
+// samples (0 = unlimited) but then extended through every sample sharing the last
+// timestamp, so resuming after that timestamp never skips or repeats a sample
+func PageAfter(metrics []types.Metric, after int64, limit int) []types.Metric {
+	page := make([]types.Metric, 0)
+	for _, metric := range metrics {
+		if after < metric.Timestamp {
+			page = append(page, metric)
+		}
+	}
+	sort.SliceStable(page, func(i, j int) bool {
+		return page[i].Timestamp < page[j].Timestamp
+	})
+
+	if limit <= 0 || len(page) <= limit {
+		return page
+	}
+	end := limit
+	for end < len(page) && page[end].Timestamp == page[limit-1].Timestamp {
+		end++
+	}
+	return page[:end]
+}
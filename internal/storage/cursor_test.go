@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+func TestPageAfter_AscendingAndStrict(t *testing.T) {
+	metrics := []types.Metric{
+		{Name: "m", Timestamp: 300, Value: 3},
+		{Name: "m", Timestamp: 100, Value: 1},
+		{Name: "m", Timestamp: 200, Value: 2},
+	}
+
+	page := PageAfter(metrics, 100, 0)
+	if len(page) != 2 || page[0].Timestamp != 200 || page[1].Timestamp != 300 {
+		t.Errorf("expected samples 200 and 300 in order, got %+v", page)
+	}
+}
+
+func TestPageAfter_ExtendsThroughTies(t *testing.T) {
+	metrics := []types.Metric{
+		{Name: "m", Timestamp: 100, Value: 1},
+		{Name: "m", Timestamp: 200, Value: 2},
+		{Name: "m", Timestamp: 200, Value: 3},
+		{Name: "m", Timestamp: 200, Value: 4},
+		{Name: "m", Timestamp: 300, Value: 5},
+	}
+
+	// A limit of 2 would split the samples at 200, so the page runs through all of them
+	page := PageAfter(metrics, 0, 2)
+	if len(page) != 4 {
+		t.Fatalf("expected 4 samples, got %d", len(page))
+	}
+	for i, want := range []float64{1, 2, 3, 4} {
+		if page[i].Value != want {
+			t.Errorf("expected value %v at %d (stored order kept for ties), got %v", want, i, page[i].Value)
+		}
+	}
+
+	if page := PageAfter(metrics, 200, 2); len(page) != 1 || page[0].Value != 5 {
+		t.Errorf("expected only the sample after the cursor, got %+v", page)
+	}
+}
+
+func TestGetMetricsAfter_WalkCursor(t *testing.T) {
+	store := NewMemoryStorage()
+	want := 0
+	for ts := int64(1); ts <= 20; ts++ {
+		// Some timestamps carry several samples, as when collectors share a cycle
+		for i := 0; i < int(ts%3)+1; i++ {
+			mustStoreMetric(t, store, types.Metric{Name: "account_balance", Timestamp: ts, Value: float64(want)})
+			want++
+		}
+		mustStoreMetric(t, store, types.Metric{Name: "other", Timestamp: ts})
+	}
+
+	var seen []float64
+	after := int64(0)
+	for pages := 0; ; pages++ {
+		if pages > want {
+			t.Fatal("cursor walk did not terminate")
+		}
+		page, err := store.GetMetricsAfter("account_balance", after, 4)
+		if err != nil {
+			t.Fatalf("GetMetricsAfter failed: %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, metric := range page {
+			if metric.Name != "account_balance" {
+				t.Fatalf("expected only account_balance, got %s", metric.Name)
+			}
+			seen = append(seen, metric.Value)
+		}
+		after = page[len(page)-1].Timestamp
+	}
+
+	if len(seen) != want {
+		t.Fatalf("expected %d samples across pages, got %d", want, len(seen))
+	}
+	for i, value := range seen {
+		if value != float64(i) {
+			t.Fatalf("expected sample %d in order without gaps or duplicates, got %v", i, value)
+		}
+	}
+}
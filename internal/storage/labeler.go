@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"maps"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+// LabelingStorage is a Storage decorator that adds fixed labels, such as env or
+// region, to every stored metric so metrics from several instances can be told
+// apart once aggregated. Labels a metric already has are kept
+// Every read passes straight through
+type LabelingStorage struct {
+	Storage
+
+	labels map[string]string // Added to each stored metric that lacks the key
+}
+
+// NewLabelingStorage wraps store so stored metrics get labels they don't already have
+func NewLabelingStorage(store Storage, labels map[string]string) *LabelingStorage {
+	return &LabelingStorage{
+		Storage: store,
+		labels:  maps.Clone(labels),
+	}
+}
+
+// StoreMetric merges the configured labels into a copy of the metric's labels and stores it
+func (s *LabelingStorage) StoreMetric(metric types.Metric) error {
+	// Copy so a labels map shared with the caller or other metrics isn't modified
+	labels := make(map[string]string, len(metric.Labels)+len(s.labels))
+	maps.Copy(labels, s.labels)
+	maps.Copy(labels, metric.Labels)
+	metric.Labels = labels
+	return s.Storage.StoreMetric(metric)
+}
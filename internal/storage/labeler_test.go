@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+func TestLabelingStorage_KeepsExistingLabels(t *testing.T) {
+	store := NewMemoryStorage()
+	labeling := NewLabelingStorage(store, map[string]string{"env": "prod", "account_id": "global"})
+
+	labels := map[string]string{"account_id": "0.0.5000"}
+	if err := labeling.StoreMetric(types.Metric{Name: "account_balance", Timestamp: 1, Labels: labels}); err != nil {
+		t.Fatalf("StoreMetric: %v", err)
+	}
+
+	stored, _ := store.GetMetrics("account_balance", 0)
+	if len(stored) != 1 {
+		t.Fatalf("expected 1 metric, got %d", len(stored))
+	}
+	if stored[0].Labels["env"] != "prod" || stored[0].Labels["account_id"] != "0.0.5000" {
+		t.Errorf("expected env=prod added and account_id kept, got %v", stored[0].Labels)
+	}
+	if _, ok := labels["env"]; ok {
+		t.Errorf("expected the caller's labels map to be left unchanged, got %v", labels)
+	}
+}
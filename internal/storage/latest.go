@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+// SeriesKey identifies a metric's series: its name plus its full label set
+// Labels are sorted so the key doesn't depend on map iteration order
+func SeriesKey(metric types.Metric) string {
+	keys := make([]string, 0, len(metric.Labels))
+	for key := range metric.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(metric.Name)
+	for _, key := range keys {
+		b.WriteByte(',')
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(metric.Labels[key])
+	}
+	return b.String()
+}
+
+// LatestPerSeries keeps only the newest sample (by timestamp) of each series
+// When samples share a timestamp the one stored last wins
+// Results are ordered by series key so responses are stable between calls
+func LatestPerSeries(metrics []types.Metric) []types.Metric {
+	latest := make(map[string]types.Metric)
+	for _, metric := range metrics {
+		key := SeriesKey(metric)
+		if existing, ok := latest[key]; ok && metric.Timestamp < existing.Timestamp {
+			continue
+		}
+		latest[key] = metric
+	}
+
+	keys := make([]string, 0, len(latest))
+	for key := range latest {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]types.Metric, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, latest[key])
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+func TestLatestPerSeries(t *testing.T) {
+	metrics := []types.Metric{
+		{Name: "account_balance", Timestamp: 100, Value: 1, Labels: map[string]string{"account_id": "0.0.5000"}},
+		{Name: "account_balance", Timestamp: 300, Value: 3, Labels: map[string]string{"account_id": "0.0.5000"}},
+		{Name: "account_balance", Timestamp: 200, Value: 2, Labels: map[string]string{"account_id": "0.0.5000"}},
+		{Name: "account_balance", Timestamp: 150, Value: 10, Labels: map[string]string{"account_id": "0.0.5001"}},
+		{Name: "account_balance", Timestamp: 50, Value: 5, Labels: map[string]string{"account_id": "0.0.5001"}},
+		{Name: "network_consensus_active", Timestamp: 120, Value: 1},
+	}
+
+	latest := LatestPerSeries(metrics)
+	if len(latest) != 3 {
+		t.Fatalf("expected 3 series, got %d", len(latest))
+	}
+
+	values := make(map[string]float64)
+	for _, metric := range latest {
+		values[SeriesKey(metric)] = metric.Value
+	}
+	if v := values["account_balance,account_id=0.0.5000"]; v != 3 {
+		t.Errorf("expected newest 0.0.5000 value 3, got %v", v)
+	}
+	if v := values["account_balance,account_id=0.0.5001"]; v != 10 {
+		t.Errorf("expected newest 0.0.5001 value 10, got %v", v)
+	}
+	if v := values["network_consensus_active"]; v != 1 {
+		t.Errorf("expected network_consensus_active value 1, got %v", v)
+	}
+}
+
+func TestLatestPerSeries_TieKeepsLastStored(t *testing.T) {
+	metrics := []types.Metric{
+		{Name: "metric_a", Timestamp: 100, Value: 1},
+		{Name: "metric_a", Timestamp: 100, Value: 2},
+	}
+
+	latest := LatestPerSeries(metrics)
+	if len(latest) != 1 || latest[0].Value != 2 {
+		t.Errorf("expected the last stored sample, got %+v", latest)
+	}
+}
+
+func TestSeriesKey_LabelOrder(t *testing.T) {
+	a := types.Metric{Name: "m", Labels: map[string]string{"a": "1", "b": "2"}}
+	b := types.Metric{Name: "m", Labels: map[string]string{"b": "2", "a": "1"}}
+	if SeriesKey(a) != SeriesKey(b) {
+		t.Errorf("expected equal keys, got %q and %q", SeriesKey(a), SeriesKey(b))
+	}
+
+	c := types.Metric{Name: "m", Labels: map[string]string{"a": "1"}}
+	if SeriesKey(a) == SeriesKey(c) {
+		t.Error("expected different label sets to produce different keys")
+	}
+}
@@ -93,6 +93,42 @@ func (ms *MemoryStorage) GetMetrics(name string, limit int) ([]types.Metric, err
 	return result, nil
 }
 
+// GetMetricsAfter implements Storage interface
+func (ms *MemoryStorage) GetMetricsAfter(name string, after int64, limit int) ([]types.Metric, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	named := make([]types.Metric, 0)
+	for _, metric := range ms.metrics {
+		if name == "" || metric.Name == name {
+			named = append(named, metric)
+		}
+	}
+	return PageAfter(named, after, limit), nil
+}
+
+// GetMetricsByPrefix implements Storage interface
+func (ms *MemoryStorage) GetMetricsByPrefix(prefix string, limit int) ([]types.Metric, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	result := make([]types.Metric, 0)
+
+	for _, metric := range ms.metrics {
+		if !strings.HasPrefix(metric.Name, prefix) {
+			continue
+		}
+
+		result = append(result, metric)
+
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+
+	return result, nil
+}
+
 // GetMetricsByLabel implements Storage interface
 func (ms *MemoryStorage) GetMetricsByLabel(key, value string) ([]types.Metric, error) {
 	ms.mu.RLock()
@@ -109,6 +145,41 @@ func (ms *MemoryStorage) GetMetricsByLabel(key, value string) ([]types.Metric, e
 	return result, nil
 }
 
+// GetMetricsMatching implements Storage interface
+func (ms *MemoryStorage) GetMetricsMatching(name string, labels map[string]string, limit int) ([]types.Metric, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	result := make([]types.Metric, 0)
+
+	for _, metric := range ms.metrics {
+		if name != "" && metric.Name != name {
+			continue
+		}
+		if !hasLabels(metric, labels) {
+			continue
+		}
+
+		result = append(result, metric)
+
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// hasLabels reports whether a metric carries every given label key-value pair
+func hasLabels(metric types.Metric, labels map[string]string) bool {
+	for key, value := range labels {
+		if metricValue, exists := metric.Labels[key]; !exists || metricValue != value {
+			return false
+		}
+	}
+	return true
+}
+
 // DeleteOldMetrics implements Storage interface
 func (ms *MemoryStorage) DeleteOldMetrics(beforeTimestamp int64) error {
 	ms.mu.Lock()
@@ -126,7 +197,69 @@ func (ms *MemoryStorage) DeleteOldMetrics(beforeTimestamp int64) error {
 	return nil
 }
 
+// DeleteOldMetricsByName implements Storage interface
+func (ms *MemoryStorage) DeleteOldMetricsByName(cutoffs map[string]int64, defaultCutoff int64) (int, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	newMetrics := make([]types.Metric, 0, len(ms.metrics))
+	deleted := 0
+
+	for _, metric := range ms.metrics {
+		cutoff, ok := cutoffs[metric.Name]
+		if !ok {
+			cutoff = defaultCutoff
+		}
+		if cutoff > 0 && metric.Timestamp < cutoff {
+			deleted++
+			continue
+		}
+		newMetrics = append(newMetrics, metric)
+	}
+
+	ms.metrics = newMetrics
+	return deleted, nil
+}
+
+// DeleteMetrics implements Storage interface
+func (ms *MemoryStorage) DeleteMetrics(name, labelKey, labelValue string, before int64) (int, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	newMetrics := make([]types.Metric, 0, len(ms.metrics))
+	deleted := 0
+
+	for _, metric := range ms.metrics {
+		if matchesSeries(metric, name, labelKey, labelValue) && (before == 0 || metric.Timestamp < before) {
+			deleted++
+			continue
+		}
+		newMetrics = append(newMetrics, metric)
+	}
+
+	ms.metrics = newMetrics
+	return deleted, nil
+}
+
+// matchesSeries reports whether a metric matches the name and label filters
+// An empty name matches any metric name, an empty labelKey skips label matching,
+// and an empty labelValue matches any value for labelKey
+func matchesSeries(metric types.Metric, name, labelKey, labelValue string) bool {
+	if name != "" && metric.Name != name {
+		return false
+	}
+	if labelKey == "" {
+		return true
+	}
+	metricValue, exists := metric.Labels[labelKey]
+	if !exists {
+		return false
+	}
+	return labelValue == "" || metricValue == labelValue
+}
+
 // Close implements Storage interface
+// Memory storage has nothing to flush; save a snapshot first to keep the metrics
 func (ms *MemoryStorage) Close() error {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
@@ -135,14 +268,35 @@ func (ms *MemoryStorage) Close() error {
 	return nil
 }
 
+// Info implements InfoProvider
+// Metrics are lost on restart unless a snapshot is configured separately
+func (ms *MemoryStorage) Info() StorageInfo {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return StorageInfo{
+		Backend:      "memory",
+		Persistent:   false,
+		RangeQueries: true,
+		Capacity:     ms.maxSize,
+	}
+}
+
 // Stats returns storage statistics (useful for debugging and monitoring)
 func (ms *MemoryStorage) Stats() (map[string]interface{}, error) {
+	// Count and size are read under one lock so utilization matches metric_count
 	ms.mu.RLock()
-	defer ms.mu.RUnlock()
+	count := len(ms.metrics)
+	maxSize := ms.maxSize
+	ms.mu.RUnlock()
+
+	utilization := 0.0
+	if 0 < maxSize {
+		utilization = float64(count) / float64(maxSize) * 100
+	}
 
 	return map[string]interface{}{
-		"metric_count": len(ms.metrics),
-		"max_size":     ms.maxSize,
-		"utilization":  fmt.Sprintf("%.2f%%", float64(len(ms.metrics))/float64(ms.maxSize)*100),
+		"metric_count": count,
+		"max_size":     maxSize,
+		"utilization":  fmt.Sprintf("%.2f%%", utilization),
 	}, nil
 }
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,7 +11,7 @@ import (
 
 // mustStoreMetric stores a metric, failing the test fatally if it errors.
 // This is used for test setup where metric storage failures indicate a broken test setup.
-func mustStoreMetric(t *testing.T, storage *MemoryStorage, metric types.Metric) {
+func mustStoreMetric(t *testing.T, storage Storage, metric types.Metric) {
 	t.Helper()
 	if err := storage.StoreMetric(metric); err != nil {
 		t.Fatalf("setup failed: could not store metric: %v", err)
@@ -248,6 +249,86 @@ func TestGetMetricsByLabel_NonExistent(t *testing.T) {
 	}
 }
 
+// storeLabelFixtures stores metrics with overlapping labels for matching tests
+func storeLabelFixtures(t *testing.T, storage *MemoryStorage) {
+	t.Helper()
+	mustStoreMetric(t, storage, types.Metric{
+		Name:      "metric_a",
+		Timestamp: 1,
+		Value:     1.0,
+		Labels:    map[string]string{"account": "0.0.5000", "type": "balance"},
+	})
+	mustStoreMetric(t, storage, types.Metric{
+		Name:      "metric_b",
+		Timestamp: 2,
+		Value:     2.0,
+		Labels:    map[string]string{"account": "0.0.5001", "type": "balance"},
+	})
+	mustStoreMetric(t, storage, types.Metric{
+		Name:      "metric_a",
+		Timestamp: 3,
+		Value:     3.0,
+		Labels:    map[string]string{"account": "0.0.5000", "type": "transaction"},
+	})
+}
+
+func TestGetMetricsMatching_SingleLabel(t *testing.T) {
+	storage := NewMemoryStorage()
+	storeLabelFixtures(t, storage)
+
+	metrics, err := storage.GetMetricsMatching("", map[string]string{"account": "0.0.5000"}, 0)
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if len(metrics) != 2 {
+		t.Errorf("expected 2 metrics for account 0.0.5000, got %d", len(metrics))
+	}
+}
+
+func TestGetMetricsMatching_MultiLabel(t *testing.T) {
+	storage := NewMemoryStorage()
+	storeLabelFixtures(t, storage)
+
+	labels := map[string]string{"account": "0.0.5000", "type": "balance"}
+	metrics, err := storage.GetMetricsMatching("", labels, 0)
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if len(metrics) != 1 {
+		t.Fatalf("expected 1 metric matching all labels, got %d", len(metrics))
+	}
+	if metrics[0].Timestamp != 1 {
+		t.Errorf("expected metric with timestamp 1, got %d", metrics[0].Timestamp)
+	}
+}
+
+func TestGetMetricsMatching_NameAndLimit(t *testing.T) {
+	storage := NewMemoryStorage()
+	storeLabelFixtures(t, storage)
+
+	metrics, err := storage.GetMetricsMatching("metric_a", map[string]string{"account": "0.0.5000"}, 1)
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if len(metrics) != 1 {
+		t.Errorf("expected 1 metric with limit 1, got %d", len(metrics))
+	}
+}
+
+func TestGetMetricsMatching_NoMatch(t *testing.T) {
+	storage := NewMemoryStorage()
+	storeLabelFixtures(t, storage)
+
+	labels := map[string]string{"account": "0.0.5001", "type": "transaction"}
+	metrics, err := storage.GetMetricsMatching("", labels, 0)
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if len(metrics) != 0 {
+		t.Errorf("expected 0 metrics, got %d", len(metrics))
+	}
+}
+
 func TestDeleteOldMetrics(t *testing.T) {
 	storage := NewMemoryStorage()
 
@@ -300,6 +381,129 @@ func TestDeleteOldMetrics_All(t *testing.T) {
 	}
 }
 
+func TestDeleteMetrics_ByName(t *testing.T) {
+	storage := NewMemoryStorage()
+
+	mustStoreMetric(t, storage, types.Metric{Name: "metric_a", Timestamp: 1, Value: 1.0, Labels: map[string]string{"account_id": "0.0.5000"}})
+	mustStoreMetric(t, storage, types.Metric{Name: "metric_a", Timestamp: 2, Value: 2.0, Labels: map[string]string{"account_id": "0.0.5001"}})
+	mustStoreMetric(t, storage, types.Metric{Name: "metric_b", Timestamp: 3, Value: 3.0, Labels: map[string]string{"account_id": "0.0.5000"}})
+
+	deleted, err := storage.DeleteMetrics("metric_a", "", "", 0)
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("expected 2 metrics deleted, got %d", deleted)
+	}
+
+	metrics, _ := storage.GetMetrics("", 0)
+	if len(metrics) != 1 || metrics[0].Name != "metric_b" {
+		t.Errorf("expected only metric_b to remain, got %v", metrics)
+	}
+}
+
+func TestDeleteMetrics_ByLabel(t *testing.T) {
+	storage := NewMemoryStorage()
+
+	mustStoreMetric(t, storage, types.Metric{Name: "metric_a", Timestamp: 1, Value: 1.0, Labels: map[string]string{"account_id": "0.0.5000"}})
+	mustStoreMetric(t, storage, types.Metric{Name: "metric_a", Timestamp: 2, Value: 2.0, Labels: map[string]string{"account_id": "0.0.5001"}})
+	mustStoreMetric(t, storage, types.Metric{Name: "metric_b", Timestamp: 3, Value: 3.0, Labels: map[string]string{"account_id": "0.0.5000"}})
+	mustStoreMetric(t, storage, types.Metric{Name: "metric_c", Timestamp: 4, Value: 4.0, Labels: map[string]string{}})
+
+	deleted, err := storage.DeleteMetrics("", "account_id", "0.0.5000", 0)
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("expected 2 metrics deleted, got %d", deleted)
+	}
+
+	metrics, _ := storage.GetMetricsByLabel("account_id", "0.0.5000")
+	if len(metrics) != 0 {
+		t.Errorf("expected 0 metrics for deleted label, got %d", len(metrics))
+	}
+
+	// Name and label together only delete the intersection
+	deleted, _ = storage.DeleteMetrics("metric_a", "account_id", "", 0)
+	if deleted != 1 {
+		t.Errorf("expected 1 metric deleted for name+key, got %d", deleted)
+	}
+
+	metrics, _ = storage.GetMetrics("", 0)
+	if len(metrics) != 1 || metrics[0].Name != "metric_c" {
+		t.Errorf("expected only metric_c to remain, got %v", metrics)
+	}
+}
+
+func TestDeleteMetrics_All(t *testing.T) {
+	storage := NewMemoryStorage()
+
+	mustStoreMetric(t, storage, types.Metric{Name: "metric_a", Timestamp: 1, Value: 1.0, Labels: map[string]string{}})
+	mustStoreMetric(t, storage, types.Metric{Name: "metric_b", Timestamp: 2, Value: 2.0, Labels: map[string]string{}})
+
+	deleted, err := storage.DeleteMetrics("", "", "", 0)
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("expected 2 metrics deleted, got %d", deleted)
+	}
+
+	metrics, _ := storage.GetMetrics("", 0)
+	if len(metrics) != 0 {
+		t.Errorf("expected 0 metrics after deleting all, got %d", len(metrics))
+	}
+}
+
+func TestDeleteMetrics_BeforeByName(t *testing.T) {
+	storage := NewMemoryStorage()
+
+	mustStoreMetric(t, storage, types.Metric{Name: "metric_a", Timestamp: 100, Value: 1.0, Labels: map[string]string{}})
+	mustStoreMetric(t, storage, types.Metric{Name: "metric_a", Timestamp: 200, Value: 2.0, Labels: map[string]string{}})
+	mustStoreMetric(t, storage, types.Metric{Name: "metric_b", Timestamp: 100, Value: 3.0, Labels: map[string]string{}})
+
+	deleted, err := storage.DeleteMetrics("metric_a", "", "", 150)
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected 1 metric deleted, got %d", deleted)
+	}
+
+	// The newer metric_a and the old metric_b of another name are kept
+	metrics, _ := storage.GetMetrics("", 0)
+	if len(metrics) != 2 {
+		t.Fatalf("expected 2 metrics to remain, got %v", metrics)
+	}
+	for _, metric := range metrics {
+		if metric.Name == "metric_a" && metric.Timestamp != 200 {
+			t.Errorf("expected only the newer metric_a to remain, got %v", metric)
+		}
+	}
+}
+
+func TestDeleteMetrics_BeforeAllNames(t *testing.T) {
+	storage := NewMemoryStorage()
+
+	mustStoreMetric(t, storage, types.Metric{Name: "metric_a", Timestamp: 100, Value: 1.0, Labels: map[string]string{}})
+	mustStoreMetric(t, storage, types.Metric{Name: "metric_b", Timestamp: 100, Value: 2.0, Labels: map[string]string{}})
+	mustStoreMetric(t, storage, types.Metric{Name: "metric_b", Timestamp: 150, Value: 3.0, Labels: map[string]string{}})
+
+	deleted, err := storage.DeleteMetrics("", "", "", 150)
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("expected 2 metrics deleted, got %d", deleted)
+	}
+
+	// The cutoff is exclusive, so a metric exactly at before is kept
+	metrics, _ := storage.GetMetrics("", 0)
+	if len(metrics) != 1 || metrics[0].Timestamp != 150 {
+		t.Errorf("expected only the metric at the cutoff to remain, got %v", metrics)
+	}
+}
+
 func TestClose(t *testing.T) {
 	storage := NewMemoryStorage()
 
@@ -399,3 +603,73 @@ func TestStoreMetric_ConcurrentAccess(t *testing.T) {
 		t.Errorf("expected at most %d metrics due to max size limit, got %d", storage.maxSize, len(metrics))
 	}
 }
+
+func TestStats_ZeroMaxSize(t *testing.T) {
+	storage := NewMemoryStorage()
+	storage.maxSize = 0
+
+	stats, err := storage.Stats()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if stats["utilization"] != "0.00%" {
+		t.Errorf("expected 0.00%% utilization for zero max size, got %v", stats["utilization"])
+	}
+}
+
+func TestInfo(t *testing.T) {
+	storage := NewMemoryStorage()
+	storage.maxSize = 500
+
+	var provider InfoProvider = storage
+	info := provider.Info()
+	if info.Backend != "memory" {
+		t.Errorf("expected backend memory, got %q", info.Backend)
+	}
+	if info.Persistent {
+		t.Error("expected memory storage to not be persistent")
+	}
+	if !info.RangeQueries {
+		t.Error("expected memory storage to support range queries")
+	}
+	if info.Capacity != 500 {
+		t.Errorf("expected capacity 500, got %d", info.Capacity)
+	}
+}
+
+func TestGetMetricsByPrefix(t *testing.T) {
+	storage := NewMemoryStorage()
+	for i, name := range []string{"network_node_latency", "network_node_unreachable", "network_nodes_available", "account_balance", "network_node_latency"} {
+		mustStoreMetric(t, storage, types.Metric{Name: name, Timestamp: int64(i), Value: float64(i)})
+	}
+
+	metrics, err := storage.GetMetricsByPrefix("network_node_", 0)
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if len(metrics) != 3 {
+		t.Fatalf("expected 3 network_node_* metrics, got %d", len(metrics))
+	}
+	for _, metric := range metrics {
+		if !strings.HasPrefix(metric.Name, "network_node_") {
+			t.Errorf("unexpected metric %q", metric.Name)
+		}
+	}
+
+	metrics, err = storage.GetMetricsByPrefix("network_node_", 2)
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if len(metrics) != 2 {
+		t.Errorf("expected 2 metrics with limit 2, got %d", len(metrics))
+	}
+
+	// Exact-name lookups are unaffected
+	metrics, err = storage.GetMetrics("network_node_latency", 0)
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if len(metrics) != 2 {
+		t.Errorf("expected 2 network_node_latency metrics, got %d", len(metrics))
+	}
+}
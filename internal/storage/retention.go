@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+)
+
+// DefaultRetentionInterval is how often the retention job runs
+const DefaultRetentionInterval = time.Minute
+
+// RetentionPolicy defines how long metrics are kept
+type RetentionPolicy struct {
+	Default time.Duration            // Retention for metrics without an override (0 = keep forever)
+	ByName  map[string]time.Duration // Per-metric-name overrides of Default
+}
+
+// Enabled reports whether the policy deletes anything
+func (p RetentionPolicy) Enabled() bool {
+	return 0 < p.Default || 0 < len(p.ByName)
+}
+
+// ApplyRetention deletes metrics older than the policy allows, relative to now
+// Returns the number of metrics deleted
+func ApplyRetention(store Storage, policy RetentionPolicy, now time.Time) (int, error) {
+	var defaultCutoff int64
+	if 0 < policy.Default {
+		defaultCutoff = now.Add(-policy.Default).Unix()
+	}
+
+	cutoffs := make(map[string]int64, len(policy.ByName))
+	for name, retention := range policy.ByName {
+		cutoffs[name] = now.Add(-retention).Unix()
+	}
+
+	return store.DeleteOldMetricsByName(cutoffs, defaultCutoff)
+}
+
+// RunRetention applies the retention policy on each interval until the context is cancelled
+// A non-positive interval falls back to DefaultRetentionInterval
+func RunRetention(ctx context.Context, store Storage, policy RetentionPolicy, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultRetentionInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logger.Info("Starting retention job",
+		"component", "Retention",
+		"default", policy.Default,
+		"overrides", len(policy.ByName),
+		"interval", interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Stopping retention job", "component", "Retention")
+			return ctx.Err()
+		case <-ticker.C:
+			deleted, err := ApplyRetention(store, policy, time.Now())
+			if err != nil {
+				logger.Error("Error applying retention",
+					"component", "Retention",
+					"error", err)
+				continue
+			}
+			if 0 < deleted {
+				logger.Debug("Deleted expired metrics",
+					"component", "Retention",
+					"deleted", deleted)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+func TestDeleteOldMetricsByName_PerNameCutoffs(t *testing.T) {
+	storage := NewMemoryStorage()
+
+	now := time.Now().Unix()
+
+	// Long-lived metric: two days and one hour old
+	mustStoreMetric(t, storage, types.Metric{Name: "account_balance", Timestamp: now - 2*86400, Value: 1.0, Labels: map[string]string{}})
+	mustStoreMetric(t, storage, types.Metric{Name: "account_balance", Timestamp: now - 3600, Value: 2.0, Labels: map[string]string{}})
+
+	// Short-lived metric: two hours and one minute old
+	mustStoreMetric(t, storage, types.Metric{Name: "node_latency", Timestamp: now - 7200, Value: 3.0, Labels: map[string]string{}})
+	mustStoreMetric(t, storage, types.Metric{Name: "node_latency", Timestamp: now - 60, Value: 4.0, Labels: map[string]string{}})
+
+	cutoffs := map[string]int64{
+		"account_balance": now - 3*86400, // keep three days
+		"node_latency":    now - 3600,    // keep one hour
+	}
+
+	deleted, err := storage.DeleteOldMetricsByName(cutoffs, 0)
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected 1 metric deleted, got %d", deleted)
+	}
+
+	balances, _ := storage.GetMetrics("account_balance", 0)
+	if len(balances) != 2 {
+		t.Errorf("expected both account_balance metrics kept, got %d", len(balances))
+	}
+
+	latencies, _ := storage.GetMetrics("node_latency", 0)
+	if len(latencies) != 1 || latencies[0].Value != 4.0 {
+		t.Errorf("expected only the recent node_latency metric kept, got %v", latencies)
+	}
+}
+
+func TestDeleteOldMetricsByName_DefaultCutoff(t *testing.T) {
+	storage := NewMemoryStorage()
+
+	now := time.Now().Unix()
+	mustStoreMetric(t, storage, types.Metric{Name: "metric_a", Timestamp: now - 1000, Value: 1.0, Labels: map[string]string{}})
+	mustStoreMetric(t, storage, types.Metric{Name: "metric_b", Timestamp: now - 1000, Value: 2.0, Labels: map[string]string{}})
+
+	// metric_a has an override that keeps it; metric_b falls back to the default
+	deleted, _ := storage.DeleteOldMetricsByName(map[string]int64{"metric_a": now - 2000}, now-500)
+	if deleted != 1 {
+		t.Errorf("expected 1 metric deleted, got %d", deleted)
+	}
+
+	metrics, _ := storage.GetMetrics("", 0)
+	if len(metrics) != 1 || metrics[0].Name != "metric_a" {
+		t.Errorf("expected only metric_a to remain, got %v", metrics)
+	}
+}
+
+func TestApplyRetention(t *testing.T) {
+	storage := NewMemoryStorage()
+
+	now := time.Now()
+	mustStoreMetric(t, storage, types.Metric{Name: "account_balance", Timestamp: now.Add(-48 * time.Hour).Unix(), Value: 1.0, Labels: map[string]string{}})
+	mustStoreMetric(t, storage, types.Metric{Name: "node_latency", Timestamp: now.Add(-2 * time.Hour).Unix(), Value: 2.0, Labels: map[string]string{}})
+	mustStoreMetric(t, storage, types.Metric{Name: "other_metric", Timestamp: now.Add(-48 * time.Hour).Unix(), Value: 3.0, Labels: map[string]string{}})
+
+	policy := RetentionPolicy{
+		Default: 24 * time.Hour,
+		ByName: map[string]time.Duration{
+			"account_balance": 72 * time.Hour,
+			"node_latency":    time.Hour,
+		},
+	}
+
+	deleted, err := ApplyRetention(storage, policy, now)
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("expected 2 metrics deleted, got %d", deleted)
+	}
+
+	metrics, _ := storage.GetMetrics("", 0)
+	if len(metrics) != 1 || metrics[0].Name != "account_balance" {
+		t.Errorf("expected only account_balance to remain, got %v", metrics)
+	}
+}
+
+func TestRetentionPolicy_Enabled(t *testing.T) {
+	if (RetentionPolicy{}).Enabled() {
+		t.Error("expected empty policy to be disabled")
+	}
+	if !(RetentionPolicy{Default: time.Hour}).Enabled() {
+		t.Error("expected policy with default to be enabled")
+	}
+	if !(RetentionPolicy{ByName: map[string]time.Duration{"a": time.Hour}}).Enabled() {
+		t.Error("expected policy with overrides to be enabled")
+	}
+}
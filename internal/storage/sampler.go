@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"sync"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+// SamplingStorage is a Storage decorator that stores only every Nth sample of each
+// series for configured metric names, so a metric collected every second can be
+// kept at a coarser rate without changing the collector producing it
+// Metrics without a configured rate, and every read, pass straight through
+type SamplingStorage struct {
+	Storage
+
+	every  map[string]int // Keep 1 of every N samples per series, by metric name
+	counts map[string]int // Samples seen per series since the last one kept
+	mu     sync.Mutex
+}
+
+// NewSamplingStorage wraps store so metrics named in every keep 1 of every N
+// samples per series. Rates below 2 keep every sample
+func NewSamplingStorage(store Storage, every map[string]int) *SamplingStorage {
+	rates := make(map[string]int, len(every))
+	for name, n := range every {
+		if 1 < n {
+			rates[name] = n
+		}
+	}
+	return &SamplingStorage{
+		Storage: store,
+		every:   rates,
+		counts:  make(map[string]int),
+	}
+}
+
+// StoreMetric stores the first sample of each sampled series and every Nth one
+// after it; the samples in between are dropped without error
+func (s *SamplingStorage) StoreMetric(metric types.Metric) error {
+	if n, ok := s.every[metric.Name]; ok && !s.keep(SeriesKey(metric), n) {
+		return nil
+	}
+	return s.Storage.StoreMetric(metric)
+}
+
+// keep counts a sample of the series and reports whether it is the one in n to store
+func (s *SamplingStorage) keep(series string, n int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := s.counts[series]
+	s.counts[series] = (seen + 1) % n
+	return seen == 0
+}
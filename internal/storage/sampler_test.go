@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+func TestSamplingStorage_KeepsEveryNth(t *testing.T) {
+	inner := NewMemoryStorage()
+	store := NewSamplingStorage(inner, map[string]int{"tps": 5})
+
+	for i := 0; i < 20; i++ {
+		mustStoreMetric(t, store, types.Metric{Name: "tps", Timestamp: int64(i), Value: float64(i), Labels: map[string]string{}})
+	}
+
+	metrics, _ := inner.GetMetrics("tps", 0)
+	if len(metrics) != 4 {
+		t.Fatalf("expected 4 of 20 samples stored, got %d", len(metrics))
+	}
+	for i, metric := range metrics {
+		if metric.Timestamp != int64(i*5) {
+			t.Errorf("expected sample %d to have timestamp %d, got %d", i, i*5, metric.Timestamp)
+		}
+	}
+}
+
+func TestSamplingStorage_CountsPerSeries(t *testing.T) {
+	inner := NewMemoryStorage()
+	store := NewSamplingStorage(inner, map[string]int{"tps": 5})
+
+	// Interleaved series are counted separately, so each keeps its own first sample
+	for i := 0; i < 5; i++ {
+		for _, node := range []string{"0.0.3", "0.0.4"} {
+			mustStoreMetric(t, store, types.Metric{Name: "tps", Timestamp: int64(i), Labels: map[string]string{"node": node}})
+		}
+	}
+
+	metrics, _ := inner.GetMetrics("tps", 0)
+	if len(metrics) != 2 {
+		t.Fatalf("expected 1 sample per series, got %d", len(metrics))
+	}
+	for _, metric := range metrics {
+		if metric.Timestamp != 0 {
+			t.Errorf("expected the first sample of %s to be kept, got timestamp %d", metric.Labels["node"], metric.Timestamp)
+		}
+	}
+}
+
+func TestSamplingStorage_PassesThroughOtherMetrics(t *testing.T) {
+	inner := NewMemoryStorage()
+	store := NewSamplingStorage(inner, map[string]int{"tps": 5, "account_balance": 1})
+
+	for i := 0; i < 3; i++ {
+		mustStoreMetric(t, store, types.Metric{Name: "account_balance", Timestamp: int64(i), Labels: map[string]string{}})
+		mustStoreMetric(t, store, types.Metric{Name: "network_nodes_available", Timestamp: int64(i), Labels: map[string]string{}})
+	}
+
+	// Reads go through to the wrapped storage
+	metrics, err := store.GetMetrics("", 0)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(metrics) != 6 {
+		t.Errorf("expected every unsampled metric to be stored, got %d", len(metrics))
+	}
+}
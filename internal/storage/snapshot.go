@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+)
+
+// snapshotVersion is the current snapshot file format
+const snapshotVersion = 1
+
+// memorySnapshot is the on-disk form of a MemoryStorage snapshot
+type memorySnapshot struct {
+	Version int            `json:"version"`
+	Metrics []types.Metric `json:"metrics"`
+}
+
+// Snapshot writes every stored metric to w as JSON so it can be restored after a restart
+func (ms *MemoryStorage) Snapshot(w io.Writer) error {
+	ms.mu.RLock()
+	snapshot := memorySnapshot{Version: snapshotVersion, Metrics: ms.metrics}
+	err := json.NewEncoder(w).Encode(snapshot)
+	ms.mu.RUnlock()
+
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// Restore replaces the stored metrics with a snapshot read from r
+// If the snapshot holds more than maxSize metrics, only the newest are kept
+func (ms *MemoryStorage) Restore(r io.Reader) error {
+	var snapshot memorySnapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	if snapshot.Version != snapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d", snapshot.Version)
+	}
+
+	metrics := snapshot.Metrics
+	if ms.maxSize < len(metrics) {
+		metrics = metrics[len(metrics)-ms.maxSize:]
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.metrics = append(make([]types.Metric, 0, max(len(metrics), ms.maxSize)), metrics...)
+	return nil
+}
+
+// SaveSnapshotFile snapshots storage to path, replacing the file atomically
+// so a crash mid-write can't leave a truncated snapshot behind
+func SaveSnapshotFile(ms *MemoryStorage, path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := ms.Snapshot(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace snapshot file: %w", err)
+	}
+
+	logger.Info("Saved metrics snapshot",
+		"component", "MemoryStorage",
+		"path", path)
+	return nil
+}
+
+// LoadSnapshotFile restores storage from the snapshot at path
+// A missing file isn't an error, since the first run has nothing to restore
+func LoadSnapshotFile(ms *MemoryStorage, path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+	defer f.Close()
+
+	if err := ms.Restore(f); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	logger.Info("Restored metrics snapshot",
+		"component", "MemoryStorage",
+		"path", path)
+	return nil
+}
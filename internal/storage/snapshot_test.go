@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+)
+
+func TestSnapshotRestore_RoundTrip(t *testing.T) {
+	original := NewMemoryStorage()
+	metrics := []types.Metric{
+		{Name: "account_balance", Timestamp: 1700000000, Value: 1e9, Labels: map[string]string{"account_id": "0.0.5000"}},
+		{Name: "account_balance", Timestamp: 1700000030, Value: 9.5e8, Labels: map[string]string{"account_id": "0.0.5000"}},
+		{Name: "network_nodes_available", Timestamp: 1700000060, Value: 28, Labels: map[string]string{}},
+	}
+	for _, metric := range metrics {
+		mustStoreMetric(t, original, metric)
+	}
+
+	var buf bytes.Buffer
+	if err := original.Snapshot(&buf); err != nil {
+		t.Fatalf("snapshot failed: %v", err)
+	}
+
+	restored := NewMemoryStorage()
+	if err := restored.Restore(&buf); err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+
+	got, _ := restored.GetMetrics("", 0)
+	if !reflect.DeepEqual(got, metrics) {
+		t.Errorf("expected restored metrics %v, got %v", metrics, got)
+	}
+}
+
+func TestRestore_KeepsNewestWithinMaxSize(t *testing.T) {
+	original := NewMemoryStorage()
+	for i := 0; i < 5; i++ {
+		mustStoreMetric(t, original, types.Metric{Name: "m", Timestamp: int64(i), Value: float64(i)})
+	}
+
+	var buf bytes.Buffer
+	if err := original.Snapshot(&buf); err != nil {
+		t.Fatalf("snapshot failed: %v", err)
+	}
+
+	restored := NewMemoryStorage()
+	restored.maxSize = 3
+	if err := restored.Restore(&buf); err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+
+	got, _ := restored.GetMetrics("m", 0)
+	if len(got) != 3 || got[0].Value != 2 || got[2].Value != 4 {
+		t.Errorf("expected the newest 3 metrics, got %v", got)
+	}
+}
+
+func TestRestore_InvalidSnapshot(t *testing.T) {
+	storage := NewMemoryStorage()
+	mustStoreMetric(t, storage, types.Metric{Name: "kept", Timestamp: 1})
+
+	for _, input := range []string{"not json", `{"version":99,"metrics":[]}`} {
+		if err := storage.Restore(strings.NewReader(input)); err == nil {
+			t.Errorf("expected error restoring %q", input)
+		}
+	}
+
+	if got, _ := storage.GetMetrics("kept", 0); len(got) != 1 {
+		t.Error("expected existing metrics to survive a failed restore")
+	}
+}
+
+func TestSnapshotFile_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.snapshot")
+
+	// First start: no snapshot yet
+	storage := NewMemoryStorage()
+	if err := LoadSnapshotFile(storage, path); err != nil {
+		t.Fatalf("expected missing snapshot to be ignored, got: %v", err)
+	}
+
+	mustStoreMetric(t, storage, types.Metric{Name: "account_balance", Timestamp: 1700000000, Value: 42})
+	if err := SaveSnapshotFile(storage, path); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	restored := NewMemoryStorage()
+	if err := LoadSnapshotFile(restored, path); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if got, _ := restored.GetMetrics("account_balance", 0); len(got) != 1 || got[0].Value != 42 {
+		t.Errorf("expected restored metric with value 42, got %v", got)
+	}
+}
@@ -11,16 +11,62 @@ type Storage interface {
 
 	// GetMetrics retrieves metrics matching the given criteria
 	// name: metric name filter (empty string = all)
-	// limit: maximum number of metrics to return (0 = unlimited)
+	// limit: maximum number of metrics to return (0 = unlimited); implementations must
+	// not return more, though the API truncates longer results defensively
 	GetMetrics(name string, limit int) ([]types.Metric, error)
 
+	// GetMetricsAfter retrieves metrics with a timestamp strictly after the cursor, oldest first
+	// name: metric name filter (empty string = all)
+	// limit: page size (0 = unlimited); a page may exceed it so samples sharing the
+	// last timestamp aren't split, making that timestamp a safe cursor for the next page
+	GetMetricsAfter(name string, after int64, limit int) ([]types.Metric, error)
+
+	// GetMetricsByPrefix retrieves metrics whose name starts with prefix
+	// e.g. "network_node_" matches network_node_latency and network_node_unreachable
+	// limit: maximum number of metrics to return (0 = unlimited)
+	GetMetricsByPrefix(prefix string, limit int) ([]types.Metric, error)
+
 	// GetMetricsByLabel retrieves metrics matching the given label key-value pair
 	GetMetricsByLabel(key, value string) ([]types.Metric, error)
 
+	// GetMetricsMatching retrieves metrics that have every given label key-value pair
+	// name: metric name filter (empty string = all)
+	// labels: labels that must all match (nil or empty = no label filtering)
+	// limit: maximum number of metrics to return (0 = unlimited)
+	GetMetricsMatching(name string, labels map[string]string, limit int) ([]types.Metric, error)
+
 	// DeleteOldMetrics removes metrics older than the given timestamp
 	// This is useful for cleanup and managing storage size
 	DeleteOldMetrics(beforeTimestamp int64) error
 
-	// Close closes the storage backend (cleanup, close connections, etc.)
+	// DeleteOldMetricsByName removes metrics older than a per-name cutoff timestamp
+	// Names without an entry in cutoffs use defaultCutoff (0 = keep forever)
+	// Returns the number of metrics deleted
+	DeleteOldMetricsByName(cutoffs map[string]int64, defaultCutoff int64) (int, error)
+
+	// DeleteMetrics removes metrics matching the given name and label pair
+	// An empty name, labelKey, or labelValue matches all metrics for that dimension
+	// (an empty labelKey disables label filtering entirely)
+	// before: only delete metrics with a timestamp before this (0 = any age)
+	// Returns the number of metrics deleted
+	DeleteMetrics(name, labelKey, labelValue string, before int64) (int, error)
+
+	// Close flushes any buffered writes to durable storage, then releases the backend
+	// (files, connections). A returned error means metrics stored before Close may
+	// have been lost, so callers should report it rather than ignore it
+	// The storage must not be used after Close
 	Close() error
 }
+
+// StorageInfo describes what a storage backend supports
+type StorageInfo struct {
+	Backend      string // Backend name, e.g. "memory"
+	Persistent   bool   // Whether metrics survive a restart
+	RangeQueries bool   // Whether GetMetricsAfter can page through a time range
+	Capacity     int    // Maximum number of metrics kept (0 = unknown or unbounded)
+}
+
+// InfoProvider is implemented by backends that can describe their capabilities
+type InfoProvider interface {
+	Info() StorageInfo
+}
@@ -0,0 +1,114 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Severity is how urgent an alert is
+// It is a string type so JSON, YAML and config files keep the plain names
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Severities lists every valid severity, least urgent first
+var Severities = []Severity{SeverityInfo, SeverityWarning, SeverityCritical}
+
+// ParseSeverity returns the Severity named s, or an error listing the valid names
+func ParseSeverity(s string) (Severity, error) {
+	severity := Severity(s)
+	if !severity.Valid() {
+		return "", fmt.Errorf("invalid severity %q: must be one of %s", s, SeverityNames())
+	}
+	return severity, nil
+}
+
+// SeverityNames lists the valid severities for messages, e.g. "info, warning, critical"
+func SeverityNames() string {
+	return joinNames(Severities)
+}
+
+// String returns the severity name
+func (s Severity) String() string {
+	return string(s)
+}
+
+// Valid reports whether s is one of Severities
+func (s Severity) Valid() bool {
+	return slices.Contains(Severities, s)
+}
+
+// Escalate returns the next severity level up; critical stays critical
+func (s Severity) Escalate() Severity {
+	if s == SeverityInfo {
+		return SeverityWarning
+	}
+	return SeverityCritical
+}
+
+// Condition is the comparison an alert rule applies to a metric value
+// It is a string type so JSON, YAML and config files keep the plain operators
+type Condition string
+
+const (
+	ConditionGreater      Condition = ">"
+	ConditionLess         Condition = "<"
+	ConditionGreaterEqual Condition = ">="
+	ConditionLessEqual    Condition = "<="
+	ConditionEqual        Condition = "=="
+	ConditionNotEqual     Condition = "!="
+
+	// State conditions compare against the previous value instead of the threshold
+	ConditionChanged   Condition = "changed"
+	ConditionIncreased Condition = "increased"
+	ConditionDecreased Condition = "decreased"
+)
+
+// Conditions lists every valid condition, threshold comparisons first
+var Conditions = []Condition{
+	ConditionGreater, ConditionLess, ConditionGreaterEqual, ConditionLessEqual, ConditionEqual, ConditionNotEqual,
+	ConditionChanged, ConditionIncreased, ConditionDecreased,
+}
+
+// ParseCondition returns the Condition named s, or an error listing the valid names
+func ParseCondition(s string) (Condition, error) {
+	condition := Condition(s)
+	if !condition.Valid() {
+		return "", fmt.Errorf("invalid condition %q: must be one of %s", s, ConditionNames())
+	}
+	return condition, nil
+}
+
+// ConditionNames lists the valid conditions for messages, e.g. ">, <, >=, ..."
+func ConditionNames() string {
+	return joinNames(Conditions)
+}
+
+// String returns the condition operator or name
+func (c Condition) String() string {
+	return string(c)
+}
+
+// Valid reports whether c is one of Conditions
+func (c Condition) Valid() bool {
+	return slices.Contains(Conditions, c)
+}
+
+// IsState reports whether c compares against the previous value rather than the threshold
+func (c Condition) IsState() bool {
+	return c == ConditionChanged || c == ConditionIncreased || c == ConditionDecreased
+}
+
+// joinNames joins values with ", "
+func joinNames[T ~string](values []T) string {
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = string(v)
+	}
+	return strings.Join(names, ", ")
+}
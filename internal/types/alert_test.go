@@ -0,0 +1,98 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestParseSeverity tests every valid severity parses and others are rejected
+func TestParseSeverity(t *testing.T) {
+	for _, name := range []string{"info", "warning", "critical"} {
+		severity, err := ParseSeverity(name)
+		if err != nil {
+			t.Errorf("Expected %q to parse, got: %v", name, err)
+		}
+		if severity.String() != name {
+			t.Errorf("Expected %q, got %q", name, severity)
+		}
+	}
+
+	for _, name := range []string{"", "FAKE NEWS", "Critical", "error"} {
+		_, err := ParseSeverity(name)
+		if err == nil {
+			t.Errorf("Expected error for severity %q", name)
+			continue
+		}
+		if !strings.Contains(err.Error(), "info, warning, critical") {
+			t.Errorf("Expected error to list the valid severities, got: %v", err)
+		}
+	}
+}
+
+// TestSeverity_Escalate tests severity bumps
+func TestSeverity_Escalate(t *testing.T) {
+	tests := map[Severity]Severity{
+		SeverityInfo:     SeverityWarning,
+		SeverityWarning:  SeverityCritical,
+		SeverityCritical: SeverityCritical,
+	}
+	for severity, expected := range tests {
+		if got := severity.Escalate(); got != expected {
+			t.Errorf("Expected %s to escalate to %s, got %s", severity, expected, got)
+		}
+	}
+}
+
+// TestParseCondition tests every valid condition parses and others are rejected
+func TestParseCondition(t *testing.T) {
+	for _, name := range []string{">", "<", ">=", "<=", "==", "!=", "changed", "increased", "decreased"} {
+		condition, err := ParseCondition(name)
+		if err != nil {
+			t.Errorf("Expected %q to parse, got: %v", name, err)
+		}
+		if condition.String() != name {
+			t.Errorf("Expected %q, got %q", name, condition)
+		}
+	}
+
+	for _, name := range []string{"", "=", "=>", "Changed", "gt"} {
+		if _, err := ParseCondition(name); err == nil {
+			t.Errorf("Expected error for condition %q", name)
+		}
+	}
+}
+
+// TestCondition_IsState tests only changed, increased and decreased are state conditions
+func TestCondition_IsState(t *testing.T) {
+	for _, condition := range Conditions {
+		expected := condition == ConditionChanged || condition == ConditionIncreased || condition == ConditionDecreased
+		if condition.IsState() != expected {
+			t.Errorf("Expected IsState() = %v for %q", expected, condition)
+		}
+	}
+}
+
+// TestSeverityCondition_JSON tests both types marshal as their plain string form
+func TestSeverityCondition_JSON(t *testing.T) {
+	rule := struct {
+		Condition Condition `json:"condition"`
+		Severity  Severity  `json:"severity"`
+	}{ConditionChanged, SeverityCritical}
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if string(data) != `{"condition":"changed","severity":"critical"}` {
+		t.Errorf("Expected plain string fields, got %s", data)
+	}
+
+	rule.Condition, rule.Severity = "", ""
+	if err := json.Unmarshal(data, &rule); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if rule.Condition != ConditionChanged || rule.Severity != SeverityCritical {
+		t.Errorf("Expected the values to round-trip, got %+v", rule)
+	}
+}
@@ -0,0 +1,213 @@
+package apiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultTimeout bounds each API request
+const defaultTimeout = 30 * time.Second
+
+// Metric is a stored metric sample returned by the metrics endpoints
+type Metric struct {
+	Name      string            `json:"name"`
+	Timestamp int64             `json:"timestamp"`
+	Value     float64           `json:"value"`
+	Labels    map[string]string `json:"labels"`
+}
+
+// MetricsResponse is the body of GET /api/v1/metrics
+type MetricsResponse struct {
+	Metrics []Metric `json:"metrics"`
+	Count   int      `json:"count"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// AlertRule is an alert rule as returned by the alerts endpoints
+type AlertRule struct {
+	ID              string  `json:"id"`
+	Name            string  `json:"name"`
+	Description     string  `json:"description"`
+	MetricName      string  `json:"metric_name"`
+	Condition       string  `json:"condition"`
+	Threshold       float64 `json:"threshold"`
+	Severity        string  `json:"severity"`
+	Enabled         bool    `json:"enabled"`
+	CooldownSeconds int     `json:"cooldown_seconds"`
+	ForSeconds      int     `json:"for_seconds"`
+	MinChange       float64 `json:"min_change,omitempty"`
+	WarmupSeconds   int     `json:"warmup_seconds,omitempty"`
+
+	EscalateAfter         int `json:"escalate_after"`
+	EscalateWindowSeconds int `json:"escalate_window_seconds"`
+
+	MinChangePercent float64 `json:"min_change_percent,omitempty"` // "changed" ignores moves below this percent of the previous value
+
+	DenominatorMetric string `json:"denominator_metric,omitempty"` // Makes this a ratio rule: metric_name / denominator_metric
+
+	Tags        []string          `json:"tags,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Webhooks    []string          `json:"webhooks,omitempty"`
+
+	Evaluations   int64 `json:"evaluations"`              // Metrics checked against the rule
+	LastEvaluated int64 `json:"last_evaluated,omitempty"` // Unix seconds; 0 = never evaluated
+	LastFired     int64 `json:"last_fired,omitempty"`     // Unix seconds; 0 = never fired
+}
+
+// AlertList is the body of GET /api/v1/alerts
+type AlertList struct {
+	Alerts []AlertRule `json:"alerts"`
+	Count  int         `json:"count"`
+}
+
+// CreateAlertRequest is the request payload for POST /api/v1/alerts
+type CreateAlertRequest struct {
+	Name            string  `json:"name"`
+	Description     string  `json:"description"`
+	MetricName      string  `json:"metric_name"`
+	Condition       string  `json:"condition"`
+	Threshold       float64 `json:"threshold"`
+	Severity        string  `json:"severity"`
+	CooldownSeconds int     `json:"cooldown_seconds"`
+	ForSeconds      int     `json:"for_seconds"`
+	MinChange       float64 `json:"min_change,omitempty"`
+	WarmupSeconds   int     `json:"warmup_seconds,omitempty"`
+
+	EscalateAfter         int `json:"escalate_after"`
+	EscalateWindowSeconds int `json:"escalate_window_seconds"`
+
+	MinChangePercent float64 `json:"min_change_percent,omitempty"` // "changed" ignores moves below this percent of the previous value
+
+	DenominatorMetric string `json:"denominator_metric,omitempty"` // Makes this a ratio rule: metric_name / denominator_metric
+
+	Tags        []string          `json:"tags,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Webhooks    []string          `json:"webhooks,omitempty"`
+}
+
+// StatusError is returned when the API responds with an unexpected status code
+type StatusError struct {
+	StatusCode int
+	Body       string // Response body, usually a JSON {"error": "..."} object
+}
+
+// Error implements the error interface
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// Client calls the monitoring service's HTTP API
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// New creates an API client for a base URL such as "http://localhost:8080"
+// A non-empty token is sent as "Authorization: Bearer <token>" on every request
+func New(baseURL, token string) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+// do sends a request with an optional JSON body and decodes a JSON response into out
+// Any status not in expected is returned as a *StatusError; out may be nil to
+// discard the response body
+func (c *Client) do(method, path string, query url.Values, body, out any, expected ...int) error {
+	fullURL := c.baseURL + path
+	if len(query) > 0 {
+		fullURL += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, fullURL, reader)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if !slices.Contains(expected, resp.StatusCode) {
+		respBody, _ := io.ReadAll(resp.Body)
+		return &StatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(respBody))}
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// QueryMetrics returns stored metrics named name, at most limit of them (0 = unlimited)
+func (c *Client) QueryMetrics(name string, limit int) ([]Metric, error) {
+	query := url.Values{}
+	query.Set("name", name)
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var response MetricsResponse
+	if err := c.do(http.MethodGet, "/api/v1/metrics", query, nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return response.Metrics, nil
+}
+
+// ListAlerts returns every configured alert rule
+func (c *Client) ListAlerts() (AlertList, error) {
+	var response AlertList
+	if err := c.do(http.MethodGet, "/api/v1/alerts", nil, nil, &response, http.StatusOK); err != nil {
+		return AlertList{}, err
+	}
+	return response, nil
+}
+
+// CreateAlert creates an alert rule and returns it as stored, including its ID
+func (c *Client) CreateAlert(request CreateAlertRequest) (AlertRule, error) {
+	var response AlertRule
+	err := c.do(http.MethodPost, "/api/v1/alerts", nil, request, &response, http.StatusCreated, http.StatusOK)
+	if err != nil {
+		return AlertRule{}, err
+	}
+	return response, nil
+}
+
+// DeleteAlert deletes the alert rule with the given ID
+// A rule that doesn't exist is reported as a *StatusError with status 404
+func (c *Client) DeleteAlert(id string) error {
+	query := url.Values{}
+	query.Set("id", id)
+	return c.do(http.MethodDelete, "/api/v1/alerts", query, nil, nil, http.StatusNoContent, http.StatusOK)
+}
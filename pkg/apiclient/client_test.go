@@ -0,0 +1,193 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestServer starts a server that checks the method and path before calling handler
+func newTestServer(t *testing.T, method, path string, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method || r.URL.Path != path {
+			t.Errorf("expected %s %s, got %s %s", method, path, r.Method, r.URL.Path)
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// writeError writes an API error response
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// TestQueryMetrics tests the name and limit are sent and metrics decoded
+func TestQueryMetrics(t *testing.T) {
+	server := newTestServer(t, http.MethodGet, "/api/v1/metrics", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name") != "account_balance" || r.URL.Query().Get("limit") != "10" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		_ = json.NewEncoder(w).Encode(MetricsResponse{
+			Metrics: []Metric{{Name: "account_balance", Timestamp: 1700000000, Value: 42, Labels: map[string]string{"account_id": "0.0.5000"}}},
+			Count:   1,
+		})
+	})
+
+	metrics, err := New(server.URL, "").QueryMetrics("account_balance", 10)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(metrics) != 1 || metrics[0].Value != 42 || metrics[0].Labels["account_id"] != "0.0.5000" {
+		t.Errorf("unexpected metrics %+v", metrics)
+	}
+}
+
+// TestQueryMetrics_ErrorStatus tests an error status is returned as a *StatusError
+func TestQueryMetrics_ErrorStatus(t *testing.T) {
+	server := newTestServer(t, http.MethodGet, "/api/v1/metrics", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusInternalServerError, "failed to retrieve metrics")
+	})
+
+	_, err := New(server.URL, "").QueryMetrics("account_balance", 0)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected a *StatusError, got: %v", err)
+	}
+	if statusErr.StatusCode != http.StatusInternalServerError || !strings.Contains(statusErr.Body, "failed to retrieve metrics") {
+		t.Errorf("unexpected status error %+v", statusErr)
+	}
+	if !strings.Contains(err.Error(), "status 500") {
+		t.Errorf("expected the status in the message, got: %v", err)
+	}
+}
+
+// TestListAlerts tests rules are decoded and the bearer token is sent
+func TestListAlerts(t *testing.T) {
+	server := newTestServer(t, http.MethodGet, "/api/v1/alerts", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("expected bearer token, got %q", got)
+		}
+		_ = json.NewEncoder(w).Encode(AlertList{
+			Alerts: []AlertRule{{ID: "rule1", Name: "Low Balance", Threshold: 1.5, Tags: []string{"balances"}}},
+			Count:  1,
+		})
+	})
+
+	list, err := New(server.URL+"/", "secret").ListAlerts()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if list.Count != 1 || list.Alerts[0].ID != "rule1" || list.Alerts[0].Threshold != 1.5 || list.Alerts[0].Tags[0] != "balances" {
+		t.Errorf("unexpected alert list %+v", list)
+	}
+}
+
+// TestListAlerts_Errors tests error statuses, undecodable bodies and unreachable servers
+func TestListAlerts_Errors(t *testing.T) {
+	server := newTestServer(t, http.MethodGet, "/api/v1/alerts", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusServiceUnavailable, "alerting disabled")
+	})
+	_, err := New(server.URL, "").ListAlerts()
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected a 503 *StatusError, got: %v", err)
+	}
+
+	server = newTestServer(t, http.MethodGet, "/api/v1/alerts", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{not json"))
+	})
+	if _, err := New(server.URL, "").ListAlerts(); err == nil || !strings.Contains(err.Error(), "decode") {
+		t.Errorf("expected a decode error, got: %v", err)
+	}
+
+	if _, err := New("http://localhost:1", "").ListAlerts(); err == nil || errors.As(err, &statusErr) {
+		t.Errorf("expected a connection error, got: %v", err)
+	}
+}
+
+// TestCreateAlert tests the rule is sent as JSON and the created rule returned
+func TestCreateAlert(t *testing.T) {
+	server := newTestServer(t, http.MethodPost, "/api/v1/alerts", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("expected a JSON content type, got %q", r.Header.Get("Content-Type"))
+		}
+		var request CreateAlertRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(AlertRule{
+			ID:         "rule1",
+			Name:       request.Name,
+			MetricName: request.MetricName,
+			Condition:  request.Condition,
+			Threshold:  request.Threshold,
+			Severity:   request.Severity,
+			Enabled:    true,
+		})
+	})
+
+	rule, err := New(server.URL, "").CreateAlert(CreateAlertRequest{
+		Name:       "Low Balance",
+		MetricName: "account_balance",
+		Condition:  "<",
+		Threshold:  1000000000,
+		Severity:   "warning",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if rule.ID != "rule1" || rule.Name != "Low Balance" || rule.Threshold != 1000000000 || !rule.Enabled {
+		t.Errorf("unexpected created rule %+v", rule)
+	}
+}
+
+// TestCreateAlert_ErrorStatus tests a rejected rule reports the API's message
+func TestCreateAlert_ErrorStatus(t *testing.T) {
+	server := newTestServer(t, http.MethodPost, "/api/v1/alerts", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusBadRequest, "field \"threshold\" is required")
+	})
+
+	_, err := New(server.URL, "").CreateAlert(CreateAlertRequest{Name: "Broken"})
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected a 400 *StatusError, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "threshold") {
+		t.Errorf("expected the API's message in the error, got: %v", err)
+	}
+}
+
+// TestDeleteAlert tests the rule ID is sent and 204 is success
+func TestDeleteAlert(t *testing.T) {
+	server := newTestServer(t, http.MethodDelete, "/api/v1/alerts", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("id") != "rule1" {
+			t.Errorf("expected id rule1, got %q", r.URL.Query().Get("id"))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := New(server.URL, "").DeleteAlert("rule1"); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+}
+
+// TestDeleteAlert_NotFound tests a missing rule is reported as a 404 *StatusError
+func TestDeleteAlert_NotFound(t *testing.T) {
+	server := newTestServer(t, http.MethodDelete, "/api/v1/alerts", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "alert rule not found")
+	})
+
+	err := New(server.URL, "").DeleteAlert("missing")
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected a 404 *StatusError, got: %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/websocket"
+)
+
+// streamPath is the WebSocket endpoint that pushes metrics as they are stored
+const streamPath = "/api/v1/metrics/stream"
+
+// ErrStreamRejected is returned when the server answers the stream handshake with
+// anything but a protocol switch, e.g. 404 from an older server or 401/403 for a bad
+// token. Retrying won't help
+var ErrStreamRejected = errors.New("metrics stream rejected the connection")
+
+// StreamMetrics connects to the metrics stream and calls handle for each metric
+// received, filtered server-side to name (empty = all metrics)
+// It blocks until the connection drops, returning the error, or ctx is
+// cancelled, returning ctx.Err()
+func (c *Client) StreamMetrics(ctx context.Context, name string, handle func(Metric)) error {
+	streamURL, err := c.streamURL(name)
+	if err != nil {
+		return err
+	}
+	config, err := websocket.NewConfig(streamURL, c.baseURL)
+	if err != nil {
+		return fmt.Errorf("failed to build stream request: %w", err)
+	}
+	if c.token != "" {
+		config.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	conn, err := config.DialContext(ctx)
+	if err != nil {
+		var dialErr *websocket.DialError
+		if errors.As(err, &dialErr) && dialErr.Err == websocket.ErrBadStatus {
+			return fmt.Errorf("%w (check the API URL, token, and that the server supports %s)", ErrStreamRejected, streamPath)
+		}
+		return fmt.Errorf("failed to connect to metrics stream: %w", err)
+	}
+	defer conn.Close()
+
+	// Closing the connection unblocks Receive when ctx is cancelled
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	for {
+		var metric Metric
+		if err := websocket.JSON.Receive(conn, &metric); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("metrics stream closed: %w", err)
+		}
+		handle(metric)
+	}
+}
+
+// streamURL returns the ws:// or wss:// URL of the metrics stream for the base URL
+func (c *Client) streamURL(name string) (string, error) {
+	u, err := url.Parse(c.baseURL + streamPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid API URL %q: %w", c.baseURL, err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	default:
+		return "", fmt.Errorf("invalid API URL %q: must be http or https", c.baseURL)
+	}
+	if name != "" {
+		u.RawQuery = url.Values{"name": {name}}.Encode()
+	}
+	return u.String(), nil
+}
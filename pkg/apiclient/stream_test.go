@@ -0,0 +1,52 @@
+package apiclient
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/websocket"
+)
+
+// TestStreamMetrics tests the name filter and token are sent and streamed metrics decoded
+func TestStreamMetrics(t *testing.T) {
+	server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		r := conn.Request()
+		if r.URL.Path != "/api/v1/metrics/stream" || r.URL.Query().Get("name") != "account_balance" {
+			t.Errorf("unexpected stream request %s", r.URL)
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("expected the bearer token, got %q", r.Header.Get("Authorization"))
+		}
+		_ = websocket.JSON.Send(conn, Metric{Name: "account_balance", Value: 1})
+		_ = websocket.JSON.Send(conn, Metric{Name: "account_balance", Value: 2})
+	}))
+	defer server.Close()
+
+	var received []Metric
+	err := New(server.URL, "secret").StreamMetrics(context.Background(), "account_balance", func(metric Metric) {
+		received = append(received, metric)
+	})
+	if err == nil {
+		t.Error("expected an error once the server closes the stream")
+	}
+	if len(received) != 2 || received[0].Value != 1 || received[1].Value != 2 {
+		t.Errorf("unexpected metrics %+v", received)
+	}
+}
+
+// TestStreamMetrics_Cancel tests cancelling the context ends the stream with ctx.Err()
+func TestStreamMetrics_Cancel(t *testing.T) {
+	server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		_ = websocket.JSON.Send(conn, Metric{Name: "account_balance"})
+		var discard []byte
+		_ = websocket.Message.Receive(conn, &discard) // Hold the connection open
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := New(server.URL, "").StreamMetrics(ctx, "", func(Metric) { cancel() })
+	if err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
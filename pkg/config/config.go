@@ -1,27 +1,57 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
 	"github.com/kaldun-tech/hedera-network-monitor/internal/collector"
+	"github.com/kaldun-tech/hedera-network-monitor/internal/types"
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/hedera"
 	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
+// namespacePattern matches metric name prefixes that keep names valid in Prometheus
+var namespacePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
 // Config represents the complete configuration for the monitor service
 type Config struct {
-	Network  NetworkConfig
-	Accounts []collector.AccountConfig
-	Alerting AlertingConfig
-	API      APIConfig
-	Logging  LoggingConfig
+	Network    NetworkConfig
+	Accounts   []collector.AccountConfig
+	Networks   []MonitoredNetwork // Optional: several networks in one process, replacing Network and Accounts
+	Alerting   AlertingConfig
+	API        APIConfig
+	Logging    LoggingConfig
+	Collectors CollectorsConfig
+	Export     ExportConfig
+	Storage    StorageConfig
+
+	// Labels such as env or region added to every stored metric that lacks the key
+	GlobalLabels map[string]string `mapstructure:"global_labels"`
 }
 
 // NetworkConfig contains Hedera network configuration
 type NetworkConfig struct {
 	Name        string `mapstructure:"name"`         // "mainnet" or "testnet"
+	Fallback    string `mapstructure:"fallback"`     // Optional network to fail over to after repeated failures
 	OperatorID  string `mapstructure:"operator_id"`  // "0.0.3"
 	OperatorKey string `mapstructure:"operator_key"` // Private key for operator account
+
+	// Mirror node REST URL used for account records when the consensus query fails or is empty ("" = disabled)
+	MirrorNodeURL string `mapstructure:"mirror_node_url"`
+}
+
+// MonitoredNetwork is one network monitored with its own operator and accounts
+type MonitoredNetwork struct {
+	Network  NetworkConfig             `mapstructure:"network"`
+	Accounts []collector.AccountConfig `mapstructure:"accounts"`
 }
 
 // AlertingConfig contains alert configuration
@@ -31,25 +61,235 @@ type AlertingConfig struct {
 	Rules           []AlertRule `mapstructure:"rules"`
 	CooldownSeconds int         `mapstructure:"cooldown_seconds"`  // Default cooldown for all rules (seconds)
 	QueueBufferSize int         `mapstructure:"queue_buffer_size"` // Alert queue buffer size (default: 100)
+
+	// Seconds to keep dispatching queued alerts after shutdown begins (0 = drop immediately)
+	ShutdownGraceSeconds int `mapstructure:"shutdown_grace_seconds"`
+
+	// Webhook connection pool: idle connections kept per webhook host, and how long they stay open
+	WebhookMaxIdleConns           int `mapstructure:"webhook_max_idle_conns"`
+	WebhookIdleConnTimeoutSeconds int `mapstructure:"webhook_idle_conn_timeout_seconds"`
+
+	// Maximum seconds spent delivering one alert to a webhook, including retries (0 = no limit)
+	WebhookMaxElapsedSeconds int `mapstructure:"webhook_max_elapsed_seconds"`
+
+	// Maximum webhook deliveries in flight at once across all alerts (0 = no limit)
+	WebhookMaxConcurrency int `mapstructure:"webhook_max_concurrency"`
+
+	// Webhook TLS: extra PEM CAs to trust for internal receivers, or skip verification entirely
+	WebhookCAFile             string `mapstructure:"webhook_ca_file"`
+	WebhookInsecureSkipVerify bool   `mapstructure:"webhook_insecure_skip_verify"`
+
+	// Planned maintenance: alerts are still evaluated but not sent while the window is open
+	Maintenance MaintenanceConfig `mapstructure:"maintenance"`
+
+	// Digest mode: batch alerts into one webhook payload per interval instead of one per alert
+	Digest DigestConfig `mapstructure:"digest"`
+}
+
+// DigestConfig batches alerts to reduce notification spam during alert storms
+// Each webhook receives a JSON array of the alerts buffered for it, sent every
+// IntervalSeconds or as soon as MaxAlerts are waiting, whichever comes first
+type DigestConfig struct {
+	Enabled         bool `mapstructure:"enabled"`
+	IntervalSeconds int  `mapstructure:"interval_seconds"` // How often buffered alerts are sent (default: 60)
+	MaxAlerts       int  `mapstructure:"max_alerts"`       // Send early once this many alerts are buffered (0 = interval only, default: 100)
+}
+
+// MaintenanceConfig is a maintenance window applied at startup
+// Enabled with no start or end suppresses alerts from startup until the window is
+// ended through the API; start and end (RFC 3339) bound the window instead
+type MaintenanceConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Start   string `mapstructure:"start"` // Optional: when the window opens ("" = at startup)
+	End     string `mapstructure:"end"`   // Optional: when the window closes ("" = until ended via the API)
+}
+
+// Window parses the window bounds; a bound that isn't set is returned as the zero time
+func (m MaintenanceConfig) Window() (start, end time.Time, err error) {
+	if m.Start != "" {
+		if start, err = time.Parse(time.RFC3339, m.Start); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid maintenance start %q: must be RFC 3339", m.Start)
+		}
+	}
+	if m.End != "" {
+		if end, err = time.Parse(time.RFC3339, m.End); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid maintenance end %q: must be RFC 3339", m.End)
+		}
+	}
+	if !start.IsZero() && !end.IsZero() && !end.After(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("maintenance end %s must be after start %s", m.End, m.Start)
+	}
+	return start, end, nil
 }
 
 // AlertRule represents an alert configuration
+// The yaml tags let runtime rules be written back out in config file form
 type AlertRule struct {
-	ID              string  `mapstructure:"id"`
-	Name            string  `mapstructure:"name"`
-	MetricName      string  `mapstructure:"metric_name"`
-	Condition       string  `mapstructure:"condition"`
-	Threshold       float64 `mapstructure:"threshold"`
-	Severity        string  `mapstructure:"severity"`
-	CooldownSeconds int     `mapstructure:"cooldown_seconds"` // Optional: override default cooldown (0 = use AlertingConfig default)
+	ID              string          `mapstructure:"id" yaml:"id"`
+	Name            string          `mapstructure:"name" yaml:"name"`
+	MetricName      string          `mapstructure:"metric_name" yaml:"metric_name"`
+	Condition       types.Condition `mapstructure:"condition" yaml:"condition"`
+	Threshold       float64         `mapstructure:"threshold" yaml:"threshold"`
+	Severity        types.Severity  `mapstructure:"severity" yaml:"severity"`
+	CooldownSeconds int             `mapstructure:"cooldown_seconds" yaml:"cooldown_seconds,omitempty"` // Optional: override default cooldown (0 = use AlertingConfig default)
+	ForSeconds      int             `mapstructure:"for_seconds" yaml:"for_seconds,omitempty"`           // Optional: condition must hold this long before firing (0 = immediately)
+	MinChange       float64         `mapstructure:"min_change" yaml:"min_change,omitempty"`             // Optional: smallest difference that fires "changed" (0 = any change)
+	WarmupSeconds   int             `mapstructure:"warmup_seconds" yaml:"warmup_seconds,omitempty"`     // Optional: don't fire until the rule has existed this long (0 = immediately)
+
+	// Optional escalation: after firing EscalateAfter times without recovery (within
+	// EscalateWindowSeconds, 0 = no window), alerts are re-sent with a bumped severity
+	EscalateAfter         int `mapstructure:"escalate_after" yaml:"escalate_after,omitempty"`
+	EscalateWindowSeconds int `mapstructure:"escalate_window_seconds" yaml:"escalate_window_seconds,omitempty"`
+
+	// Optional: "changed" only fires when the value moves by at least this percent
+	// of the previous value, so small oscillations are ignored (0 = any change)
+	MinChangePercent float64 `mapstructure:"min_change_percent" yaml:"min_change_percent,omitempty"`
+
+	// Optional: makes this a ratio rule, comparing metric_name / denominator_metric
+	// (latest value of each) against the threshold, e.g. failed / total transactions
+	DenominatorMetric string `mapstructure:"denominator_metric" yaml:"denominator_metric,omitempty"`
+
+	Tags []string `mapstructure:"tags" yaml:"tags,omitempty"` // Optional: groups for filtering and bulk deletion via the API
+
+	// Optional: key/value context passed through to webhooks, e.g. runbook_url or team
+	Annotations map[string]string `mapstructure:"annotations" yaml:"annotations,omitempty"`
+
+	// Optional: send this rule's alerts only to these webhooks instead of alerting.webhooks
+	Webhooks []string `mapstructure:"webhooks" yaml:"webhooks,omitempty"`
+
+	// Optional alert message. MessageTemplate is a Go text/template rendered with the
+	// alert's fields; Description is sent when it is empty or fails to render
+	Description     string `mapstructure:"description" yaml:"description,omitempty"`
+	MessageTemplate string `mapstructure:"message_template" yaml:"message_template,omitempty"`
 }
 
 // APIConfig contains API server configuration
 type APIConfig struct {
-	Port int    `mapstructure:"port"` // Port to listen on
-	Host string `mapstructure:"host"` // Host to bind to
+	Port    int    `mapstructure:"port"`     // Port to listen on
+	Host    string `mapstructure:"host"`     // Host to bind to
+	TLSCert string `mapstructure:"tls_cert"` // PEM certificate file; serves HTTPS when set with TLSKey
+	TLSKey  string `mapstructure:"tls_key"`  // PEM private key file
+
+	AuthToken   string `mapstructure:"auth_token"`   // Bearer token for all endpoints except /health (empty = no auth)
+	ScrapeToken string `mapstructure:"scrape_token"` // Bearer token that may only read metrics
+
+	MaxBodyBytes int64 `mapstructure:"max_body_bytes"` // Largest accepted request body (0 = unlimited, default: 1 MiB)
+
+	CORS CORSConfig `mapstructure:"cors"` // Cross-origin access for browser dashboards (disabled by default)
+
+	StrictParams bool `mapstructure:"strict_params"` // Reject unrecognized query parameters with 400 (default: false)
+}
+
+// DefaultCORSMethods are the methods allowed cross-origin unless configured otherwise
+var DefaultCORSMethods = []string{"GET", "POST", "DELETE"}
+
+// DefaultCORSHeaders are the request headers allowed cross-origin unless configured otherwise
+var DefaultCORSHeaders = []string{"Authorization", "Content-Type", "Idempotency-Key", "X-Request-ID"}
+
+// CORSConfig lets browser dashboards served from other origins call the API
+// CORS is disabled while AllowedOrigins is empty
+type CORSConfig struct {
+	AllowedOrigins []string `mapstructure:"allowed_origins"` // e.g. "https://dashboard.example.com", or "*" for any origin
+	AllowedMethods []string `mapstructure:"allowed_methods"` // Default: DefaultCORSMethods
+	AllowedHeaders []string `mapstructure:"allowed_headers"` // Default: DefaultCORSHeaders
+	Paths          []string `mapstructure:"paths"`           // Path prefixes CORS applies to, e.g. "/api/v1/metrics" (empty = every endpoint)
+}
+
+// corsMethods are the methods that may be listed in CORSConfig.AllowedMethods
+var corsMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"}
+
+// Validate checks that origins are bare http(s) origins, methods are known and paths are absolute
+func (c CORSConfig) Validate() error {
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || !isHTTPURL(origin) || u.Path != "" || u.RawQuery != "" || u.Fragment != "" {
+			return fmt.Errorf("invalid CORS origin %q: must be \"*\" or scheme://host[:port]", origin)
+		}
+	}
+	for _, method := range c.AllowedMethods {
+		if !slices.Contains(corsMethods, method) {
+			return fmt.Errorf("invalid CORS method %q: must be one of %s", method, strings.Join(corsMethods, ", "))
+		}
+	}
+	for _, header := range c.AllowedHeaders {
+		if strings.TrimSpace(header) == "" {
+			return fmt.Errorf("CORS headers cannot be empty")
+		}
+	}
+	for _, path := range c.Paths {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("invalid CORS path %q: must start with /", path)
+		}
+	}
+	return nil
 }
 
+// CollectorsConfig contains settings shared by all collectors
+type CollectorsConfig struct {
+	JitterSeconds int `mapstructure:"jitter_seconds"` // Max random delay before each collection (0 = disabled)
+
+	// Maximum seconds a single SDK query may take before it is abandoned (0 = no timeout, default: 30)
+	QueryTimeoutSeconds int `mapstructure:"query_timeout_seconds"`
+
+	// Emit 0-valued transaction type metrics for types with no records
+	IncludeZeroTransactionTypes bool `mapstructure:"include_zero_transaction_types"`
+
+	// Transaction types emitted as account_transaction_type_count metrics (empty = all)
+	TransactionTypes []string `mapstructure:"transaction_types"`
+
+	// Maximum transaction records queried per account each cycle (default: 50)
+	RecordsLimit int `mapstructure:"records_limit"`
+
+	// Seconds a fetched node address book is reused before querying again (0 = no cache, default: 300)
+	AddressBookTTLSeconds int `mapstructure:"address_book_ttl_seconds"`
+
+	// Operator balance (tinybar) below which a warning is logged (0 = disabled, default: 1 HBAR)
+	OperatorBalanceFloor int64 `mapstructure:"operator_balance_floor"`
+
+	// Monitored accounts summed into portfolio_balance_total each cycle (empty = disabled)
+	PortfolioAccounts []string `mapstructure:"portfolio_accounts"`
+
+	// Collect hbar_price_usd from the mirror node exchange rate and derive account_balance_usd
+	HbarPrice bool `mapstructure:"hbar_price"`
+
+	// Prefix joined to every collected metric name with "_", e.g. "prod" (empty = none)
+	Namespace string `mapstructure:"namespace"`
+}
+
+// ExportConfig contains metric export configuration
+type ExportConfig struct {
+	RemoteWriteURL  string `mapstructure:"remote_write_url"` // Prometheus remote-write endpoint ("" = disabled)
+	IntervalSeconds int    `mapstructure:"interval_seconds"` // Seconds between remote-write pushes (default: 30)
+	AccountLabel    bool   `mapstructure:"account_label"`    // Add the account's human label as account_label
+}
+
+// StorageConfig contains metric storage configuration
+type StorageConfig struct {
+	RetentionSeconds int            `mapstructure:"retention_seconds"` // Default retention for all metrics (0 = keep forever)
+	RetentionByName  map[string]int `mapstructure:"retention_by_name"` // Per-metric-name retention overrides in seconds
+	SnapshotPath     string         `mapstructure:"snapshot_path"`     // Metrics are saved here on shutdown and restored on startup (empty = disabled)
+
+	// Per-metric-name sampling: collected metrics with an entry store only 1 of every N samples per series
+	SampleEveryByName map[string]int `mapstructure:"sample_every_by_name"`
+
+	// Rounding on ingestion: collected metric values are rounded to this many decimal places,
+	// per metric name or for every metric, before being stored and checked against alert rules
+	// (unset = no rounding)
+	RoundDecimals       *int           `mapstructure:"round_decimals"`
+	RoundDecimalsByName map[string]int `mapstructure:"round_decimals_by_name"`
+
+	// Cardinality guardrail: collected samples that would add a series to a metric name
+	// already holding this many distinct label sets are rejected (0 = unlimited)
+	MaxSeriesPerMetric int `mapstructure:"max_series_per_metric"`
+}
+
+// MaxRoundDecimals is the most decimal places values can be rounded to; float64
+// can't represent more reliably
+const MaxRoundDecimals = 15
+
 // LoggingConfig contains logging configuration
 type LoggingConfig struct {
 	Level  string `mapstructure:"level"`  // "debug", "info", "warn", "error"
@@ -65,11 +305,34 @@ func Load(configFile string) (*Config, error) {
 	viper.SetDefault("network.name", "testnet")
 	viper.SetDefault("api.port", 8080)
 	viper.SetDefault("api.host", "localhost")
+	viper.SetDefault("api.max_body_bytes", 1<<20)
+	viper.SetDefault("api.cors.allowed_methods", DefaultCORSMethods)
+	viper.SetDefault("api.cors.allowed_headers", DefaultCORSHeaders)
+	viper.SetDefault("api.strict_params", false)
 	viper.SetDefault("logging.level", "info")
 	viper.SetDefault("logging.format", "text")
 	viper.SetDefault("alerting.enabled", true)
 	viper.SetDefault("alerting.cooldown_seconds", 300)
 	viper.SetDefault("alerting.queue_buffer_size", 100)
+	viper.SetDefault("alerting.shutdown_grace_seconds", 10)
+	viper.SetDefault("alerting.webhook_max_idle_conns", 10)
+	viper.SetDefault("alerting.webhook_idle_conn_timeout_seconds", 90)
+	viper.SetDefault("alerting.webhook_insecure_skip_verify", false)
+	viper.SetDefault("alerting.webhook_max_elapsed_seconds", 0)
+	viper.SetDefault("alerting.webhook_max_concurrency", 10)
+	viper.SetDefault("alerting.maintenance.enabled", false)
+	viper.SetDefault("alerting.digest.enabled", false)
+	viper.SetDefault("alerting.digest.interval_seconds", 60)
+	viper.SetDefault("alerting.digest.max_alerts", 100)
+	viper.SetDefault("collectors.jitter_seconds", 0)
+	viper.SetDefault("collectors.query_timeout_seconds", int(collector.DefaultQueryTimeout.Seconds()))
+	viper.SetDefault("collectors.include_zero_transaction_types", false)
+	viper.SetDefault("collectors.records_limit", hedera.DefaultRecordsLimit)
+	viper.SetDefault("collectors.address_book_ttl_seconds", int(hedera.DefaultAddressBookTTL.Seconds()))
+	viper.SetDefault("collectors.operator_balance_floor", collector.DefaultOperatorBalanceFloor)
+	viper.SetDefault("collectors.hbar_price", false)
+	viper.SetDefault("export.interval_seconds", 30)
+	viper.SetDefault("export.account_label", false)
 
 	// Read configuration file
 	if err := viper.ReadInConfig(); err != nil {
@@ -80,6 +343,21 @@ func Load(configFile string) (*Config, error) {
 		return getDefaultConfig(), nil
 	}
 
+	// Merge in any included files, e.g. a long accounts list kept in its own file
+	if viper.IsSet(includeKey) {
+		merged, err := loadWithIncludes(configFile, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve config includes: %w", err)
+		}
+		data, err := yaml.Marshal(merged)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge config includes: %w", err)
+		}
+		if err := viper.ReadConfig(bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("failed to read merged configuration: %w", err)
+		}
+	}
+
 	// Unmarshal configuration
 	var config Config
 	if err := viper.Unmarshal(&config); err != nil {
@@ -95,6 +373,24 @@ func Load(configFile string) (*Config, error) {
 	return &config, nil
 }
 
+// isHTTPURL reports whether raw is an absolute http or https URL with a host
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// ValidateWebhookURL checks that a webhook URL is an absolute http or https URL with a host,
+// so misconfigured webhooks are caught at load time instead of on the first failed send
+func ValidateWebhookURL(webhookURL string) error {
+	if webhookURL == "" {
+		return fmt.Errorf("webhook URL is empty")
+	}
+	if !isHTTPURL(webhookURL) {
+		return fmt.Errorf("webhook URL %q must be an http or https URL with a host", webhookURL)
+	}
+	return nil
+}
+
 // Validate checks if the alert rule is valid
 func (r *AlertRule) Validate() error {
 	if r.ID == "" {
@@ -107,52 +403,174 @@ func (r *AlertRule) Validate() error {
 		return fmt.Errorf("rule metric name cannot be empty")
 	}
 
-	// Validate condition is supported
-	validConditions := []string{">", "<", ">=", "<=", "==", "!=", "changed", "increased", "decreased"}
-	isValid := false
-	for _, vc := range validConditions {
-		if r.Condition == vc {
-			isValid = true
-			break
-		}
+	if _, err := types.ParseCondition(r.Condition.String()); err != nil {
+		return err
 	}
-	if !isValid {
-		return fmt.Errorf("invalid condition: %s", r.Condition)
+	if _, err := types.ParseSeverity(r.Severity.String()); err != nil {
+		return err
 	}
 
-	// Validate severity
-	validSeverities := []string{"info", "warning", "critical"}
-	isSevere := false
-	for _, vs := range validSeverities {
-		if r.Severity == vs {
-			isSevere = true
-			break
-		}
+	if r.CooldownSeconds < 0 {
+		return fmt.Errorf("cooldown seconds cannot be negative: %d", r.CooldownSeconds)
 	}
-	if !isSevere {
-		return fmt.Errorf("invalid severity: %s", r.Severity)
+
+	if r.ForSeconds < 0 {
+		return fmt.Errorf("for seconds cannot be negative: %d", r.ForSeconds)
 	}
 
-	if r.CooldownSeconds < 0 {
-		return fmt.Errorf("cooldown seconds cannot be negative: %d", r.CooldownSeconds)
+	if r.WarmupSeconds < 0 {
+		return fmt.Errorf("warmup seconds cannot be negative: %d", r.WarmupSeconds)
+	}
+
+	if r.MinChange < 0 {
+		return fmt.Errorf("min change cannot be negative: %g", r.MinChange)
+	}
+	if r.MinChange != 0 && r.Condition != types.ConditionChanged {
+		return fmt.Errorf("min change only applies to the changed condition, not %s", r.Condition)
+	}
+
+	if r.MinChangePercent < 0 {
+		return fmt.Errorf("min change percent cannot be negative: %g", r.MinChangePercent)
+	}
+	if r.MinChangePercent != 0 && r.Condition != types.ConditionChanged {
+		return fmt.Errorf("min change percent only applies to the changed condition, not %s", r.Condition)
+	}
+
+	if r.EscalateAfter < 0 {
+		return fmt.Errorf("escalate after cannot be negative: %d", r.EscalateAfter)
+	}
+
+	if r.EscalateWindowSeconds < 0 {
+		return fmt.Errorf("escalate window seconds cannot be negative: %d", r.EscalateWindowSeconds)
+	}
+
+	if r.DenominatorMetric == r.MetricName {
+		return fmt.Errorf("denominator metric must differ from the metric name: %s", r.DenominatorMetric)
+	}
+
+	for _, tag := range r.Tags {
+		if strings.TrimSpace(tag) == "" {
+			return fmt.Errorf("rule tags cannot be empty")
+		}
+	}
+
+	for i, webhookURL := range r.Webhooks {
+		if err := ValidateWebhookURL(webhookURL); err != nil {
+			return fmt.Errorf("invalid rule webhook at index %d: %w", i, err)
+		}
 	}
 
 	return nil
 }
 
-// Validate validates the configuration
-func (c *Config) Validate() error {
+// Validate checks if the network configuration is valid
+func (n *NetworkConfig) Validate() error {
 	// Network name must be valid
-	if c.Network.Name != "mainnet" && c.Network.Name != "testnet" {
-		return fmt.Errorf("invalid network name: %s", c.Network.Name)
+	if n.Name != "mainnet" && n.Name != "testnet" {
+		return fmt.Errorf("invalid network name: %s", n.Name)
+	}
+
+	// Fallback network is optional but must be valid and differ from the primary
+	if n.Fallback != "" {
+		if n.Fallback != "mainnet" && n.Fallback != "testnet" {
+			return fmt.Errorf("invalid fallback network name: %s", n.Fallback)
+		}
+		if n.Fallback == n.Name {
+			return fmt.Errorf("fallback network must differ from primary network: %s", n.Fallback)
+		}
+	}
+
+	// Mirror node is optional but must be an HTTP(S) URL
+	if n.MirrorNodeURL != "" && !isHTTPURL(n.MirrorNodeURL) {
+		return fmt.Errorf("invalid mirror node URL: %s", n.MirrorNodeURL)
 	}
+	return nil
+}
 
-	// Account IDs must be valid format. At least one account must be configured for monitoring
-	if len(c.Accounts) == 0 {
+// validateAccounts checks that at least one account is configured and every ID is well-formed
+func validateAccounts(accounts []collector.AccountConfig) error {
+	if len(accounts) == 0 {
 		return fmt.Errorf("no accounts configured")
 	}
+	for i, account := range accounts {
+		if _, err := hiero.AccountIDFromString(account.ID); err != nil {
+			return fmt.Errorf("invalid account ID %q at index %d: %w", account.ID, i, err)
+		}
+	}
+	return nil
+}
+
+// MonitoredNetworks returns every network to monitor: the networks list when set,
+// otherwise the single top-level network with its accounts
+func (c *Config) MonitoredNetworks() []MonitoredNetwork {
+	if len(c.Networks) > 0 {
+		return c.Networks
+	}
+	return []MonitoredNetwork{{Network: c.Network, Accounts: c.Accounts}}
+}
+
+// PortfolioNetwork returns the name of the monitored network whose accounts include
+// every portfolio account, or "" if none does
+func (c *Config) PortfolioNetwork() string {
+	for _, monitored := range c.MonitoredNetworks() {
+		ids := make(map[string]bool, len(monitored.Accounts))
+		for _, account := range monitored.Accounts {
+			ids[account.ID] = true
+		}
+		all := true
+		for _, id := range c.Collectors.PortfolioAccounts {
+			if !ids[id] {
+				all = false
+				break
+			}
+		}
+		if all {
+			return monitored.Network.Name
+		}
+	}
+	return ""
+}
+
+// Validate validates the configuration
+func (c *Config) Validate() error {
+	if len(c.Networks) > 0 {
+		// Each network block carries its own accounts, so top-level ones would be ambiguous
+		if len(c.Accounts) > 0 {
+			return fmt.Errorf("accounts cannot be combined with networks; list them under each network")
+		}
+
+		// Metrics are labelled by network name, so each network can only appear once
+		seen := make(map[string]bool, len(c.Networks))
+		for i, monitored := range c.Networks {
+			if err := monitored.Network.Validate(); err != nil {
+				return fmt.Errorf("invalid network at index %d: %w", i, err)
+			}
+			if seen[monitored.Network.Name] {
+				return fmt.Errorf("duplicate network at index %d: %s", i, monitored.Network.Name)
+			}
+			seen[monitored.Network.Name] = true
+			if err := validateAccounts(monitored.Accounts); err != nil {
+				return fmt.Errorf("invalid network at index %d: %w", i, err)
+			}
+		}
+	} else {
+		if err := c.Network.Validate(); err != nil {
+			return err
+		}
+		// At least one account must be configured for monitoring
+		if err := validateAccounts(c.Accounts); err != nil {
+			return err
+		}
+	}
 
 	// Webhook URLs must be valid
+	for i, webhookURL := range c.Alerting.Webhooks {
+		if err := ValidateWebhookURL(webhookURL); err != nil {
+			return fmt.Errorf("invalid webhook at index %d: %w", i, err)
+		}
+	}
+
+	// Alerting needs at least one rule
 	if c.Alerting.Enabled && len(c.Alerting.Rules) == 0 {
 		return fmt.Errorf("no alerting rules configured")
 	}
@@ -173,11 +591,155 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid alert queue buffer size: %d", c.Alerting.QueueBufferSize)
 	}
 
+	// Shutdown grace period cannot be negative
+	if c.Alerting.ShutdownGraceSeconds < 0 {
+		return fmt.Errorf("invalid alert shutdown grace seconds: %d", c.Alerting.ShutdownGraceSeconds)
+	}
+
+	// Webhook pool settings cannot be negative (0 = use built-in defaults)
+	if c.Alerting.WebhookMaxIdleConns < 0 {
+		return fmt.Errorf("invalid webhook max idle conns: %d", c.Alerting.WebhookMaxIdleConns)
+	}
+	if c.Alerting.WebhookIdleConnTimeoutSeconds < 0 {
+		return fmt.Errorf("invalid webhook idle conn timeout seconds: %d", c.Alerting.WebhookIdleConnTimeoutSeconds)
+	}
+	if c.Alerting.WebhookMaxElapsedSeconds < 0 {
+		return fmt.Errorf("invalid webhook max elapsed seconds: %d", c.Alerting.WebhookMaxElapsedSeconds)
+	}
+	if c.Alerting.WebhookMaxConcurrency < 0 {
+		return fmt.Errorf("invalid webhook max concurrency: %d", c.Alerting.WebhookMaxConcurrency)
+	}
+
+	// Maintenance window bounds must parse and be in order
+	if _, _, err := c.Alerting.Maintenance.Window(); err != nil {
+		return err
+	}
+
+	// Digest mode needs a positive interval; the size limit cannot be negative
+	if c.Alerting.Digest.Enabled && c.Alerting.Digest.IntervalSeconds <= 0 {
+		return fmt.Errorf("invalid alert digest interval seconds: %d", c.Alerting.Digest.IntervalSeconds)
+	}
+	if c.Alerting.Digest.MaxAlerts < 0 {
+		return fmt.Errorf("invalid alert digest max alerts: %d", c.Alerting.Digest.MaxAlerts)
+	}
+
+	// Collector jitter cannot be negative
+	if c.Collectors.JitterSeconds < 0 {
+		return fmt.Errorf("invalid collector jitter seconds: %d", c.Collectors.JitterSeconds)
+	}
+
+	// Namespace becomes part of every metric name, so it must be a valid name itself
+	if c.Collectors.Namespace != "" && !namespacePattern.MatchString(c.Collectors.Namespace) {
+		return fmt.Errorf("invalid collector namespace %q: use letters, digits and underscores, not starting with a digit",
+			c.Collectors.Namespace)
+	}
+
+	// Query timeout cannot be negative
+	if c.Collectors.QueryTimeoutSeconds < 0 {
+		return fmt.Errorf("invalid collector query timeout seconds: %d", c.Collectors.QueryTimeoutSeconds)
+	}
+
+	// Address book TTL cannot be negative
+	if c.Collectors.AddressBookTTLSeconds < 0 {
+		return fmt.Errorf("invalid address book ttl seconds: %d", c.Collectors.AddressBookTTLSeconds)
+	}
+
+	// Operator balance floor cannot be negative
+	if c.Collectors.OperatorBalanceFloor < 0 {
+		return fmt.Errorf("invalid operator balance floor: %d", c.Collectors.OperatorBalanceFloor)
+	}
+
+	// Portfolio accounts must be monitored on one network, or their balances are never
+	// collected together
+	if len(c.Collectors.PortfolioAccounts) > 0 && c.PortfolioNetwork() == "" {
+		return fmt.Errorf("portfolio accounts must all be monitored accounts on one network")
+	}
+
+	// Transaction type allowlist entries must name a type records are classified as
+	for _, name := range c.Collectors.TransactionTypes {
+		if !hedera.TransactionType(name).IsValid() {
+			return fmt.Errorf("invalid transaction type in collectors.transaction_types: %s", name)
+		}
+	}
+
+	// Records limit must be within what the client will return
+	if c.Collectors.RecordsLimit < 0 || hedera.MaxRecordsLimit < c.Collectors.RecordsLimit {
+		return fmt.Errorf("invalid collector records limit: %d (must be 0-%d)",
+			c.Collectors.RecordsLimit, hedera.MaxRecordsLimit)
+	}
+
+	// Remote-write export is optional but needs a valid URL and interval when enabled
+	if c.Export.RemoteWriteURL != "" {
+		if !isHTTPURL(c.Export.RemoteWriteURL) {
+			return fmt.Errorf("invalid remote write URL: %s", c.Export.RemoteWriteURL)
+		}
+		if c.Export.IntervalSeconds <= 0 {
+			return fmt.Errorf("invalid export interval seconds: %d", c.Export.IntervalSeconds)
+		}
+	}
+
+	// Retention periods cannot be negative; overrides must keep metrics for some time
+	if c.Storage.RetentionSeconds < 0 {
+		return fmt.Errorf("invalid storage retention seconds: %d", c.Storage.RetentionSeconds)
+	}
+	for name, seconds := range c.Storage.RetentionByName {
+		if seconds <= 0 {
+			return fmt.Errorf("invalid retention seconds for metric %s: %d", name, seconds)
+		}
+	}
+	for name, every := range c.Storage.SampleEveryByName {
+		if every < 1 {
+			return fmt.Errorf("invalid sample rate for metric %s: %d (must be at least 1)", name, every)
+		}
+	}
+	if d := c.Storage.RoundDecimals; d != nil && (*d < 0 || MaxRoundDecimals < *d) {
+		return fmt.Errorf("invalid storage round decimals: %d (must be 0-%d)", *d, MaxRoundDecimals)
+	}
+	for name, decimals := range c.Storage.RoundDecimalsByName {
+		if decimals < 0 || MaxRoundDecimals < decimals {
+			return fmt.Errorf("invalid round decimals for metric %s: %d (must be 0-%d)", name, decimals, MaxRoundDecimals)
+		}
+	}
+	if c.Storage.MaxSeriesPerMetric < 0 {
+		return fmt.Errorf("invalid storage max series per metric: %d (must be 0 or more)", c.Storage.MaxSeriesPerMetric)
+	}
+
+	// Global labels need a value, or they'd tag every metric with an empty label
+	for key, value := range c.GlobalLabels {
+		if value == "" {
+			return fmt.Errorf("global label %s has an empty value", key)
+		}
+	}
+
 	// Port must be in range [1: 65535]
 	if c.API.Port < 1 || 65535 < c.API.Port {
 		return fmt.Errorf("invalid API port: %d", c.API.Port)
 	}
 
+	// Body limit cannot be negative
+	if c.API.MaxBodyBytes < 0 {
+		return fmt.Errorf("invalid API max body bytes: %d", c.API.MaxBodyBytes)
+	}
+
+	if err := c.API.CORS.Validate(); err != nil {
+		return err
+	}
+
+	// TLS needs both a certificate and a key
+	if (c.API.TLSCert == "") != (c.API.TLSKey == "") {
+		return fmt.Errorf("api.tls_cert and api.tls_key must be set together")
+	}
+
+	// A scrape token narrows access, so it only makes sense alongside the main token
+	if c.API.ScrapeToken != "" {
+		if c.API.AuthToken == "" {
+			return fmt.Errorf("api.scrape_token requires api.auth_token to be set")
+		}
+		if c.API.ScrapeToken == c.API.AuthToken {
+			return fmt.Errorf("api.scrape_token must differ from api.auth_token")
+		}
+	}
+
 	return nil
 }
 
@@ -189,19 +751,43 @@ func getDefaultConfig() *Config {
 		},
 		Accounts: make([]collector.AccountConfig, 0),
 		Alerting: AlertingConfig{
-			Enabled:         true,
-			Webhooks:        make([]string, 0),
-			Rules:           make([]AlertRule, 0),
-			CooldownSeconds: 300,
-			QueueBufferSize: 100,
+			Enabled:              true,
+			Webhooks:             make([]string, 0),
+			Rules:                make([]AlertRule, 0),
+			CooldownSeconds:      300,
+			QueueBufferSize:      100,
+			ShutdownGraceSeconds: 10,
+
+			WebhookMaxIdleConns:           10,
+			WebhookIdleConnTimeoutSeconds: 90,
+			WebhookMaxConcurrency:         10,
+
+			Digest: DigestConfig{
+				IntervalSeconds: 60,
+				MaxAlerts:       100,
+			},
 		},
 		API: APIConfig{
-			Port: 8080,
-			Host: "localhost",
+			Port:         8080,
+			Host:         "localhost",
+			MaxBodyBytes: 1 << 20,
+			CORS: CORSConfig{
+				AllowedMethods: slices.Clone(DefaultCORSMethods),
+				AllowedHeaders: slices.Clone(DefaultCORSHeaders),
+			},
 		},
 		Logging: LoggingConfig{
 			Level:  "info",
 			Format: "text",
 		},
+		Collectors: CollectorsConfig{
+			QueryTimeoutSeconds:   int(collector.DefaultQueryTimeout.Seconds()),
+			RecordsLimit:          hedera.DefaultRecordsLimit,
+			AddressBookTTLSeconds: int(hedera.DefaultAddressBookTTL.Seconds()),
+			OperatorBalanceFloor:  collector.DefaultOperatorBalanceFloor,
+		},
+		Export: ExportConfig{
+			IntervalSeconds: 30,
+		},
 	}
 }
@@ -2,6 +2,9 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/kaldun-tech/hedera-network-monitor/internal/collector"
@@ -386,6 +389,148 @@ func TestValidate_AlertRule_NegativeCooldown(t *testing.T) {
 	}
 }
 
+func TestValidate_AlertRule_NegativeWarmup(t *testing.T) {
+	rule := &AlertRule{
+		ID:            "test_rule_1",
+		Name:          "Test Rule",
+		MetricName:    "account_balance",
+		Condition:     "<",
+		Threshold:     1000000000,
+		Severity:      "warning",
+		WarmupSeconds: -1,
+	}
+
+	if err := rule.Validate(); err == nil {
+		t.Error("expected error for negative warmup seconds")
+	}
+}
+
+func TestValidate_AlertRule_SameDenominator(t *testing.T) {
+	rule := &AlertRule{
+		ID:                "test_rule_1",
+		Name:              "Test Rule",
+		MetricName:        "failed_transactions",
+		DenominatorMetric: "failed_transactions",
+		Condition:         ">",
+		Threshold:         0.1,
+		Severity:          "warning",
+	}
+
+	if err := rule.Validate(); err == nil {
+		t.Error("expected error for a denominator equal to the metric name")
+	}
+}
+
+func TestValidate_AlertRule_NegativeEscalation(t *testing.T) {
+	rule := &AlertRule{
+		ID:            "test_rule_1",
+		Name:          "Test Rule",
+		MetricName:    "account_balance",
+		Condition:     "<",
+		Threshold:     1000000000,
+		Severity:      "warning",
+		EscalateAfter: -1,
+	}
+	if err := rule.Validate(); err == nil {
+		t.Error("expected error for negative escalate after")
+	}
+
+	rule.EscalateAfter = 3
+	rule.EscalateWindowSeconds = -1
+	if err := rule.Validate(); err == nil {
+		t.Error("expected error for negative escalate window seconds")
+	}
+}
+
+func TestValidate_AlertRule_MinChange(t *testing.T) {
+	rule := &AlertRule{
+		ID:         "test_rule_1",
+		Name:       "Test Rule",
+		MetricName: "account_balance",
+		Condition:  "changed",
+		Severity:   "warning",
+		MinChange:  100000000,
+	}
+	if err := rule.Validate(); err != nil {
+		t.Errorf("expected no error for min change on changed rule, got: %v", err)
+	}
+
+	rule.MinChange = -1
+	if err := rule.Validate(); err == nil {
+		t.Error("expected error for negative min change")
+	}
+
+	rule.MinChange = 100
+	rule.Condition = "<"
+	if err := rule.Validate(); err == nil {
+		t.Error("expected error for min change on a threshold condition")
+	}
+}
+
+func TestValidate_AlertRule_MinChangePercent(t *testing.T) {
+	rule := &AlertRule{
+		ID:               "test_rule_1",
+		Name:             "Test Rule",
+		MetricName:       "account_balance",
+		Condition:        "changed",
+		Severity:         "warning",
+		MinChangePercent: 5,
+	}
+	if err := rule.Validate(); err != nil {
+		t.Errorf("expected no error for min change percent on changed rule, got: %v", err)
+	}
+
+	rule.MinChangePercent = -1
+	if err := rule.Validate(); err == nil {
+		t.Error("expected error for negative min change percent")
+	}
+
+	rule.MinChangePercent = 5
+	rule.Condition = "<"
+	if err := rule.Validate(); err == nil {
+		t.Error("expected error for min change percent on a threshold condition")
+	}
+}
+
+func TestValidate_AlertRule_Webhooks(t *testing.T) {
+	rule := &AlertRule{
+		ID:         "test_rule_1",
+		Name:       "Test Rule",
+		MetricName: "account_balance",
+		Condition:  "<",
+		Severity:   "warning",
+		Webhooks:   []string{"https://hooks.example.com/oncall"},
+	}
+	if err := rule.Validate(); err != nil {
+		t.Errorf("expected no error for a valid rule webhook, got: %v", err)
+	}
+
+	rule.Webhooks = []string{"hooks.example.com/oncall"}
+	if err := rule.Validate(); err == nil {
+		t.Error("expected error for a rule webhook without a scheme")
+	}
+}
+
+func TestValidate_AlertRule_EmptyTag(t *testing.T) {
+	rule := &AlertRule{
+		ID:         "test_rule_1",
+		Name:       "Test Rule",
+		MetricName: "account_balance",
+		Condition:  "<",
+		Threshold:  1000000000,
+		Severity:   "warning",
+		Tags:       []string{"balances", ""},
+	}
+	if err := rule.Validate(); err == nil {
+		t.Error("expected error for empty tag")
+	}
+
+	rule.Tags = []string{"balances"}
+	if err := rule.Validate(); err != nil {
+		t.Errorf("expected no error for valid tags, got: %v", err)
+	}
+}
+
 func TestValidate_AlertRule_InvalidSeverity(t *testing.T) {
 	rule := &AlertRule{
 		ID:              "test_rule_1",
@@ -434,3 +579,751 @@ func TestValidate_AlertRule_InvalidCondition(t *testing.T) {
 		t.Error("expected error for invalid condition")
 	}
 }
+
+func TestValidate_NegativeCollectorJitter(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API:        APIConfig{Port: 8080},
+		Collectors: CollectorsConfig{JitterSeconds: -1},
+	}
+	err := config.Validate()
+	if err == nil {
+		t.Error("expected error for negative collector jitter")
+	}
+}
+
+func TestValidate_NegativeQueryTimeout(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API:        APIConfig{Port: 8080},
+		Collectors: CollectorsConfig{QueryTimeoutSeconds: -1},
+	}
+	err := config.Validate()
+	if err == nil {
+		t.Error("expected error for negative query timeout")
+	}
+}
+
+func TestValidate_FallbackNetwork(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet", Fallback: "mainnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API: APIConfig{Port: 8080},
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for valid fallback, got: %v", err)
+	}
+
+	config.Network.Fallback = "testnet"
+	if err := config.Validate(); err == nil {
+		t.Error("expected error when fallback matches primary network")
+	}
+
+	config.Network.Fallback = "invalid"
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for invalid fallback network")
+	}
+}
+
+func TestValidate_ExportRemoteWriteURL(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API:    APIConfig{Port: 8080},
+		Export: ExportConfig{RemoteWriteURL: "http://localhost:9090/api/v1/write", IntervalSeconds: 30},
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for valid remote write URL, got: %v", err)
+	}
+
+	config.Export.RemoteWriteURL = "not a url"
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for malformed remote write URL")
+	}
+
+	config.Export.RemoteWriteURL = "http://localhost:9090/api/v1/write"
+	config.Export.IntervalSeconds = 0
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for non-positive export interval")
+	}
+}
+
+func TestValidate_TLSCertWithoutKey(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API: APIConfig{Port: 8080, TLSCert: "/etc/hmon/cert.pem"},
+	}
+	err := config.Validate()
+	if err == nil {
+		t.Error("expected error for tls_cert without tls_key")
+	}
+
+	config.API.TLSKey = "/etc/hmon/key.pem"
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error with both tls_cert and tls_key, got: %v", err)
+	}
+}
+
+func TestValidate_AccountIDs(t *testing.T) {
+	tests := []struct {
+		id      string
+		wantErr bool
+	}{
+		{"0.0.5000", false},
+		{"0.0.2", false},
+		{"1.2.3", false},
+		{"0.0.abc", true},
+		{"0.0", true},
+		{"", true},
+		{"5000", true},
+	}
+
+	for _, tt := range tests {
+		config := &Config{
+			Network: NetworkConfig{Name: "testnet"},
+			Accounts: []collector.AccountConfig{
+				{ID: "0.0.5000", Label: "Valid"},
+				{ID: tt.id, Label: "Test"},
+			},
+			API: APIConfig{Port: 8080},
+		}
+		err := config.Validate()
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("expected error for account ID %q", tt.id)
+			} else if !strings.Contains(err.Error(), "index 1") {
+				t.Errorf("expected error to name index 1, got: %v", err)
+			}
+		} else if err != nil {
+			t.Errorf("expected no error for account ID %q, got: %v", tt.id, err)
+		}
+	}
+}
+
+func TestValidate_Networks(t *testing.T) {
+	config := &Config{
+		Networks: []MonitoredNetwork{
+			{Network: NetworkConfig{Name: "testnet"}, Accounts: []collector.AccountConfig{{ID: "0.0.5000"}}},
+			{Network: NetworkConfig{Name: "mainnet"}, Accounts: []collector.AccountConfig{{ID: "0.0.6000"}}},
+		},
+		API: APIConfig{Port: 8080},
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for two networks, got: %v", err)
+	}
+	if got := config.MonitoredNetworks(); len(got) != 2 {
+		t.Errorf("expected 2 monitored networks, got %d", len(got))
+	}
+
+	config.Collectors.PortfolioAccounts = []string{"0.0.5000", "0.0.6000"}
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for portfolio accounts spread across networks")
+	}
+	config.Collectors.PortfolioAccounts = []string{"0.0.6000"}
+	if got := config.PortfolioNetwork(); got != "mainnet" {
+		t.Errorf("expected portfolio network mainnet, got %q", got)
+	}
+
+	config.Networks[1].Network.Name = "testnet"
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for duplicate network")
+	}
+
+	config.Networks[1].Network.Name = "mainnet"
+	config.Networks[1].Accounts = nil
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for network without accounts")
+	}
+
+	config.Networks[1].Accounts = []collector.AccountConfig{{ID: "0.0.6000"}}
+	config.Accounts = []collector.AccountConfig{{ID: "0.0.7000"}}
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for top-level accounts combined with networks")
+	}
+}
+
+func TestLoad_Networks(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+networks:
+  - network:
+      name: testnet
+      operator_id: "0.0.3"
+    accounts:
+      - id: "0.0.5000"
+        label: "Test Account"
+  - network:
+      name: mainnet
+      operator_id: "0.0.4"
+    accounts:
+      - id: "0.0.6000"
+alerting:
+  enabled: false
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	config, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(config.Networks) != 2 {
+		t.Fatalf("expected 2 networks, got %d", len(config.Networks))
+	}
+	if config.Networks[1].Network.Name != "mainnet" || config.Networks[1].Network.OperatorID != "0.0.4" {
+		t.Errorf("expected mainnet with operator 0.0.4, got %+v", config.Networks[1].Network)
+	}
+	if len(config.Networks[0].Accounts) != 1 || config.Networks[0].Accounts[0].Label != "Test Account" {
+		t.Errorf("expected testnet account with label, got %+v", config.Networks[0].Accounts)
+	}
+}
+
+// writeConfigFiles writes each name -> content pair into dir
+func writeConfigFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestLoad_Include(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFiles(t, dir, map[string]string{
+		"config.yaml": `
+include:
+  - accounts/main.yaml
+  - alerting.yaml
+network:
+  name: testnet
+  operator_id: "0.0.3"
+accounts:
+  - id: "0.0.5000"
+    label: "Base Account"
+alerting:
+  enabled: false
+  cooldown_seconds: 60
+`,
+		"accounts/main.yaml": `
+include: more.yaml
+accounts:
+  - id: "0.0.5001"
+    label: "Included Account"
+`,
+		"accounts/more.yaml": `
+accounts:
+  - id: "0.0.5002"
+    label: "Nested Account"
+`,
+		"alerting.yaml": `
+alerting:
+  cooldown_seconds: 900
+  queue_buffer_size: 50
+`,
+	})
+
+	config, err := Load(filepath.Join(dir, "config.yaml"))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	var ids []string
+	for _, account := range config.Accounts {
+		ids = append(ids, account.ID)
+	}
+	if !slices.Equal(ids, []string{"0.0.5002", "0.0.5001", "0.0.5000"}) {
+		t.Errorf("expected included accounts before the base file's, got %v", ids)
+	}
+	if config.Network.OperatorID != "0.0.3" {
+		t.Errorf("expected operator 0.0.3 from the base file, got %s", config.Network.OperatorID)
+	}
+	if config.Alerting.CooldownSeconds != 60 {
+		t.Errorf("expected the base file's cooldown 60 to win, got %d", config.Alerting.CooldownSeconds)
+	}
+	if config.Alerting.QueueBufferSize != 50 {
+		t.Errorf("expected queue buffer size 50 from the include, got %d", config.Alerting.QueueBufferSize)
+	}
+}
+
+func TestLoad_IncludeCycle(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFiles(t, dir, map[string]string{
+		"config.yaml": "include: a.yaml\nnetwork:\n  name: testnet\n",
+		"a.yaml":      "include: b.yaml\n",
+		"b.yaml":      "include: config.yaml\n",
+	})
+
+	_, err := Load(filepath.Join(dir, "config.yaml"))
+	if err == nil || !strings.Contains(err.Error(), "include cycle") {
+		t.Errorf("expected an include cycle error, got: %v", err)
+	}
+}
+
+func TestLoad_IncludeMissingFile(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFiles(t, dir, map[string]string{
+		"config.yaml": "include: missing.yaml\nnetwork:\n  name: testnet\n",
+	})
+
+	_, err := Load(filepath.Join(dir, "config.yaml"))
+	if err == nil || !strings.Contains(err.Error(), "missing.yaml") {
+		t.Errorf("expected an error naming the missing include, got: %v", err)
+	}
+}
+
+func TestValidate_TransactionTypes(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API:        APIConfig{Port: 8080},
+		Collectors: CollectorsConfig{TransactionTypes: []string{"CryptoTransfer", "Other"}},
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for known transaction types, got: %v", err)
+	}
+
+	config.Collectors.TransactionTypes = []string{"CryptoTransfer", "cryptotransfer"}
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for unknown transaction type")
+	}
+}
+
+func TestValidate_PortfolioAccounts(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Treasury"},
+			{ID: "0.0.5001", Label: "Trading"},
+		},
+		API:        APIConfig{Port: 8080},
+		Collectors: CollectorsConfig{PortfolioAccounts: []string{"0.0.5000", "0.0.5001"}},
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for monitored portfolio accounts, got: %v", err)
+	}
+
+	config.Collectors.PortfolioAccounts = append(config.Collectors.PortfolioAccounts, "0.0.9999")
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for portfolio account that isn't monitored")
+	}
+}
+
+func TestValidate_MirrorNodeURL(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet", MirrorNodeURL: "testnet.mirrornode.hedera.com"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API: APIConfig{Port: 8080},
+	}
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for mirror node URL without scheme")
+	}
+
+	config.Network.MirrorNodeURL = "https://testnet.mirrornode.hedera.com"
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for valid mirror node URL, got: %v", err)
+	}
+}
+
+func TestValidate_WebhookURLs(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		Alerting: AlertingConfig{Webhooks: []string{"https://hooks.example.com/alerts", "http://localhost:9000/hook"}},
+		API:      APIConfig{Port: 8080},
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for valid webhook URLs, got: %v", err)
+	}
+
+	for _, webhookURL := range []string{"hooks.example.com/alerts", "", "ftp://hooks.example.com", "https://"} {
+		config.Alerting.Webhooks = []string{"https://hooks.example.com/alerts", webhookURL}
+		err := config.Validate()
+		if err == nil {
+			t.Errorf("expected error for webhook URL %q", webhookURL)
+			continue
+		}
+		if !strings.Contains(err.Error(), "index 1") {
+			t.Errorf("expected error to name the webhook index, got: %v", err)
+		}
+	}
+}
+
+func TestValidate_MaxBodyBytes(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API: APIConfig{Port: 8080, MaxBodyBytes: -1},
+	}
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for negative max body bytes")
+	}
+
+	config.API.MaxBodyBytes = 0
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for unlimited body size, got: %v", err)
+	}
+}
+
+func TestValidate_ScrapeToken(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API: APIConfig{Port: 8080, ScrapeToken: "scrape-secret"},
+	}
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for scrape_token without auth_token")
+	}
+
+	config.API.AuthToken = "scrape-secret"
+	if err := config.Validate(); err == nil {
+		t.Error("expected error when scrape_token equals auth_token")
+	}
+
+	config.API.AuthToken = "admin-secret"
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error with distinct tokens, got: %v", err)
+	}
+}
+
+func TestValidate_CollectorRecordsLimit(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API:        APIConfig{Port: 8080},
+		Collectors: CollectorsConfig{RecordsLimit: -1},
+	}
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for negative records limit")
+	}
+
+	config.Collectors.RecordsLimit = 100000
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for records limit above maximum")
+	}
+
+	config.Collectors.RecordsLimit = 100
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for valid records limit, got: %v", err)
+	}
+}
+
+func TestValidate_OperatorBalanceFloor(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API:        APIConfig{Port: 8080},
+		Collectors: CollectorsConfig{OperatorBalanceFloor: -1},
+	}
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for negative operator balance floor")
+	}
+
+	config.Collectors.OperatorBalanceFloor = 0
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for disabled floor, got: %v", err)
+	}
+}
+
+func TestValidate_AddressBookTTL(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API:        APIConfig{Port: 8080},
+		Collectors: CollectorsConfig{AddressBookTTLSeconds: -1},
+	}
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for negative address book ttl")
+	}
+
+	config.Collectors.AddressBookTTLSeconds = 0
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for disabled address book cache, got: %v", err)
+	}
+}
+
+func TestValidate_Digest(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API:      APIConfig{Port: 8080},
+		Alerting: AlertingConfig{Digest: DigestConfig{Enabled: true}},
+	}
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for digest mode without an interval")
+	}
+
+	config.Alerting.Digest.IntervalSeconds = 60
+	config.Alerting.Digest.MaxAlerts = -1
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for negative digest max alerts")
+	}
+
+	config.Alerting.Digest.MaxAlerts = 100
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for valid digest settings, got: %v", err)
+	}
+}
+
+func TestValidate_WebhookPoolSettings(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API:      APIConfig{Port: 8080},
+		Alerting: AlertingConfig{WebhookMaxIdleConns: -1},
+	}
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for negative webhook max idle conns")
+	}
+
+	config.Alerting.WebhookMaxIdleConns = 10
+	config.Alerting.WebhookIdleConnTimeoutSeconds = -5
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for negative webhook idle conn timeout")
+	}
+
+	config.Alerting.WebhookIdleConnTimeoutSeconds = 90
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for valid pool settings, got: %v", err)
+	}
+}
+
+func TestValidate_CollectorNamespace(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API: APIConfig{Port: 8080},
+	}
+
+	for _, namespace := range []string{"", "prod", "prod_eu_1", "_staging"} {
+		config.Collectors.Namespace = namespace
+		if err := config.Validate(); err != nil {
+			t.Errorf("expected namespace %q to be valid, got: %v", namespace, err)
+		}
+	}
+
+	for _, namespace := range []string{"1prod", "prod-eu", "prod.eu", "prod eu"} {
+		config.Collectors.Namespace = namespace
+		if err := config.Validate(); err == nil {
+			t.Errorf("expected error for namespace %q", namespace)
+		}
+	}
+}
+
+func TestValidate_NegativeWebhookMaxElapsed(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API:      APIConfig{Port: 8080},
+		Alerting: AlertingConfig{WebhookMaxElapsedSeconds: -1},
+	}
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for negative webhook max elapsed seconds")
+	}
+}
+
+func TestValidate_Maintenance(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API: APIConfig{Port: 8080},
+		Alerting: AlertingConfig{Maintenance: MaintenanceConfig{
+			Enabled: true,
+			Start:   "2030-01-01T00:00:00Z",
+			End:     "2030-01-01T02:00:00Z",
+		}},
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for a valid maintenance window, got: %v", err)
+	}
+
+	config.Alerting.Maintenance = MaintenanceConfig{Enabled: true}
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for an open-ended maintenance window, got: %v", err)
+	}
+
+	for _, window := range []MaintenanceConfig{
+		{Enabled: true, Start: "tomorrow"},
+		{Enabled: true, End: "2030-01-01"},
+		{Enabled: true, Start: "2030-01-01T02:00:00Z", End: "2030-01-01T00:00:00Z"},
+	} {
+		config.Alerting.Maintenance = window
+		if err := config.Validate(); err == nil {
+			t.Errorf("expected error for maintenance window %+v", window)
+		}
+	}
+}
+
+func TestValidate_CORS(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API: APIConfig{Port: 8080, CORS: CORSConfig{
+			AllowedOrigins: []string{"https://dashboard.example.com", "http://localhost:3000"},
+			AllowedMethods: []string{"GET", "POST"},
+			Paths:          []string{"/api/v1/metrics"},
+		}},
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for a valid CORS config, got: %v", err)
+	}
+
+	for _, cors := range []CORSConfig{
+		{AllowedOrigins: []string{"dashboard.example.com"}},
+		{AllowedOrigins: []string{"https://dashboard.example.com/app"}},
+		{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"get"}},
+		{AllowedOrigins: []string{"*"}, AllowedHeaders: []string{" "}},
+		{AllowedOrigins: []string{"*"}, Paths: []string{"api/v1/metrics"}},
+	} {
+		config.API.CORS = cors
+		if err := config.Validate(); err == nil {
+			t.Errorf("expected error for CORS config %+v", cors)
+		}
+	}
+}
+
+func TestValidate_SampleEveryByName(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API:     APIConfig{Port: 8080},
+		Storage: StorageConfig{SampleEveryByName: map[string]int{"network_node_available": 60}},
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for a valid sample rate, got: %v", err)
+	}
+
+	config.Storage.SampleEveryByName["network_node_available"] = 0
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for a zero sample rate")
+	}
+}
+
+func TestValidate_RoundDecimals(t *testing.T) {
+	decimals := 2
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API: APIConfig{Port: 8080},
+		Storage: StorageConfig{
+			RoundDecimals:       &decimals,
+			RoundDecimalsByName: map[string]int{"account_balance_usd": 0},
+		},
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for valid round decimals, got: %v", err)
+	}
+
+	decimals = MaxRoundDecimals + 1
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for round decimals above the maximum")
+	}
+
+	decimals = 2
+	config.Storage.RoundDecimalsByName["account_balance_usd"] = -1
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for negative round decimals by name")
+	}
+}
+
+func TestValidate_MaxSeriesPerMetric(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API:     APIConfig{Port: 8080},
+		Storage: StorageConfig{MaxSeriesPerMetric: 1000},
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for a positive series limit, got: %v", err)
+	}
+
+	config.Storage.MaxSeriesPerMetric = -1
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for a negative series limit")
+	}
+}
+
+func TestValidate_GlobalLabels(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API:          APIConfig{Port: 8080},
+		GlobalLabels: map[string]string{"env": "prod", "region": "eu-west-1"},
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("expected no error for valid global labels, got: %v", err)
+	}
+
+	config.GlobalLabels["instance"] = ""
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for a global label with an empty value")
+	}
+}
+
+func TestValidate_NegativeWebhookMaxConcurrency(t *testing.T) {
+	config := &Config{
+		Network: NetworkConfig{Name: "testnet"},
+		Accounts: []collector.AccountConfig{
+			{ID: "0.0.5000", Label: "Test"},
+		},
+		API:      APIConfig{Port: 8080},
+		Alerting: AlertingConfig{WebhookMaxConcurrency: -1},
+	}
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for negative webhook max concurrency")
+	}
+}
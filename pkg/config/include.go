@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// includeKey is the top-level key naming further YAML files to merge into a config file
+const includeKey = "include"
+
+// loadWithIncludes reads a YAML config file and merges in the files named by its
+// top-level include key (a path or list of paths), recursively
+// Included files are merged in order, then the including file on top: maps merge
+// key by key, lists are concatenated (included entries first) and the including
+// file's scalar values win. Relative paths are resolved against the including
+// file's directory. chain holds the files currently being included, so a file
+// that includes itself, directly or indirectly, is reported instead of looping
+func loadWithIncludes(path string, chain []string) (map[string]any, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	if slices.Contains(chain, absPath) {
+		return nil, fmt.Errorf("include cycle: %s", strings.Join(append(chain, absPath), " -> "))
+	}
+	chain = append(chain, absPath)
+
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if doc == nil {
+		doc = map[string]any{}
+	}
+
+	includes, err := includePaths(doc[includeKey])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	delete(doc, includeKey)
+
+	merged := map[string]any{}
+	for _, include := range includes {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(absPath), include)
+		}
+		included, err := loadWithIncludes(include, chain)
+		if err != nil {
+			return nil, err
+		}
+		merged = mergeYAML(merged, included)
+	}
+	return mergeYAML(merged, doc), nil
+}
+
+// includePaths reads the include value, which may be a single path or a list of paths
+func includePaths(value any) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []any:
+		paths := make([]string, 0, len(v))
+		for i, item := range v {
+			path, ok := item.(string)
+			if !ok || path == "" {
+				return nil, fmt.Errorf("include entry %d must be a file path", i)
+			}
+			paths = append(paths, path)
+		}
+		return paths, nil
+	default:
+		return nil, fmt.Errorf("include must be a file path or a list of file paths")
+	}
+}
+
+// mergeYAML merges override into base: maps merge recursively, lists are appended
+// and any other value in override replaces the one in base
+func mergeYAML(base, override map[string]any) map[string]any {
+	for key, value := range override {
+		switch v := value.(type) {
+		case map[string]any:
+			if existing, ok := base[key].(map[string]any); ok {
+				base[key] = mergeYAML(existing, v)
+				continue
+			}
+		case []any:
+			if existing, ok := base[key].([]any); ok {
+				base[key] = append(existing, v...)
+				continue
+			}
+		}
+		base[key] = value
+	}
+	return base
+}
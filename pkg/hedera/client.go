@@ -2,7 +2,10 @@ package hedera
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"sync"
+	"time"
 
 	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
 	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
@@ -12,6 +15,25 @@ import (
 const TinybarPerHbar = 100_000_000
 const getAddressBookMaxAttempts = 5
 
+// DefaultAddressBookTTL is how long GetNodeAddressBook reuses a fetched address book;
+// it changes rarely and is relatively expensive to query
+const DefaultAddressBookTTL = 5 * time.Minute
+
+// addressBookRetryAttempts and addressBookRetryBackoff bound the retries around a
+// failed address book query, on top of the SDK's own per-node attempts
+const addressBookRetryAttempts = 3
+const addressBookRetryBackoff = 100 * time.Millisecond
+
+// DefaultRecordsLimit is the number of records returned when GetAccountRecords
+// is called with a non-positive limit; MaxRecordsLimit caps larger requests
+const DefaultRecordsLimit = 50
+const MaxRecordsLimit = 1000
+
+// maxConsecutiveFailures is the number of consecutive query failures before the
+// client rebuilds its connection: switching to the fallback network if one is
+// configured and not yet in use, otherwise reconnecting to the current network
+const maxConsecutiveFailures = 3
+
 // Record represents a transaction record for an account
 type Record struct {
 	TransactionID string
@@ -21,6 +43,20 @@ type Record struct {
 	Status        string
 }
 
+// TokenInfo holds the token properties needed to present token balances
+type TokenInfo struct {
+	TokenID  string
+	Name     string
+	Symbol   string
+	Decimals uint32 // Number of decimal places in the token's smallest unit
+}
+
+// ScaleTokenAmount converts a raw token amount in the smallest unit to a
+// human-scaled value using the token's decimals (e.g. 12345 with 2 decimals = 123.45)
+func ScaleTokenAmount(amount int64, decimals uint32) float64 {
+	return float64(amount) / math.Pow10(int(decimals))
+}
+
 // Client is a wrapper around the Hedera SDK client
 type Client interface {
 	// GetAccountBalance retrieves the balance for a given account in tinybar
@@ -30,7 +66,8 @@ type Client interface {
 	GetAccountInfo(accountID string) (*hiero.AccountInfo, error)
 
 	// GetAccountRecords retrieves recent transaction records for an account
-	// limit: maximum number of records to return
+	// limit: maximum number of records to return (<= 0 = DefaultRecordsLimit,
+	// capped at MaxRecordsLimit)
 	GetAccountRecords(accountID string, limit int) ([]Record, error)
 
 	// GetTransactionReceipt retrieves the receipt for a specific transaction
@@ -42,22 +79,49 @@ type Client interface {
 	// GetNodeAddressBook retrieves information about network nodes
 	GetNodeAddressBook() (*hiero.NodeAddressBook, error)
 
+	// GetTokenInfo retrieves the symbol and decimals for a token
+	// Results are cached since token properties rarely change
+	GetTokenInfo(tokenID string) (TokenInfo, error)
+
 	// Close closes the Hedera client connection
 	Close() error
 }
 
 type HederaClient struct {
-	client *hiero.Client
+	client      *hiero.Client
+	network     string // Network the inner client is currently connected to
+	primary     string
+	fallback    string // Optional fallback network ("" = no failover)
+	operatorID  hiero.AccountID
+	operatorKey hiero.PrivateKey
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+
+	tokenMu    sync.RWMutex
+	tokenCache map[string]TokenInfo // Token info keyed by token ID
+
+	addressBookMu      sync.Mutex
+	addressBook        *hiero.NodeAddressBook // Last fetched address book; nil = not cached
+	addressBookNetwork string                 // Network addressBook was fetched from
+	addressBookFetched time.Time
+	addressBookTTL     time.Duration // 0 disables the cache
+
+	// queryAddressBook runs a single address book query (injectable for tests)
+	queryAddressBook func() (*hiero.NodeAddressBook, error)
+	now              func() time.Time // Clock for the address book cache (injectable for tests)
+
+	mirror *MirrorClient // Optional source for records when AccountRecordsQuery fails or is empty
+
+	// newHieroClient builds an inner client for a network name (injectable for tests)
+	newHieroClient func(network string) (*hiero.Client, error)
 }
 
 // NewClient creates a new Hedera SDK client wrapper
 // operatorID and operatorKey can come from config file or environment variables
-func NewClient(network, operatorID, operatorKey string) (Client, error) {
-	logger.Info("Creating Hedera client", "network", network)
-	client, err := hiero.ClientForName(network)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create client: %w", err)
-	}
+// fallback is an optional network to switch to after repeated failures on the primary
+func NewClient(network, fallback, operatorID, operatorKey string) (Client, error) {
+	logger.Info("Creating Hedera client", "network", network, "fallback", fallback)
 
 	// Use provided credentials, fallback to environment variables
 	if operatorID == "" {
@@ -83,10 +147,169 @@ func NewClient(network, operatorID, operatorKey string) (Client, error) {
 		return nil, fmt.Errorf("invalid OPERATOR_KEY: %w", err)
 	}
 
+	hc := &HederaClient{
+		primary:        network,
+		fallback:       fallback,
+		operatorID:     operatorAccountID,
+		operatorKey:    privateKey,
+		tokenCache:     make(map[string]TokenInfo),
+		addressBookTTL: DefaultAddressBookTTL,
+		newHieroClient: hiero.ClientForName,
+	}
+	if err := hc.connect(network); err != nil {
+		return nil, err
+	}
+
+	return hc, nil
+}
+
+// connect builds a new inner client for the given network and sets the operator
+// Any previous inner client is closed. Callers must not hold hc.mu.
+func (hc *HederaClient) connect(network string) error {
+	client, err := hc.newHieroClient(network)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
 	// Set the client operator ID and key
-	client.SetOperator(operatorAccountID, privateKey)
+	client.SetOperator(hc.operatorID, hc.operatorKey)
+
+	hc.mu.Lock()
+	previous := hc.client
+	hc.client = client
+	hc.network = network
+	hc.consecutiveFailures = 0
+	hc.mu.Unlock()
+
+	if previous != nil {
+		if err := previous.Close(); err != nil {
+			logger.Warn("Failed to close previous Hedera client",
+				"component", "HederaClient",
+				"error", err)
+		}
+	}
+	return nil
+}
 
-	return &HederaClient{client: client}, nil
+// OperatorAccountID returns the operator account that pays for queries
+func (hc *HederaClient) OperatorAccountID() string {
+	return hc.operatorID.String()
+}
+
+// SetMirrorNode sets a mirror node REST URL used for account records when the
+// consensus AccountRecordsQuery fails or returns nothing. An empty URL disables it
+func (hc *HederaClient) SetMirrorNode(baseURL string) {
+	if baseURL == "" {
+		hc.mirror = nil
+		return
+	}
+	hc.mirror = NewMirrorClient(baseURL)
+}
+
+// SetAddressBookTTL sets how long GetNodeAddressBook reuses a fetched address book
+// 0 disables the cache so every call queries the network
+func (hc *HederaClient) SetAddressBookTTL(ttl time.Duration) {
+	hc.addressBookMu.Lock()
+	defer hc.addressBookMu.Unlock()
+	hc.addressBookTTL = ttl
+	hc.addressBook = nil
+}
+
+// Network returns the name of the network the client is currently using
+func (hc *HederaClient) Network() string {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	return hc.network
+}
+
+// Reconnect closes the inner client and rebuilds it for the current network,
+// re-setting the operator. Use this to recover from stale connections.
+func (hc *HederaClient) Reconnect() error {
+	network := hc.Network()
+	logger.Info("Reconnecting Hedera client",
+		"component", "HederaClient",
+		"network", network)
+	return hc.connect(network)
+}
+
+// execute runs a query against the current inner client, returning failures as a
+// *QueryError so callers can tell transient failures from permanent ones
+func (hc *HederaClient) execute(query func(client *hiero.Client) error) error {
+	return NewQueryError(hc.executeWithRecovery(query))
+}
+
+// executeWithRecovery runs a query against the current inner client
+// After maxConsecutiveFailures consecutive failures the client fails over to the
+// fallback network (if configured and still on the primary) or reconnects to the
+// current network, then retries the query once
+func (hc *HederaClient) executeWithRecovery(query func(client *hiero.Client) error) error {
+	hc.mu.Lock()
+	client := hc.client
+	hc.mu.Unlock()
+
+	err := query(client)
+	if !hc.recordResult(err) {
+		return err
+	}
+
+	if hc.shouldFailover() {
+		logger.Warn("Primary network failing, switching to fallback",
+			"component", "HederaClient",
+			"primary", hc.primary,
+			"fallback", hc.fallback,
+			"failures", maxConsecutiveFailures,
+			"error", err)
+		if connErr := hc.connect(hc.fallback); connErr != nil {
+			logger.Error("Failed to switch to fallback network",
+				"component", "HederaClient",
+				"fallback", hc.fallback,
+				"error", connErr)
+			return err
+		}
+	} else {
+		logger.Warn("Persistent query failures, reconnecting",
+			"component", "HederaClient",
+			"failures", maxConsecutiveFailures,
+			"error", err)
+		if connErr := hc.Reconnect(); connErr != nil {
+			logger.Error("Failed to reconnect Hedera client",
+				"component", "HederaClient",
+				"error", connErr)
+			return err
+		}
+	}
+
+	hc.mu.Lock()
+	client = hc.client
+	hc.mu.Unlock()
+
+	err = query(client)
+	hc.recordResult(err)
+	return err
+}
+
+// recordResult updates the consecutive failure count for a query result
+// Returns true when the failure threshold is reached and the connection should be rebuilt
+// Permanent errors (e.g. a deleted account) mean the network answered, so they reset
+// the count rather than triggering a reconnect or failover
+func (hc *HederaClient) recordResult(err error) bool {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	if err == nil || IsPermanent(err) {
+		hc.consecutiveFailures = 0
+		return false
+	}
+
+	hc.consecutiveFailures++
+	return hc.consecutiveFailures >= maxConsecutiveFailures
+}
+
+// shouldFailover reports whether a fallback network is configured and not yet in use
+func (hc *HederaClient) shouldFailover() bool {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	return hc.fallback != "" && hc.network == hc.primary
 }
 
 func getAccount(accountID string) (hiero.AccountID, error) {
@@ -102,13 +325,18 @@ func (hc *HederaClient) GetAccountBalance(accountID string) (int64, error) {
 	logger.Debug("Querying balance", "account_id", accountID)
 	parsedAccount, err := getAccount(accountID)
 	if err != nil {
-		return 0, fmt.Errorf("invalid accountID: %w", err)
+		return 0, permanentError(fmt.Errorf("invalid accountID: %w", err))
 	}
 
 	query := hiero.NewAccountBalanceQuery()
 	query.SetAccountID(parsedAccount)
 
-	balance, err := query.Execute(hc.client)
+	var balance hiero.AccountBalance
+	err = hc.execute(func(client *hiero.Client) error {
+		var execErr error
+		balance, execErr = query.Execute(client)
+		return execErr
+	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to execute balance query: %w", err)
 	}
@@ -121,12 +349,17 @@ func (hc *HederaClient) GetAccountInfo(accountID string) (*hiero.AccountInfo, er
 	logger.Debug("Querying account info", "account_id", accountID)
 	parsedAccount, err := getAccount(accountID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid accountID: %w", err)
+		return nil, permanentError(fmt.Errorf("invalid accountID: %w", err))
 	}
 
 	query := hiero.NewAccountInfoQuery().
 		SetAccountID(parsedAccount)
-	info, err := query.Execute(hc.client)
+	var info hiero.AccountInfo
+	err = hc.execute(func(client *hiero.Client) error {
+		var execErr error
+		info, execErr = query.Execute(client)
+		return execErr
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute info query: %w", err)
 	}
@@ -134,12 +367,13 @@ func (hc *HederaClient) GetAccountInfo(accountID string) (*hiero.AccountInfo, er
 	return &info, nil
 }
 
-func buildRecordStruct(nextRec hiero.TransactionRecord) Record {
-	// Get amount from transfers as tiny bar
+// buildRecordStruct converts an SDK record to a Record whose amount is the net
+// HBAR change for account. Summing every leg would mix in the payer, fee and
+// node transfers, which cancel out or double count
+func buildRecordStruct(nextRec hiero.TransactionRecord, account hiero.AccountID) Record {
 	var amountTinyBar int64
-	if nextRec.Transfers != nil {
-		// Transfers is typically a map[AccountID]Hbar
-		for _, transfer := range nextRec.Transfers {
+	for _, transfer := range nextRec.Transfers {
+		if transfer.AccountID.Equals(account) {
 			amountTinyBar += transfer.Amount.AsTinybar()
 		}
 	}
@@ -159,27 +393,53 @@ func (hc *HederaClient) GetAccountRecords(accountID string, limit int) ([]Record
 	logger.Debug("Querying account records", "account_id", accountID, "limit", limit)
 	parsedAccount, err := getAccount(accountID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid accountID: %w", err)
+		return nil, permanentError(fmt.Errorf("invalid accountID: %w", err))
 	}
 
 	query := hiero.NewAccountRecordsQuery().
 		SetAccountID(parsedAccount)
-	records, err := query.Execute(hc.client)
+	var records []hiero.TransactionRecord
+	err = hc.execute(func(client *hiero.Client) error {
+		var execErr error
+		records, execErr = query.Execute(client)
+		return execErr
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving records: %w", err)
+		if hc.mirror == nil {
+			return nil, fmt.Errorf("error retrieving records: %w", err)
+		}
+		logger.Warn("Account records query failed, falling back to mirror node",
+			"account_id", accountID,
+			"error", err)
+		return hc.mirror.GetAccountRecords(accountID, limit)
 	}
 
-	// Convert hiero records to Record struct slice, respecting the limit
-	result := make([]Record, 0, min(len(records), limit))
-	for i, nextRec := range records {
-		if i >= limit {
-			break
-		}
-		nextRes := buildRecordStruct(nextRec)
-		result = append(result, nextRes)
+	// Consensus nodes only keep recent records, so an empty result is common on mainnet
+	if len(records) == 0 && hc.mirror != nil {
+		logger.Debug("Account records query returned nothing, using mirror node", "account_id", accountID)
+		return hc.mirror.GetAccountRecords(accountID, limit)
 	}
 
-	return result, nil
+	return convertRecords(records, parsedAccount, limit), nil
+}
+
+// normalizeRecordsLimit applies the default to non-positive limits and caps large ones
+func normalizeRecordsLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultRecordsLimit
+	}
+	return min(limit, MaxRecordsLimit)
+}
+
+// convertRecords converts hiero records to Record structs, keeping at most limit records
+// The limit is normalized with normalizeRecordsLimit
+func convertRecords(records []hiero.TransactionRecord, account hiero.AccountID, limit int) []Record {
+	limit = normalizeRecordsLimit(limit)
+	result := make([]Record, 0, min(len(records), limit))
+	for _, nextRec := range records[:min(len(records), limit)] {
+		result = append(result, buildRecordStruct(nextRec, account))
+	}
+	return result
 }
 
 // GetTransactionReceipt implements Client interface
@@ -188,11 +448,16 @@ func (hc *HederaClient) GetTransactionReceipt(transactionID string) (*hiero.Tran
 	// Parse transactionID, execute query
 	tID, err := getTransactionID(transactionID)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing transaction ID: %w", err)
+		return nil, permanentError(fmt.Errorf("error parsing transaction ID: %w", err))
 	}
 
 	query := hiero.NewTransactionReceiptQuery().SetTransactionID(tID)
-	receipt, err := query.Execute(hc.client)
+	var receipt hiero.TransactionReceipt
+	err = hc.execute(func(client *hiero.Client) error {
+		var execErr error
+		receipt, execErr = query.Execute(client)
+		return execErr
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving transaction receipt: %w", err)
 	}
@@ -211,21 +476,144 @@ func (hc *HederaClient) GetAccountExpiry(accountID string) (int64, error) {
 }
 
 // GetNodeAddressBook implements Client interface
+// The result is cached for the address book TTL, per network so a failover never
+// serves the other network's nodes. Failed queries are retried and never cached
+// The cache lock isn't held while querying, so concurrent callers may each fetch
 func (hc *HederaClient) GetNodeAddressBook() (*hiero.NodeAddressBook, error) {
+	now := time.Now
+	if hc.now != nil {
+		now = hc.now
+	}
+	network := hc.Network()
+	if addressBook := hc.cachedAddressBook(network, now()); addressBook != nil {
+		logger.Debug("Using cached node address book", "network", network)
+		return addressBook, nil
+	}
+
+	query := hc.queryAddressBook
+	if query == nil {
+		query = hc.queryNodeAddressBook
+	}
+	addressBook, err := retryQuery("GetNodeAddressBook", addressBookRetryAttempts, addressBookRetryBackoff, query)
+	if err != nil {
+		return nil, err
+	}
+
+	hc.addressBookMu.Lock()
+	defer hc.addressBookMu.Unlock()
+	if 0 < hc.addressBookTTL {
+		hc.addressBook = addressBook
+		hc.addressBookNetwork = network
+		hc.addressBookFetched = now()
+	}
+	return addressBook, nil
+}
+
+// cachedAddressBook returns the cached address book if it was fetched from network
+// within the TTL, or nil
+func (hc *HederaClient) cachedAddressBook(network string, at time.Time) *hiero.NodeAddressBook {
+	hc.addressBookMu.Lock()
+	defer hc.addressBookMu.Unlock()
+	if hc.addressBook != nil && hc.addressBookNetwork == network && at.Sub(hc.addressBookFetched) < hc.addressBookTTL {
+		return hc.addressBook
+	}
+	return nil
+}
+
+// queryNodeAddressBook queries the address book from the network without caching
+func (hc *HederaClient) queryNodeAddressBook() (*hiero.NodeAddressBook, error) {
 	logger.Debug("Querying node address book")
 	// Address book is stored in file 0.0.102 on all Hedera networks
 	addressBookFileID, _ := hiero.FileIDFromString("0.0.102")
 	query := hiero.NewAddressBookQuery().
 		SetFileID(addressBookFileID).
 		SetMaxAttempts(getAddressBookMaxAttempts)
-	addressBook, err := query.Execute(hc.client)
+	var addressBook hiero.NodeAddressBook
+	err := hc.execute(func(client *hiero.Client) error {
+		var execErr error
+		addressBook, execErr = query.Execute(client)
+		return execErr
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving address book query: %w", err)
 	}
 	return &addressBook, nil
 }
 
+// retryQuery runs query up to attempts times, waiting backoff between failures
+// Permanent errors are returned at once since retrying them cannot succeed
+// Returns the last error if every attempt fails
+func retryQuery[T any](name string, attempts int, backoff time.Duration, query func() (T, error)) (T, error) {
+	var result T
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		result, err = query()
+		if err == nil {
+			return result, nil
+		}
+		if IsPermanent(err) {
+			return result, err
+		}
+		if attempt < attempts {
+			logger.Warn("Query failed, retrying",
+				"component", "HederaClient",
+				"query", name,
+				"attempt", attempt,
+				"max_attempts", attempts,
+				"error", err)
+			time.Sleep(backoff)
+		}
+	}
+	return result, err
+}
+
+// GetTokenInfo implements Client interface
+func (hc *HederaClient) GetTokenInfo(tokenID string) (TokenInfo, error) {
+	hc.tokenMu.RLock()
+	cached, ok := hc.tokenCache[tokenID]
+	hc.tokenMu.RUnlock()
+	if ok {
+		return cached, nil
+	}
+
+	logger.Debug("Querying token info", "token_id", tokenID)
+	parsedToken, err := hiero.TokenIDFromString(tokenID)
+	if err != nil {
+		return TokenInfo{}, permanentError(fmt.Errorf("invalid tokenID: %w", err))
+	}
+
+	query := hiero.NewTokenInfoQuery().
+		SetTokenID(parsedToken)
+	var info hiero.TokenInfo
+	err = hc.execute(func(client *hiero.Client) error {
+		var execErr error
+		info, execErr = query.Execute(client)
+		return execErr
+	})
+	if err != nil {
+		return TokenInfo{}, fmt.Errorf("failed to execute token info query: %w", err)
+	}
+
+	result := TokenInfo{
+		TokenID:  tokenID,
+		Name:     info.Name,
+		Symbol:   info.Symbol,
+		Decimals: info.Decimals,
+	}
+
+	hc.tokenMu.Lock()
+	if hc.tokenCache == nil {
+		hc.tokenCache = make(map[string]TokenInfo)
+	}
+	hc.tokenCache[tokenID] = result
+	hc.tokenMu.Unlock()
+
+	return result, nil
+}
+
 // Close implements Client interface
 func (hc *HederaClient) Close() error {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
 	return hc.client.Close()
 }
@@ -2,7 +2,9 @@ package hedera
 
 import (
 	"fmt"
+	"math"
 	"testing"
+	"time"
 
 	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
 )
@@ -21,6 +23,8 @@ type MockClient struct {
 	mockReceiptErr          error
 	mockExpiryErr           error
 	mockNodeAddressBookErr  error
+	mockTokenInfo           TokenInfo
+	mockTokenInfoErr        error
 	mockCloseErr            error
 	getBalanceCalls         int
 	getInfoCalls            int
@@ -28,6 +32,7 @@ type MockClient struct {
 	getReceiptCalls         int
 	getExpiryCalls          int
 	getNodeAddressBookCalls int
+	getTokenInfoCalls       int
 	closeCalls              int
 }
 
@@ -79,6 +84,14 @@ func (m *MockClient) GetNodeAddressBook() (*hiero.NodeAddressBook, error) {
 	return m.mockNodeAddressBook, nil
 }
 
+func (m *MockClient) GetTokenInfo(tokenID string) (TokenInfo, error) {
+	m.getTokenInfoCalls++
+	if m.mockTokenInfoErr != nil {
+		return TokenInfo{}, m.mockTokenInfoErr
+	}
+	return m.mockTokenInfo, nil
+}
+
 func (m *MockClient) Close() error {
 	m.closeCalls++
 	return m.mockCloseErr
@@ -529,3 +542,472 @@ func TestNewClient_MissingOperatorKey(t *testing.T) {
 	}
 }
 */
+
+// newTestHederaClient builds a HederaClient with a fake inner client factory
+// that records which networks were connected to
+func newTestHederaClient(t *testing.T, primary, fallback string, connected *[]string) *HederaClient {
+	t.Helper()
+	key, err := hiero.PrivateKeyGenerateEd25519()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	hc := &HederaClient{
+		primary:     primary,
+		fallback:    fallback,
+		operatorID:  hiero.AccountID{Account: 2},
+		operatorKey: key,
+		newHieroClient: func(network string) (*hiero.Client, error) {
+			*connected = append(*connected, network)
+			return hiero.ClientForName(network)
+		},
+	}
+	if err := hc.connect(primary); err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	return hc
+}
+
+// TestExecute_FailsOverAfterRepeatedFailures tests switching to the fallback network
+func TestExecute_FailsOverAfterRepeatedFailures(t *testing.T) {
+	var connected []string
+	hc := newTestHederaClient(t, "testnet", "mainnet", &connected)
+	defer hc.Close()
+
+	// Query executor that fails on the primary and succeeds on the fallback
+	query := func(client *hiero.Client) error {
+		if hc.Network() == "testnet" {
+			return fmt.Errorf("primary unavailable")
+		}
+		return nil
+	}
+
+	for i := 1; i < maxConsecutiveFailures; i++ {
+		if err := hc.execute(query); err == nil {
+			t.Fatalf("expected error on attempt %d before failover", i)
+		}
+		if hc.Network() != "testnet" {
+			t.Fatalf("expected to stay on primary before threshold, got %s", hc.Network())
+		}
+	}
+
+	// The threshold failure switches networks and retries on the fallback
+	if err := hc.execute(query); err != nil {
+		t.Errorf("expected query to succeed after failover, got: %v", err)
+	}
+	if hc.Network() != "mainnet" {
+		t.Errorf("expected network to be mainnet after failover, got %s", hc.Network())
+	}
+	if len(connected) != 2 || connected[1] != "mainnet" {
+		t.Errorf("expected connections [testnet mainnet], got %v", connected)
+	}
+}
+
+// TestExecute_ReconnectsWithoutFallback tests that persistent failures rebuild the
+// inner client on the same network when no fallback is configured
+func TestExecute_ReconnectsWithoutFallback(t *testing.T) {
+	var connected []string
+	hc := newTestHederaClient(t, "testnet", "", &connected)
+	defer hc.Close()
+
+	// The first inner client is stale; any rebuilt client works
+	stale := hc.client
+	query := func(client *hiero.Client) error {
+		if client == stale {
+			return fmt.Errorf("connection stale")
+		}
+		return nil
+	}
+
+	for i := 1; i < maxConsecutiveFailures; i++ {
+		if err := hc.execute(query); err == nil {
+			t.Fatalf("expected error on attempt %d before reconnect", i)
+		}
+	}
+
+	// The threshold failure triggers a reconnect and a successful retry
+	if err := hc.execute(query); err != nil {
+		t.Errorf("expected query to succeed after reconnect, got: %v", err)
+	}
+	if hc.Network() != "testnet" {
+		t.Errorf("expected to stay on testnet, got %s", hc.Network())
+	}
+	if len(connected) != 2 || connected[1] != "testnet" {
+		t.Errorf("expected connections [testnet testnet], got %v", connected)
+	}
+	if hc.client == stale {
+		t.Error("expected inner client to be rebuilt")
+	}
+}
+
+// TestReconnect_ResetsOperator tests that Reconnect re-sets the operator on the new client
+func TestReconnect_ResetsOperator(t *testing.T) {
+	var connected []string
+	hc := newTestHederaClient(t, "testnet", "", &connected)
+	defer hc.Close()
+
+	if err := hc.Reconnect(); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if got := hc.client.GetOperatorAccountID(); got != hc.operatorID {
+		t.Errorf("expected operator %s, got %s", hc.operatorID, got)
+	}
+	if len(connected) != 2 {
+		t.Errorf("expected 2 connections, got %v", connected)
+	}
+}
+
+// TestReconnect_FactoryError tests that a failed rebuild keeps the existing client
+func TestReconnect_FactoryError(t *testing.T) {
+	var connected []string
+	hc := newTestHederaClient(t, "testnet", "", &connected)
+	defer hc.Close()
+
+	original := hc.client
+	hc.newHieroClient = func(network string) (*hiero.Client, error) {
+		return nil, fmt.Errorf("factory failure")
+	}
+
+	if err := hc.Reconnect(); err == nil {
+		t.Error("expected error from failing factory")
+	}
+	if hc.client != original {
+		t.Error("expected original client to be kept after failed reconnect")
+	}
+}
+
+// TestExecute_SuccessResetsFailures tests that a success resets the failure count
+func TestExecute_SuccessResetsFailures(t *testing.T) {
+	var connected []string
+	hc := newTestHederaClient(t, "testnet", "mainnet", &connected)
+	defer hc.Close()
+
+	fail := func(client *hiero.Client) error { return fmt.Errorf("transient") }
+	succeed := func(client *hiero.Client) error { return nil }
+
+	for i := 0; i < 10; i++ {
+		for j := 1; j < maxConsecutiveFailures; j++ {
+			_ = hc.execute(fail)
+		}
+		_ = hc.execute(succeed)
+	}
+
+	if hc.Network() != "testnet" {
+		t.Errorf("expected intermittent failures not to trigger failover, got %s", hc.Network())
+	}
+}
+
+// TestExecute_PermanentErrorsDontFailOver tests that repeated permanent errors, such as
+// querying a deleted account, never trigger a failover
+func TestExecute_PermanentErrorsDontFailOver(t *testing.T) {
+	var connected []string
+	hc := newTestHederaClient(t, "testnet", "mainnet", &connected)
+	defer hc.Close()
+
+	deleted := func(client *hiero.Client) error {
+		return hiero.ErrHederaPreCheckStatus{Status: hiero.StatusAccountDeleted}
+	}
+
+	for i := 0; i < 2*maxConsecutiveFailures; i++ {
+		err := hc.execute(deleted)
+		if !IsPermanent(err) {
+			t.Fatalf("expected a permanent error, got: %v", err)
+		}
+	}
+
+	if hc.Network() != "testnet" || len(connected) != 1 {
+		t.Errorf("expected no failover for permanent errors, got network %s and connections %v", hc.Network(), connected)
+	}
+}
+
+// TestScaleTokenAmount tests converting raw token amounts using decimals
+func TestScaleTokenAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		decimals uint32
+		want     float64
+	}{
+		{"no decimals", 500, 0, 500},
+		{"two decimals", 12345, 2, 123.45},
+		{"eight decimals", 150_000_000, 8, 1.5},
+		{"fractional only", 1, 6, 0.000001},
+		{"negative amount", -2500, 3, -2.5},
+		{"zero", 0, 8, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScaleTokenAmount(tt.amount, tt.decimals)
+			if math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("ScaleTokenAmount(%d, %d) = %v, want %v", tt.amount, tt.decimals, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGetTokenInfo_Cached tests that cached token info is returned without a query
+func TestGetTokenInfo_Cached(t *testing.T) {
+	hc := &HederaClient{
+		tokenCache: map[string]TokenInfo{
+			"0.0.7000": {TokenID: "0.0.7000", Symbol: "USDC", Decimals: 6},
+		},
+		newHieroClient: func(network string) (*hiero.Client, error) {
+			t.Fatal("expected cached token info without a network query")
+			return nil, nil
+		},
+	}
+
+	info, err := hc.GetTokenInfo("0.0.7000")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if info.Symbol != "USDC" || info.Decimals != 6 {
+		t.Errorf("expected USDC with 6 decimals, got %+v", info)
+	}
+}
+
+// TestGetTokenInfo_InvalidTokenID tests rejection of malformed token IDs
+func TestGetTokenInfo_InvalidTokenID(t *testing.T) {
+	hc := &HederaClient{tokenCache: make(map[string]TokenInfo)}
+
+	if _, err := hc.GetTokenInfo("not-a-token"); err == nil {
+		t.Error("expected error for invalid token ID")
+	}
+}
+
+// newAddressBookTestClient builds a HederaClient whose address book queries are
+// served by query, with a controllable clock
+func newAddressBookTestClient(t *testing.T, query func() (*hiero.NodeAddressBook, error), now *time.Time) *HederaClient {
+	t.Helper()
+	var connected []string
+	hc := newTestHederaClient(t, "testnet", "", &connected)
+	hc.addressBookTTL = time.Minute
+	hc.queryAddressBook = query
+	hc.now = func() time.Time { return *now }
+	return hc
+}
+
+// TestGetNodeAddressBook_CacheHitAndExpiry tests the address book is reused within the TTL
+// and fetched again once it expires
+func TestGetNodeAddressBook_CacheHitAndExpiry(t *testing.T) {
+	calls := 0
+	now := time.Unix(1700000000, 0)
+	hc := newAddressBookTestClient(t, func() (*hiero.NodeAddressBook, error) {
+		calls++
+		return &hiero.NodeAddressBook{}, nil
+	}, &now)
+	defer hc.Close()
+
+	first, err := hc.GetNodeAddressBook()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 query on a cache miss, got %d", calls)
+	}
+
+	now = now.Add(30 * time.Second)
+	second, err := hc.GetNodeAddressBook()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if calls != 1 || second != first {
+		t.Errorf("expected the cached address book within the TTL, got %d queries", calls)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := hc.GetNodeAddressBook(); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected a new query after the TTL expired, got %d queries", calls)
+	}
+}
+
+// TestGetNodeAddressBook_CacheDisabled tests a zero TTL queries on every call
+func TestGetNodeAddressBook_CacheDisabled(t *testing.T) {
+	calls := 0
+	now := time.Unix(1700000000, 0)
+	hc := newAddressBookTestClient(t, func() (*hiero.NodeAddressBook, error) {
+		calls++
+		return &hiero.NodeAddressBook{}, nil
+	}, &now)
+	defer hc.Close()
+	hc.SetAddressBookTTL(0)
+
+	for i := 0; i < 2; i++ {
+		if _, err := hc.GetNodeAddressBook(); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 queries with caching disabled, got %d", calls)
+	}
+}
+
+// TestGetNodeAddressBook_RetriesTransientError tests a failed query is retried and the
+// eventual result is cached
+func TestGetNodeAddressBook_RetriesTransientError(t *testing.T) {
+	calls := 0
+	now := time.Unix(1700000000, 0)
+	hc := newAddressBookTestClient(t, func() (*hiero.NodeAddressBook, error) {
+		calls++
+		if calls == 1 {
+			return nil, fmt.Errorf("transient")
+		}
+		return &hiero.NodeAddressBook{}, nil
+	}, &now)
+	defer hc.Close()
+
+	if _, err := hc.GetNodeAddressBook(); err != nil {
+		t.Fatalf("expected the retry to succeed, got: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 queries (failure + retry), got %d", calls)
+	}
+
+	if _, err := hc.GetNodeAddressBook(); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected the retried result to be cached, got %d queries", calls)
+	}
+}
+
+// TestGetNodeAddressBook_ErrorNotCached tests that persistent failures give up after
+// the retry attempts and aren't cached
+func TestGetNodeAddressBook_ErrorNotCached(t *testing.T) {
+	calls := 0
+	now := time.Unix(1700000000, 0)
+	hc := newAddressBookTestClient(t, func() (*hiero.NodeAddressBook, error) {
+		calls++
+		return nil, fmt.Errorf("network unreachable")
+	}, &now)
+	defer hc.Close()
+
+	if _, err := hc.GetNodeAddressBook(); err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+	if calls != addressBookRetryAttempts {
+		t.Errorf("expected %d attempts, got %d", addressBookRetryAttempts, calls)
+	}
+	if hc.addressBook != nil {
+		t.Error("expected a failed query not to be cached")
+	}
+}
+
+// TestGetNodeAddressBook_UnlockedWhileQuerying tests that a slow address book query
+// doesn't block other callers of the cache lock
+func TestGetNodeAddressBook_UnlockedWhileQuerying(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	now := time.Unix(1700000000, 0)
+	hc := newAddressBookTestClient(t, func() (*hiero.NodeAddressBook, error) {
+		close(started)
+		<-release
+		return &hiero.NodeAddressBook{}, nil
+	}, &now)
+	defer hc.Close()
+
+	fetched := make(chan error, 1)
+	go func() {
+		_, err := hc.GetNodeAddressBook()
+		fetched <- err
+	}()
+	<-started
+
+	done := make(chan struct{})
+	go func() {
+		hc.SetAddressBookTTL(time.Minute)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("expected SetAddressBookTTL not to wait for the in-flight query")
+	}
+
+	close(release)
+	if err := <-fetched; err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	<-done
+}
+
+// TestConvertRecords_Limit tests default, capped and partial record limits
+func TestConvertRecords_Limit(t *testing.T) {
+	account := hiero.AccountID{Account: 5000}
+	makeRecords := func(n int) []hiero.TransactionRecord {
+		records := make([]hiero.TransactionRecord, n)
+		for i := range records {
+			records[i].Transfers = []hiero.Transfer{{AccountID: account, Amount: hiero.HbarFromTinybar(int64(i))}}
+		}
+		return records
+	}
+
+	tests := []struct {
+		name      string
+		available int
+		limit     int
+		want      int
+	}{
+		{"zero limit uses default", 100, 0, DefaultRecordsLimit},
+		{"negative limit uses default", 100, -5, DefaultRecordsLimit},
+		{"small limit", 100, 3, 3},
+		{"limit larger than available", 4, 10, 4},
+		{"limit above max is capped", MaxRecordsLimit + 10, MaxRecordsLimit + 5, MaxRecordsLimit},
+		{"no records", 0, 10, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records := convertRecords(makeRecords(tt.available), account, tt.limit)
+			if len(records) != tt.want {
+				t.Fatalf("expected %d records, got %d", tt.want, len(records))
+			}
+			// Records keep their original order
+			for i, rec := range records {
+				if rec.AmountTinyBar != int64(i) {
+					t.Errorf("record %d: expected amount %d, got %d", i, i, rec.AmountTinyBar)
+				}
+			}
+		})
+	}
+}
+
+// TestBuildRecordStruct_NetAmount tests amounts are the net change for the queried account
+// across multi-party transfers, rather than the sum of every leg
+func TestBuildRecordStruct_NetAmount(t *testing.T) {
+	payer := hiero.AccountID{Account: 5000}
+	receiver := hiero.AccountID{Account: 6000}
+	other := hiero.AccountID{Account: 7000}
+	record := hiero.TransactionRecord{
+		Transfers: []hiero.Transfer{
+			{AccountID: payer, Amount: hiero.HbarFromTinybar(-1_500)},   // Sent to receiver and other
+			{AccountID: payer, Amount: hiero.HbarFromTinybar(-10)},      // Transaction fee
+			{AccountID: receiver, Amount: hiero.HbarFromTinybar(1_000)}, // Received
+			{AccountID: other, Amount: hiero.HbarFromTinybar(500)},      // Received
+			{AccountID: hiero.AccountID{Account: 3}, Amount: hiero.HbarFromTinybar(2)},
+			{AccountID: hiero.AccountID{Account: 98}, Amount: hiero.HbarFromTinybar(8)},
+		},
+	}
+
+	tests := []struct {
+		account hiero.AccountID
+		want    int64
+	}{
+		{payer, -1_510},
+		{receiver, 1_000},
+		{other, 500},
+		{hiero.AccountID{Account: 9999}, 0},
+	}
+
+	for _, tt := range tests {
+		got := buildRecordStruct(record, tt.account).AmountTinyBar
+		if got != tt.want {
+			t.Errorf("account %s: expected amount %d, got %d", tt.account, tt.want, got)
+		}
+	}
+}
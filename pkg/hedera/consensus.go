@@ -0,0 +1,22 @@
+package hedera
+
+// Values of the network_consensus_active metric
+const (
+	ConsensusUp   = 1.0 // The address book query succeeded, so the network is reaching consensus
+	ConsensusDown = 0.0 // The address book query failed
+)
+
+// ConsensusValue returns the network_consensus_active value for active
+func ConsensusValue(active bool) float64 {
+	if active {
+		return ConsensusUp
+	}
+	return ConsensusDown
+}
+
+// IsConsensusUp interprets a network_consensus_active value
+// Anything closer to ConsensusUp than ConsensusDown counts as up, so values that went
+// through float math (e.g. averaged in a summary) are still read correctly
+func IsConsensusUp(value float64) bool {
+	return (ConsensusUp+ConsensusDown)/2 < value
+}
@@ -0,0 +1,43 @@
+package hedera
+
+import "testing"
+
+// TestConsensusValue tests the emitted value round-trips through IsConsensusUp
+func TestConsensusValue(t *testing.T) {
+	if ConsensusValue(true) != ConsensusUp {
+		t.Errorf("ConsensusValue(true) = %v, want %v", ConsensusValue(true), ConsensusUp)
+	}
+	if ConsensusValue(false) != ConsensusDown {
+		t.Errorf("ConsensusValue(false) = %v, want %v", ConsensusValue(false), ConsensusDown)
+	}
+	for _, active := range []bool{true, false} {
+		if got := IsConsensusUp(ConsensusValue(active)); got != active {
+			t.Errorf("IsConsensusUp(ConsensusValue(%v)) = %v", active, got)
+		}
+	}
+}
+
+// TestIsConsensusUp tests interpreting network_consensus_active values
+func TestIsConsensusUp(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float64
+		want  bool
+	}{
+		{"up", ConsensusUp, true},
+		{"down", ConsensusDown, false},
+		{"float error near up", 0.9999999, true},
+		{"mostly up average", 0.75, true},
+		{"mostly down average", 0.25, false},
+		{"midpoint", 0.5, false},
+		{"negative", -1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsConsensusUp(tt.value); got != tt.want {
+				t.Errorf("IsConsensusUp(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package hedera
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+)
+
+// ErrorClass says whether a failed query is worth retrying
+type ErrorClass int
+
+const (
+	ErrorUnknown   ErrorClass = iota // Not recognised; treated like a transient error by retries
+	ErrorTransient                   // The network was unreachable, busy or slow; retrying may succeed
+	ErrorPermanent                   // The query itself is wrong (bad account, auth); retrying cannot succeed
+)
+
+// String returns the class name used in logs and metric labels
+func (c ErrorClass) String() string {
+	switch c {
+	case ErrorTransient:
+		return "transient"
+	case ErrorPermanent:
+		return "permanent"
+	default:
+		return "unknown"
+	}
+}
+
+// transientStatuses are precheck statuses reporting a busy or unavailable node
+var transientStatuses = map[hiero.Status]bool{
+	hiero.StatusBusy:                          true,
+	hiero.StatusPlatformNotActive:             true,
+	hiero.StatusPlatformTransactionNotCreated: true,
+	hiero.StatusThrottledAtConsensus:          true,
+	hiero.StatusUnknown:                       true,
+}
+
+// transientMessages are error fragments of transport failures that don't carry a typed error
+var transientMessages = []string{
+	"timeout",
+	"timed out",
+	"deadline exceeded",
+	"unavailable",
+	"connection refused",
+	"connection reset",
+	"max attempts",
+}
+
+// QueryError is a failed client query together with its ErrorClass
+// Error returns the underlying message unchanged and Unwrap exposes the SDK error
+type QueryError struct {
+	Class ErrorClass
+	Err   error
+}
+
+// Error implements the error interface
+func (e *QueryError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *QueryError) Unwrap() error {
+	return e.Err
+}
+
+// NewQueryError classifies err and wraps it in a *QueryError
+// Returns nil for a nil error and err itself if it is already classified
+func NewQueryError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var queryErr *QueryError
+	if errors.As(err, &queryErr) {
+		return err
+	}
+	return &QueryError{Class: ClassifyError(err), Err: err}
+}
+
+// permanentError marks err as permanent, e.g. an identifier that fails to parse
+func permanentError(err error) error {
+	return &QueryError{Class: ErrorPermanent, Err: err}
+}
+
+// ClassifyError maps an SDK, status or transport error to an ErrorClass
+// An existing *QueryError in the chain keeps its class
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ErrorUnknown
+	}
+
+	var queryErr *QueryError
+	if errors.As(err, &queryErr) {
+		return queryErr.Class
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrorTransient
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrorTransient
+	}
+
+	var networkErr hiero.ErrHederaNetwork
+	if errors.As(err, &networkErr) {
+		return ErrorTransient
+	}
+	var precheckErr hiero.ErrHederaPreCheckStatus
+	if errors.As(err, &precheckErr) {
+		return classifyStatus(precheckErr.Status)
+	}
+	var receiptErr hiero.ErrHederaReceiptStatus
+	if errors.As(err, &receiptErr) {
+		return classifyStatus(receiptErr.Status)
+	}
+	var recordErr hiero.ErrHederaRecordStatus
+	if errors.As(err, &recordErr) {
+		return classifyStatus(recordErr.Status)
+	}
+	var validationErr hiero.ErrLocalValidation
+	if errors.As(err, &validationErr) {
+		return ErrorPermanent
+	}
+	var paymentErr hiero.ErrMaxQueryPaymentExceeded
+	if errors.As(err, &paymentErr) {
+		return ErrorPermanent
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, fragment := range transientMessages {
+		if strings.Contains(message, fragment) {
+			return ErrorTransient
+		}
+	}
+	return ErrorUnknown
+}
+
+// classifyStatus treats any exceptional status other than a busy node as permanent,
+// e.g. INVALID_ACCOUNT_ID, ACCOUNT_DELETED or INVALID_SIGNATURE
+func classifyStatus(status hiero.Status) ErrorClass {
+	if transientStatuses[status] {
+		return ErrorTransient
+	}
+	return ErrorPermanent
+}
+
+// IsPermanent reports whether retrying the query that returned err cannot succeed
+func IsPermanent(err error) bool {
+	return ClassifyError(err) == ErrorPermanent
+}
@@ -0,0 +1,83 @@
+package hedera
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+)
+
+// TestClassifyError tests representative SDK, status and transport errors
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorClass
+	}{
+		{"nil", nil, ErrorUnknown},
+		{"busy node", hiero.ErrHederaPreCheckStatus{Status: hiero.StatusBusy}, ErrorTransient},
+		{"platform not active", hiero.ErrHederaPreCheckStatus{Status: hiero.StatusPlatformNotActive}, ErrorTransient},
+		{"invalid account", hiero.ErrHederaPreCheckStatus{Status: hiero.StatusInvalidAccountID}, ErrorPermanent},
+		{"deleted account", hiero.ErrHederaPreCheckStatus{Status: hiero.StatusAccountDeleted}, ErrorPermanent},
+		{"bad signature", hiero.ErrHederaPreCheckStatus{Status: hiero.StatusInvalidSignature}, ErrorPermanent},
+		{"wrapped receipt status", fmt.Errorf("receipt: %w", hiero.ErrHederaReceiptStatus{Status: hiero.StatusInvalidAccountID}), ErrorPermanent},
+		{"transport error", hiero.ErrHederaNetwork{}, ErrorTransient},
+		{"deadline exceeded", fmt.Errorf("query: %w", context.DeadlineExceeded), ErrorTransient},
+		{"grpc unavailable message", errors.New("rpc error: code = Unavailable desc = connection refused"), ErrorTransient},
+		{"max attempts message", errors.New("max attempts of 10 was reached for request"), ErrorTransient},
+		{"query payment exceeded", hiero.ErrMaxQueryPaymentExceeded{}, ErrorPermanent},
+		{"unrecognised", errors.New("something odd"), ErrorUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyError(tt.err); got != tt.want {
+				t.Errorf("ClassifyError(%v) = %s, want %s", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNewQueryError tests wrapping keeps the message, the SDK error and an existing class
+func TestNewQueryError(t *testing.T) {
+	if NewQueryError(nil) != nil {
+		t.Error("expected nil for a nil error")
+	}
+
+	sdkErr := hiero.ErrHederaPreCheckStatus{Status: hiero.StatusInvalidAccountID}
+	err := NewQueryError(sdkErr)
+	var queryErr *QueryError
+	if !errors.As(err, &queryErr) || queryErr.Class != ErrorPermanent {
+		t.Fatalf("expected a permanent *QueryError, got %#v", err)
+	}
+	if err.Error() != sdkErr.Error() {
+		t.Errorf("expected message %q, got %q", sdkErr.Error(), err.Error())
+	}
+	var precheckErr hiero.ErrHederaPreCheckStatus
+	if !errors.As(err, &precheckErr) {
+		t.Error("expected the SDK error to be reachable with errors.As")
+	}
+
+	// An already classified error keeps its class, even when wrapped
+	marked := fmt.Errorf("balance: %w", permanentError(errors.New("connection refused")))
+	if got := ClassifyError(NewQueryError(marked)); got != ErrorPermanent {
+		t.Errorf("expected an explicit class to win over the message, got %s", got)
+	}
+}
+
+// TestRetryQuery_PermanentErrorNotRetried tests permanent failures return after one attempt
+func TestRetryQuery_PermanentErrorNotRetried(t *testing.T) {
+	calls := 0
+	_, err := retryQuery("Query", 3, 0, func() (int, error) {
+		calls++
+		return 0, NewQueryError(hiero.ErrHederaPreCheckStatus{Status: hiero.StatusInvalidAccountID})
+	})
+	if !IsPermanent(err) {
+		t.Errorf("expected a permanent error, got: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 attempt for a permanent error, got %d", calls)
+	}
+}
@@ -0,0 +1,63 @@
+package hedera
+
+import (
+	"strconv"
+	"strings"
+)
+
+// HbarDecimals is the number of decimal places in an HBAR (1 tinybar = 0.00000001 HBAR)
+const HbarDecimals = 8
+
+// TinybarToHbar converts tinybar to HBAR
+// Use FormatHbar for display; large balances lose precision as a float64
+func TinybarToHbar(tinybar int64) float64 {
+	return float64(tinybar) / float64(TinybarPerHbar)
+}
+
+// FormatHbar formats tinybar as an exact HBAR amount without trailing zeros
+// (e.g. 1250000000 = "12.5", 1 = "0.00000001"). Integer math is used so very
+// large balances display exactly
+func FormatHbar(tinybar int64) string {
+	formatted := FormatHbarDecimals(tinybar, HbarDecimals)
+	formatted = strings.TrimRight(formatted, "0")
+	return strings.TrimSuffix(formatted, ".")
+}
+
+// FormatHbarDecimals formats tinybar as HBAR with a fixed number of decimal places,
+// rounding half away from zero. decimals is clamped to 0..HbarDecimals
+func FormatHbarDecimals(tinybar int64, decimals int) string {
+	decimals = min(max(decimals, 0), HbarDecimals)
+
+	// Work on the magnitude as uint64 so math.MinInt64 negates safely
+	negative := tinybar < 0
+	magnitude := uint64(tinybar)
+	if negative {
+		magnitude = ^magnitude + 1
+	}
+
+	// Round away the tinybar digits that aren't displayed
+	unit := pow10(HbarDecimals - decimals)
+	magnitude = (magnitude + unit/2) / unit
+
+	scale := pow10(decimals)
+	formatted := strconv.FormatUint(magnitude/scale, 10)
+	if 0 < decimals {
+		frac := strconv.FormatUint(magnitude%scale, 10)
+		formatted += "." + strings.Repeat("0", decimals-len(frac)) + frac
+	}
+
+	// Amounts that round to zero are shown unsigned
+	if negative && magnitude != 0 {
+		formatted = "-" + formatted
+	}
+	return formatted
+}
+
+// pow10 returns 10^n for small non-negative n
+func pow10(n int) uint64 {
+	result := uint64(1)
+	for i := 0; i < n; i++ {
+		result *= 10
+	}
+	return result
+}
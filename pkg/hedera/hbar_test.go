@@ -0,0 +1,87 @@
+package hedera
+
+import (
+	"math"
+	"testing"
+)
+
+// TestTinybarToHbar tests converting tinybar to HBAR
+func TestTinybarToHbar(t *testing.T) {
+	tests := []struct {
+		name    string
+		tinybar int64
+		want    float64
+	}{
+		{"zero", 0, 0},
+		{"exact", 5 * TinybarPerHbar, 5},
+		{"fractional", 150_000_000, 1.5},
+		{"one tinybar", 1, 0.00000001},
+		{"negative", -250_000_000, -2.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TinybarToHbar(tt.tinybar); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("TinybarToHbar(%d) = %v, want %v", tt.tinybar, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFormatHbar tests exact HBAR formatting
+func TestFormatHbar(t *testing.T) {
+	tests := []struct {
+		name    string
+		tinybar int64
+		want    string
+	}{
+		{"zero", 0, "0"},
+		{"exact", 5 * TinybarPerHbar, "5"},
+		{"fractional", 1_250_000_000, "12.5"},
+		{"one tinybar", 1, "0.00000001"},
+		{"negative fractional", -150_000_001, "-1.50000001"},
+		// 50 billion HBAR (the total supply) in tinybar; float64 can't hold every digit here
+		{"total supply plus one tinybar", 50_000_000_000*TinybarPerHbar + 1, "50000000000.00000001"},
+		{"max int64", math.MaxInt64, "92233720368.54775807"},
+		{"min int64", math.MinInt64, "-92233720368.54775808"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatHbar(tt.tinybar); got != tt.want {
+				t.Errorf("FormatHbar(%d) = %q, want %q", tt.tinybar, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFormatHbarDecimals tests fixed-precision HBAR formatting and rounding
+func TestFormatHbarDecimals(t *testing.T) {
+	tests := []struct {
+		name     string
+		tinybar  int64
+		decimals int
+		want     string
+	}{
+		{"zero two decimals", 0, 2, "0.00"},
+		{"exact two decimals", 3 * TinybarPerHbar, 2, "3.00"},
+		{"rounds down", 123_449_999, 2, "1.23"},
+		{"rounds half up", 123_500_000, 2, "1.24"},
+		{"rounds negative away from zero", -123_500_000, 2, "-1.24"},
+		{"negative rounds to zero unsigned", -1, 2, "0.00"},
+		{"no decimals", 250_000_000, 0, "3"},
+		{"all decimals", 1, 8, "0.00000001"},
+		{"clamps above eight", 1, 12, "0.00000001"},
+		{"clamps below zero", 149_999_999, -1, "1"},
+		{"large balance", 50_000_000_000*TinybarPerHbar + 1, 2, "50000000000.00"},
+		{"max int64 rounds up", math.MaxInt64, 2, "92233720368.55"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatHbarDecimals(tt.tinybar, tt.decimals); got != tt.want {
+				t.Errorf("FormatHbarDecimals(%d, %d) = %q, want %q", tt.tinybar, tt.decimals, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package hedera
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kaldun-tech/hedera-network-monitor/pkg/logger"
+)
+
+// MaxMirrorPageSize is the most transactions the mirror node returns per request
+const MaxMirrorPageSize = 100
+
+// defaultMirrorTimeout bounds each mirror node request
+const defaultMirrorTimeout = 10 * time.Second
+
+// DefaultMirrorNodeURL returns the public mirror node REST URL for a network, or "" if unknown
+func DefaultMirrorNodeURL(network string) string {
+	switch network {
+	case "mainnet":
+		return "https://mainnet-public.mirrornode.hedera.com"
+	case "testnet":
+		return "https://testnet.mirrornode.hedera.com"
+	default:
+		return ""
+	}
+}
+
+// MirrorClient queries a Hedera mirror node REST API
+// Mirror node queries are free, unlike paid consensus node queries
+type MirrorClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewMirrorClient creates a mirror node client for a base URL such as
+// "https://testnet.mirrornode.hedera.com"
+func NewMirrorClient(baseURL string) *MirrorClient {
+	return &MirrorClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: defaultMirrorTimeout},
+	}
+}
+
+// getJSON GETs a mirror node path and decodes the JSON response into v
+func (mc *MirrorClient) getJSON(path string, v any) error {
+	resp, err := mc.httpClient.Get(mc.baseURL + path)
+	if err != nil {
+		return fmt.Errorf("mirror node request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("mirror node returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode mirror node response: %w", err)
+	}
+	return nil
+}
+
+// Ping checks the mirror node is reachable and serving its REST API by fetching
+// the network supply, one of its smallest responses
+func (mc *MirrorClient) Ping() error {
+	var supply struct{}
+	return mc.getJSON("/api/v1/network/supply", &supply)
+}
+
+// mirrorExchangeRate is the network's HBAR to USD cent exchange rate
+type mirrorExchangeRate struct {
+	CentEquivalent int64 `json:"cent_equivalent"`
+	HbarEquivalent int64 `json:"hbar_equivalent"`
+}
+
+// mirrorExchangeRateResponse is the body of GET /api/v1/network/exchangerate
+type mirrorExchangeRateResponse struct {
+	CurrentRate mirrorExchangeRate `json:"current_rate"`
+}
+
+// GetHbarPriceUSD returns the USD price of one HBAR from the network's current
+// exchange rate, which the network itself uses to price transaction fees
+func (mc *MirrorClient) GetHbarPriceUSD() (float64, error) {
+	logger.Debug("Querying mirror node exchange rate")
+
+	var rate mirrorExchangeRateResponse
+	if err := mc.getJSON("/api/v1/network/exchangerate", &rate); err != nil {
+		return 0, err
+	}
+	if rate.CurrentRate.HbarEquivalent <= 0 || rate.CurrentRate.CentEquivalent <= 0 {
+		return 0, fmt.Errorf("mirror node returned an invalid exchange rate: %d cents per %d HBAR",
+			rate.CurrentRate.CentEquivalent, rate.CurrentRate.HbarEquivalent)
+	}
+	return float64(rate.CurrentRate.CentEquivalent) / float64(rate.CurrentRate.HbarEquivalent) / 100, nil
+}
+
+// mirrorTransfer is an HBAR transfer leg in a mirror node transaction
+type mirrorTransfer struct {
+	Account string `json:"account"`
+	Amount  int64  `json:"amount"`
+}
+
+// mirrorTransaction is the subset of a mirror node transaction used to build a Record
+type mirrorTransaction struct {
+	ConsensusTimestamp string           `json:"consensus_timestamp"`
+	Name               string           `json:"name"`
+	Result             string           `json:"result"`
+	TransactionID      string           `json:"transaction_id"`
+	Transfers          []mirrorTransfer `json:"transfers"`
+}
+
+// mirrorTransactionsResponse is the body of GET /api/v1/transactions
+type mirrorTransactionsResponse struct {
+	Transactions []mirrorTransaction `json:"transactions"`
+}
+
+// GetAccountRecords returns the account's most recent transactions, newest first
+// Limits above MaxMirrorPageSize are capped, since only one page is fetched
+func (mc *MirrorClient) GetAccountRecords(accountID string, limit int) ([]Record, error) {
+	limit = min(normalizeRecordsLimit(limit), MaxMirrorPageSize)
+	logger.Debug("Querying mirror node records", "account_id", accountID, "limit", limit)
+
+	params := url.Values{}
+	params.Set("account.id", accountID)
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("order", "desc")
+
+	var page mirrorTransactionsResponse
+	if err := mc.getJSON("/api/v1/transactions?"+params.Encode(), &page); err != nil {
+		return nil, err
+	}
+
+	records := make([]Record, 0, len(page.Transactions))
+	for _, tx := range page.Transactions {
+		records = append(records, buildMirrorRecord(tx, accountID))
+	}
+	return records, nil
+}
+
+// buildMirrorRecord converts a mirror node transaction to a Record, with the
+// amount being the net HBAR change for accountID
+func buildMirrorRecord(tx mirrorTransaction, accountID string) Record {
+	var amountTinyBar int64
+	for _, transfer := range tx.Transfers {
+		if transfer.Account == accountID {
+			amountTinyBar += transfer.Amount
+		}
+	}
+
+	// Consensus timestamps are "seconds.nanoseconds"
+	seconds, _, _ := strings.Cut(tx.ConsensusTimestamp, ".")
+	timestamp, _ := strconv.ParseInt(seconds, 10, 64)
+
+	return Record{
+		TransactionID: sdkTransactionID(tx.TransactionID),
+		Timestamp:     timestamp,
+		AmountTinyBar: amountTinyBar,
+		Type:          TransactionTypeFromName(tx.Name),
+		Status:        tx.Result,
+	}
+}
+
+// sdkTransactionID converts a mirror node transaction ID ("0.0.2-1700000000-123456789")
+// to the SDK form ("0.0.2@1700000000.123456789") so IDs look the same from either source
+func sdkTransactionID(mirrorID string) string {
+	parts := strings.Split(mirrorID, "-")
+	if len(parts) != 3 {
+		return mirrorID
+	}
+	return parts[0] + "@" + parts[1] + "." + parts[2]
+}
@@ -0,0 +1,158 @@
+package hedera
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestMirrorClient_GetAccountRecords tests parsing records and net amounts from the mirror node
+func TestMirrorClient_GetAccountRecords(t *testing.T) {
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/transactions" {
+			t.Errorf("expected path /api/v1/transactions, got %s", r.URL.Path)
+		}
+		gotQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"transactions":[
+			{"consensus_timestamp":"1700000060.000000001","name":"CRYPTOTRANSFER","result":"SUCCESS",
+			 "transaction_id":"0.0.5000-1700000055-000000000",
+			 "transfers":[{"account":"0.0.5000","amount":-1010},{"account":"0.0.6000","amount":1000},
+			              {"account":"0.0.3","amount":2},{"account":"0.0.98","amount":8}]},
+			{"consensus_timestamp":"1700000000.5","name":"TOKENMINT","result":"SUCCESS",
+			 "transaction_id":"0.0.2-1699999990-123","transfers":[{"account":"0.0.2","amount":-5}]}
+		],"links":{"next":null}}`))
+	}))
+	defer server.Close()
+
+	records, err := NewMirrorClient(server.URL+"/").GetAccountRecords("0.0.5000", 10)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if gotQuery != "account.id=0.0.5000&limit=10&order=desc" {
+		t.Errorf("unexpected query: %s", gotQuery)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	first := records[0]
+	if first.AmountTinyBar != -1010 {
+		t.Errorf("expected net amount -1010, got %d", first.AmountTinyBar)
+	}
+	if first.Timestamp != 1700000060 {
+		t.Errorf("expected timestamp 1700000060, got %d", first.Timestamp)
+	}
+	if first.TransactionID != "0.0.5000@1700000055.000000000" {
+		t.Errorf("expected SDK-style transaction ID, got %s", first.TransactionID)
+	}
+	if first.Type != TransactionTypeCryptoTransfer || first.Status != "SUCCESS" {
+		t.Errorf("unexpected type/status: %s/%s", first.Type, first.Status)
+	}
+
+	// The account isn't a party to the second transaction's HBAR transfers
+	if records[1].AmountTinyBar != 0 || records[1].Type != TransactionTypeTokenMint {
+		t.Errorf("unexpected second record: %+v", records[1])
+	}
+}
+
+// TestMirrorClient_LimitCapped tests limits are capped to one mirror node page
+func TestMirrorClient_LimitCapped(t *testing.T) {
+	var gotLimit string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotLimit = r.URL.Query().Get("limit")
+		_, _ = w.Write([]byte(`{"transactions":[]}`))
+	}))
+	defer server.Close()
+
+	if _, err := NewMirrorClient(server.URL).GetAccountRecords("0.0.5000", 500); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotLimit != "100" {
+		t.Errorf("expected limit capped to 100, got %s", gotLimit)
+	}
+}
+
+// TestMirrorClient_ErrorStatus tests non-200 responses are returned as errors
+func TestMirrorClient_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"_status":{"messages":[{"message":"Invalid parameter: account.id"}]}}`))
+	}))
+	defer server.Close()
+
+	if _, err := NewMirrorClient(server.URL).GetAccountRecords("bad", 10); err == nil {
+		t.Error("expected error for 400 response")
+	}
+}
+
+// TestMirrorClient_GetHbarPriceUSD tests converting the exchange rate to a USD price
+func TestMirrorClient_GetHbarPriceUSD(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/network/exchangerate" {
+			t.Errorf("expected path /api/v1/network/exchangerate, got %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"current_rate":{"cent_equivalent":596987,"expiration_time":1700003600,"hbar_equivalent":30000},
+			"next_rate":{"cent_equivalent":600000,"expiration_time":1700007200,"hbar_equivalent":30000},"timestamp":"1700000000.000000000"}`))
+	}))
+	defer server.Close()
+
+	price, err := NewMirrorClient(server.URL).GetHbarPriceUSD()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if math.Abs(price-0.19899566) > 1e-6 {
+		t.Errorf("expected price ~0.198996, got %v", price)
+	}
+}
+
+// TestMirrorClient_GetHbarPriceUSD_InvalidRate tests a zero rate is an error, not a free price
+func TestMirrorClient_GetHbarPriceUSD_InvalidRate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"current_rate":{"cent_equivalent":0,"hbar_equivalent":0}}`))
+	}))
+	defer server.Close()
+
+	if _, err := NewMirrorClient(server.URL).GetHbarPriceUSD(); err == nil {
+		t.Error("expected error for zero exchange rate")
+	}
+}
+
+// TestMirrorClient_Ping tests the health probe succeeds on a JSON response and fails on errors
+func TestMirrorClient_Ping(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/network/supply" {
+			t.Errorf("expected path /api/v1/network/supply, got %s", r.URL.Path)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"released_supply":"3999999999999999949","timestamp":"1700000000.000000000","total_supply":"5000000000000000000"}`))
+	}))
+	defer server.Close()
+
+	mirror := NewMirrorClient(server.URL)
+	if err := mirror.Ping(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	status = http.StatusServiceUnavailable
+	if err := mirror.Ping(); err == nil {
+		t.Error("expected error for 503 response")
+	}
+}
+
+// TestDefaultMirrorNodeURL tests the public mirror node URLs per network
+func TestDefaultMirrorNodeURL(t *testing.T) {
+	if DefaultMirrorNodeURL("mainnet") != "https://mainnet-public.mirrornode.hedera.com" {
+		t.Errorf("unexpected mainnet URL: %s", DefaultMirrorNodeURL("mainnet"))
+	}
+	if DefaultMirrorNodeURL("testnet") != "https://testnet.mirrornode.hedera.com" {
+		t.Errorf("unexpected testnet URL: %s", DefaultMirrorNodeURL("testnet"))
+	}
+	if DefaultMirrorNodeURL("previewnet") != "" {
+		t.Error("expected empty URL for unknown network")
+	}
+}
@@ -1,6 +1,10 @@
 package hedera
 
-import hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+import (
+	"strings"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+)
 
 // TransactionType represents the different types of Hedera transactions
 type TransactionType string
@@ -9,9 +13,24 @@ const (
 	// HBAR transfers between accounts
 	TransactionTypeCryptoTransfer TransactionType = "CryptoTransfer"
 
+	// Account creation
+	TransactionTypeCryptoCreate TransactionType = "CryptoCreate"
+
+	// Account deletion
+	TransactionTypeCryptoDelete TransactionType = "CryptoDelete"
+
 	// Token transfers (fungible tokens)
 	TransactionTypeTokenTransfer TransactionType = "TokenTransfer"
 
+	// Token supply increase (fungible or NFT)
+	TransactionTypeTokenMint TransactionType = "TokenMint"
+
+	// Token supply decrease
+	TransactionTypeTokenBurn TransactionType = "TokenBurn"
+
+	// Token association with an account
+	TransactionTypeTokenAssociate TransactionType = "TokenAssociate"
+
 	// Smart contract creation
 	TransactionTypeContractCreate TransactionType = "ContractCreate"
 
@@ -24,7 +43,13 @@ const (
 	// File operations
 	TransactionTypeFileOperation TransactionType = "FileOperation"
 
-	// Unknown or unsupported transaction type
+	// Scheduled transaction creation
+	TransactionTypeScheduleCreate TransactionType = "ScheduleCreate"
+
+	// Recognized transaction that doesn't map to one of the types above
+	TransactionTypeOther TransactionType = "Other"
+
+	// Unknown or unsupported transaction type (e.g. missing record)
 	TransactionTypeUnknown TransactionType = "Unknown"
 )
 
@@ -37,11 +62,18 @@ func (t TransactionType) String() string {
 func (t TransactionType) IsValid() bool {
 	switch t {
 	case TransactionTypeCryptoTransfer,
+		TransactionTypeCryptoCreate,
+		TransactionTypeCryptoDelete,
 		TransactionTypeTokenTransfer,
+		TransactionTypeTokenMint,
+		TransactionTypeTokenBurn,
+		TransactionTypeTokenAssociate,
 		TransactionTypeContractCreate,
 		TransactionTypeContractCall,
 		TransactionTypeConsensusSubmitMessage,
 		TransactionTypeFileOperation,
+		TransactionTypeScheduleCreate,
+		TransactionTypeOther,
 		TransactionTypeUnknown:
 		return true
 	default:
@@ -49,25 +81,115 @@ func (t TransactionType) IsValid() bool {
 	}
 }
 
+// KnownTransactionTypes returns every transaction type a record can be classified as,
+// including the Other bucket. Unknown is excluded since it signals missing data
+// rather than a kind of transaction
+func KnownTransactionTypes() []TransactionType {
+	return []TransactionType{
+		TransactionTypeCryptoTransfer,
+		TransactionTypeCryptoCreate,
+		TransactionTypeCryptoDelete,
+		TransactionTypeTokenTransfer,
+		TransactionTypeTokenMint,
+		TransactionTypeTokenBurn,
+		TransactionTypeTokenAssociate,
+		TransactionTypeContractCreate,
+		TransactionTypeContractCall,
+		TransactionTypeConsensusSubmitMessage,
+		TransactionTypeFileOperation,
+		TransactionTypeScheduleCreate,
+		TransactionTypeOther,
+	}
+}
+
+// transactionTypesByName maps Hedera transaction names (as reported by the
+// mirror node, e.g. "CRYPTOTRANSFER") to transaction types
+var transactionTypesByName = map[string]TransactionType{
+	"CRYPTOTRANSFER":         TransactionTypeCryptoTransfer,
+	"CRYPTOCREATEACCOUNT":    TransactionTypeCryptoCreate,
+	"CRYPTODELETE":           TransactionTypeCryptoDelete,
+	"TOKENMINT":              TransactionTypeTokenMint,
+	"TOKENBURN":              TransactionTypeTokenBurn,
+	"TOKENASSOCIATE":         TransactionTypeTokenAssociate,
+	"CONTRACTCREATEINSTANCE": TransactionTypeContractCreate,
+	"CONTRACTCALL":           TransactionTypeContractCall,
+	"CONSENSUSSUBMITMESSAGE": TransactionTypeConsensusSubmitMessage,
+	"FILECREATE":             TransactionTypeFileOperation,
+	"FILEAPPEND":             TransactionTypeFileOperation,
+	"FILEUPDATE":             TransactionTypeFileOperation,
+	"FILEDELETE":             TransactionTypeFileOperation,
+	"SCHEDULECREATE":         TransactionTypeScheduleCreate,
+}
+
+// TransactionTypeFromName maps a Hedera transaction name (e.g. "TOKENMINT") to a
+// transaction type. Names that aren't mapped explicitly return TransactionTypeOther
+func TransactionTypeFromName(name string) TransactionType {
+	if name == "" {
+		return TransactionTypeUnknown
+	}
+	if txType, ok := transactionTypesByName[strings.ToUpper(name)]; ok {
+		return txType
+	}
+	return TransactionTypeOther
+}
+
 // GetTransactionType determines the transaction type from a Hedera TransactionRecord
+// Records don't carry the transaction body, so the type is inferred from the receipt
+// and transfers. TokenAssociate and CryptoDelete leave no distinguishing fields in a
+// record and are only reported via TransactionTypeFromName.
+// Records that match no known shape return TransactionTypeOther
 func GetTransactionType(rec *hiero.TransactionRecord) TransactionType {
+	if rec == nil {
+		return TransactionTypeUnknown
+	}
 	if rec.CallResult != nil {
 		if rec.CallResultIsCreate {
 			return TransactionTypeContractCreate
 		}
 		return TransactionTypeContractCall
 	}
+	if rec.Receipt.ScheduleID != nil {
+		return TransactionTypeScheduleCreate
+	}
+	if rec.Receipt.AccountID != nil {
+		return TransactionTypeCryptoCreate
+	}
+	if len(rec.Receipt.SerialNumbers) > 0 {
+		// Only NFT mints return new serial numbers
+		return TransactionTypeTokenMint
+	}
+	if rec.Receipt.TotalSupply > 0 {
+		// Supply changes credit or debit the treasury without a counterparty
+		if net := netTokenTransfer(rec.TokenTransfers); net > 0 {
+			return TransactionTypeTokenMint
+		} else if net < 0 {
+			return TransactionTypeTokenBurn
+		}
+	}
 	if len(rec.TokenTransfers) > 0 {
 		return TransactionTypeTokenTransfer
 	}
-	if len(rec.Transfers) > 0 {
-		return TransactionTypeCryptoTransfer
-	}
+	// Every record carries fee transfers, so receipt IDs are checked before HBAR transfers
 	if rec.Receipt.TopicID != nil {
 		return TransactionTypeConsensusSubmitMessage
 	}
 	if rec.Receipt.FileID != nil {
 		return TransactionTypeFileOperation
 	}
-	return TransactionTypeUnknown
+	if len(rec.Transfers) > 0 {
+		return TransactionTypeCryptoTransfer
+	}
+	return TransactionTypeOther
+}
+
+// netTokenTransfer sums all token transfer amounts in a record
+// A balanced transfer nets to zero; a mint is positive and a burn is negative
+func netTokenTransfer(tokenTransfers map[hiero.TokenID][]hiero.TokenTransfer) int64 {
+	var net int64
+	for _, transfers := range tokenTransfers {
+		for _, transfer := range transfers {
+			net += transfer.Amount
+		}
+	}
+	return net
 }
@@ -0,0 +1,195 @@
+package hedera
+
+import (
+	"testing"
+
+	hiero "github.com/hiero-ledger/hiero-sdk-go/v2/sdk"
+)
+
+// TestGetTransactionType tests inferring transaction types from record shapes
+func TestGetTransactionType(t *testing.T) {
+	accountID := hiero.AccountID{Account: 5000}
+	treasury := hiero.AccountID{Account: 6000}
+	tokenID := hiero.TokenID{Token: 7000}
+	scheduleID := hiero.ScheduleID{Schedule: 8000}
+	topicID := hiero.TopicID{Topic: 9000}
+	fileID := hiero.FileID{File: 150}
+	feeTransfers := []hiero.Transfer{
+		{AccountID: accountID, Amount: hiero.HbarFromTinybar(-100)},
+		{AccountID: hiero.AccountID{Account: 3}, Amount: hiero.HbarFromTinybar(100)},
+	}
+
+	tests := []struct {
+		name string
+		rec  *hiero.TransactionRecord
+		want TransactionType
+	}{
+		{
+			name: "nil record",
+			rec:  nil,
+			want: TransactionTypeUnknown,
+		},
+		{
+			name: "contract call",
+			rec:  &hiero.TransactionRecord{CallResult: &hiero.ContractFunctionResult{}, Transfers: feeTransfers},
+			want: TransactionTypeContractCall,
+		},
+		{
+			name: "contract create",
+			rec: &hiero.TransactionRecord{
+				CallResult:         &hiero.ContractFunctionResult{},
+				CallResultIsCreate: true,
+			},
+			want: TransactionTypeContractCreate,
+		},
+		{
+			name: "crypto create",
+			rec: &hiero.TransactionRecord{
+				Receipt:   hiero.TransactionReceipt{AccountID: &accountID},
+				Transfers: feeTransfers,
+			},
+			want: TransactionTypeCryptoCreate,
+		},
+		{
+			name: "schedule create",
+			rec: &hiero.TransactionRecord{
+				Receipt:   hiero.TransactionReceipt{ScheduleID: &scheduleID},
+				Transfers: feeTransfers,
+			},
+			want: TransactionTypeScheduleCreate,
+		},
+		{
+			name: "fungible token mint",
+			rec: &hiero.TransactionRecord{
+				Receipt: hiero.TransactionReceipt{TotalSupply: 1500},
+				TokenTransfers: map[hiero.TokenID][]hiero.TokenTransfer{
+					tokenID: {{AccountID: treasury, Amount: 500}},
+				},
+				Transfers: feeTransfers,
+			},
+			want: TransactionTypeTokenMint,
+		},
+		{
+			name: "nft mint",
+			rec: &hiero.TransactionRecord{
+				Receipt:   hiero.TransactionReceipt{TotalSupply: 3, SerialNumbers: []int64{3}},
+				Transfers: feeTransfers,
+			},
+			want: TransactionTypeTokenMint,
+		},
+		{
+			name: "token burn",
+			rec: &hiero.TransactionRecord{
+				Receipt: hiero.TransactionReceipt{TotalSupply: 1000},
+				TokenTransfers: map[hiero.TokenID][]hiero.TokenTransfer{
+					tokenID: {{AccountID: treasury, Amount: -500}},
+				},
+				Transfers: feeTransfers,
+			},
+			want: TransactionTypeTokenBurn,
+		},
+		{
+			name: "token transfer",
+			rec: &hiero.TransactionRecord{
+				TokenTransfers: map[hiero.TokenID][]hiero.TokenTransfer{
+					tokenID: {
+						{AccountID: treasury, Amount: -500},
+						{AccountID: accountID, Amount: 500},
+					},
+				},
+				Transfers: feeTransfers,
+			},
+			want: TransactionTypeTokenTransfer,
+		},
+		{
+			name: "consensus submit with fees",
+			rec: &hiero.TransactionRecord{
+				Receipt:   hiero.TransactionReceipt{TopicID: &topicID},
+				Transfers: feeTransfers,
+			},
+			want: TransactionTypeConsensusSubmitMessage,
+		},
+		{
+			name: "file operation with fees",
+			rec: &hiero.TransactionRecord{
+				Receipt:   hiero.TransactionReceipt{FileID: &fileID},
+				Transfers: feeTransfers,
+			},
+			want: TransactionTypeFileOperation,
+		},
+		{
+			name: "crypto transfer",
+			rec:  &hiero.TransactionRecord{Transfers: feeTransfers},
+			want: TransactionTypeCryptoTransfer,
+		},
+		{
+			name: "unrecognized record",
+			rec:  &hiero.TransactionRecord{},
+			want: TransactionTypeOther,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetTransactionType(tt.rec); got != tt.want {
+				t.Errorf("GetTransactionType() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTransactionTypeFromName tests mapping Hedera transaction names to types
+func TestTransactionTypeFromName(t *testing.T) {
+	tests := []struct {
+		name string
+		want TransactionType
+	}{
+		{"CRYPTOTRANSFER", TransactionTypeCryptoTransfer},
+		{"CRYPTOCREATEACCOUNT", TransactionTypeCryptoCreate},
+		{"CRYPTODELETE", TransactionTypeCryptoDelete},
+		{"TOKENMINT", TransactionTypeTokenMint},
+		{"TOKENBURN", TransactionTypeTokenBurn},
+		{"TOKENASSOCIATE", TransactionTypeTokenAssociate},
+		{"ScheduleCreate", TransactionTypeScheduleCreate},
+		{"FILEAPPEND", TransactionTypeFileOperation},
+		{"TOKENFREEZE", TransactionTypeOther},
+		{"", TransactionTypeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TransactionTypeFromName(tt.name); got != tt.want {
+				t.Errorf("TransactionTypeFromName(%q) = %s, want %s", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTransactionType_IsValid tests that all declared types are valid
+func TestTransactionType_IsValid(t *testing.T) {
+	types := []TransactionType{
+		TransactionTypeCryptoTransfer,
+		TransactionTypeCryptoCreate,
+		TransactionTypeCryptoDelete,
+		TransactionTypeTokenTransfer,
+		TransactionTypeTokenMint,
+		TransactionTypeTokenBurn,
+		TransactionTypeTokenAssociate,
+		TransactionTypeContractCreate,
+		TransactionTypeContractCall,
+		TransactionTypeConsensusSubmitMessage,
+		TransactionTypeFileOperation,
+		TransactionTypeScheduleCreate,
+		TransactionTypeOther,
+		TransactionTypeUnknown,
+	}
+	for _, txType := range types {
+		if !txType.IsValid() {
+			t.Errorf("expected %s to be valid", txType)
+		}
+	}
+
+	if TransactionType("Bogus").IsValid() {
+		t.Error("expected Bogus to be invalid")
+	}
+}
@@ -9,6 +9,9 @@ import (
 var (
 	// Default is the default logger instance used throughout the application
 	Default *slog.Logger
+
+	// level is shared by every handler Init creates so SetLevel takes effect immediately
+	level = new(slog.LevelVar)
 )
 
 // Level represents the logging level
@@ -24,7 +27,8 @@ const (
 // Init initializes the default logger with the specified level and output
 // level: the minimum log level to output (LevelDebug, LevelInfo, LevelWarn, LevelError)
 // output: where to write logs (typically os.Stdout or os.Stderr)
-func Init(level Level, output io.Writer) {
+func Init(minLevel Level, output io.Writer) {
+	level.Set(minLevel)
 	opts := &slog.HandlerOptions{
 		Level: level,
 	}
@@ -34,7 +38,8 @@ func Init(level Level, output io.Writer) {
 }
 
 // InitJSON initializes the default logger with JSON output
-func InitJSON(level Level, output io.Writer) {
+func InitJSON(minLevel Level, output io.Writer) {
+	level.Set(minLevel)
 	opts := &slog.HandlerOptions{
 		Level: level,
 	}
@@ -43,6 +48,11 @@ func InitJSON(level Level, output io.Writer) {
 	Default = slog.New(handler)
 }
 
+// SetLevel changes the minimum level of the default logger without replacing it
+func SetLevel(minLevel Level) {
+	level.Set(minLevel)
+}
+
 // ParseLevel converts a string level to slog.Level
 func ParseLevel(level string) Level {
 	switch level {